@@ -43,6 +43,7 @@ const (
 	headerRulesetVersion   = "scanoss-ruleset-version"
 	headerChecksumSHA256   = "x-checksum-sha256"
 	headerRulesetCreatedAt = "scanoss-ruleset-created-at"
+	headerSignatureEd25519 = "x-signature-ed25519"
 )
 
 // Manifest represents the manifest.json file reconstructed from HTTP headers.
@@ -51,6 +52,9 @@ type Manifest struct {
 	Version        string    `json:"version"`
 	ChecksumSHA256 string    `json:"checksum_sha256"`
 	CreatedAt      time.Time `json:"created_at"`
+	// Signature is the base64 detached ed25519 signature of the tarball.
+	// Empty when the API serves the ruleset unsigned.
+	Signature string `json:"signature_ed25519,omitempty"`
 }
 
 // Client is an HTTP client for the SCANOSS REST API.
@@ -219,6 +223,7 @@ func (c *Client) manifestFromHeaders(headers http.Header) (*Manifest, error) {
 		Version:        version,
 		ChecksumSHA256: checksum,
 		CreatedAt:      createdAt,
+		Signature:      c.getHeaderValue(headers, headerSignatureEd25519),
 	}, nil
 }
 
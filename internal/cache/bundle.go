@@ -0,0 +1,431 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/rs/zerolog/log"
+
+	api "github.com/scanoss/crypto-finder/internal/api"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/utils"
+)
+
+const (
+	// BundleExtension is the file extension of offline ruleset bundles.
+	BundleExtension = ".tar.zst"
+
+	bundleFormatVersion = 1
+	bundleIndexName     = "bundle.json"
+	bundleRulesetDir    = "ruleset"
+	maxBundleFileSize   = 100 * 1024 * 1024
+)
+
+// ErrInvalidBundle indicates an offline ruleset bundle that is malformed or
+// whose contents do not match its checksums.
+var ErrInvalidBundle = errors.New("invalid ruleset bundle")
+
+// BundleInfo is the bundle.json index at the root of an offline ruleset
+// bundle. Every file of the cached ruleset, including manifest.json and
+// .cache-meta.json, is listed with its SHA-256 under ruleset/.
+type BundleInfo struct {
+	FormatVersion int    `json:"format_version"`
+	RulesetName   string `json:"ruleset_name"`
+	// Version is the concrete ruleset version from the cache metadata.
+	Version string `json:"version"`
+	// CacheVersion is the version the ruleset was cached under (the version
+	// scans request, e.g. "latest"); import installs it there.
+	CacheVersion string       `json:"cache_version"`
+	ExportedAt   time.Time    `json:"exported_at"`
+	Files        []BundleFile `json:"files"`
+}
+
+// BundleFile is one file of a bundled ruleset.
+type BundleFile struct {
+	// Path is slash-separated and relative to the ruleset directory.
+	Path           string `json:"path"`
+	ChecksumSHA256 string `json:"checksum_sha256"`
+}
+
+// FileName returns the conventional bundle file name, <name>-<version>.tar.zst.
+func (b *BundleInfo) FileName() string {
+	return fmt.Sprintf("%s-%s%s", b.RulesetName, b.Version, BundleExtension)
+}
+
+// ExportBundle packages the cached ruleset name@version, with its cache
+// metadata and manifest, as a zstd-compressed tarball written to w.
+func (m *Manager) ExportBundle(name, version string, w io.Writer) (*BundleInfo, error) {
+	rulesetPath := m.getRulesetCachePath(name, version)
+	metadata, err := LoadMetadata(filepath.Join(rulesetPath, metadataFileName))
+	if err != nil {
+		return nil, fmt.Errorf("ruleset %s@%s is not cached: %w", name, version, err)
+	}
+	if err := utils.ValidateRuleDirNotEmpty(rulesetPath); err != nil {
+		return nil, fmt.Errorf("cached ruleset %s@%s is invalid: %w", name, version, err)
+	}
+
+	info := &BundleInfo{
+		FormatVersion: bundleFormatVersion,
+		RulesetName:   name,
+		Version:       metadata.Version,
+		CacheVersion:  version,
+		ExportedAt:    time.Now().UTC(),
+	}
+	err = filepath.WalkDir(rulesetPath, func(filePath string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || d.IsDir() {
+			return walkErr
+		}
+		if !d.Type().IsRegular() {
+			return fmt.Errorf("unexpected non-regular file %s in cached ruleset", filePath)
+		}
+		rel, err := filepath.Rel(rulesetPath, filePath)
+		if err != nil {
+			return err
+		}
+		// #nosec G304 -- filePath is inside the ruleset cache directory.
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		info.Files = append(info.Files, BundleFile{Path: filepath.ToSlash(rel), ChecksumSHA256: CalculateSHA256(data)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(info.Files, func(i, j int) bool { return info.Files[i].Path < info.Files[j].Path })
+
+	if err := writeBundle(w, rulesetPath, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func writeBundle(w io.Writer, rulesetPath string, info *BundleInfo) error {
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return fmt.Errorf("failed to create zstd writer: %w", err)
+	}
+	tw := tar.NewWriter(zw)
+
+	index, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle index: %w", err)
+	}
+	if err := writeBundleEntry(tw, bundleIndexName, index, info.ExportedAt); err != nil {
+		return err
+	}
+	for _, file := range info.Files {
+		data, err := os.ReadFile(filepath.Join(rulesetPath, filepath.FromSlash(file.Path)))
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		if err := writeBundleEntry(tw, path.Join(bundleRulesetDir, file.Path), data, info.ExportedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle tarball: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle compression: %w", err)
+	}
+	return nil
+}
+
+func writeBundleEntry(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:     name,
+		Mode:     0o600,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write bundle entry %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write bundle entry %s: %w", name, err)
+	}
+	return nil
+}
+
+// ImportBundle installs an offline ruleset bundle read from r into the
+// cache, under the ruleset name and version it was exported from. Every
+// file is checked against the bundle's checksums before the cache is
+// replaced. The signature status is never taken from the bundle: the
+// downloaded tarball it carries is verified against the manager's trusted
+// keys and the rules are extracted from it again, and a bundle without the
+// tarball is recorded as unverified. In strict mode a bundle that does not
+// verify is refused. The imported ruleset is pinned: it never expires, so
+// scans use it without contacting the API.
+func (m *Manager) ImportBundle(r io.Reader) (*BundleInfo, error) {
+	if err := os.MkdirAll(m.cacheDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	stagingDir, err := os.MkdirTemp(m.cacheDir, ".import-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			log.Warn().Err(err).Str("path", stagingDir).Msg("Failed to clean up bundle staging directory")
+		}
+	}()
+
+	info, err := extractBundle(r, stagingDir)
+	if err != nil {
+		return nil, err
+	}
+	stagedRuleset := filepath.Join(stagingDir, bundleRulesetDir)
+	if err := verifyBundle(info, stagedRuleset); err != nil {
+		return nil, err
+	}
+
+	metadata, err := LoadMetadata(filepath.Join(stagedRuleset, metadataFileName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if metadata.RulesetName != info.RulesetName || metadata.Version != info.Version {
+		return nil, fmt.Errorf("%w: cache metadata is for %s@%s, bundle index for %s@%s",
+			ErrInvalidBundle, metadata.RulesetName, metadata.Version, info.RulesetName, info.Version)
+	}
+	stagedRuleset, signature, err := m.verifyBundleSignature(info, stagingDir)
+	if err != nil {
+		return nil, err
+	}
+	metadata.SignatureStatus = signature.Status
+	metadata.SignatureKeyID = signature.KeyID
+	metadata.Pinned = true
+	metadata.UpdateLastAccessed()
+	if err := metadata.Save(filepath.Join(stagedRuleset, metadataFileName)); err != nil {
+		return nil, err
+	}
+
+	targetPath := m.getRulesetCachePath(info.RulesetName, info.CacheVersion)
+	lockFile, err := m.acquireLock(targetPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire cache lock: %w", err)
+	}
+	defer m.releaseLock(lockFile)
+	if err := m.replaceCachedRuleset(targetPath, stagedRuleset); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("ruleset", info.RulesetName).
+		Str("version", info.Version).
+		Str("path", targetPath).
+		Msg("Ruleset bundle imported")
+	return info, nil
+}
+
+// verifyBundleSignature verifies the downloaded tarball of a bundle
+// extracted into stagingDir against the trusted keys and rebuilds the
+// ruleset from it, so the installed rules are exactly the signed ones. It
+// returns the directory to install and the signature result. Bundles
+// exported from caches that did not keep the tarball are installed as
+// unverified, or refused in strict mode.
+func (m *Manager) verifyBundleSignature(info *BundleInfo, stagingDir string) (string, SignatureResult, error) {
+	bundled := filepath.Join(stagingDir, bundleRulesetDir)
+	// #nosec G304 -- the tarball is inside the staging directory.
+	tarball, err := os.ReadFile(filepath.Join(bundled, tarballFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		if m.strictMode {
+			return "", SignatureResult{}, failure.New(
+				failure.CodeRulesetSignatureRejected,
+				failure.StageRules,
+				fmt.Sprintf("refusing ruleset bundle %s@%s in strict mode: bundle carries no signed tarball to verify", info.RulesetName, info.Version),
+				failure.WithDetail("signature", SignatureUnverified),
+			)
+		}
+		log.Warn().
+			Str("ruleset", info.RulesetName).
+			Str("version", info.Version).
+			Msg("Ruleset bundle carries no signed tarball; installing it as unverified")
+		return bundled, SignatureResult{Status: SignatureUnverified}, nil
+	}
+	if err != nil {
+		return "", SignatureResult{}, fmt.Errorf("failed to read bundled tarball: %w", err)
+	}
+
+	// #nosec G304 -- the manifest is inside the staging directory.
+	data, err := os.ReadFile(filepath.Join(bundled, manifestFileName))
+	if err != nil {
+		return "", SignatureResult{}, fmt.Errorf("%w: missing %s: %w", ErrInvalidBundle, manifestFileName, err)
+	}
+	var manifest api.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return "", SignatureResult{}, fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidBundle, manifestFileName, err)
+	}
+	if err := VerifyChecksum(tarball, manifest.ChecksumSHA256); err != nil {
+		return "", SignatureResult{}, fmt.Errorf("%w: %s: %w", ErrInvalidBundle, tarballFileName, err)
+	}
+	signature := VerifySignature(tarball, manifest.Signature, m.trustedKeys)
+	if err := m.checkSignature(info.RulesetName, info.Version, signature); err != nil {
+		return "", SignatureResult{}, err
+	}
+
+	verified := filepath.Join(stagingDir, "verified")
+	if err := m.extractTarball(tarball, verified); err != nil {
+		return "", SignatureResult{}, fmt.Errorf("%w: failed to extract bundled tarball: %w", ErrInvalidBundle, err)
+	}
+	for _, name := range []string{manifestFileName, tarballFileName} {
+		if err := os.Rename(filepath.Join(bundled, name), filepath.Join(verified, name)); err != nil {
+			return "", SignatureResult{}, fmt.Errorf("failed to stage %s: %w", name, err)
+		}
+	}
+	if err := utils.ValidateRuleDirNotEmpty(verified); err != nil {
+		return "", SignatureResult{}, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	return verified, signature, nil
+}
+
+// extractBundle unpacks a bundle into dir and returns its index. Only
+// bundle.json and regular files under ruleset/ are accepted.
+func extractBundle(r io.Reader, dir string) (*BundleInfo, error) {
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	defer zr.Close()
+
+	var info *BundleInfo
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("%w: unsupported entry %s", ErrInvalidBundle, header.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBundleFileSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+		}
+		if len(data) > maxBundleFileSize {
+			return nil, fmt.Errorf("%w: entry %s is too large", ErrInvalidBundle, header.Name)
+		}
+
+		if header.Name == bundleIndexName {
+			info = &BundleInfo{}
+			if err := json.Unmarshal(data, info); err != nil {
+				return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidBundle, bundleIndexName, err)
+			}
+			continue
+		}
+		rel, ok := strings.CutPrefix(header.Name, bundleRulesetDir+"/")
+		if !ok || !isLocalBundlePath(rel) {
+			return nil, fmt.Errorf("%w: unexpected entry %s", ErrInvalidBundle, header.Name)
+		}
+		target := filepath.Join(dir, bundleRulesetDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(target, data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+	}
+
+	if info == nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidBundle, bundleIndexName)
+	}
+	if info.FormatVersion != bundleFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidBundle, info.FormatVersion)
+	}
+	for _, name := range []string{info.RulesetName, info.CacheVersion} {
+		if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+			return nil, fmt.Errorf("%w: invalid ruleset name or version %q", ErrInvalidBundle, name)
+		}
+	}
+	return info, nil
+}
+
+// isLocalBundlePath reports whether a slash-separated entry path stays
+// inside the directory it is extracted to.
+func isLocalBundlePath(rel string) bool {
+	return rel != "" && filepath.IsLocal(filepath.FromSlash(rel)) && path.Clean(rel) == rel
+}
+
+// verifyBundle checks that the extracted ruleset holds exactly the files
+// listed in the index, with matching checksums, and contains rule files.
+func verifyBundle(info *BundleInfo, rulesetDir string) error {
+	expected := make(map[string]string, len(info.Files))
+	for _, file := range info.Files {
+		expected[file.Path] = file.ChecksumSHA256
+	}
+	if _, ok := expected[metadataFileName]; !ok {
+		return fmt.Errorf("%w: missing %s", ErrInvalidBundle, metadataFileName)
+	}
+
+	seen := 0
+	err := filepath.WalkDir(rulesetDir, func(filePath string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || d.IsDir() {
+			return walkErr
+		}
+		rel, err := filepath.Rel(rulesetDir, filePath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		checksum, ok := expected[rel]
+		if !ok {
+			return fmt.Errorf("%w: %s is not listed in %s", ErrInvalidBundle, rel, bundleIndexName)
+		}
+		// #nosec G304 -- filePath is inside the staging directory.
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		if err := VerifyChecksum(data, checksum); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidBundle, rel, err)
+		}
+		seen++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if seen != len(expected) {
+		return fmt.Errorf("%w: %d of %d listed files are missing", ErrInvalidBundle, len(expected)-seen, len(expected))
+	}
+	if err := utils.ValidateRuleDirNotEmpty(rulesetDir); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	return nil
+}
@@ -0,0 +1,393 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	api "github.com/scanoss/crypto-finder/internal/api"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+// newExportedBundle caches a downloaded ruleset as dca@latest and returns it
+// exported as a bundle, with the key its tarball is signed with.
+func newExportedBundle(t *testing.T) ([]byte, *BundleInfo, TrustedKey) {
+	t.Helper()
+
+	key, priv := newTestKey(t)
+	source := &Manager{apiClient: createSignedTarballClient(t, priv), cacheDir: t.TempDir()}
+	if _, err := source.GetRulesetPath(context.Background(), "dca", "latest"); err != nil {
+		t.Fatalf("GetRulesetPath() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	info, err := source.ExportBundle("dca", "latest", &buf)
+	if err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+	return buf.Bytes(), info, key
+}
+
+// rewriteBundle re-packs a bundle, letting edit rename, change or drop entries.
+func rewriteBundle(t *testing.T, bundle []byte, edit func(header *tar.Header, data []byte) ([]byte, bool)) []byte {
+	t.Helper()
+
+	zr, err := zstd.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatalf("zstd.NewReader() error = %v", err)
+	}
+	defer zr.Close()
+
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("zstd.NewWriter() error = %v", err)
+	}
+	tr := tar.NewReader(zr)
+	tw := tar.NewWriter(zw)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar.Next() error = %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		data, keep := edit(header, data)
+		if !keep {
+			continue
+		}
+		header.Size = int64(len(data))
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("WriteHeader() error = %v", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close() error = %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zstd Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// forgeBundle re-packs a bundle the way anyone holding it could: edit
+// changes or drops ruleset files, and the index is rewritten to match.
+func forgeBundle(t *testing.T, bundle []byte, edit func(files map[string][]byte)) []byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+		if rel, ok := strings.CutPrefix(header.Name, bundleRulesetDir+"/"); ok {
+			files[rel] = data
+		}
+		return data, true
+	})
+	edit(files)
+
+	return rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+		if header.Name == bundleIndexName {
+			var info BundleInfo
+			if err := json.Unmarshal(data, &info); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			info.Files = info.Files[:0]
+			for rel, content := range files {
+				info.Files = append(info.Files, BundleFile{Path: rel, ChecksumSHA256: CalculateSHA256(content)})
+			}
+			forged, err := json.Marshal(info)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			return forged, true
+		}
+		if rel, ok := strings.CutPrefix(header.Name, bundleRulesetDir+"/"); ok {
+			content, keep := files[rel]
+			return content, keep
+		}
+		return data, true
+	})
+}
+
+// claimVerified rewrites the bundled cache metadata to claim a signature
+// verified by key.
+func claimVerified(t *testing.T, files map[string][]byte, key TrustedKey) {
+	t.Helper()
+
+	var metadata Metadata
+	if err := json.Unmarshal(files[metadataFileName], &metadata); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	metadata.SignatureStatus = SignatureVerified
+	metadata.SignatureKeyID = key.ID
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	files[metadataFileName] = data
+}
+
+func TestExportImportBundle(t *testing.T) {
+	t.Parallel()
+
+	bundle, exported, key := newExportedBundle(t)
+	if exported.FileName() != "dca-v1.0.0.tar.zst" || exported.CacheVersion != "latest" {
+		t.Errorf("exported bundle = %s cached as %s, want dca-v1.0.0.tar.zst cached as latest", exported.FileName(), exported.CacheVersion)
+	}
+	paths := make([]string, 0, len(exported.Files))
+	for _, file := range exported.Files {
+		paths = append(paths, file.Path)
+	}
+	want := []string{".cache-meta.json", ".ruleset.tar.gz", "manifest.json", "semgrep-rules/java/example.yaml", "semgrep-rules/python/example.yml"}
+	if len(paths) != len(want) {
+		t.Fatalf("bundled files = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("bundled files = %v, want %v", paths, want)
+		}
+	}
+
+	// The offline manager's API always fails: the imported ruleset must be
+	// used without a download attempt.
+	offline := &Manager{
+		apiClient: newMockAPIClient(func(w http.ResponseWriter, _ *http.Request) {
+			t.Error("offline scan contacted the API")
+			w.WriteHeader(http.StatusNotFound)
+		}),
+		cacheDir:    t.TempDir(),
+		strictMode:  true,
+		trustedKeys: []TrustedKey{key},
+	}
+	imported, err := offline.ImportBundle(bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+	if imported.RulesetName != "dca" || imported.Version != "v1.0.0" {
+		t.Errorf("ImportBundle() = %s@%s, want dca@v1.0.0", imported.RulesetName, imported.Version)
+	}
+
+	rulesetPath := filepath.Join(offline.cacheDir, "dca", "latest")
+	metadataPath := filepath.Join(rulesetPath, metadataFileName)
+	metadata, err := LoadMetadata(metadataPath)
+	if err != nil {
+		t.Fatalf("LoadMetadata() error = %v", err)
+	}
+	if !metadata.Pinned || metadata.Version != "v1.0.0" {
+		t.Errorf("imported metadata = %+v, want pinned v1.0.0", metadata)
+	}
+	if metadata.SignatureStatus != SignatureVerified || metadata.SignatureKeyID != key.ID {
+		t.Errorf("imported signature = %s by %q, want verified by %s", metadata.SignatureStatus, metadata.SignatureKeyID, key.ID)
+	}
+
+	// Pinned rulesets outlive their TTL.
+	metadata.DownloadedAt = time.Now().Add(-365 * 24 * time.Hour)
+	if err := metadata.Save(metadataPath); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	offline.strictMode = false
+	path, err := offline.GetRulesetPath(context.Background(), "dca", "latest")
+	if err != nil {
+		t.Fatalf("GetRulesetPath() error = %v", err)
+	}
+	if path != rulesetPath {
+		t.Errorf("GetRulesetPath() = %s, want %s", path, rulesetPath)
+	}
+	if _, err := os.Stat(filepath.Join(rulesetPath, "semgrep-rules", "java", "example.yaml")); err != nil {
+		t.Errorf("imported rule file missing: %v", err)
+	}
+
+	entries, err := os.ReadDir(offline.cacheDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "dca" {
+			t.Errorf("unexpected cache entry %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestImportBundle_Invalid(t *testing.T) {
+	t.Parallel()
+
+	bundle, _, _ := newExportedBundle(t)
+	tests := []struct {
+		name   string
+		bundle []byte
+	}{
+		{"not zstd", []byte("plain text")},
+		{"tampered rule file", rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+			if header.Name == "ruleset/semgrep-rules/java/example.yaml" {
+				return []byte("rules: [{id: injected}]\n"), true
+			}
+			return data, true
+		})},
+		{"unlisted file", rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+			if header.Name == "ruleset/manifest.json" {
+				header.Name = "ruleset/semgrep-rules/injected.yaml"
+			}
+			return data, true
+		})},
+		{"missing file", rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+			return data, header.Name != "ruleset/semgrep-rules/python/example.yml"
+		})},
+		{"missing index", rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+			return data, header.Name != bundleIndexName
+		})},
+		{"entry outside ruleset", rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+			if header.Name == "ruleset/manifest.json" {
+				header.Name = "ruleset/../../manifest.json"
+			}
+			return data, true
+		})},
+		{"version escapes cache", rewriteBundle(t, bundle, func(header *tar.Header, data []byte) ([]byte, bool) {
+			if header.Name == bundleIndexName {
+				var info BundleInfo
+				_ = json.Unmarshal(data, &info)
+				info.CacheVersion = "../../escape"
+				data, _ = json.Marshal(info)
+			}
+			return data, true
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			manager := &Manager{cacheDir: t.TempDir()}
+			_, err := manager.ImportBundle(bytes.NewReader(tt.bundle))
+			if !errors.Is(err, ErrInvalidBundle) {
+				t.Fatalf("ImportBundle() error = %v, want ErrInvalidBundle", err)
+			}
+			if _, statErr := os.Stat(filepath.Join(manager.cacheDir, "dca")); !os.IsNotExist(statErr) {
+				t.Errorf("invalid bundle was installed (stat error = %v)", statErr)
+			}
+		})
+	}
+}
+
+// TestImportBundle_ForgedSignature imports bundles whose metadata claims a
+// signature verified by the importer's trusted key. The claim must be
+// ignored: strict mode refuses them, and otherwise the signature status is
+// what the importer verified itself.
+func TestImportBundle_ForgedSignature(t *testing.T) {
+	t.Parallel()
+
+	bundle, _, key := newExportedBundle(t)
+
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzWriter)
+	injected := []byte("rules: [{id: injected}]\n")
+	if err := tarWriter.WriteHeader(&tar.Header{Name: "semgrep-rules/java/example.yaml", Mode: 0o600, Size: int64(len(injected))}); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if _, err := tarWriter.Write(injected); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatalf("tar Close() error = %v", err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		t.Fatalf("gzip Close() error = %v", err)
+	}
+	swappedTarball := buf.Bytes()
+
+	tests := []struct {
+		name   string
+		edit   func(files map[string][]byte)
+		status string
+	}{
+		{
+			name: "tarball swapped",
+			edit: func(files map[string][]byte) {
+				var manifest api.Manifest
+				if err := json.Unmarshal(files[manifestFileName], &manifest); err != nil {
+					t.Fatalf("Unmarshal() error = %v", err)
+				}
+				manifest.ChecksumSHA256 = CalculateSHA256(swappedTarball)
+				data, err := json.Marshal(manifest)
+				if err != nil {
+					t.Fatalf("Marshal() error = %v", err)
+				}
+				files[manifestFileName] = data
+				files[tarballFileName] = swappedTarball
+				files["semgrep-rules/java/example.yaml"] = injected
+				delete(files, "semgrep-rules/python/example.yml")
+				claimVerified(t, files, key)
+			},
+			status: SignatureInvalid,
+		},
+		{
+			name: "tarball dropped",
+			edit: func(files map[string][]byte) {
+				delete(files, tarballFileName)
+				claimVerified(t, files, key)
+			},
+			status: SignatureUnverified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			forged := forgeBundle(t, bundle, tt.edit)
+
+			strict := &Manager{cacheDir: t.TempDir(), strictMode: true, trustedKeys: []TrustedKey{key}}
+			_, err := strict.ImportBundle(bytes.NewReader(forged))
+			structured, ok := failure.As(err)
+			if !ok || structured.Code != failure.CodeRulesetSignatureRejected {
+				t.Fatalf("strict ImportBundle() error = %v, want %s", err, failure.CodeRulesetSignatureRejected)
+			}
+			if _, statErr := os.Stat(filepath.Join(strict.cacheDir, "dca")); !os.IsNotExist(statErr) {
+				t.Errorf("refused bundle was installed (stat error = %v)", statErr)
+			}
+
+			lenient := &Manager{cacheDir: t.TempDir(), trustedKeys: []TrustedKey{key}}
+			if _, err := lenient.ImportBundle(bytes.NewReader(forged)); err != nil {
+				t.Fatalf("ImportBundle() error = %v", err)
+			}
+			metadata, err := LoadMetadata(filepath.Join(lenient.cacheDir, "dca", "latest", metadataFileName))
+			if err != nil {
+				t.Fatalf("LoadMetadata() error = %v", err)
+			}
+			if metadata.SignatureStatus != tt.status || metadata.SignatureKeyID != "" {
+				t.Errorf("imported signature = %s by %q, want %s by no key", metadata.SignatureStatus, metadata.SignatureKeyID, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/config"
+)
+
+// ErrRulesetNotCached is returned for a ruleset version that is not in the cache.
+var ErrRulesetNotCached = errors.New("ruleset is not cached")
+
+// CachedRuleset is a ruleset version in the local cache.
+type CachedRuleset struct {
+	Name string
+	// Version is the version the ruleset is cached under, as requested
+	// (e.g. "latest"); Metadata.Version holds the concrete version.
+	Version   string
+	Path      string
+	SizeBytes int64
+	// Metadata is nil when .cache-meta.json is missing or unreadable, as
+	// for a download that was interrupted before it completed.
+	Metadata *Metadata
+	modTime  time.Time
+}
+
+// LastUsed returns when a scan last used the ruleset. Rulesets without
+// metadata fall back to the modification time of their directory.
+func (r *CachedRuleset) LastUsed() time.Time {
+	if r.Metadata == nil {
+		return r.modTime
+	}
+	if r.Metadata.LastAccessed.IsZero() {
+		return r.Metadata.DownloadedAt
+	}
+	return r.Metadata.LastAccessed
+}
+
+// ListRulesets returns the cached ruleset versions sorted by name and
+// version. Lock files, in-progress downloads and imports, and the
+// language-filtered working copies are not listed.
+func (m *Manager) ListRulesets() ([]CachedRuleset, error) {
+	names, err := os.ReadDir(m.cacheDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var rulesets []CachedRuleset
+	for _, name := range names {
+		if !name.IsDir() || isCacheBookkeepingEntry(name.Name()) {
+			continue
+		}
+		versions, err := os.ReadDir(filepath.Join(m.cacheDir, name.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cache directory: %w", err)
+		}
+		for _, version := range versions {
+			if !version.IsDir() || isCacheBookkeepingEntry(version.Name()) {
+				continue
+			}
+			ruleset, err := m.cachedRuleset(name.Name(), version.Name())
+			if err != nil {
+				return nil, err
+			}
+			rulesets = append(rulesets, *ruleset)
+		}
+	}
+
+	sort.Slice(rulesets, func(i, j int) bool {
+		if rulesets[i].Name != rulesets[j].Name {
+			return rulesets[i].Name < rulesets[j].Name
+		}
+		return rulesets[i].Version < rulesets[j].Version
+	})
+	return rulesets, nil
+}
+
+// EvictRulesets removes the cached rulesets selected by evict, or only
+// counts them when dryRun is set, calling removed for each ruleset once it
+// is removed.
+func (m *Manager) EvictRulesets(dryRun bool, evict func(*CachedRuleset) bool, removed func(*CachedRuleset) error) (Eviction, error) {
+	rulesets, err := m.ListRulesets()
+	if err != nil {
+		return Eviction{}, err
+	}
+	result := Eviction{Total: len(rulesets)}
+	for i := range rulesets {
+		ruleset := &rulesets[i]
+		if !evict(ruleset) {
+			continue
+		}
+		if !dryRun {
+			if err := m.RemoveRuleset(ruleset.Name, ruleset.Version); err != nil {
+				return result, err
+			}
+		}
+		result.Removed++
+		result.FreedBytes += ruleset.SizeBytes
+		if err := removed(ruleset); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// RulesetInfo returns the cached ruleset name@version, or an error wrapping
+// ErrRulesetNotCached.
+func (m *Manager) RulesetInfo(name, version string) (*CachedRuleset, error) {
+	if !isValidCacheComponent(name) || !isValidCacheComponent(version) {
+		return nil, fmt.Errorf("%w: %s@%s", ErrRulesetNotCached, name, version)
+	}
+	info, err := os.Stat(m.getRulesetCachePath(name, version))
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s@%s", ErrRulesetNotCached, name, version)
+	}
+	return m.cachedRuleset(name, version)
+}
+
+// RemoveRuleset deletes the cached ruleset name@version and its
+// language-filtered working copies, holding the ruleset's cache lock so a
+// concurrent download does not race the removal.
+func (m *Manager) RemoveRuleset(name, version string) error {
+	if _, err := m.RulesetInfo(name, version); err != nil {
+		return err
+	}
+
+	rulesetPath := m.getRulesetCachePath(name, version)
+	lockFile, err := m.acquireLock(rulesetPath)
+	if err != nil {
+		return fmt.Errorf("failed to acquire cache lock: %w", err)
+	}
+	defer m.releaseLock(lockFile)
+
+	if err := os.RemoveAll(rulesetPath); err != nil {
+		return fmt.Errorf("failed to remove cached ruleset %s@%s: %w", name, version, err)
+	}
+	filteredPath := filepath.Join(m.cacheDir, name, config.FilteredRulesDirName, version)
+	if err := os.RemoveAll(filteredPath); err != nil {
+		return fmt.Errorf("failed to remove filtered rules of %s@%s: %w", name, version, err)
+	}
+	// Only succeeds once no other version has filtered copies left.
+	_ = os.Remove(filepath.Dir(filteredPath))
+	return nil
+}
+
+func (m *Manager) cachedRuleset(name, version string) (*CachedRuleset, error) {
+	rulesetPath := m.getRulesetCachePath(name, version)
+	info, err := os.Stat(rulesetPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat cached ruleset %s@%s: %w", name, version, err)
+	}
+	size, err := DirSize(rulesetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleset := &CachedRuleset{Name: name, Version: version, Path: rulesetPath, SizeBytes: size, modTime: info.ModTime()}
+	if metadata, err := LoadMetadata(filepath.Join(rulesetPath, metadataFileName)); err == nil {
+		ruleset.Metadata = metadata
+	}
+	return ruleset, nil
+}
+
+// isCacheBookkeepingEntry reports whether a name in the rulesets directory
+// belongs to the cache's own bookkeeping rather than to a cached ruleset.
+func isCacheBookkeepingEntry(name string) bool {
+	return name == config.FilteredRulesDirName ||
+		strings.HasPrefix(name, ".import-") ||
+		strings.HasSuffix(name, lockSuffix) ||
+		strings.HasSuffix(name, tempSuffix)
+}
+
+// isValidCacheComponent rejects ruleset names and versions that would
+// resolve outside the ruleset's own cache directory.
+func isValidCacheComponent(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`) && !isCacheBookkeepingEntry(name)
+}
+
+// DirSize returns the total size of the regular files under path.
+func DirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure %s: %w", path, err)
+	}
+	return size, nil
+}
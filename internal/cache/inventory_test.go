@@ -0,0 +1,155 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/config"
+)
+
+func writeInventoryFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestManager_ListRemoveRulesets(t *testing.T) {
+	cacheDir := t.TempDir()
+	manager := &Manager{cacheDir: cacheDir}
+
+	lastAccessed := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	metadata := NewMetadata("dca", "v1.0.4", "abc", 3600)
+	metadata.LastAccessed = lastAccessed
+	latest := filepath.Join(cacheDir, "dca", "latest")
+	writeInventoryFile(t, filepath.Join(latest, "java", "crypto.yaml"), "rules: []\n")
+	if err := metadata.Save(filepath.Join(latest, metadataFileName)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// An interrupted download: rule files without metadata.
+	writeInventoryFile(t, filepath.Join(cacheDir, "dca", "v1.0.3", "java", "crypto.yaml"), "rules: []\n")
+	// Bookkeeping entries that are not rulesets.
+	writeInventoryFile(t, filepath.Join(cacheDir, "dca", config.FilteredRulesDirName, "latest", "run-1", "crypto.yaml"), "rules: []\n")
+	writeInventoryFile(t, filepath.Join(cacheDir, "dca", "latest.lock"), "")
+	writeInventoryFile(t, filepath.Join(cacheDir, "dca", "latest.tmp", "crypto.yaml"), "rules: []\n")
+	writeInventoryFile(t, filepath.Join(cacheDir, ".import-123", "bundle.json"), "{}")
+
+	rulesets, err := manager.ListRulesets()
+	if err != nil {
+		t.Fatalf("ListRulesets() error = %v", err)
+	}
+	if len(rulesets) != 2 || rulesets[0].Version != "latest" || rulesets[1].Version != "v1.0.3" {
+		t.Fatalf("ListRulesets() = %+v, want dca@latest and dca@v1.0.3", rulesets)
+	}
+	if rulesets[0].Metadata == nil || rulesets[0].Metadata.Version != "v1.0.4" || !rulesets[0].LastUsed().Equal(lastAccessed) {
+		t.Errorf("dca@latest = %+v, want metadata for v1.0.4 last used %v", rulesets[0], lastAccessed)
+	}
+	if rulesets[0].SizeBytes == 0 {
+		t.Error("dca@latest SizeBytes = 0")
+	}
+	if rulesets[1].Metadata != nil || rulesets[1].LastUsed().IsZero() {
+		t.Errorf("dca@v1.0.3 = %+v, want no metadata and a directory mtime", rulesets[1])
+	}
+
+	if err := manager.RemoveRuleset("dca", "latest"); err != nil {
+		t.Fatalf("RemoveRuleset() error = %v", err)
+	}
+	for _, removed := range []string{latest, filepath.Join(cacheDir, "dca", config.FilteredRulesDirName)} {
+		if _, err := os.Stat(removed); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after RemoveRuleset()", removed)
+		}
+	}
+	if _, err := manager.RulesetInfo("dca", "latest"); !errors.Is(err, ErrRulesetNotCached) {
+		t.Errorf("RulesetInfo() of removed ruleset error = %v, want ErrRulesetNotCached", err)
+	}
+	if _, err := manager.RulesetInfo("dca", "v1.0.3"); err != nil {
+		t.Errorf("RulesetInfo() of other version error = %v", err)
+	}
+}
+
+func TestManager_RulesetInfo_RejectsNonRulesetPaths(t *testing.T) {
+	cacheDir := t.TempDir()
+	manager := &Manager{cacheDir: cacheDir}
+	writeInventoryFile(t, filepath.Join(cacheDir, "dca", config.FilteredRulesDirName, "x.yaml"), "rules: []\n")
+
+	for _, ref := range [][2]string{
+		{"dca", config.FilteredRulesDirName},
+		{"dca", ".."},
+		{"..", "dca"},
+		{"dca", "../dca"},
+		{"", "latest"},
+	} {
+		if _, err := manager.RulesetInfo(ref[0], ref[1]); !errors.Is(err, ErrRulesetNotCached) {
+			t.Errorf("RulesetInfo(%q, %q) error = %v, want ErrRulesetNotCached", ref[0], ref[1], err)
+		}
+		if err := manager.RemoveRuleset(ref[0], ref[1]); !errors.Is(err, ErrRulesetNotCached) {
+			t.Errorf("RemoveRuleset(%q, %q) error = %v, want ErrRulesetNotCached", ref[0], ref[1], err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "dca")); err != nil {
+		t.Errorf("cache directory was modified: %v", err)
+	}
+}
+
+func TestManager_ListRulesets_EmptyCache(t *testing.T) {
+	manager := &Manager{cacheDir: filepath.Join(t.TempDir(), "missing")}
+	rulesets, err := manager.ListRulesets()
+	if err != nil || len(rulesets) != 0 {
+		t.Errorf("ListRulesets() = %v, %v; want no rulesets", rulesets, err)
+	}
+}
+
+func TestManager_EvictRulesets(t *testing.T) {
+	cacheDir := t.TempDir()
+	manager := &Manager{cacheDir: cacheDir}
+	for _, version := range []string{"v1.0.3", "v1.0.4"} {
+		writeInventoryFile(t, filepath.Join(cacheDir, "dca", version, "java", "crypto.yaml"), "rules: []\n")
+	}
+	evictOld := func(ruleset *CachedRuleset) bool { return ruleset.Version == "v1.0.3" }
+
+	for _, dryRun := range []bool{true, false} {
+		var reported []string
+		eviction, err := manager.EvictRulesets(dryRun, evictOld, func(ruleset *CachedRuleset) error {
+			reported = append(reported, ruleset.Name+"@"+ruleset.Version)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("EvictRulesets(dryRun=%v) error = %v", dryRun, err)
+		}
+		if eviction.Removed != 1 || eviction.Total != 2 || eviction.FreedBytes == 0 {
+			t.Errorf("EvictRulesets(dryRun=%v) = %+v, want 1 of 2 removed", dryRun, eviction)
+		}
+		if len(reported) != 1 || reported[0] != "dca@v1.0.3" {
+			t.Errorf("EvictRulesets(dryRun=%v) reported %v, want dca@v1.0.3", dryRun, reported)
+		}
+		_, err = os.Stat(filepath.Join(cacheDir, "dca", "v1.0.3"))
+		if dryRun == os.IsNotExist(err) {
+			t.Errorf("EvictRulesets(dryRun=%v): stat dca@v1.0.3 = %v", dryRun, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "dca", "v1.0.4")); err != nil {
+		t.Errorf("kept ruleset removed: %v", err)
+	}
+}
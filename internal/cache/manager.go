@@ -34,14 +34,18 @@ import (
 
 	api "github.com/scanoss/crypto-finder/internal/api"
 	"github.com/scanoss/crypto-finder/internal/config"
+	"github.com/scanoss/crypto-finder/internal/failure"
 	"github.com/scanoss/crypto-finder/internal/utils"
 )
 
 const (
 	metadataFileName = ".cache-meta.json"
 	manifestFileName = "manifest.json"
-	tempSuffix       = ".tmp"
-	lockSuffix       = ".lock"
+	// tarballFileName keeps the downloaded tarball next to the extracted
+	// ruleset, so an exported bundle can be verified against its signature.
+	tarballFileName = ".ruleset.tar.gz"
+	tempSuffix      = ".tmp"
+	lockSuffix      = ".lock"
 )
 
 // Manager manages the local cache of downloaded rulesets.
@@ -51,6 +55,7 @@ type Manager struct {
 	noCache          bool
 	strictMode       bool
 	maxStaleCacheAge time.Duration
+	trustedKeys      []TrustedKey
 }
 
 // NewManager creates a new cache manager.
@@ -78,7 +83,8 @@ func (m *Manager) SetNoCache(enabled bool) {
 
 // SetStrictMode enables or disables strict mode.
 // When enabled, the manager will fail if cache is expired and API is unreachable,
-// instead of falling back to stale cache.
+// instead of falling back to stale cache, and refuses rulesets whose signature
+// does not verify against a trusted key.
 func (m *Manager) SetStrictMode(enabled bool) {
 	m.strictMode = enabled
 }
@@ -89,6 +95,11 @@ func (m *Manager) SetMaxStaleCacheAge(maxAge time.Duration) {
 	m.maxStaleCacheAge = maxAge
 }
 
+// SetTrustedKeys sets the public keys ruleset signatures are verified against.
+func (m *Manager) SetTrustedKeys(keys []TrustedKey) {
+	m.trustedKeys = keys
+}
+
 // GetRulesetPath returns the path to a cached ruleset
 // If the ruleset is not cached or expired, it downloads it first.
 // If noCache is enabled, it always downloads a fresh copy and updates the cache.
@@ -217,9 +228,34 @@ func (m *Manager) isCacheValid(rulesetPath, metadataPath string) bool {
 		return false
 	}
 
+	// In strict mode a cache is only usable if it was verified by a key
+	// that is still trusted; otherwise it is downloaded and verified again.
+	if m.strictMode && !m.isTrustedSignature(metadata) {
+		log.Debug().
+			Str("ruleset", metadata.RulesetName).
+			Str("version", metadata.Version).
+			Str("signature", metadata.SignatureStatus).
+			Msg("Cached ruleset was not verified by a trusted key")
+		return false
+	}
+
 	return true
 }
 
+// isTrustedSignature reports whether the cached ruleset was verified by one
+// of the currently trusted keys.
+func (m *Manager) isTrustedSignature(metadata *Metadata) bool {
+	if metadata.SignatureStatus != SignatureVerified {
+		return false
+	}
+	for _, key := range m.trustedKeys {
+		if key.ID == metadata.SignatureKeyID {
+			return true
+		}
+	}
+	return false
+}
+
 // updateLastAccessed updates the last accessed timestamp in the metadata.
 func (m *Manager) updateLastAccessed(metadataPath string) error {
 	metadata, err := LoadMetadata(metadataPath)
@@ -275,7 +311,12 @@ func (m *Manager) downloadAndCache(ctx context.Context, name, version, targetPat
 		Str("checksum", manifest.ChecksumSHA256).
 		Msg("Checksum verified successfully")
 
-	if err := m.persistDownloadedRuleset(name, version, targetPath, tarball, manifest); err != nil {
+	signature := VerifySignature(tarball, manifest.Signature, m.trustedKeys)
+	if err := m.checkSignature(name, version, signature); err != nil {
+		return err
+	}
+
+	if err := m.persistDownloadedRuleset(name, version, targetPath, tarball, manifest, signature); err != nil {
 		return err
 	}
 
@@ -288,6 +329,43 @@ func (m *Manager) downloadAndCache(ctx context.Context, name, version, targetPat
 	return nil
 }
 
+// checkSignature logs the signature verification result and, in strict
+// mode, rejects any ruleset that is not verified by a trusted key.
+func (m *Manager) checkSignature(name, version string, signature SignatureResult) error {
+	if signature.Status == SignatureVerified {
+		log.Debug().
+			Str("ruleset", name).
+			Str("version", version).
+			Str("key_id", signature.KeyID).
+			Msg("Signature verified successfully")
+		return nil
+	}
+
+	var reason string
+	switch signature.Status {
+	case SignatureUnsigned:
+		reason = "ruleset is not signed"
+	case SignatureUnverified:
+		reason = "ruleset is signed but no trusted public key is configured"
+	default:
+		reason = "ruleset signature does not verify against any trusted public key"
+	}
+	if m.strictMode {
+		return failure.New(
+			failure.CodeRulesetSignatureRejected,
+			failure.StageRules,
+			fmt.Sprintf("refusing ruleset %s@%s in strict mode: %s", name, version, reason),
+			failure.WithDetail("signature", signature.Status),
+		)
+	}
+	log.Warn().
+		Str("ruleset", name).
+		Str("version", version).
+		Str("signature", signature.Status).
+		Msg("Using ruleset without a verified signature: " + reason)
+	return nil
+}
+
 func (m *Manager) hasUsableCache(rulesetPath, metadataPath string) bool {
 	if !m.isCacheValid(rulesetPath, metadataPath) {
 		return false
@@ -295,14 +373,14 @@ func (m *Manager) hasUsableCache(rulesetPath, metadataPath string) bool {
 	return utils.ValidateRuleDirNotEmpty(rulesetPath) == nil
 }
 
-func (m *Manager) persistDownloadedRuleset(name, version, targetPath string, tarball []byte, manifest *api.Manifest) error {
+func (m *Manager) persistDownloadedRuleset(name, version, targetPath string, tarball []byte, manifest *api.Manifest, signature SignatureResult) error {
 	tempPath := targetPath + tempSuffix
 	if err := m.extractTarball(tarball, tempPath); err != nil {
 		m.cleanupTempPath(tempPath, name, version)
 		return fmt.Errorf("failed to extract tarball: %w", err)
 	}
 
-	if err := m.writeCacheMetadata(name, version, tempPath, manifest); err != nil {
+	if err := m.writeCacheMetadata(name, version, tempPath, manifest, signature); err != nil {
 		m.cleanupTempPath(tempPath, name, version)
 		return err
 	}
@@ -313,6 +391,11 @@ func (m *Manager) persistDownloadedRuleset(name, version, targetPath string, tar
 		return fmt.Errorf("failed to save manifest: %w", err)
 	}
 
+	if err := os.WriteFile(filepath.Join(tempPath, tarballFileName), tarball, 0o600); err != nil {
+		m.cleanupTempPath(tempPath, name, version)
+		return fmt.Errorf("failed to save tarball: %w", err)
+	}
+
 	if err := m.replaceCachedRuleset(targetPath, tempPath); err != nil {
 		m.cleanupTempPath(tempPath, name, version)
 		return err
@@ -321,7 +404,7 @@ func (m *Manager) persistDownloadedRuleset(name, version, targetPath string, tar
 	return nil
 }
 
-func (m *Manager) writeCacheMetadata(name, version, tempPath string, manifest *api.Manifest) error {
+func (m *Manager) writeCacheMetadata(name, version, tempPath string, manifest *api.Manifest, signature SignatureResult) error {
 	// Create cache metadata. The version we record is the *manifest* version
 	// returned by the SCANOSS API (concrete, e.g. "v1.0.4"), NOT the operator's
 	// request label (which can be "latest" — opaque, indistinguishable across
@@ -331,6 +414,8 @@ func (m *Manager) writeCacheMetadata(name, version, tempPath string, manifest *a
 	// detectable.
 	ttl := m.getTTL(version)
 	metadata := NewMetadata(name, manifest.Version, manifest.ChecksumSHA256, int64(ttl.Seconds()))
+	metadata.SignatureStatus = signature.Status
+	metadata.SignatureKeyID = signature.KeyID
 	tempMetadataPath := filepath.Join(tempPath, metadataFileName)
 	if err := metadata.Save(tempMetadataPath); err != nil {
 		return fmt.Errorf("failed to save metadata: %w", err)
@@ -33,10 +33,23 @@ type Metadata struct {
 	LastAccessed   time.Time `json:"last_accessed"`
 	ChecksumSHA256 string    `json:"checksum_sha256"`
 	TTLSeconds     int64     `json:"ttl_seconds"`
+	// SignatureStatus is the outcome of verifying the tarball signature when
+	// it was downloaded (one of the Signature* constants). Empty for caches
+	// written before signatures were checked.
+	SignatureStatus string `json:"signature_status,omitempty"`
+	// SignatureKeyID identifies the trusted key that verified the signature.
+	SignatureKeyID string `json:"signature_key_id,omitempty"`
+	// Pinned marks a ruleset installed from an offline bundle. Pinned
+	// rulesets never expire and are never too stale for fallback.
+	Pinned bool `json:"pinned,omitempty"`
 }
 
 // IsExpired checks if the cache has expired based on TTL.
+// Pinned caches never expire.
 func (m *Metadata) IsExpired() bool {
+	if m.Pinned {
+		return false
+	}
 	expiryTime := m.DownloadedAt.Add(time.Duration(m.TTLSeconds) * time.Second)
 	return time.Now().After(expiryTime)
 }
@@ -47,7 +60,11 @@ func (m *Metadata) Age() time.Duration {
 }
 
 // IsTooStale checks if the cache is older than the specified maximum age.
+// Pinned caches are never too stale.
 func (m *Metadata) IsTooStale(maxAge time.Duration) bool {
+	if m.Pinned {
+		return false
+	}
 	return m.Age() > maxAge
 }
 
@@ -0,0 +1,135 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Signature statuses recorded in .cache-meta.json and in the scan's RulesInfo.
+const (
+	// SignatureVerified means the tarball signature verifies against a trusted key.
+	SignatureVerified = "verified"
+	// SignatureUnsigned means the API delivered no signature for the tarball.
+	SignatureUnsigned = "unsigned"
+	// SignatureInvalid means the signature is malformed or verifies against
+	// none of the trusted keys.
+	SignatureInvalid = "invalid"
+	// SignatureUnverified means the tarball is signed but no trusted key is
+	// configured to check it against.
+	SignatureUnverified = "unverified"
+)
+
+// PinnedPublicKey is the ed25519 public key (base64 or PEM) that SCANOSS
+// rulesets are signed with. Release builds set it with
+// -ldflags "-X github.com/scanoss/crypto-finder/internal/cache.PinnedPublicKey=...";
+// it is empty in source builds, which then trust only configured keys.
+var PinnedPublicKey = ""
+
+// TrustedKey is a public key ruleset signatures are verified against.
+type TrustedKey struct {
+	// ID identifies the key in metadata and logs: the first 8 bytes of the
+	// SHA-256 of the raw key, hex-encoded.
+	ID  string
+	Key ed25519.PublicKey
+}
+
+// SignatureResult is the outcome of verifying a ruleset tarball.
+type SignatureResult struct {
+	// Status is one of the Signature* constants.
+	Status string
+	// KeyID is the ID of the key that verified the signature. Set only
+	// when Status is SignatureVerified.
+	KeyID string
+}
+
+// LoadPublicKey reads a trusted key from value, which is either the path of
+// a key file or the key itself, PEM-encoded or base64.
+func LoadPublicKey(value string) (TrustedKey, error) {
+	data := []byte(value)
+	if info, err := os.Stat(value); err == nil && !info.IsDir() {
+		// #nosec G304 -- value is a key path supplied by the operator.
+		data, err = os.ReadFile(value)
+		if err != nil {
+			return TrustedKey{}, fmt.Errorf("failed to read public key file: %w", err)
+		}
+	}
+	return ParsePublicKey(data)
+}
+
+// ParsePublicKey parses an ed25519 public key. Accepted encodings are a PEM
+// "PUBLIC KEY" block (PKIX, as written by openssl pkey -pubout), or base64
+// of either the 32 raw key bytes or the PKIX DER.
+func ParsePublicKey(data []byte) (TrustedKey, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return TrustedKey{}, fmt.Errorf("public key is neither PEM nor base64: %w", err)
+		}
+		der = decoded
+	}
+
+	if len(der) == ed25519.PublicKeySize {
+		return newTrustedKey(ed25519.PublicKey(der)), nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return TrustedKey{}, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return TrustedKey{}, fmt.Errorf("unsupported public key type %T (want ed25519)", parsed)
+	}
+	return newTrustedKey(key), nil
+}
+
+func newTrustedKey(key ed25519.PublicKey) TrustedKey {
+	sum := sha256.Sum256(key)
+	return TrustedKey{ID: hex.EncodeToString(sum[:8]), Key: key}
+}
+
+// VerifySignature checks a base64 detached ed25519 signature of data
+// against the trusted keys.
+func VerifySignature(data []byte, signature string, keys []TrustedKey) SignatureResult {
+	if signature == "" {
+		return SignatureResult{Status: SignatureUnsigned}
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return SignatureResult{Status: SignatureInvalid}
+	}
+	if len(keys) == 0 {
+		return SignatureResult{Status: SignatureUnverified}
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key.Key, data, sig) {
+			return SignatureResult{Status: SignatureVerified, KeyID: key.ID}
+		}
+	}
+	return SignatureResult{Status: SignatureInvalid}
+}
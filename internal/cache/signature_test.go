@@ -0,0 +1,261 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	api "github.com/scanoss/crypto-finder/internal/api"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+func newTestKey(t *testing.T) (TrustedKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return newTrustedKey(pub), priv
+}
+
+// createSignedTarballClient returns an API client serving a valid tarball
+// signed with priv, or unsigned when priv is nil.
+func createSignedTarballClient(t *testing.T, priv ed25519.PrivateKey) *api.Client {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	if err := writeRulesetTarball(gzWriter); err != nil {
+		t.Fatalf("Failed to write tarball: %v", err)
+	}
+	_ = gzWriter.Close()
+	tarball := buf.Bytes()
+
+	return newMockAPIClient(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("scanoss-ruleset-name", "dca")
+		w.Header().Set("scanoss-ruleset-version", "v1.0.0")
+		w.Header().Set("x-checksum-sha256", CalculateSHA256(tarball))
+		w.Header().Set("scanoss-ruleset-created-at", time.Now().Format(time.RFC3339))
+		if priv != nil {
+			w.Header().Set("x-signature-ed25519", base64.StdEncoding.EncodeToString(ed25519.Sign(priv, tarball)))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(tarball)
+	})
+}
+
+func TestParsePublicKey(t *testing.T) {
+	t.Parallel()
+
+	key, _ := newTestKey(t)
+	der, err := x509.MarshalPKIXPublicKey(key.Key)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"raw base64", base64.StdEncoding.EncodeToString(key.Key)},
+		{"PKIX base64", base64.StdEncoding.EncodeToString(der)},
+		{"PEM", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParsePublicKey([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParsePublicKey() error = %v", err)
+			}
+			if !got.Key.Equal(key.Key) || got.ID != key.ID || len(got.ID) != 16 {
+				t.Errorf("ParsePublicKey() = %+v, want %+v", got, key)
+			}
+		})
+	}
+
+	for _, bad := range []string{"not a key!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := ParsePublicKey([]byte(bad)); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestLoadPublicKey_File(t *testing.T) {
+	t.Parallel()
+
+	key, _ := newTestKey(t)
+	path := filepath.Join(t.TempDir(), "rules.pub")
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key.Key)+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	got, err := LoadPublicKey(path)
+	if err != nil {
+		t.Fatalf("LoadPublicKey() error = %v", err)
+	}
+	if got.ID != key.ID {
+		t.Errorf("LoadPublicKey() ID = %s, want %s", got.ID, key.ID)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	key, priv := newTestKey(t)
+	otherKey, _ := newTestKey(t)
+	data := []byte("ruleset tarball")
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, data))
+
+	tests := []struct {
+		name      string
+		data      []byte
+		signature string
+		keys      []TrustedKey
+		want      SignatureResult
+	}{
+		{"verified", data, signature, []TrustedKey{otherKey, key}, SignatureResult{Status: SignatureVerified, KeyID: key.ID}},
+		{"unsigned", data, "", []TrustedKey{key}, SignatureResult{Status: SignatureUnsigned}},
+		{"no trusted key", data, signature, nil, SignatureResult{Status: SignatureUnverified}},
+		{"wrong key", data, signature, []TrustedKey{otherKey}, SignatureResult{Status: SignatureInvalid}},
+		{"tampered data", []byte("ruleset tarball!"), signature, []TrustedKey{key}, SignatureResult{Status: SignatureInvalid}},
+		{"malformed signature", data, "not base64", []TrustedKey{key}, SignatureResult{Status: SignatureInvalid}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := VerifySignature(tt.data, tt.signature, tt.keys); got != tt.want {
+				t.Errorf("VerifySignature() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_GetRulesetPath_Signature(t *testing.T) {
+	t.Parallel()
+
+	key, priv := newTestKey(t)
+	otherKey, otherPriv := newTestKey(t)
+
+	tests := []struct {
+		name       string
+		priv       ed25519.PrivateKey
+		keys       []TrustedKey
+		strict     bool
+		wantStatus string
+		wantKeyID  string
+		wantReject bool
+	}{
+		{name: "verified", priv: priv, keys: []TrustedKey{key}, wantStatus: SignatureVerified, wantKeyID: key.ID},
+		{name: "verified strict", priv: priv, keys: []TrustedKey{key}, strict: true, wantStatus: SignatureVerified, wantKeyID: key.ID},
+		{name: "unsigned", keys: []TrustedKey{key}, wantStatus: SignatureUnsigned},
+		{name: "unsigned strict", keys: []TrustedKey{key}, strict: true, wantReject: true},
+		{name: "invalid", priv: otherPriv, keys: []TrustedKey{key}, wantStatus: SignatureInvalid},
+		{name: "invalid strict", priv: otherPriv, keys: []TrustedKey{key}, strict: true, wantReject: true},
+		{name: "no trusted key strict", priv: otherPriv, strict: true, wantReject: true},
+		{name: "second trusted key", priv: otherPriv, keys: []TrustedKey{key, otherKey}, strict: true, wantStatus: SignatureVerified, wantKeyID: otherKey.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manager := &Manager{
+				apiClient:   createSignedTarballClient(t, tt.priv),
+				cacheDir:    t.TempDir(),
+				strictMode:  tt.strict,
+				trustedKeys: tt.keys,
+			}
+			path, err := manager.GetRulesetPath(context.Background(), "dca", "v1.0.0")
+			if tt.wantReject {
+				structured, ok := failure.As(err)
+				if !ok || structured.Code != failure.CodeRulesetSignatureRejected {
+					t.Fatalf("GetRulesetPath() error = %v, want %s", err, failure.CodeRulesetSignatureRejected)
+				}
+				if _, statErr := os.Stat(filepath.Join(manager.cacheDir, "dca", "v1.0.0")); !os.IsNotExist(statErr) {
+					t.Errorf("rejected ruleset was cached (stat error = %v)", statErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRulesetPath() error = %v", err)
+			}
+
+			metadata, err := LoadMetadata(filepath.Join(path, metadataFileName))
+			if err != nil {
+				t.Fatalf("LoadMetadata() error = %v", err)
+			}
+			if metadata.SignatureStatus != tt.wantStatus || metadata.SignatureKeyID != tt.wantKeyID {
+				t.Errorf("metadata signature = %q/%q, want %q/%q",
+					metadata.SignatureStatus, metadata.SignatureKeyID, tt.wantStatus, tt.wantKeyID)
+			}
+		})
+	}
+}
+
+func TestManager_GetRulesetPath_StrictRedownloadsUnverifiedCache(t *testing.T) {
+	t.Parallel()
+
+	key, priv := newTestKey(t)
+	tempDir := t.TempDir()
+	rulesetPath := filepath.Join(tempDir, "dca", "v1.0.0")
+	writeRuleFile(t, filepath.Join(rulesetPath, "semgrep-rules", "example.yaml"))
+
+	// A fresh cache written before signatures were checked.
+	metadata := NewMetadata("dca", "v1.0.0", "checksum123", 3600)
+	if err := metadata.Save(filepath.Join(rulesetPath, metadataFileName)); err != nil {
+		t.Fatalf("Failed to save metadata: %v", err)
+	}
+
+	manager := &Manager{
+		apiClient:   createSignedTarballClient(t, priv),
+		cacheDir:    tempDir,
+		strictMode:  true,
+		trustedKeys: []TrustedKey{key},
+	}
+	if _, err := manager.GetRulesetPath(context.Background(), "dca", "v1.0.0"); err != nil {
+		t.Fatalf("GetRulesetPath() error = %v", err)
+	}
+
+	got, err := LoadMetadata(filepath.Join(rulesetPath, metadataFileName))
+	if err != nil {
+		t.Fatalf("LoadMetadata() error = %v", err)
+	}
+	if got.SignatureStatus != SignatureVerified || got.SignatureKeyID != key.ID {
+		t.Errorf("metadata signature = %q/%q, want verified/%s", got.SignatureStatus, got.SignatureKeyID, key.ID)
+	}
+	if !manager.isCacheValid(rulesetPath, filepath.Join(rulesetPath, metadataFileName)) {
+		t.Error("verified cache is not valid in strict mode")
+	}
+
+	// A key rotation invalidates the verified cache in strict mode.
+	rotated, _ := newTestKey(t)
+	manager.trustedKeys = []TrustedKey{rotated}
+	if manager.isCacheValid(rulesetPath, filepath.Join(rulesetPath, metadataFileName)) {
+		t.Error("cache verified by an untrusted key is valid in strict mode")
+	}
+}
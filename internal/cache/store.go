@@ -0,0 +1,152 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Store is one of the scan's on-disk caches other than the ruleset cache,
+// such as the dependency source cache or the findings cache. Its entries
+// are the files or directories exactly Depth levels below Dir.
+type Store struct {
+	Name  string
+	Dir   string
+	Depth int
+}
+
+// StoreEntry is one entry of a Store.
+type StoreEntry struct {
+	// Name is the entry's slash-separated path relative to the store.
+	Name      string
+	Path      string
+	SizeBytes int64
+	// ModTime is when the entry was last written or, for caches that
+	// record reads, last used.
+	ModTime time.Time
+}
+
+// Entries returns the store's entries sorted by name. A store whose
+// directory does not exist yet has no entries.
+func (s Store) Entries() ([]StoreEntry, error) {
+	var entries []StoreEntry
+	err := filepath.WalkDir(s.Dir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if path == s.Dir && errors.Is(walkErr, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return walkErr
+		}
+		rel, err := filepath.Rel(s.Dir, path)
+		if err != nil {
+			return err
+		}
+		depth := 0
+		if rel != "." {
+			depth = strings.Count(filepath.ToSlash(rel), "/") + 1
+		}
+		if depth < s.Depth {
+			if !d.IsDir() {
+				// Stray files above the entry level are entries of their own.
+				return s.appendEntry(&entries, rel, path, d)
+			}
+			return nil
+		}
+		if err := s.appendEntry(&entries, rel, path, d); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s cache: %w", s.Name, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries, nil
+}
+
+func (s Store) appendEntry(entries *[]StoreEntry, rel, path string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	if d.IsDir() {
+		if size, err = DirSize(path); err != nil {
+			return err
+		}
+	}
+	*entries = append(*entries, StoreEntry{Name: filepath.ToSlash(rel), Path: path, SizeBytes: size, ModTime: info.ModTime()})
+	return nil
+}
+
+// Remove deletes an entry, then the directories above it that the removal
+// left empty, up to but excluding the store directory.
+func (s Store) Remove(entry StoreEntry) error {
+	if err := os.RemoveAll(entry.Path); err != nil {
+		return fmt.Errorf("failed to remove %s cache entry %s: %w", s.Name, entry.Name, err)
+	}
+	for dir := filepath.Dir(entry.Path); dir != s.Dir && len(dir) > len(s.Dir); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			// Not empty, or removed concurrently: either way, done.
+			break
+		}
+	}
+	return nil
+}
+
+// Eviction reports what an eviction removed from one cache, or would have
+// removed in a dry run.
+type Eviction struct {
+	// Removed counts the removed entries out of Total.
+	Removed    int
+	Total      int
+	FreedBytes int64
+}
+
+// Evict removes the entries selected by evict, or only counts them when
+// dryRun is set.
+func (s Store) Evict(dryRun bool, evict func(*StoreEntry) bool) (Eviction, error) {
+	entries, err := s.Entries()
+	if err != nil {
+		return Eviction{}, err
+	}
+	result := Eviction{Total: len(entries)}
+	for i := range entries {
+		entry := &entries[i]
+		if !evict(entry) {
+			continue
+		}
+		if !dryRun {
+			if err := s.Remove(*entry); err != nil {
+				return result, err
+			}
+		}
+		result.Removed++
+		result.FreedBytes += entry.SizeBytes
+	}
+	return result, nil
+}
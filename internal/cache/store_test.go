@@ -0,0 +1,110 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_EntriesRemove(t *testing.T) {
+	dir := t.TempDir()
+	store := Store{Name: "sources", Dir: dir, Depth: 2}
+	writeInventoryFile(t, filepath.Join(dir, "org.bc-bcprov", "1.70", "org", "A.java"), "class A {}")
+	writeInventoryFile(t, filepath.Join(dir, "org.bc-bcprov", "1.78", "B.java"), "class B {}\n")
+	writeInventoryFile(t, filepath.Join(dir, "stray.tmp"), "x")
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "org.bc-bcprov", "1.70"), old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	want := []string{"org.bc-bcprov/1.70", "org.bc-bcprov/1.78", "stray.tmp"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] || names[2] != want[2] {
+		t.Fatalf("Entries() = %v, want %v", names, want)
+	}
+	if entries[0].SizeBytes != int64(len("class A {}")) || !entries[0].ModTime.Equal(old) {
+		t.Errorf("entry %s = %+v, want size %d and mtime %v", entries[0].Name, entries[0], len("class A {}"), old)
+	}
+
+	if err := store.Remove(entries[0]); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "org.bc-bcprov", "1.78")); err != nil {
+		t.Errorf("sibling entry removed: %v", err)
+	}
+	if err := store.Remove(entries[1]); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "org.bc-bcprov")); !os.IsNotExist(err) {
+		t.Errorf("empty parent directory was kept: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("store directory removed: %v", err)
+	}
+}
+
+func TestStore_EntriesMissingDir(t *testing.T) {
+	store := Store{Name: "findings", Dir: filepath.Join(t.TempDir(), "missing"), Depth: 1}
+	entries, err := store.Entries()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Entries() = %v, %v; want no entries", entries, err)
+	}
+}
+
+func TestStore_Evict(t *testing.T) {
+	dir := t.TempDir()
+	store := Store{Name: "findings", Dir: dir, Depth: 1}
+	writeInventoryFile(t, filepath.Join(dir, "old.json"), "old")
+	writeInventoryFile(t, filepath.Join(dir, "new.json"), "new!")
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "old.json"), old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	cutoff := time.Now().Add(-24 * time.Hour)
+	olderThanCutoff := func(entry *StoreEntry) bool { return entry.ModTime.Before(cutoff) }
+
+	for _, dryRun := range []bool{true, false} {
+		eviction, err := store.Evict(dryRun, olderThanCutoff)
+		if err != nil {
+			t.Fatalf("Evict(dryRun=%v) error = %v", dryRun, err)
+		}
+		if want := (Eviction{Removed: 1, Total: 2, FreedBytes: 3}); eviction != want {
+			t.Errorf("Evict(dryRun=%v) = %+v, want %+v", dryRun, eviction, want)
+		}
+		_, err = os.Stat(filepath.Join(dir, "old.json"))
+		if dryRun && err != nil {
+			t.Errorf("dry run removed the old entry: %v", err)
+		}
+		if !dryRun && !os.IsNotExist(err) {
+			t.Errorf("old entry still exists: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.json")); err != nil {
+		t.Errorf("recent entry removed: %v", err)
+	}
+}
@@ -47,6 +47,21 @@ type ParserCloner interface {
 	CloneParser() Parser
 }
 
+// ArtifactParser is implemented by parsers that can build declarations from a
+// package's compiled artifact instead of its source tree. BuildFromDirectories
+// uses it for type-only packages so call chains through dependencies without
+// published sources stay connected.
+type ArtifactParser interface {
+	ParseArtifact(pkg PackageDir) ([]*FileAnalysis, error)
+}
+
+// ImportParser is implemented by parsers that can read the package and
+// imports of source files without extracting their declarations, for passes
+// that only need to know which packages a tree imports.
+type ImportParser interface {
+	ParseImports(dir string, packagePath string) ([]*FileAnalysis, error)
+}
+
 // PackageDir associates a filesystem directory with its package/module path.
 type PackageDir struct {
 	Dir                  string // Absolute filesystem path
@@ -98,8 +113,10 @@ func (b *Builder) PackageSeparator() string {
 //
 // Two-phase approach for performance:
 //   - packages: get full source parsing (user code + deps with findings)
-//   - typeOnlyPackages: used only for bytecode type indexing (no source parsing),
-//     preserving type resolution accuracy for fluent chains across dependency boundaries
+//   - typeOnlyPackages: used for bytecode type indexing (no source parsing),
+//     preserving type resolution accuracy for fluent chains across dependency
+//     boundaries; when the parser implements ArtifactParser their compiled
+//     artifacts also contribute declarations and calls
 func (b *Builder) BuildFromDirectories(packages, typeOnlyPackages []PackageDir) (*CallGraph, error) {
 	buildStart := time.Now()
 	graph := &CallGraph{
@@ -119,6 +136,7 @@ func (b *Builder) BuildFromDirectories(packages, typeOnlyPackages []PackageDir)
 			continue
 		}
 	}
+	b.analyzeArtifacts(typeOnlyPackages, graph)
 	if b.ecosystem == ecosystemCPP {
 		markCPPProjectLocalCalls(graph)
 	}
@@ -214,6 +232,91 @@ func (b *Builder) analyzePackage(pkg PackageDir, graph *CallGraph) error {
 	return b.analyzePackageParallel(pkg, graph, cloner, workers)
 }
 
+// analyzeArtifacts adds declarations read from the compiled artifacts of
+// type-only packages. Declarations already parsed from source keep priority.
+func (b *Builder) analyzeArtifacts(packages []PackageDir, graph *CallGraph) {
+	artifactParser, ok := b.parser.(ArtifactParser)
+	if !ok {
+		return
+	}
+	artifacts, functions := 0, 0
+	for _, pkg := range packages {
+		if pkg.CompiledArtifactPath == "" {
+			continue
+		}
+		analyses, err := artifactParser.ParseArtifact(pkg)
+		if err != nil {
+			log.Debug().Err(err).Str("package", pkg.ImportPath).Msg("Failed to analyze compiled artifact")
+			continue
+		}
+		artifacts++
+		for _, analysis := range analyses {
+			for i := range analysis.Functions {
+				fn := &analysis.Functions[i]
+				key := fn.ID.String()
+				if _, exists := graph.Functions[key]; exists {
+					continue
+				}
+				graph.Functions[key] = fn
+				functions++
+			}
+		}
+	}
+	if artifacts > 0 {
+		log.Info().Int("artifacts", artifacts).Int("functions", functions).Msg("Added call graph declarations from compiled artifacts")
+	}
+}
+
+// ParseImports reads the imports of every source file under pkg, using the
+// same traversal as BuildFromDirectories. The returned analyses carry only the
+// package and import fields. ok is false when the parser does not implement
+// ImportParser.
+func (b *Builder) ParseImports(pkg PackageDir) (analyses []*FileAnalysis, ok bool) {
+	if _, ok := b.parser.(ImportParser); !ok {
+		return nil, false
+	}
+	work := b.collectParseDirs(pkg.Dir, pkg.ImportPath, b.parser.SkipDirs())
+	workers := 1
+	cloner, canClone := b.parser.(ParserCloner)
+	if canClone {
+		workers = min(runtime.GOMAXPROCS(0), len(work))
+	}
+
+	results := make([][]*FileAnalysis, len(work))
+	var next atomic.Int64
+	next.Store(-1)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parser := b.parser.(ImportParser)
+			if canClone && workers > 1 {
+				if cloned, ok := cloner.CloneParser().(ImportParser); ok {
+					parser = cloned
+				}
+			}
+			for {
+				i := int(next.Add(1))
+				if i >= len(work) {
+					return
+				}
+				var err error
+				results[i], err = parser.ParseImports(work[i].dir, work[i].importPath)
+				if err != nil {
+					log.Debug().Err(err).Str("dir", work[i].dir).Msg("Failed to read imports")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range results {
+		analyses = append(analyses, results[i]...)
+	}
+	return analyses, true
+}
+
 // parseDirWork is one directory to parse: the unit of parallelism.
 type parseDirWork struct {
 	dir        string
@@ -453,6 +556,7 @@ func (b *Builder) buildCallerIndex(graph *CallGraph) {
 		methodsByQualifiedArity: indexMethodsByQualifiedArity(graph),
 		subclassByTypeName:      indexSubclassByTypeName(graph),
 		knownClassTypes:         indexKnownClassTypes(graph),
+		externalCFunctions:      b.indexExternalCFunctions(graph),
 		interfaceDispatchMemo:   make(map[string][]interfaceDispatchAlias),
 		abstractDispatchMemo:    make(map[string][]interfaceDispatchAlias),
 		callerSeen:              make(map[string]map[string]struct{}),
@@ -484,6 +588,7 @@ type dispatchIndexes struct {
 	methodsByQualifiedArity map[string][]string
 	subclassByTypeName      map[string][]*FunctionDecl
 	knownClassTypes         map[string]bool
+	externalCFunctions      map[string][]string
 	interfaceDispatchMemo   map[string][]interfaceDispatchAlias
 	abstractDispatchMemo    map[string][]interfaceDispatchAlias
 	callerSeen              map[string]map[string]struct{}
@@ -561,6 +666,16 @@ func (b *Builder) indexCallDispatch(graph *CallGraph, callerKey string, call *Fu
 		idx.addCallerIndexed(graph.Callers, alias, callerKey)
 		recordCallEdgeResolution(graph, callerKey, alias, EdgeKindNameOnly, "", call)
 	}
+
+	externalTargets := expandExternalCLinkage(call, graph, idx.externalCFunctions)
+	kind := EdgeKindExact
+	if len(externalTargets) > 1 {
+		kind = EdgeKindNameOnly
+	}
+	for _, target := range externalTargets {
+		idx.addCallerIndexed(graph.Callers, target, callerKey)
+		recordCallEdgeResolution(graph, callerKey, target, kind, "", call)
+	}
 }
 
 func recordCallEdgeResolution(graph *CallGraph, callerKey, calleeKey string, kind EdgeKind, declaredType string, call *FunctionCall) {
@@ -629,6 +744,43 @@ func indexMethodsByName(graph *CallGraph) map[string][]*FunctionDecl {
 	return index
 }
 
+// indexExternalCFunctions maps each C/C++ free function name to the keys of
+// its externally linked definitions. Parsers qualify an unresolved call with
+// the caller's package, so a call into another directory or a dependency
+// (e.g. mbedtls_aes_setkey_enc) only reaches its definition through this
+// linker-style index. Other ecosystems get a nil index.
+func (b *Builder) indexExternalCFunctions(graph *CallGraph) map[string][]string {
+	if b.ecosystem != ecosystemC && b.ecosystem != ecosystemCPP {
+		return nil
+	}
+
+	index := make(map[string][]string)
+	for key, fn := range graph.Functions {
+		if fn.ID.Type != "" || fn.ID.Linkage == LinkageInternal {
+			continue
+		}
+		index[fn.ID.Name] = append(index[fn.ID.Name], key)
+	}
+	for name := range index {
+		sort.Strings(index[name])
+	}
+	return index
+}
+
+// expandExternalCLinkage resolves a free-function call with no definition in
+// the caller's package to the externally linked definitions of that name.
+// Static (internal linkage) callees never leave their translation unit.
+func expandExternalCLinkage(call *FunctionCall, graph *CallGraph, externalFunctions map[string][]string) []string {
+	callee := call.Callee
+	if len(externalFunctions) == 0 || callee.Type != "" || callee.Linkage == LinkageInternal {
+		return nil
+	}
+	if _, ok := graph.Functions[callee.String()]; ok {
+		return nil
+	}
+	return externalFunctions[callee.Name]
+}
+
 func indexMethodsByQualifiedArity(graph *CallGraph) map[string][]string {
 	index := make(map[string][]string)
 	keys := make([]string, 0, len(graph.Functions))
@@ -9,6 +9,9 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
 
 	"github.com/scanoss/crypto-finder/internal/config"
 )
@@ -197,11 +200,23 @@ func NewDiskBytecodeIndexCacheWithDir(dir string) (*DiskBytecodeIndexCache, erro
 	return &DiskBytecodeIndexCache{dir: dir}, nil
 }
 
-// Get loads a cached bytecode index entry by key.
+// Dir returns the bytecode cache directory.
+func (c *DiskBytecodeIndexCache) Dir() string {
+	return c.dir
+}
+
+// Get loads a cached bytecode index entry by key. A hit refreshes the
+// file's modification time, which `cache prune` treats as its last use.
 func (c *DiskBytecodeIndexCache) Get(_ context.Context, key string) (*CachedBytecodeIndex, bool, error) {
 	path := filepath.Join(c.dir, bytecodeCacheKeyToFilename(key))
 	entry, ok, err := readBytecodeCacheFile(path, bytecodeCacheSchemaVersion)
 	if err != nil || ok {
+		if ok {
+			now := time.Now()
+			if touchErr := os.Chtimes(path, now, now); touchErr != nil {
+				log.Debug().Err(touchErr).Str("path", path).Msg("Failed to record bytecode cache use")
+			}
+		}
 		return entry, ok, err
 	}
 
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCachedBytecodeIndex_MarshalUnmarshalJSON(t *testing.T) {
@@ -423,3 +424,36 @@ func TestLegacyBytecodeCacheKeyToFilename_PreservesLegacyLayout(t *testing.T) {
 		t.Fatalf("legacyBytecodeCacheKeyToFilename(%q) = %q, want %q", key, got, want)
 	}
 }
+
+func TestDiskBytecodeIndexCache_Get_RecordsUse(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskBytecodeIndexCacheWithDir(dir)
+	if err != nil {
+		t.Fatalf("NewDiskBytecodeIndexCacheWithDir: %v", err)
+	}
+	if cache.Dir() != dir {
+		t.Fatalf("Dir() = %q, want %q", cache.Dir(), dir)
+	}
+
+	key := bytecodeCacheStorageKey("io.jsonwebtoken:jjwt-api@0.12.5")
+	entry := &CachedBytecodeIndex{SchemaVersion: bytecodeCacheSchemaVersion, ArtifactKey: "io.jsonwebtoken:jjwt-api@0.12.5"}
+	if err := cache.Put(context.Background(), key, entry); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	path := filepath.Join(dir, bytecodeCacheKeyToFilename(key))
+	old := time.Now().Add(-72 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if _, ok, err := cache.Get(context.Background(), key); err != nil || !ok {
+		t.Fatalf("Get: ok=%v err=%v, want hit", ok, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if !info.ModTime().After(old) {
+		t.Errorf("mtime = %v, want refreshed after hit", info.ModTime())
+	}
+}
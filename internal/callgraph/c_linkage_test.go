@@ -0,0 +1,99 @@
+package callgraph
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCLinkageFile(t *testing.T, path, src string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuilder_CExternalLinkageAcrossPackages(t *testing.T) {
+	appDir := t.TempDir()
+	depDir := t.TempDir()
+	writeCLinkageFile(t, filepath.Join(appDir, "src", "main.c"), `#include <mbedtls/aes.h>
+
+static int helper(void) { return 0; }
+
+int main(void) {
+    mbedtls_aes_context ctx;
+    helper();
+    mbedtls_aes_setkey_enc(&ctx, key, 256);
+    return 0;
+}
+`)
+	writeCLinkageFile(t, filepath.Join(depDir, "library", "aes.c"), `int mbedtls_aes_setkey_enc(mbedtls_aes_context *ctx, const unsigned char *key, unsigned int keybits) {
+    return aes_gen_tables(ctx);
+}
+
+static int helper(void) { return 1; }
+`)
+	writeCLinkageFile(t, filepath.Join(depDir, "_deps", "vendored-src", "copy.c"), "int mbedtls_aes_setkey_enc(void) { return 0; }\n")
+
+	builder := NewBuilderForEcosystem("c", NewCParser())
+	graph, err := builder.BuildFromDirectories([]PackageDir{
+		{Dir: appDir, ImportPath: "firmware"},
+		{Dir: depDir, ImportPath: "mbedtls"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("BuildFromDirectories: %v", err)
+	}
+
+	target := FunctionID{Package: "mbedtls/library", Name: "mbedtls_aes_setkey_enc", Linkage: LinkageExternal}
+	callers := graph.Callers[target.String()]
+	if len(callers) != 1 || callers[0] != "firmware/src.main" {
+		t.Fatalf("Callers[%s] = %v, want [firmware/src.main]", target, callers)
+	}
+	for _, caller := range graph.Callers["mbedtls/library/aes.c.helper"] {
+		t.Fatalf("static helper in the dependency gained caller %s", caller)
+	}
+
+	chains, _ := NewTracer(graph, builder.PackageSeparator()).TraceBackLimited(target, map[string]bool{"firmware": true}, 5, 5)
+	if len(chains) != 1 {
+		t.Fatalf("TraceBackLimited chains = %d, want 1", len(chains))
+	}
+	steps := chains[0].Steps
+	if len(steps) != 2 || steps[0].Function.Name != "main" || steps[0].Line != 8 {
+		t.Fatalf("chain steps = %+v, want main at line 8 then the dependency", steps)
+	}
+}
+
+func TestBuilder_CExternalLinkageAmbiguousIsNameOnly(t *testing.T) {
+	dir := t.TempDir()
+	writeCLinkageFile(t, filepath.Join(dir, "app", "main.c"), "int main(void) { return sign(); }\n")
+	writeCLinkageFile(t, filepath.Join(dir, "a", "sign.c"), "int sign(void) { return 1; }\n")
+	writeCLinkageFile(t, filepath.Join(dir, "b", "sign.c"), "int sign(void) { return 2; }\n")
+
+	graph, err := NewBuilderForEcosystem("c", NewCParser()).BuildFromDirectories([]PackageDir{{Dir: dir, ImportPath: "fw"}}, nil)
+	if err != nil {
+		t.Fatalf("BuildFromDirectories: %v", err)
+	}
+
+	for _, pkg := range []string{"fw/a", "fw/b"} {
+		callee := pkg + ".sign"
+		callers := graph.Callers[callee]
+		if len(callers) != 1 || callers[0] != "fw/app.main" {
+			t.Fatalf("Callers[%s] = %v, want [fw/app.main]", callee, callers)
+		}
+		found := false
+		for _, resolution := range graph.EdgeResolutions {
+			if resolution.calleeKey == callee && resolution.callerKey == "fw/app.main" {
+				found = true
+				if resolution.Kind != EdgeKindNameOnly {
+					t.Fatalf("edge to %s kind = %s, want %s", callee, resolution.Kind, EdgeKindNameOnly)
+				}
+			}
+		}
+		if !found {
+			t.Fatalf("no edge resolution recorded for %s", callee)
+		}
+	}
+}
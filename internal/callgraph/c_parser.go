@@ -374,7 +374,7 @@ func (p *CParser) cReturnSource(expr *sitter.Node, src []byte, filePath, package
 
 // SkipDirs returns build and dependency directories excluded from C traversal.
 func (p *CParser) SkipDirs() map[string]bool {
-	skip := map[string]bool{"build": true, "vendor": true}
+	skip := map[string]bool{"build": true, "vendor": true, "_deps": true, "vcpkg_installed": true}
 	if !p.includeTests {
 		skip["test"] = true
 		skip["tests"] = true
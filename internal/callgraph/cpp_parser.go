@@ -437,7 +437,7 @@ func cppChainRoot(node *sitter.Node) *sitter.Node {
 
 // SkipDirs returns build and dependency directories excluded from C++ traversal.
 func (p *CPPParser) SkipDirs() map[string]bool {
-	skip := map[string]bool{"build": true, "vendor": true}
+	skip := map[string]bool{"build": true, "vendor": true, "_deps": true, "vcpkg_installed": true}
 	if !p.includeTests {
 		skip["test"] = true
 		skip["tests"] = true
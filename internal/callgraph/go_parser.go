@@ -81,6 +81,19 @@ func (p *GoParser) ParseFile(filePath, packagePath string) (*FileAnalysis, error
 
 // ParseDirectory parses all .go files in a directory.
 func (p *GoParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, error) {
+	return p.parseDirectory(dir, packagePath, p.ParseFile)
+}
+
+// ParseImports reads the package clause and imports of all .go files in a
+// directory, without extracting declarations.
+func (p *GoParser) ParseImports(dir, packagePath string) ([]*FileAnalysis, error) {
+	return p.parseDirectory(dir, packagePath, p.parseFileImports)
+}
+
+func (p *GoParser) parseDirectory(
+	dir, packagePath string,
+	parseFile func(filePath, packagePath string) (*FileAnalysis, error),
+) ([]*FileAnalysis, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("reading directory %s: %w", dir, err)
@@ -97,7 +110,7 @@ func (p *GoParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, err
 		}
 
 		fullPath := filepath.Join(dir, name)
-		analysis, err := p.ParseFile(fullPath, packagePath)
+		analysis, err := parseFile(fullPath, packagePath)
 		if err != nil {
 			log.Error().Err(err).Str("file", fullPath).Str("package", packagePath).Msg("failed to parse file")
 			continue
@@ -108,6 +121,30 @@ func (p *GoParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, err
 	return analyses, nil
 }
 
+// parseFileImports extracts the package name and imports of a single Go file.
+func (p *GoParser) parseFileImports(filePath, packagePath string) (*FileAnalysis, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filePath, err)
+	}
+
+	tree, err := p.parser.ParseCtx(context.TODO(), nil, src)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filePath, err)
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	analysis := &FileAnalysis{
+		FilePath:    filePath,
+		PackagePath: packagePath,
+		Imports:     make(map[string]string),
+	}
+	analysis.PackageName = p.extractPackageName(root, src)
+	p.extractImports(root, src, analysis)
+	return analysis, nil
+}
+
 // SkipDirs returns directory names to skip during Go package traversal.
 func (p *GoParser) SkipDirs() map[string]bool {
 	return map[string]bool{"vendor": true, "testdata": true}
@@ -0,0 +1,1511 @@
+package callgraph
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// javaArtifactEntrySeparator joins an archive path and the class entry inside
+// it in FunctionDecl.FilePath, following the JVM "jar:" URL convention
+// (e.g. "/m2/foo-1.0.jar!/com/example/Foo.class").
+const javaArtifactEntrySeparator = "!/"
+
+// JVM access flags (JVMS §4.1, §4.6) read from class and method headers.
+const (
+	javaAccPublic    = 0x0001
+	javaAccPrivate   = 0x0002
+	javaAccProtected = 0x0004
+	javaAccStatic    = 0x0008
+	javaAccBridge    = 0x0040
+	javaAccInterface = 0x0200
+)
+
+// Bootstrap owners whose invokedynamic call sites are modelled explicitly.
+const (
+	javaLambdaMetafactory   = "java.lang.invoke.LambdaMetafactory"
+	javaStringConcatFactory = "java.lang.invoke.StringConcatFactory"
+)
+
+// JVM opcodes the bytecode call extractor interprets individually. Grouped
+// opcodes (loads, stores, arithmetic, conversions) are handled by range.
+const (
+	opAconstNull      = 0x01
+	opIconstM1        = 0x02
+	opIconst5         = 0x08
+	opLconst0         = 0x09
+	opLconst1         = 0x0a
+	opFconst0         = 0x0b
+	opFconst2         = 0x0d
+	opDconst0         = 0x0e
+	opDconst1         = 0x0f
+	opBipush          = 0x10
+	opSipush          = 0x11
+	opLdc             = 0x12
+	opLdcW            = 0x13
+	opLdc2W           = 0x14
+	opIload           = 0x15
+	opAload           = 0x19
+	opIload0          = 0x1a
+	opAload3          = 0x2d
+	opIaload          = 0x2e
+	opSaload          = 0x35
+	opIstore          = 0x36
+	opAstore          = 0x3a
+	opIstore0         = 0x3b
+	opAstore3         = 0x4e
+	opIastore         = 0x4f
+	opSastore         = 0x56
+	opPop             = 0x57
+	opPop2            = 0x58
+	opDup             = 0x59
+	opDupX1           = 0x5a
+	opDupX2           = 0x5b
+	opDup2            = 0x5c
+	opDup2X1          = 0x5d
+	opDup2X2          = 0x5e
+	opSwap            = 0x5f
+	opIadd            = 0x60
+	opIneg            = 0x74
+	opDneg            = 0x77
+	opLxor            = 0x83
+	opIinc            = 0x84
+	opI2l             = 0x85
+	opI2s             = 0x93
+	opLcmp            = 0x94
+	opDcmpg           = 0x98
+	opIfeq            = 0x99
+	opIfIcmpeq        = 0x9f
+	opIfAcmpne        = 0xa6
+	opGoto            = 0xa7
+	opJsr             = 0xa8
+	opRet             = 0xa9
+	opTableswitch     = 0xaa
+	opLookupswitch    = 0xab
+	opIreturn         = 0xac
+	opReturn          = 0xb1
+	opGetstatic       = 0xb2
+	opPutstatic       = 0xb3
+	opGetfield        = 0xb4
+	opPutfield        = 0xb5
+	opInvokevirtual   = 0xb6
+	opInvokespecial   = 0xb7
+	opInvokestatic    = 0xb8
+	opInvokeinterface = 0xb9
+	opInvokedynamic   = 0xba
+	opNew             = 0xbb
+	opNewarray        = 0xbc
+	opAnewarray       = 0xbd
+	opArraylength     = 0xbe
+	opAthrow          = 0xbf
+	opCheckcast       = 0xc0
+	opInstanceof      = 0xc1
+	opMonitorenter    = 0xc2
+	opMonitorexit     = 0xc3
+	opWide            = 0xc4
+	opMultianewarray  = 0xc5
+	opIfnull          = 0xc6
+	opIfnonnull       = 0xc7
+	opGotoW           = 0xc8
+	opJsrW            = 0xc9
+)
+
+// javaInstructionSizes holds the fixed encoded length of every opcode; zero
+// marks variable-length (tableswitch, lookupswitch, wide) or undefined opcodes.
+var javaInstructionSizes = func() [256]int {
+	var sizes [256]int
+	for op := 0; op <= opJsrW; op++ {
+		sizes[op] = 1
+	}
+	for _, op := range []int{opBipush, opLdc, opNewarray, opRet} {
+		sizes[op] = 2
+	}
+	for op := opIload; op <= opAload; op++ {
+		sizes[op] = 2
+	}
+	for op := opIstore; op <= opAstore; op++ {
+		sizes[op] = 2
+	}
+	for _, op := range []int{opSipush, opLdcW, opLdc2W, opIinc, opNew, opAnewarray, opCheckcast, opInstanceof, opIfnull, opIfnonnull} {
+		sizes[op] = 3
+	}
+	for op := opIfeq; op <= opJsr; op++ {
+		sizes[op] = 3
+	}
+	for op := opGetstatic; op <= opInvokestatic; op++ {
+		sizes[op] = 3
+	}
+	sizes[opMultianewarray] = 4
+	for _, op := range []int{opInvokeinterface, opInvokedynamic, opGotoW, opJsrW} {
+		sizes[op] = 5
+	}
+	for _, op := range []int{opTableswitch, opLookupswitch, opWide} {
+		sizes[op] = 0
+	}
+	return sizes
+}()
+
+// ParseArtifact implements ArtifactParser over .class files, so dependencies
+// that never publish a sources JAR still contribute declarations and call
+// edges. CompiledArtifactPath may name a JAR or an exploded classes directory.
+func (p *JavaParser) ParseArtifact(pkg PackageDir) ([]*FileAnalysis, error) {
+	if pkg.CompiledArtifactPath == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(pkg.CompiledArtifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("stat compiled artifact %s: %w", pkg.CompiledArtifactPath, err)
+	}
+	if info.IsDir() {
+		return parseJavaClassDirectory(pkg.CompiledArtifactPath)
+	}
+	return parseJavaClassArchive(pkg.CompiledArtifactPath)
+}
+
+// parseJavaClassArchive builds one FileAnalysis per class file in a JAR.
+// Unparseable entries are logged and skipped, mirroring extractFromZIPArchive.
+func parseJavaClassArchive(archivePath string) ([]*FileAnalysis, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Java archive %s: %w", archivePath, err)
+	}
+	defer func() {
+		if cerr := reader.Close(); cerr != nil {
+			log.Debug().Err(cerr).Str("archive", archivePath).Msg("Failed to close Java archive reader")
+		}
+	}()
+
+	analyses := make([]*FileAnalysis, 0, len(reader.File))
+	for _, f := range reader.File {
+		if !isJavaCallGraphClassEntry(f.Name) {
+			continue
+		}
+		data, err := readZIPEntry(f)
+		if err != nil {
+			log.Debug().Err(err).Str("class", f.Name).Msg("Failed to read class file")
+			continue
+		}
+		analysis, err := parseJavaClassCallGraph(data, filepath.ToSlash(archivePath)+javaArtifactEntrySeparator+f.Name)
+		if err != nil {
+			log.Debug().Err(err).Str("class", f.Name).Msg("Failed to parse class file")
+			continue
+		}
+		analyses = append(analyses, analysis)
+	}
+	return analyses, nil
+}
+
+// parseJavaClassDirectory builds one FileAnalysis per class file below an
+// exploded classes directory (e.g. a Gradle or Maven target/classes output).
+func parseJavaClassDirectory(root string) ([]*FileAnalysis, error) {
+	var analyses []*FileAnalysis
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, relErr := filepath.Rel(root, path)
+		if relErr != nil || d.IsDir() || !isJavaCallGraphClassEntry(filepath.ToSlash(rel)) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Debug().Err(err).Str("class", path).Msg("Failed to read class file")
+			return nil
+		}
+		analysis, err := parseJavaClassCallGraph(data, path)
+		if err != nil {
+			log.Debug().Err(err).Str("class", path).Msg("Failed to parse class file")
+			return nil
+		}
+		analyses = append(analyses, analysis)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking classes directory %s: %w", root, err)
+	}
+	return analyses, nil
+}
+
+// isJavaCallGraphClassEntry reports whether an archive entry holds a class
+// whose methods belong in the call graph. Module/package descriptors carry no
+// code, and multi-release overlays under META-INF/versions would duplicate the
+// base classes.
+func isJavaCallGraphClassEntry(name string) bool {
+	if !strings.HasSuffix(name, ".class") || strings.HasPrefix(name, "META-INF/") {
+		return false
+	}
+	base := name[strings.LastIndex(name, "/")+1:]
+	return base != "module-info.class" && base != "package-info.class"
+}
+
+// bytecodeClass is a class file parsed far enough to walk method bodies.
+type bytecodeClass struct {
+	filePath         string
+	cp               []cpEntry
+	access           int
+	binaryName       string // e.g. "com.example.Outer$Inner"
+	superClass       string
+	interfaces       []string
+	methods          []bytecodeMethod
+	bootstrapMethods []bytecodeBootstrapMethod
+}
+
+type bytecodeMethod struct {
+	access     int
+	name       string
+	descriptor string
+	code       []byte
+	handlers   []int
+	lines      []bytecodeLine
+	locals     []bytecodeLocal
+}
+
+type bytecodeLine struct {
+	pc   int
+	line int
+}
+
+type bytecodeLocal struct {
+	start      int
+	length     int
+	slot       int
+	name       string
+	descriptor string
+}
+
+type bytecodeBootstrapMethod struct {
+	handle int
+	args   []int
+}
+
+// parseJavaClassCallGraph decodes one class file into a FileAnalysis whose
+// FunctionDecls mirror what JavaParser produces from source: Java package,
+// source-style nested type names, "name#arity" method names, and calls taken
+// from invoke* instructions with LDC/immediate constants surfaced as VALUE
+// argument sources.
+func parseJavaClassCallGraph(data []byte, filePath string) (*FileAnalysis, error) {
+	class, err := parseBytecodeClass(data, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, typeName := javaBytecodeFunctionOwner(class.binaryName)
+	analysis := &FileAnalysis{
+		FilePath:    filePath,
+		PackageName: pkg,
+		PackagePath: pkg,
+		Imports:     make(map[string]string),
+	}
+
+	var bases []string
+	if class.superClass != "" {
+		bases = append(bases, javaBytecodeSimpleTypeName(class.superClass))
+	}
+	for _, iface := range class.interfaces {
+		bases = append(bases, javaBytecodeSimpleTypeName(iface))
+	}
+	recordJavaClassBases(analysis, typeName, bases)
+
+	decls := make([]*FunctionDecl, 0, len(class.methods))
+	for i := range class.methods {
+		if decl := class.buildFunctionDecl(&class.methods[i], pkg, typeName); decl != nil {
+			decls = append(decls, decl)
+		}
+	}
+	disambiguateJavaMethodOverloads(decls)
+	appendJavaDecls(analysis, decls)
+	return analysis, nil
+}
+
+func parseBytecodeClass(data []byte, filePath string) (*bytecodeClass, error) {
+	cp, offset, err := parseClassConstantPool(data)
+	if err != nil {
+		return nil, err
+	}
+	if offset+2 > len(data) {
+		return nil, fmt.Errorf("unexpected end reading access flags")
+	}
+	class := &bytecodeClass{filePath: filePath, cp: cp, access: int(binary.BigEndian.Uint16(data[offset:]))}
+
+	_, class.binaryName, offset, err = parseClassIdentity(data, cp, offset)
+	if err != nil {
+		return nil, err
+	}
+	if class.superClass, offset, err = parseClassSuperClass(data, cp, offset); err != nil {
+		return nil, err
+	}
+	if class.interfaces, offset, err = parseClassInterfaces(data, cp, offset); err != nil {
+		return nil, err
+	}
+	if offset, err = skipFieldsOrMethods(data, offset, cp); err != nil {
+		return nil, fmt.Errorf("failed to skip fields: %w", err)
+	}
+	if class.methods, offset, err = parseBytecodeMethods(data, cp, offset); err != nil {
+		return nil, err
+	}
+	class.bootstrapMethods = parseBootstrapMethods(data, cp, offset)
+	return class, nil
+}
+
+func parseBytecodeMethods(data []byte, cp []cpEntry, offset int) ([]bytecodeMethod, int, error) {
+	if offset+2 > len(data) {
+		return nil, offset, fmt.Errorf("unexpected end reading methods count")
+	}
+	count := int(binary.BigEndian.Uint16(data[offset:]))
+	offset += 2
+
+	methods := make([]bytecodeMethod, 0, count)
+	for range count {
+		if offset+8 > len(data) {
+			return nil, offset, fmt.Errorf("unexpected end in method")
+		}
+		method := bytecodeMethod{access: int(binary.BigEndian.Uint16(data[offset:]))}
+		method.name, method.descriptor = methodSignatureStrings(cp,
+			int(binary.BigEndian.Uint16(data[offset+2:])), int(binary.BigEndian.Uint16(data[offset+4:])))
+		attrCount := int(binary.BigEndian.Uint16(data[offset+6:]))
+		offset += 8
+		for range attrCount {
+			name, body, next, err := readClassAttribute(data, cp, offset)
+			if err != nil {
+				return nil, offset, err
+			}
+			if name == "Code" {
+				parseBytecodeCodeAttribute(body, cp, &method)
+			}
+			offset = next
+		}
+		methods = append(methods, method)
+	}
+	return methods, offset, nil
+}
+
+// readClassAttribute returns the name and body of the attribute at offset and
+// the offset just past it.
+func readClassAttribute(data []byte, cp []cpEntry, offset int) (string, []byte, int, error) {
+	if offset+6 > len(data) {
+		return "", nil, offset, fmt.Errorf("unexpected end in attribute")
+	}
+	nameIdx := int(binary.BigEndian.Uint16(data[offset:]))
+	length := int(binary.BigEndian.Uint32(data[offset+2:]))
+	body := offset + 6
+	if length < 0 || body+length > len(data) {
+		return "", nil, offset, fmt.Errorf("truncated attribute")
+	}
+	name := ""
+	if nameIdx > 0 && nameIdx < len(cp) {
+		name = cp[nameIdx].strValue
+	}
+	return name, data[body : body+length], body + length, nil
+}
+
+// parseBytecodeCodeAttribute reads the instructions, exception handler entry
+// points, and the LineNumberTable/LocalVariableTable debug attributes of a
+// Code attribute (JVMS §4.7.3). A malformed attribute leaves the method
+// without a body rather than failing the class.
+func parseBytecodeCodeAttribute(body []byte, cp []cpEntry, method *bytecodeMethod) {
+	if len(body) < 8 {
+		return
+	}
+	codeLen := int(binary.BigEndian.Uint32(body[4:]))
+	offset := 8
+	if codeLen < 0 || offset+codeLen+2 > len(body) {
+		return
+	}
+	method.code = body[offset : offset+codeLen]
+	offset += codeLen
+
+	handlerCount := int(binary.BigEndian.Uint16(body[offset:]))
+	offset += 2
+	for range handlerCount {
+		if offset+8 > len(body) {
+			return
+		}
+		method.handlers = append(method.handlers, int(binary.BigEndian.Uint16(body[offset+4:])))
+		offset += 8
+	}
+	if offset+2 > len(body) {
+		return
+	}
+	attrCount := int(binary.BigEndian.Uint16(body[offset:]))
+	offset += 2
+	for range attrCount {
+		name, attr, next, err := readClassAttribute(body, cp, offset)
+		if err != nil {
+			return
+		}
+		switch name {
+		case "LineNumberTable":
+			method.lines = append(method.lines, parseLineNumberTable(attr)...)
+		case "LocalVariableTable":
+			method.locals = append(method.locals, parseLocalVariableTable(attr, cp)...)
+		}
+		offset = next
+	}
+	sort.SliceStable(method.lines, func(i, j int) bool { return method.lines[i].pc < method.lines[j].pc })
+}
+
+func parseLineNumberTable(attr []byte) []bytecodeLine {
+	if len(attr) < 2 {
+		return nil
+	}
+	count := int(binary.BigEndian.Uint16(attr))
+	lines := make([]bytecodeLine, 0, count)
+	for i := range count {
+		entry := 2 + i*4
+		if entry+4 > len(attr) {
+			break
+		}
+		lines = append(lines, bytecodeLine{
+			pc:   int(binary.BigEndian.Uint16(attr[entry:])),
+			line: int(binary.BigEndian.Uint16(attr[entry+2:])),
+		})
+	}
+	return lines
+}
+
+func parseLocalVariableTable(attr []byte, cp []cpEntry) []bytecodeLocal {
+	if len(attr) < 2 {
+		return nil
+	}
+	count := int(binary.BigEndian.Uint16(attr))
+	locals := make([]bytecodeLocal, 0, count)
+	for i := range count {
+		entry := 2 + i*10
+		if entry+10 > len(attr) {
+			break
+		}
+		name, descriptor := methodSignatureStrings(cp,
+			int(binary.BigEndian.Uint16(attr[entry+4:])), int(binary.BigEndian.Uint16(attr[entry+6:])))
+		locals = append(locals, bytecodeLocal{
+			start:      int(binary.BigEndian.Uint16(attr[entry:])),
+			length:     int(binary.BigEndian.Uint16(attr[entry+2:])),
+			slot:       int(binary.BigEndian.Uint16(attr[entry+8:])),
+			name:       name,
+			descriptor: descriptor,
+		})
+	}
+	return locals
+}
+
+// parseBootstrapMethods reads the class-level BootstrapMethods attribute
+// (JVMS §4.7.23) that invokedynamic instructions index into. Any other class
+// attribute is skipped; truncation yields whatever was read so far.
+func parseBootstrapMethods(data []byte, cp []cpEntry, offset int) []bytecodeBootstrapMethod {
+	if offset+2 > len(data) {
+		return nil
+	}
+	attrCount := int(binary.BigEndian.Uint16(data[offset:]))
+	offset += 2
+	for range attrCount {
+		name, attr, next, err := readClassAttribute(data, cp, offset)
+		if err != nil {
+			return nil
+		}
+		if name == "BootstrapMethods" {
+			return decodeBootstrapMethods(attr)
+		}
+		offset = next
+	}
+	return nil
+}
+
+func decodeBootstrapMethods(attr []byte) []bytecodeBootstrapMethod {
+	if len(attr) < 2 {
+		return nil
+	}
+	count := int(binary.BigEndian.Uint16(attr))
+	methods := make([]bytecodeBootstrapMethod, 0, count)
+	offset := 2
+	for range count {
+		if offset+4 > len(attr) {
+			break
+		}
+		method := bytecodeBootstrapMethod{handle: int(binary.BigEndian.Uint16(attr[offset:]))}
+		argCount := int(binary.BigEndian.Uint16(attr[offset+2:]))
+		offset += 4
+		for range argCount {
+			if offset+2 > len(attr) {
+				return append(methods, method)
+			}
+			method.args = append(method.args, int(binary.BigEndian.Uint16(attr[offset:])))
+			offset += 2
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// buildFunctionDecl converts one method into a FunctionDecl. Bridge methods
+// are compiler-generated overload forwarders and are skipped; synthetic lambda
+// bodies are kept so invokedynamic edges have a target.
+func (c *bytecodeClass) buildFunctionDecl(method *bytecodeMethod, pkg, typeName string) *FunctionDecl {
+	if method.name == "" || method.descriptor == "" || method.access&javaAccBridge != 0 {
+		return nil
+	}
+	paramTypes, returnType := parseMethodDescriptor(method.descriptor)
+
+	ownerType := ownerTypeClass
+	if c.access&javaAccInterface != 0 {
+		ownerType = ownerTypeInterface
+	}
+	ownerVisibility := VisibilityPackagePrivate
+	if c.access&javaAccPublic != 0 {
+		ownerVisibility = VisibilityPublic
+	}
+
+	decl := &FunctionDecl{
+		FilePath:        c.filePath,
+		OwnerType:       ownerType,
+		OwnerName:       typeName,
+		FunctionType:    javaFunctionTypeMethod,
+		ReturnType:      javaBytecodeSimpleTypeName(returnType),
+		Visibility:      javaBytecodeVisibility(method.access),
+		OwnerVisibility: ownerVisibility,
+	}
+	switch method.name {
+	case constructorMethodName:
+		decl.FunctionType = javaFunctionTypeConstructor
+		decl.ReturnType = typeName
+	case clinitMethodName:
+		decl.FunctionType = javaFunctionTypeClassInit
+		decl.ReturnType = ""
+		decl.Visibility = VisibilityPrivate
+	}
+	decl.ID = FunctionID{Package: pkg, Type: typeName, Name: javaMethodWithArity(method.name, len(paramTypes))}
+
+	slot := 0
+	if method.access&javaAccStatic == 0 {
+		slot = 1
+	}
+	for _, paramType := range paramTypes {
+		decl.Parameters = append(decl.Parameters, FunctionParameter{
+			Type: javaBytecodeSimpleTypeName(paramType),
+			Name: method.localName(slot, 0),
+		})
+		slot += javaBytecodeSlotWidth(paramType)
+	}
+
+	if len(method.lines) > 0 {
+		decl.StartLine, decl.EndLine = method.lines[0].line, method.lines[0].line
+		for _, line := range method.lines {
+			decl.StartLine = min(decl.StartLine, line.line)
+			decl.EndLine = max(decl.EndLine, line.line)
+		}
+	}
+	if len(method.code) > 0 {
+		decl.Calls = newBytecodeFrame(c, method, paramTypes).run()
+	}
+	return decl
+}
+
+func javaBytecodeVisibility(access int) string {
+	switch {
+	case access&javaAccPublic != 0:
+		return VisibilityPublic
+	case access&javaAccProtected != 0:
+		return VisibilityProtected
+	case access&javaAccPrivate != 0:
+		return VisibilityPrivate
+	default:
+		return VisibilityPackagePrivate
+	}
+}
+
+// localName returns the debug name of a local variable slot live at pc, or
+// an empty string when the class was compiled without -g.
+func (m *bytecodeMethod) localName(slot, pc int) string {
+	if local := m.local(slot, pc); local != nil {
+		return local.name
+	}
+	return ""
+}
+
+func (m *bytecodeMethod) local(slot, pc int) *bytecodeLocal {
+	for i := range m.locals {
+		local := &m.locals[i]
+		if local.slot == slot && pc >= local.start && pc <= local.start+local.length {
+			return local
+		}
+	}
+	return nil
+}
+
+// lineAt maps a bytecode offset to its source line via the LineNumberTable.
+func (m *bytecodeMethod) lineAt(pc int) int {
+	line := 0
+	for _, entry := range m.lines {
+		if entry.pc > pc {
+			break
+		}
+		line = entry.line
+	}
+	return line
+}
+
+// javaBytecodeFunctionOwner splits a binary class name into the package and
+// the owner type name JavaParser uses: member classes are dotted
+// ("Outer.Inner") while anonymous classes keep javac's "$N" suffix.
+func javaBytecodeFunctionOwner(binaryName string) (string, string) {
+	pkg := ""
+	simple := binaryName
+	if lastDot := strings.LastIndex(binaryName, "."); lastDot >= 0 {
+		pkg, simple = binaryName[:lastDot], binaryName[lastDot+1:]
+	}
+	return pkg, javaBytecodeNestedName(simple)
+}
+
+func javaBytecodeNestedName(simple string) string {
+	parts := strings.Split(simple, "$")
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part != "" && part[0] >= '0' && part[0] <= '9' {
+			b.WriteString("$")
+		} else {
+			b.WriteString(".")
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// javaBytecodeSimpleTypeName reduces a descriptor type such as
+// "java.lang.String[]" or "com.example.Outer$Inner" to the simple form the
+// source parser records ("String[]", "Outer.Inner").
+func javaBytecodeSimpleTypeName(typeName string) string {
+	base := strings.TrimRight(typeName, "[]")
+	suffix := typeName[len(base):]
+	_, simple := javaBytecodeFunctionOwner(base)
+	return simple + suffix
+}
+
+func javaBytecodeSlotWidth(typeName string) int {
+	if typeName == "long" || typeName == "double" {
+		return 2
+	}
+	return 1
+}
+
+// javaBytecodeFunctionID builds the callee identity for a member reference
+// owner. Array receivers (e.g. "[B".clone()) dispatch to java.lang.Object.
+func javaBytecodeFunctionID(owner, name string, arity int) FunctionID {
+	if strings.HasPrefix(owner, "[") {
+		owner = "java.lang.Object"
+	}
+	pkg, typeName := javaBytecodeFunctionOwner(owner)
+	return FunctionID{Package: pkg, Type: typeName, Name: javaMethodWithArity(name, arity)}
+}
+
+// memberRef resolves a Fieldref/Methodref/InterfaceMethodref entry into its
+// owner class, member name, and descriptor.
+func (c *bytecodeClass) memberRef(index int) (string, string, string) {
+	if index <= 0 || index >= len(c.cp) {
+		return "", "", ""
+	}
+	ref := c.cp[index]
+	name, descriptor := c.nameAndType(ref.refIndex)
+	return classNameFromConstantPool(c.cp, ref.intValue), name, descriptor
+}
+
+func (c *bytecodeClass) nameAndType(index int) (string, string) {
+	if index <= 0 || index >= len(c.cp) || c.cp[index].tag != 12 {
+		return "", ""
+	}
+	return methodSignatureStrings(c.cp, c.cp[index].intValue, c.cp[index].refIndex)
+}
+
+// bytecodeValue is the abstract content of one operand stack entry or local
+// variable: the source nodes describing where it came from plus the text a
+// source parser would have seen for the same expression.
+type bytecodeValue struct {
+	nodes []SourceNode
+	text  string
+	// wide marks long/double values, which occupy two stack slots.
+	wide bool
+	// newSite is the offset of the `new` that produced an uninitialized
+	// object, or -1. The matching <init> call replaces every copy.
+	newSite int
+	// callIndex is the index of the call whose result this is, or -1. Used to
+	// link fluent chains and record AssignedVar.
+	callIndex int
+	depth     int
+}
+
+func unknownBytecodeValue() bytecodeValue {
+	return bytecodeValue{newSite: -1, callIndex: -1}
+}
+
+func sourceBytecodeValue(node SourceNode, text string, children ...bytecodeValue) bytecodeValue {
+	value := bytecodeValue{text: text, newSite: -1, callIndex: -1, depth: 1}
+	for _, child := range children {
+		if len(child.nodes) == 0 || child.depth >= maxTraceDepth {
+			continue
+		}
+		node.SourceNodes = append(node.SourceNodes, child.nodes...)
+		value.depth = max(value.depth, child.depth+1)
+	}
+	value.nodes = []SourceNode{node}
+	return value
+}
+
+func constantBytecodeValue(literal string) bytecodeValue {
+	return sourceBytecodeValue(SourceNode{Type: "VALUE", Value: literal}, literal)
+}
+
+// bytecodeFrame abstractly interprets one method body, tracking operand stack
+// and local variable provenance along the linear instruction stream. Forward
+// branch targets receive the stack state of every branch that reaches them;
+// backward branches are not followed, so loop-carried values keep the state of
+// the first iteration.
+type bytecodeFrame struct {
+	class     *bytecodeClass
+	method    *bytecodeMethod
+	stack     []bytecodeValue
+	locals    map[int]bytecodeValue
+	pending   map[int][]bytecodeValue
+	reachable bool
+	calls     []FunctionCall
+	callSites []int
+}
+
+func newBytecodeFrame(class *bytecodeClass, method *bytecodeMethod, paramTypes []string) *bytecodeFrame {
+	f := &bytecodeFrame{
+		class:     class,
+		method:    method,
+		locals:    make(map[int]bytecodeValue),
+		pending:   make(map[int][]bytecodeValue),
+		reachable: true,
+	}
+	slot := 0
+	if method.access&javaAccStatic == 0 {
+		f.locals[0] = sourceBytecodeValue(SourceNode{Type: "EXPRESSION", Value: "this"}, "this")
+		slot = 1
+	}
+	for i, paramType := range paramTypes {
+		name := method.localName(slot, 0)
+		if name == "" {
+			name = "arg" + strconv.Itoa(i)
+		}
+		value := sourceBytecodeValue(SourceNode{
+			Type:           javaSourceTypeParameter,
+			Name:           name,
+			DeclaredType:   paramType,
+			ParameterIndex: i,
+		}, name)
+		value.wide = javaBytecodeSlotWidth(paramType) == 2
+		f.locals[slot] = value
+		slot += javaBytecodeSlotWidth(paramType)
+	}
+	for _, handler := range method.handlers {
+		f.pending[handler] = []bytecodeValue{unknownBytecodeValue()}
+	}
+	return f
+}
+
+func (f *bytecodeFrame) run() []FunctionCall {
+	code := f.method.code
+	for pc := 0; pc < len(code); {
+		f.enter(pc)
+		size := javaInstructionSize(code, pc)
+		if size <= 0 || pc+size > len(code) {
+			break
+		}
+		f.step(code, pc, size)
+		pc += size
+	}
+	return f.calls
+}
+
+// enter merges the stack states recorded for pc by earlier forward branches.
+func (f *bytecodeFrame) enter(pc int) {
+	saved, ok := f.pending[pc]
+	if !ok {
+		if !f.reachable {
+			f.stack = f.stack[:0]
+			f.reachable = true
+		}
+		return
+	}
+	delete(f.pending, pc)
+	if !f.reachable {
+		f.stack = append(f.stack[:0], saved...)
+		f.reachable = true
+		return
+	}
+	f.stack = mergeBytecodeStacks(saved, f.stack)
+}
+
+func mergeBytecodeStacks(current, other []bytecodeValue) []bytecodeValue {
+	if len(current) != len(other) {
+		return current
+	}
+	merged := make([]bytecodeValue, len(current))
+	for i := range current {
+		merged[i] = mergeBytecodeValues(current[i], other[i])
+	}
+	return merged
+}
+
+func mergeBytecodeValues(a, b bytecodeValue) bytecodeValue {
+	if a.text == b.text && len(a.nodes) == len(b.nodes) {
+		return a
+	}
+	merged := a
+	merged.nodes = append(append([]SourceNode(nil), a.nodes...), b.nodes...)
+	merged.depth = max(a.depth, b.depth)
+	merged.callIndex = -1
+	if merged.text != b.text {
+		merged.text = ""
+	}
+	return merged
+}
+
+// branch records the current stack for a forward jump target.
+func (f *bytecodeFrame) branch(pc, target int) {
+	if target <= pc {
+		return
+	}
+	if saved, ok := f.pending[target]; ok {
+		f.pending[target] = mergeBytecodeStacks(saved, f.stack)
+		return
+	}
+	f.pending[target] = append([]bytecodeValue(nil), f.stack...)
+}
+
+func (f *bytecodeFrame) push(value bytecodeValue) {
+	f.stack = append(f.stack, value)
+}
+
+func (f *bytecodeFrame) pop() bytecodeValue {
+	if len(f.stack) == 0 {
+		return unknownBytecodeValue()
+	}
+	value := f.stack[len(f.stack)-1]
+	f.stack = f.stack[:len(f.stack)-1]
+	return value
+}
+
+// popN pops n values and returns them in push order.
+func (f *bytecodeFrame) popN(n int) []bytecodeValue {
+	values := make([]bytecodeValue, n)
+	for i := n - 1; i >= 0; i-- {
+		values[i] = f.pop()
+	}
+	return values
+}
+
+func (f *bytecodeFrame) discard(n int) {
+	for range n {
+		f.pop()
+	}
+}
+
+// entriesForSlots counts how many stack entries, starting depth entries below
+// the top, cover the given number of JVM stack slots. It returns -1 when the
+// tracked stack is too shallow or a long/double straddles the boundary.
+func (f *bytecodeFrame) entriesForSlots(depth, slots int) int {
+	count, covered := 0, 0
+	for covered < slots {
+		idx := len(f.stack) - 1 - depth - count
+		if idx < 0 {
+			return -1
+		}
+		covered++
+		if f.stack[idx].wide {
+			covered++
+		}
+		count++
+	}
+	if covered != slots {
+		return -1
+	}
+	return count
+}
+
+// dup implements the dup/dup_x/dup2 family: the top topSlots slots are copied
+// beneath the next underSlots slots. Shapes that cannot be matched against
+// the tracked stack reset it, trading provenance for stack alignment.
+func (f *bytecodeFrame) dup(topSlots, underSlots int) {
+	top := f.entriesForSlots(0, topSlots)
+	under := 0
+	if top > 0 && underSlots > 0 {
+		under = f.entriesForSlots(top, underSlots)
+	}
+	if top <= 0 || under < 0 {
+		f.stack = f.stack[:0]
+		return
+	}
+	n := len(f.stack)
+	copied := append([]bytecodeValue(nil), f.stack[n-top:]...)
+	insertAt := n - top - under
+	rest := append([]bytecodeValue(nil), f.stack[insertAt:]...)
+	f.stack = append(append(f.stack[:insertAt], copied...), rest...)
+}
+
+func (f *bytecodeFrame) popSlots(slots int) {
+	n := f.entriesForSlots(0, slots)
+	if n < 0 {
+		f.stack = f.stack[:0]
+		return
+	}
+	f.discard(n)
+}
+
+func (f *bytecodeFrame) expression(pops int, wide bool) {
+	operands := f.popN(pops)
+	value := sourceBytecodeValue(SourceNode{Type: "EXPRESSION"}, "", operands...)
+	value.wide = wide
+	f.push(value)
+}
+
+func (f *bytecodeFrame) step(code []byte, pc, size int) {
+	op := int(code[pc])
+	switch {
+	case op >= opIconstM1 && op <= opIconst5:
+		f.push(constantBytecodeValue(strconv.Itoa(op - opIconst0Offset)))
+	case op >= opIload && op <= opAload:
+		f.load(int(code[pc+1]), op == opIload+1 || op == opIload+3, pc)
+	case op >= opIload0 && op <= opAload3:
+		group := (op - opIload0) / 4
+		f.load((op-opIload0)%4, group == 1 || group == 3, pc)
+	case op >= opIaload && op <= opSaload:
+		f.expression(2, op == opIaload+1 || op == opIaload+3)
+	case op >= opIstore && op <= opAstore:
+		f.store(int(code[pc+1]), pc+size)
+	case op >= opIstore0 && op <= opAstore3:
+		f.store((op-opIstore0)%4, pc+size)
+	case op >= opIastore && op <= opSastore:
+		f.discard(3)
+	case op >= opIadd && op <= opLxor:
+		pops := 2
+		if op >= opIneg && op <= opDneg {
+			pops = 1
+		}
+		f.expression(pops, op%2 == 1)
+	case op >= opI2l && op <= opI2s:
+		f.expression(1, javaWideConversions[op])
+	case op >= opLcmp && op <= opDcmpg:
+		f.expression(2, false)
+	case op >= opIfeq && op <= opIfAcmpne:
+		pops := 1
+		if op >= opIfIcmpeq {
+			pops = 2
+		}
+		f.discard(pops)
+		f.branch(pc, pc+int(int16(binary.BigEndian.Uint16(code[pc+1:]))))
+	case op >= opIreturn && op <= opReturn:
+		f.reachable = false
+	case op >= opGetstatic && op <= opPutfield:
+		f.field(op, int(binary.BigEndian.Uint16(code[pc+1:])))
+	case op >= opInvokevirtual && op <= opInvokeinterface:
+		f.invoke(op, pc, int(binary.BigEndian.Uint16(code[pc+1:])))
+	default:
+		f.stepOther(code, pc, op)
+	}
+}
+
+// opIconst0Offset converts an iconst_<n> opcode into n.
+const opIconst0Offset = opIconstM1 + 1
+
+// javaWideConversions marks the conversion opcodes producing long or double.
+var javaWideConversions = map[int]bool{0x85: true, 0x87: true, 0x8a: true, 0x8c: true, 0x8d: true, 0x8f: true}
+
+func (f *bytecodeFrame) stepOther(code []byte, pc, op int) {
+	switch op {
+	case opAconstNull:
+		f.push(constantBytecodeValue("null"))
+	case opLconst0, opLconst1:
+		value := constantBytecodeValue(strconv.Itoa(op-opLconst0) + "L")
+		value.wide = true
+		f.push(value)
+	case opFconst0, opFconst0 + 1, opFconst2:
+		f.push(constantBytecodeValue(strconv.Itoa(op-opFconst0) + ".0f"))
+	case opDconst0, opDconst1:
+		value := constantBytecodeValue(strconv.Itoa(op-opDconst0) + ".0")
+		value.wide = true
+		f.push(value)
+	case opBipush:
+		f.push(constantBytecodeValue(strconv.Itoa(int(int8(code[pc+1])))))
+	case opSipush:
+		f.push(constantBytecodeValue(strconv.Itoa(int(int16(binary.BigEndian.Uint16(code[pc+1:]))))))
+	case opLdc:
+		f.push(f.constant(int(code[pc+1])))
+	case opLdcW, opLdc2W:
+		f.push(f.constant(int(binary.BigEndian.Uint16(code[pc+1:]))))
+	case opPop:
+		f.popSlots(1)
+	case opPop2:
+		f.popSlots(2)
+	case opDup:
+		f.dup(1, 0)
+	case opDupX1:
+		f.dup(1, 1)
+	case opDupX2:
+		f.dup(1, 2)
+	case opDup2:
+		f.dup(2, 0)
+	case opDup2X1:
+		f.dup(2, 1)
+	case opDup2X2:
+		f.dup(2, 2)
+	case opSwap:
+		a, b := f.pop(), f.pop()
+		f.push(a)
+		f.push(b)
+	case opGoto:
+		f.branch(pc, pc+int(int16(binary.BigEndian.Uint16(code[pc+1:]))))
+		f.reachable = false
+	case opGotoW:
+		f.branch(pc, pc+int(int32(binary.BigEndian.Uint32(code[pc+1:]))))
+		f.reachable = false
+	case opIfnull, opIfnonnull:
+		f.pop()
+		f.branch(pc, pc+int(int16(binary.BigEndian.Uint16(code[pc+1:]))))
+	case opTableswitch, opLookupswitch:
+		f.pop()
+		for _, target := range javaSwitchTargets(code, pc) {
+			f.branch(pc, target)
+		}
+		f.reachable = false
+	case opRet, opAthrow:
+		f.reachable = false
+	case opInvokedynamic:
+		f.invokeDynamic(pc, int(binary.BigEndian.Uint16(code[pc+1:])))
+	case opNew:
+		typeName := classNameFromConstantPool(f.class.cp, int(binary.BigEndian.Uint16(code[pc+1:])))
+		value := sourceBytecodeValue(SourceNode{Type: "EXPRESSION", DeclaredType: typeName}, "")
+		value.newSite = pc
+		f.push(value)
+	case opNewarray, opAnewarray, opArraylength, opInstanceof:
+		f.expression(1, false)
+	case opMultianewarray:
+		f.expression(int(code[pc+3]), false)
+	case opMonitorenter, opMonitorexit:
+		f.pop()
+	case opWide:
+		f.wide(code, pc)
+	}
+	// nop, iinc, checkcast, jsr and jsr_w leave the tracked stack unchanged.
+}
+
+func (f *bytecodeFrame) wide(code []byte, pc int) {
+	op := int(code[pc+1])
+	slot := int(binary.BigEndian.Uint16(code[pc+2:]))
+	switch {
+	case op >= opIload && op <= opAload:
+		f.load(slot, op == opIload+1 || op == opIload+3, pc)
+	case op >= opIstore && op <= opAstore:
+		f.store(slot, pc+javaInstructionSize(code, pc))
+	case op == opRet:
+		f.reachable = false
+	}
+}
+
+// javaInstructionSize returns the encoded length of the instruction at pc,
+// or 0 when it is undefined or truncated.
+func javaInstructionSize(code []byte, pc int) int {
+	op := code[pc]
+	switch op {
+	case opTableswitch:
+		base := javaSwitchOperandsStart(pc)
+		if base+12 > len(code) {
+			return 0
+		}
+		low := int(int32(binary.BigEndian.Uint32(code[base+4:])))
+		high := int(int32(binary.BigEndian.Uint32(code[base+8:])))
+		if high < low {
+			return 0
+		}
+		return base - pc + 12 + 4*(high-low+1)
+	case opLookupswitch:
+		base := javaSwitchOperandsStart(pc)
+		if base+8 > len(code) {
+			return 0
+		}
+		pairs := int(int32(binary.BigEndian.Uint32(code[base+4:])))
+		if pairs < 0 {
+			return 0
+		}
+		return base - pc + 8 + 8*pairs
+	case opWide:
+		if pc+1 >= len(code) {
+			return 0
+		}
+		if code[pc+1] == opIinc {
+			return 6
+		}
+		return 4
+	default:
+		return javaInstructionSizes[op]
+	}
+}
+
+// javaSwitchOperandsStart skips the 0-3 padding bytes that align switch
+// operands to a 4-byte boundary from the start of the method code.
+func javaSwitchOperandsStart(pc int) int {
+	return pc + 1 + (4-(pc+1)%4)%4
+}
+
+func javaSwitchTargets(code []byte, pc int) []int {
+	base := javaSwitchOperandsStart(pc)
+	targets := []int{pc + int(int32(binary.BigEndian.Uint32(code[base:])))}
+	if code[pc] == opTableswitch {
+		low := int(int32(binary.BigEndian.Uint32(code[base+4:])))
+		high := int(int32(binary.BigEndian.Uint32(code[base+8:])))
+		for i := 0; i <= high-low; i++ {
+			targets = append(targets, pc+int(int32(binary.BigEndian.Uint32(code[base+12+4*i:]))))
+		}
+		return targets
+	}
+	pairs := int(int32(binary.BigEndian.Uint32(code[base+4:])))
+	for i := range pairs {
+		targets = append(targets, pc+int(int32(binary.BigEndian.Uint32(code[base+12+8*i:]))))
+	}
+	return targets
+}
+
+func (f *bytecodeFrame) load(slot int, wide bool, pc int) {
+	value, ok := f.locals[slot]
+	if !ok {
+		name := f.method.localName(slot, pc)
+		if name == "" {
+			f.push(bytecodeValue{wide: wide, newSite: -1, callIndex: -1})
+			return
+		}
+		value = sourceBytecodeValue(SourceNode{Type: "VARIABLE", Name: name}, name)
+	}
+	value.wide = wide
+	value.callIndex = -1
+	f.push(value)
+}
+
+// store binds the popped value to a local. Loads of the local then surface a
+// VARIABLE node whose SourceNodes hold the stored value's provenance, the
+// same shape JavaParser produces for an initialized local declaration.
+func (f *bytecodeFrame) store(slot, next int) {
+	value := f.pop()
+	local := f.method.local(slot, next)
+	name := "local" + strconv.Itoa(slot)
+	declaredType := ""
+	if local != nil {
+		name = local.name
+		declaredType, _ = parseJVMType(local.descriptor, 0)
+	}
+	if value.callIndex >= 0 && f.calls[value.callIndex].AssignedVar == "" {
+		f.calls[value.callIndex].AssignedVar = name
+	}
+	stored := sourceBytecodeValue(SourceNode{
+		Type:         "VARIABLE",
+		Name:         name,
+		DeclaredType: declaredType,
+		Location:     &SourceLocation{FilePath: f.class.filePath, Line: f.method.lineAt(next)},
+	}, name, value)
+	stored.wide = value.wide
+	f.locals[slot] = stored
+}
+
+// constant turns an ldc/ldc_w/ldc2_w operand into a VALUE node rendered as
+// the equivalent Java literal.
+func (f *bytecodeFrame) constant(index int) bytecodeValue {
+	if index <= 0 || index >= len(f.class.cp) {
+		return unknownBytecodeValue()
+	}
+	entry := f.class.cp[index]
+	switch entry.tag {
+	case 3:
+		return constantBytecodeValue(strconv.Itoa(int(int32(uint32(entry.intValue)))))
+	case 4:
+		value := math.Float32frombits(uint32(entry.intValue))
+		return constantBytecodeValue(strconv.FormatFloat(float64(value), 'g', -1, 32) + "f")
+	case 5:
+		value := constantBytecodeValue(strconv.FormatInt(int64(entry.wideValue), 10) + "L")
+		value.wide = true
+		return value
+	case 6:
+		value := constantBytecodeValue(strconv.FormatFloat(math.Float64frombits(entry.wideValue), 'g', -1, 64))
+		value.wide = true
+		return value
+	case 7:
+		return constantBytecodeValue(javaBytecodeSimpleTypeName(classNameFromConstantPool(f.class.cp, index)) + ".class")
+	case 8:
+		if entry.intValue > 0 && entry.intValue < len(f.class.cp) {
+			return constantBytecodeValue(strconv.Quote(f.class.cp[entry.intValue].strValue))
+		}
+	}
+	return sourceBytecodeValue(SourceNode{Type: "EXPRESSION"}, "")
+}
+
+// field models getstatic/putstatic/getfield/putfield. Static fields of other
+// classes surface as VALUE nodes named "Owner.FIELD", matching how JavaParser
+// traces a qualified constant such as SignatureAlgorithm.HS256.
+func (f *bytecodeFrame) field(op, index int) {
+	owner, name, descriptor := f.class.memberRef(index)
+	fieldType, _ := parseJVMType(descriptor, 0)
+	wide := javaBytecodeSlotWidth(fieldType) == 2
+	switch op {
+	case opPutstatic:
+		f.pop()
+		return
+	case opPutfield:
+		f.discard(2)
+		return
+	case opGetfield:
+		receiver := f.pop()
+		text := name
+		if receiver.text != "" && receiver.text != "this" {
+			text = receiver.text + "." + name
+		}
+		value := sourceBytecodeValue(SourceNode{Type: "FIELD", Name: name, DeclaredType: fieldType}, text)
+		value.wide = wide
+		f.push(value)
+		return
+	}
+	if owner == f.class.binaryName {
+		value := sourceBytecodeValue(SourceNode{Type: "FIELD", Name: name, DeclaredType: fieldType}, name)
+		value.wide = wide
+		f.push(value)
+		return
+	}
+	qualified := javaBytecodeSimpleTypeName(owner) + "." + name
+	value := sourceBytecodeValue(SourceNode{Type: "VALUE", Name: qualified, DeclaredType: fieldType, Value: qualified}, qualified)
+	value.wide = wide
+	f.push(value)
+}
+
+func (f *bytecodeFrame) invoke(op, pc, index int) {
+	owner, name, descriptor := f.class.memberRef(index)
+	if owner == "" || name == "" {
+		f.stack = f.stack[:0]
+		return
+	}
+	paramTypes, returnType := parseMethodDescriptor(descriptor)
+	args := f.popN(len(paramTypes))
+	receiver := unknownBytecodeValue()
+	if op != opInvokestatic {
+		receiver = f.pop()
+	}
+	callee := javaBytecodeFunctionID(owner, name, len(paramTypes))
+
+	argTexts := make([]string, len(args))
+	for i, arg := range args {
+		argTexts[i] = arg.text
+	}
+	argList := "(" + strings.Join(argTexts, ", ") + ")"
+	raw := BaseFunctionName(callee.Name) + argList
+	switch {
+	case name == constructorMethodName && receiver.newSite >= 0:
+		raw = "new " + callee.Type + argList
+	case name == constructorMethodName:
+		raw = "super" + argList
+		if owner == f.class.binaryName {
+			raw = "this" + argList
+		}
+	case op == opInvokestatic:
+		raw = callee.Type + "." + raw
+	case receiver.text != "":
+		raw = receiver.text + "." + raw
+	}
+
+	call := FunctionCall{
+		Callee:          callee,
+		Raw:             raw,
+		FilePath:        f.class.filePath,
+		Line:            f.method.lineAt(pc),
+		Arguments:       argTexts,
+		ArgumentSources: make([][]SourceNode, len(args)),
+	}
+	for i, arg := range args {
+		call.ArgumentSources[i] = arg.nodes
+	}
+	if len(args) == 0 {
+		call.Arguments, call.ArgumentSources = nil, nil
+	}
+	if len(receiver.nodes) > 0 {
+		switch receiver.nodes[0].Type {
+		case "VARIABLE", "FIELD", javaSourceTypeParameter:
+			call.ReceiverVar = receiver.nodes[0].Name
+		}
+	}
+	if receiver.callIndex >= 0 {
+		root := &f.calls[receiver.callIndex]
+		if root.ChainID == "" {
+			root.ChainID = strconv.Itoa(f.callSites[receiver.callIndex])
+		}
+		call.ChainID = root.ChainID
+	}
+	f.calls = append(f.calls, call)
+	f.callSites = append(f.callSites, pc)
+	callIndex := len(f.calls) - 1
+
+	if name == constructorMethodName {
+		if receiver.newSite >= 0 {
+			f.replaceNewSite(receiver.newSite, f.callResult(callee, raw, owner, callIndex, receiver, args))
+		}
+		return
+	}
+	if returnType != "void" {
+		f.push(f.callResult(callee, raw, returnType, callIndex, receiver, args))
+	}
+}
+
+// callResult builds the CALL_RESULT node for an invocation, nesting the
+// receiver and argument provenance the way JavaParser's
+// traceMethodCallExpression does.
+func (f *bytecodeFrame) callResult(callee FunctionID, raw, returnType string, callIndex int, receiver bytecodeValue, args []bytecodeValue) bytecodeValue {
+	target := callee
+	children := make([]bytecodeValue, 0, len(args)+1)
+	if receiver.newSite < 0 {
+		children = append(children, receiver)
+	}
+	for i, arg := range args {
+		child := arg
+		child.nodes = make([]SourceNode, len(arg.nodes))
+		for j, node := range arg.nodes {
+			node.ParameterIndex = i
+			node.Flow = &SourceFlow{CallArgument: true}
+			child.nodes[j] = node
+		}
+		children = append(children, child)
+	}
+	value := sourceBytecodeValue(SourceNode{Type: "CALL_RESULT", Value: raw, DeclaredType: returnType, CallTarget: &target}, raw, children...)
+	value.wide = javaBytecodeSlotWidth(returnType) == 2
+	value.callIndex = callIndex
+	return value
+}
+
+// replaceNewSite swaps every stack copy of an uninitialized `new` object for
+// the constructor call result once its <init> has been invoked.
+func (f *bytecodeFrame) replaceNewSite(site int, value bytecodeValue) {
+	for i := range f.stack {
+		if f.stack[i].newSite == site {
+			f.stack[i] = value
+		}
+	}
+}
+
+// invokeDynamic models the two invokedynamic shapes javac emits routinely.
+// LambdaMetafactory sites become a call to the lambda body or referenced
+// method, keeping code inside lambdas reachable from the enclosing method.
+// StringConcatFactory sites become an EXPRESSION rendered from the concat
+// recipe, e.g. "AES/" + mode.
+func (f *bytecodeFrame) invokeDynamic(pc, index int) {
+	if index <= 0 || index >= len(f.class.cp) {
+		f.stack = f.stack[:0]
+		return
+	}
+	entry := f.class.cp[index]
+	_, descriptor := f.class.nameAndType(entry.refIndex)
+	paramTypes, returnType := parseMethodDescriptor(descriptor)
+	args := f.popN(len(paramTypes))
+
+	var bootstrap *bytecodeBootstrapMethod
+	if entry.intValue >= 0 && entry.intValue < len(f.class.bootstrapMethods) {
+		bootstrap = &f.class.bootstrapMethods[entry.intValue]
+	}
+	bootstrapOwner := ""
+	if bootstrap != nil {
+		bootstrapOwner, _, _ = f.class.methodHandle(bootstrap.handle)
+	}
+
+	var value bytecodeValue
+	switch bootstrapOwner {
+	case javaLambdaMetafactory:
+		value = f.lambdaCall(pc, bootstrap, args)
+	case javaStringConcatFactory:
+		text := f.concatText(bootstrap, args)
+		value = sourceBytecodeValue(SourceNode{Type: "EXPRESSION", Value: text}, text, args...)
+	default:
+		value = sourceBytecodeValue(SourceNode{Type: "EXPRESSION"}, "", args...)
+	}
+	if returnType != "void" {
+		value.wide = javaBytecodeSlotWidth(returnType) == 2
+		f.push(value)
+	}
+}
+
+func (f *bytecodeFrame) lambdaCall(pc int, bootstrap *bytecodeBootstrapMethod, captured []bytecodeValue) bytecodeValue {
+	if len(bootstrap.args) < 2 {
+		return sourceBytecodeValue(SourceNode{Type: "EXPRESSION"}, "", captured...)
+	}
+	owner, name, descriptor := f.class.methodHandle(bootstrap.args[1])
+	if owner == "" || name == "" {
+		return sourceBytecodeValue(SourceNode{Type: "EXPRESSION"}, "", captured...)
+	}
+	paramTypes, _ := parseMethodDescriptor(descriptor)
+	callee := javaBytecodeFunctionID(owner, name, len(paramTypes))
+	raw := callee.Type + "::" + BaseFunctionName(callee.Name)
+	if name == constructorMethodName {
+		raw = callee.Type + "::new"
+	}
+	f.calls = append(f.calls, FunctionCall{
+		Callee:   callee,
+		Raw:      raw,
+		FilePath: f.class.filePath,
+		Line:     f.method.lineAt(pc),
+	})
+	f.callSites = append(f.callSites, pc)
+	return sourceBytecodeValue(SourceNode{Type: "EXPRESSION", Value: raw}, raw, captured...)
+}
+
+// concatText renders a StringConcatFactory recipe back into Java source form:
+// \x01 marks a dynamic argument and \x02 a bootstrap constant.
+func (f *bytecodeFrame) concatText(bootstrap *bytecodeBootstrapMethod, args []bytecodeValue) string {
+	recipe := ""
+	constants := []int(nil)
+	if len(bootstrap.args) > 0 {
+		recipe = f.constant(bootstrap.args[0]).text
+		recipe, _ = strconv.Unquote(recipe)
+		constants = bootstrap.args[1:]
+	}
+	if recipe == "" {
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			parts[i] = arg.text
+		}
+		return strings.Join(parts, " + ")
+	}
+
+	var parts []string
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			parts = append(parts, strconv.Quote(literal.String()))
+			literal.Reset()
+		}
+	}
+	argIdx, constIdx := 0, 0
+	for _, r := range recipe {
+		switch {
+		case r == '\x01' && argIdx < len(args):
+			flush()
+			parts = append(parts, args[argIdx].text)
+			argIdx++
+		case r == '\x02' && constIdx < len(constants):
+			flush()
+			parts = append(parts, f.constant(constants[constIdx]).text)
+			constIdx++
+		default:
+			literal.WriteRune(r)
+		}
+	}
+	flush()
+	return strings.Join(parts, " + ")
+}
+
+// methodHandle resolves a MethodHandle constant to the referenced member's
+// owner, name, and descriptor.
+func (c *bytecodeClass) methodHandle(index int) (string, string, string) {
+	if index <= 0 || index >= len(c.cp) || c.cp[index].tag != 15 {
+		return "", "", ""
+	}
+	return c.memberRef(c.cp[index].refIndex)
+}
@@ -0,0 +1,603 @@
+package callgraph
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testClassWriter assembles minimal class files so bytecode call graph tests
+// do not depend on a JDK being installed.
+type testClassWriter struct {
+	pool      [][]byte
+	index     map[string]int
+	bootstrap [][]int
+}
+
+type testClassMethod struct {
+	access uint16
+	name   string
+	desc   string
+	code   []byte
+	lines  [][2]int // {pc, line}
+	locals []testClassLocal
+}
+
+type testClassLocal struct {
+	start, length, slot int
+	name, desc          string
+}
+
+func newTestClassWriter() *testClassWriter {
+	return &testClassWriter{index: make(map[string]int)}
+}
+
+func (w *testClassWriter) add(key string, entry []byte) int {
+	if idx, ok := w.index[key]; ok {
+		return idx
+	}
+	w.pool = append(w.pool, entry)
+	idx := len(w.pool)
+	w.index[key] = idx
+	return idx
+}
+
+func (w *testClassWriter) utf8(s string) int {
+	entry := []byte{1, 0, 0}
+	binary.BigEndian.PutUint16(entry[1:], uint16(len(s)))
+	return w.add("utf8:"+s, append(entry, s...))
+}
+
+func (w *testClassWriter) twoIndex(tag byte, key string, a, b int) int {
+	entry := []byte{tag, 0, 0, 0, 0}
+	binary.BigEndian.PutUint16(entry[1:], uint16(a))
+	binary.BigEndian.PutUint16(entry[3:], uint16(b))
+	return w.add(key, entry)
+}
+
+func (w *testClassWriter) oneIndex(tag byte, key string, a int) int {
+	entry := []byte{tag, 0, 0}
+	binary.BigEndian.PutUint16(entry[1:], uint16(a))
+	return w.add(key, entry)
+}
+
+func (w *testClassWriter) class(name string) int {
+	return w.oneIndex(7, "class:"+name, w.utf8(name))
+}
+
+func (w *testClassWriter) str(s string) int {
+	return w.oneIndex(8, "string:"+s, w.utf8(s))
+}
+
+func (w *testClassWriter) nameAndType(name, desc string) int {
+	return w.twoIndex(12, "nat:"+name+":"+desc, w.utf8(name), w.utf8(desc))
+}
+
+func (w *testClassWriter) methodRef(owner, name, desc string) int {
+	return w.twoIndex(10, "method:"+owner+"."+name+desc, w.class(owner), w.nameAndType(name, desc))
+}
+
+func (w *testClassWriter) interfaceMethodRef(owner, name, desc string) int {
+	return w.twoIndex(11, "imethod:"+owner+"."+name+desc, w.class(owner), w.nameAndType(name, desc))
+}
+
+func (w *testClassWriter) methodHandle(kind byte, ref int) int {
+	entry := []byte{15, kind, 0, 0}
+	binary.BigEndian.PutUint16(entry[2:], uint16(ref))
+	return w.add("handle:"+string(entry), entry)
+}
+
+func (w *testClassWriter) methodType(desc string) int {
+	return w.oneIndex(16, "mtype:"+desc, w.utf8(desc))
+}
+
+func (w *testClassWriter) invokeDynamic(bootstrapHandle int, args []int, name, desc string) int {
+	w.bootstrap = append(w.bootstrap, append([]int{bootstrapHandle}, args...))
+	return w.twoIndex(18, "indy:"+name+desc+string(rune(len(w.bootstrap))), len(w.bootstrap)-1, w.nameAndType(name, desc))
+}
+
+func u2(v int) []byte {
+	return binary.BigEndian.AppendUint16(nil, uint16(v))
+}
+
+func (w *testClassWriter) attribute(name string, body []byte) []byte {
+	out := u2(w.utf8(name))
+	out = binary.BigEndian.AppendUint32(out, uint32(len(body)))
+	return append(out, body...)
+}
+
+func (w *testClassWriter) build(access uint16, name, super string, methods []testClassMethod) []byte {
+	thisIdx, superIdx := w.class(name), w.class(super)
+	methodBytes := u2(len(methods))
+	for _, m := range methods {
+		methodBytes = append(methodBytes, u2(int(m.access))...)
+		methodBytes = append(methodBytes, u2(w.utf8(m.name))...)
+		methodBytes = append(methodBytes, u2(w.utf8(m.desc))...)
+		if m.code == nil {
+			methodBytes = append(methodBytes, u2(0)...)
+			continue
+		}
+		var codeAttrs [][]byte
+		if len(m.lines) > 0 {
+			body := u2(len(m.lines))
+			for _, l := range m.lines {
+				body = append(append(body, u2(l[0])...), u2(l[1])...)
+			}
+			codeAttrs = append(codeAttrs, w.attribute("LineNumberTable", body))
+		}
+		if len(m.locals) > 0 {
+			body := u2(len(m.locals))
+			for _, l := range m.locals {
+				body = append(body, u2(l.start)...)
+				body = append(body, u2(l.length)...)
+				body = append(body, u2(w.utf8(l.name))...)
+				body = append(body, u2(w.utf8(l.desc))...)
+				body = append(body, u2(l.slot)...)
+			}
+			codeAttrs = append(codeAttrs, w.attribute("LocalVariableTable", body))
+		}
+		code := append(u2(8), u2(8)...)
+		code = binary.BigEndian.AppendUint32(code, uint32(len(m.code)))
+		code = append(code, m.code...)
+		code = append(code, u2(0)...)
+		code = append(code, u2(len(codeAttrs))...)
+		for _, attr := range codeAttrs {
+			code = append(code, attr...)
+		}
+		methodBytes = append(methodBytes, u2(1)...)
+		methodBytes = append(methodBytes, w.attribute("Code", code)...)
+	}
+
+	classAttrs := u2(0)
+	if len(w.bootstrap) > 0 {
+		body := u2(len(w.bootstrap))
+		for _, bsm := range w.bootstrap {
+			body = append(body, u2(bsm[0])...)
+			body = append(body, u2(len(bsm)-1)...)
+			for _, arg := range bsm[1:] {
+				body = append(body, u2(arg)...)
+			}
+		}
+		classAttrs = append(u2(1), w.attribute("BootstrapMethods", body)...)
+	}
+
+	out := []byte{0xCA, 0xFE, 0xBA, 0xBE, 0, 0, 0, 61}
+	out = append(out, u2(len(w.pool)+1)...)
+	for _, entry := range w.pool {
+		out = append(out, entry...)
+	}
+	out = append(out, u2(int(access))...)
+	out = append(out, u2(thisIdx)...)
+	out = append(out, u2(superIdx)...)
+	out = append(out, u2(0)...) // interfaces
+	out = append(out, u2(0)...) // fields
+	out = append(out, methodBytes...)
+	return append(out, classAttrs...)
+}
+
+func op(code ...int) []byte {
+	out := make([]byte, len(code))
+	for i, c := range code {
+		out[i] = byte(c)
+	}
+	return out
+}
+
+func opIndex(opcode, index int) []byte {
+	return append([]byte{byte(opcode)}, u2(index)...)
+}
+
+// buildSignerClass assembles com.vendor.sdk.Signer, roughly:
+//
+//	public class Signer {
+//	    public Signer() { super(); }
+//	    public static byte[] sign(byte[] data) {
+//	        Signature signer = Signature.getInstance("SHA256withRSA");
+//	        signer.update(data);
+//	        return signer.sign();
+//	    }
+//	    public static byte[] digest(byte[] data) { return Digests.sha(data); }
+//	    static Cipher cipher(String mode) { return Cipher.getInstance("AES/" + mode); }
+//	    static Cipher pick(boolean fips) { return Cipher.getInstance(fips ? "AES" : "DES"); }
+//	    Key key(byte[] raw) { return new SecretKeySpec(raw, "AES"); }
+//	    void run() { Runnable r = () -> sign(null); r.run(); }
+//	    private static void lambda$run$0() { sign(null); }
+//	}
+func buildSignerClass() []byte {
+	w := newTestClassWriter()
+	const owner = "com/vendor/sdk/Signer"
+
+	var sign []byte
+	sign = append(sign, op(opLdc, w.str("SHA256withRSA"))...)
+	sign = append(sign, opIndex(opInvokestatic, w.methodRef("java/security/Signature", "getInstance", "(Ljava/lang/String;)Ljava/security/Signature;"))...)
+	sign = append(sign, op(0x4c, 0x2b, 0x2a)...) // astore_1, aload_1, aload_0
+	sign = append(sign, opIndex(opInvokevirtual, w.methodRef("java/security/Signature", "update", "([B)V"))...)
+	sign = append(sign, op(0x2b)...) // aload_1
+	sign = append(sign, opIndex(opInvokevirtual, w.methodRef("java/security/Signature", "sign", "()[B"))...)
+	sign = append(sign, op(0xb0)...) // areturn
+
+	var digest []byte
+	digest = append(digest, op(0x2a)...) // aload_0
+	digest = append(digest, opIndex(opInvokestatic, w.methodRef("com/crypto/Digests", "sha", "([B)[B"))...)
+	digest = append(digest, op(0xb0)...)
+
+	getCipher := w.methodRef("javax/crypto/Cipher", "getInstance", "(Ljava/lang/String;)Ljavax/crypto/Cipher;")
+	concat := w.invokeDynamic(
+		w.methodHandle(6, w.methodRef("java/lang/invoke/StringConcatFactory", "makeConcatWithConstants", "()Ljava/lang/invoke/CallSite;")),
+		[]int{w.str("AES/\x01")},
+		"makeConcatWithConstants", "(Ljava/lang/String;)Ljava/lang/String;")
+	var cipher []byte
+	cipher = append(cipher, op(0x2a)...) // aload_0
+	cipher = append(cipher, opIndex(opInvokedynamic, concat)...)
+	cipher = append(cipher, 0, 0)
+	cipher = append(cipher, opIndex(opInvokestatic, getCipher)...)
+	cipher = append(cipher, op(0xb0)...)
+
+	// 0: iload_0; 1: ifeq +8 -> 9; 4: ldc "AES"; 6: goto +5 -> 11; 9: ldc "DES"; 11: invokestatic
+	var pick []byte
+	pick = append(pick, op(0x1a, opIfeq, 0, 8)...)
+	pick = append(pick, op(opLdc, w.str("AES"))...)
+	pick = append(pick, op(opGoto, 0, 5)...)
+	pick = append(pick, op(opLdc, w.str("DES"))...)
+	pick = append(pick, opIndex(opInvokestatic, getCipher)...)
+	pick = append(pick, op(0xb0)...)
+
+	var key []byte
+	key = append(key, opIndex(opNew, w.class("javax/crypto/spec/SecretKeySpec"))...)
+	key = append(key, op(opDup, 0x2b, opLdc, w.str("AES"))...)
+	key = append(key, opIndex(opInvokespecial, w.methodRef("javax/crypto/spec/SecretKeySpec", "<init>", "([BLjava/lang/String;)V"))...)
+	key = append(key, op(0xb0)...)
+
+	lambda := w.invokeDynamic(
+		w.methodHandle(6, w.methodRef("java/lang/invoke/LambdaMetafactory", "metafactory", "()Ljava/lang/invoke/CallSite;")),
+		[]int{w.methodType("()V"), w.methodHandle(6, w.methodRef(owner, "lambda$run$0", "()V")), w.methodType("()V")},
+		"run", "()Ljava/lang/Runnable;")
+	var run []byte
+	run = append(run, opIndex(opInvokedynamic, lambda)...)
+	run = append(run, 0, 0)
+	run = append(run, op(0x4c, 0x2b)...) // astore_1, aload_1
+	run = append(run, opIndex(opInvokeinterface, w.interfaceMethodRef("java/lang/Runnable", "run", "()V"))...)
+	run = append(run, 1, 0, opReturn)
+
+	signRef := w.methodRef(owner, "sign", "([B)[B")
+	var lambdaBody []byte
+	lambdaBody = append(lambdaBody, op(opAconstNull)...)
+	lambdaBody = append(lambdaBody, opIndex(opInvokestatic, signRef)...)
+	lambdaBody = append(lambdaBody, op(opPop, opReturn)...)
+
+	var init []byte
+	init = append(init, op(0x2a)...)
+	init = append(init, opIndex(opInvokespecial, w.methodRef("java/lang/Object", "<init>", "()V"))...)
+	init = append(init, op(opReturn)...)
+
+	return w.build(javaAccPublic, owner, "java/lang/Object", []testClassMethod{
+		{access: javaAccPublic, name: "<init>", desc: "()V", code: init, lines: [][2]int{{0, 3}}},
+		{
+			access: javaAccPublic | javaAccStatic, name: "sign", desc: "([B)[B", code: sign,
+			lines: [][2]int{{0, 5}, {6, 6}, {11, 7}},
+			locals: []testClassLocal{
+				{start: 0, length: len(sign), slot: 0, name: "data", desc: "[B"},
+				{start: 6, length: len(sign) - 6, slot: 1, name: "signer", desc: "Ljava/security/Signature;"},
+			},
+		},
+		{access: javaAccPublic | javaAccStatic, name: "digest", desc: "([B)[B", code: digest, lines: [][2]int{{0, 9}}},
+		{access: javaAccStatic, name: "cipher", desc: "(Ljava/lang/String;)Ljavax/crypto/Cipher;", code: cipher, lines: [][2]int{{0, 10}}},
+		{access: javaAccStatic, name: "pick", desc: "(Z)Ljavax/crypto/Cipher;", code: pick, lines: [][2]int{{0, 13}}},
+		{access: 0, name: "key", desc: "([B)Ljava/security/Key;", code: key, lines: [][2]int{{0, 16}}},
+		{access: 0, name: "run", desc: "()V", code: run, lines: [][2]int{{0, 19}}},
+		{access: javaAccPrivate | javaAccStatic | 0x1000, name: "lambda$run$0", desc: "()V", code: lambdaBody, lines: [][2]int{{0, 19}}},
+	})
+}
+
+func writeSignerJAR(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "signer-1.0.jar")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create jar: %v", err)
+	}
+	zw := zip.NewWriter(file)
+	for name, data := range map[string][]byte{
+		"com/vendor/sdk/Signer.class": buildSignerClass(),
+		"META-INF/MANIFEST.MF":        []byte("Manifest-Version: 1.0\n"),
+	} {
+		entry, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("create entry: %v", err)
+		}
+		if _, err := entry.Write(data); err != nil {
+			t.Fatalf("write entry: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close jar: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close file: %v", err)
+	}
+	return path
+}
+
+func bytecodeDeclByName(t *testing.T, analysis *FileAnalysis, name string) *FunctionDecl {
+	t.Helper()
+	for i := range analysis.Functions {
+		if analysis.Functions[i].ID.Name == name {
+			return &analysis.Functions[i]
+		}
+	}
+	t.Fatalf("function %q not found in %s", name, analysis.FilePath)
+	return nil
+}
+
+func TestParseJavaClassCallGraph_DecodesInvocations(t *testing.T) {
+	t.Parallel()
+
+	analysis, err := parseJavaClassCallGraph(buildSignerClass(), "signer.jar!/com/vendor/sdk/Signer.class")
+	if err != nil {
+		t.Fatalf("parseJavaClassCallGraph: %v", err)
+	}
+	if analysis.PackagePath != "com.vendor.sdk" {
+		t.Fatalf("PackagePath = %q, want com.vendor.sdk", analysis.PackagePath)
+	}
+
+	sign := bytecodeDeclByName(t, analysis, "sign#1")
+	if sign.ID.String() != "com.vendor.sdk.(Signer).sign#1" {
+		t.Fatalf("sign ID = %s", sign.ID.String())
+	}
+	if sign.StartLine != 5 || sign.EndLine != 7 || sign.Visibility != VisibilityPublic || sign.ReturnType != "byte[]" {
+		t.Fatalf("sign decl = lines %d-%d visibility %q return %q", sign.StartLine, sign.EndLine, sign.Visibility, sign.ReturnType)
+	}
+	if len(sign.Parameters) != 1 || sign.Parameters[0].Name != "data" || sign.Parameters[0].Type != "byte[]" {
+		t.Fatalf("sign parameters = %+v", sign.Parameters)
+	}
+	if len(sign.Calls) != 3 {
+		t.Fatalf("sign calls = %d, want 3: %+v", len(sign.Calls), sign.Calls)
+	}
+
+	getInstance := sign.Calls[0]
+	if getInstance.Callee.String() != "java.security.(Signature).getInstance#1" || getInstance.Line != 5 {
+		t.Fatalf("getInstance call = %s line %d", getInstance.Callee.String(), getInstance.Line)
+	}
+	if getInstance.AssignedVar != "signer" {
+		t.Fatalf("getInstance AssignedVar = %q, want signer", getInstance.AssignedVar)
+	}
+	if len(getInstance.ArgumentSources) != 1 || getInstance.ArgumentSources[0][0].Type != "VALUE" ||
+		getInstance.ArgumentSources[0][0].Value != `"SHA256withRSA"` || getInstance.Arguments[0] != `"SHA256withRSA"` {
+		t.Fatalf("getInstance argument sources = %+v", getInstance.ArgumentSources)
+	}
+
+	update := sign.Calls[1]
+	if update.Callee.String() != "java.security.(Signature).update#1" || update.ReceiverVar != "signer" || update.Line != 6 {
+		t.Fatalf("update call = %+v", update)
+	}
+	param := update.ArgumentSources[0][0]
+	if param.Type != javaSourceTypeParameter || param.Name != "data" || param.ParameterIndex != 0 {
+		t.Fatalf("update argument source = %+v, want PARAMETER data", param)
+	}
+	receiverOrigin := sign.Calls[2]
+	if receiverOrigin.Raw != "signer.sign()" {
+		t.Fatalf("sign call Raw = %q", receiverOrigin.Raw)
+	}
+}
+
+func TestParseJavaClassCallGraph_ArgumentProvenance(t *testing.T) {
+	t.Parallel()
+
+	analysis, err := parseJavaClassCallGraph(buildSignerClass(), "Signer.class")
+	if err != nil {
+		t.Fatalf("parseJavaClassCallGraph: %v", err)
+	}
+
+	cipher := bytecodeDeclByName(t, analysis, "cipher#1")
+	if len(cipher.Calls) != 1 || cipher.Calls[0].Arguments[0] != `"AES/" + arg0` {
+		t.Fatalf("cipher calls = %+v, want concat argument rendered from recipe", cipher.Calls)
+	}
+	if nested := cipher.Calls[0].ArgumentSources[0][0].SourceNodes; len(nested) != 1 || nested[0].Type != javaSourceTypeParameter {
+		t.Fatalf("concat argument provenance = %+v, want the mode parameter", nested)
+	}
+
+	pick := bytecodeDeclByName(t, analysis, "pick#1")
+	var values []string
+	for _, node := range pick.Calls[0].ArgumentSources[0] {
+		values = append(values, node.Value)
+	}
+	if strings.Join(values, ",") != `"AES","DES"` {
+		t.Fatalf("pick argument values = %v, want both branch constants", values)
+	}
+
+	key := bytecodeDeclByName(t, analysis, "key#1")
+	if len(key.Calls) != 1 || key.Calls[0].Callee.String() != "javax.crypto.spec.(SecretKeySpec).<init>#2" {
+		t.Fatalf("key calls = %+v", key.Calls)
+	}
+	if key.Calls[0].Raw != `new SecretKeySpec(arg0, "AES")` {
+		t.Fatalf("constructor Raw = %q", key.Calls[0].Raw)
+	}
+	if got := key.Calls[0].ArgumentSources[1][0].Value; got != `"AES"` {
+		t.Fatalf("constructor algorithm argument = %q", got)
+	}
+
+	run := bytecodeDeclByName(t, analysis, "run#0")
+	if len(run.Calls) != 2 || run.Calls[0].Callee.String() != "com.vendor.sdk.(Signer).lambda$run$0#0" ||
+		run.Calls[1].Callee.String() != "java.lang.(Runnable).run#0" {
+		t.Fatalf("run calls = %+v, want lambda body then Runnable.run", run.Calls)
+	}
+
+	init := bytecodeDeclByName(t, analysis, "<init>#0")
+	if init.FunctionType != javaFunctionTypeConstructor || init.Calls[0].Raw != "super()" {
+		t.Fatalf("constructor = %+v", init)
+	}
+}
+
+func TestJavaBytecodeNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		binary  string
+		pkg     string
+		typeStr string
+	}{
+		{"com.example.Outer", "com.example", "Outer"},
+		{"com.example.Outer$Inner", "com.example", "Outer.Inner"},
+		{"com.example.Outer$1", "com.example", "Outer$1"},
+		{"com.example.Outer$Inner$2", "com.example", "Outer.Inner$2"},
+		{"Default", "", "Default"},
+	}
+	for _, tt := range tests {
+		pkg, typeName := javaBytecodeFunctionOwner(tt.binary)
+		if pkg != tt.pkg || typeName != tt.typeStr {
+			t.Errorf("javaBytecodeFunctionOwner(%q) = %q, %q; want %q, %q", tt.binary, pkg, typeName, tt.pkg, tt.typeStr)
+		}
+	}
+	if got := javaBytecodeSimpleTypeName("java.util.Map$Entry[][]"); got != "Map.Entry[][]" {
+		t.Errorf("javaBytecodeSimpleTypeName = %q", got)
+	}
+}
+
+func TestJavaInstructionSize_Switches(t *testing.T) {
+	t.Parallel()
+
+	// tableswitch at pc 1: 2 padding bytes, default/low/high, then 2 offsets.
+	table := make([]byte, 1+1+2+12+8)
+	table[1] = opTableswitch
+	binary.BigEndian.PutUint32(table[8:], 0)
+	binary.BigEndian.PutUint32(table[12:], 1)
+	if got := javaInstructionSize(table, 1); got != 3+12+8 {
+		t.Fatalf("tableswitch size = %d, want %d", got, 3+12+8)
+	}
+
+	// lookupswitch at pc 0: 3 padding bytes, default/npairs, then one pair.
+	lookup := make([]byte, 4+8+8)
+	lookup[0] = opLookupswitch
+	binary.BigEndian.PutUint32(lookup[8:], 1)
+	if got := javaInstructionSize(lookup, 0); got != 4+8+8 {
+		t.Fatalf("lookupswitch size = %d, want %d", got, 4+8+8)
+	}
+
+	if got := javaInstructionSize([]byte{opWide, opIinc, 0, 1, 0, 1}, 0); got != 6 {
+		t.Fatalf("wide iinc size = %d, want 6", got)
+	}
+}
+
+func TestParseJavaClassCallGraph_RejectsInvalidClass(t *testing.T) {
+	t.Parallel()
+
+	if _, err := parseJavaClassCallGraph([]byte("not a class"), "bad.class"); err == nil {
+		t.Fatal("expected error for invalid class file")
+	}
+	// Truncating a valid class must not panic.
+	data := buildSignerClass()
+	for _, n := range []int{len(data) / 4, len(data) / 2, len(data) - 3} {
+		_, _ = parseJavaClassCallGraph(data[:n], "truncated.class")
+	}
+}
+
+func TestBuilder_BuildFromDirectories_TracesThroughCompiledArtifact(t *testing.T) {
+	t.Parallel()
+
+	userDir, cryptoDir := t.TempDir(), t.TempDir()
+	cryptoSrc := `package com.crypto;
+
+import java.security.MessageDigest;
+
+public class Digests {
+    public static byte[] sha(byte[] data) throws Exception {
+        return MessageDigest.getInstance("SHA-256").digest(data);
+    }
+}
+`
+	if err := os.WriteFile(filepath.Join(cryptoDir, "Digests.java"), []byte(cryptoSrc), 0o600); err != nil {
+		t.Fatalf("write Digests.java: %v", err)
+	}
+	src := `package com.example.app;
+
+import com.vendor.sdk.Signer;
+
+public class App {
+    public byte[] handle(byte[] body) {
+        return Signer.digest(body);
+    }
+}
+`
+	if err := os.WriteFile(filepath.Join(userDir, "App.java"), []byte(src), 0o600); err != nil {
+		t.Fatalf("write App.java: %v", err)
+	}
+	jar := writeSignerJAR(t)
+
+	builder := NewBuilder(NewJavaParser())
+	graph, err := builder.BuildFromDirectories(
+		[]PackageDir{
+			{Dir: userDir, ImportPath: "com.example.app"},
+			{Dir: cryptoDir, ImportPath: "com.crypto", Version: "2.0"},
+		},
+		[]PackageDir{{ImportPath: "com.vendor:sdk", Version: "1.0", CompiledArtifactPath: jar}},
+	)
+	if err != nil {
+		t.Fatalf("BuildFromDirectories: %v", err)
+	}
+
+	signKey := "com.vendor.sdk.(Signer).sign#1"
+	fn, ok := graph.Functions[signKey]
+	if !ok {
+		t.Fatalf("bytecode declaration %s missing from graph", signKey)
+	}
+	if !strings.HasSuffix(fn.FilePath, "signer-1.0.jar!/com/vendor/sdk/Signer.class") {
+		t.Fatalf("bytecode FilePath = %q", fn.FilePath)
+	}
+
+	target := FunctionID{Package: "com.crypto", Type: "Digests", Name: "sha#1"}
+	chains, _ := NewTracer(graph, builder.PackageSeparator()).TraceBackLimited(target, map[string]bool{"com.example.app": true}, 16, 16)
+	if len(chains) == 0 {
+		t.Fatal("expected a chain from user code through the compiled artifact")
+	}
+	var sigs []string
+	for _, chain := range chains {
+		sigs = append(sigs, chainSignature(chain))
+	}
+	want := "App.handle#1 -> Signer.digest#1 -> Digests.sha#1"
+	for _, sig := range sigs {
+		if sig == want {
+			return
+		}
+	}
+	t.Fatalf("chains = %v, want %q", sigs, want)
+}
+
+func TestBuilder_BuildFromDirectories_SourceDeclarationsWinOverArtifact(t *testing.T) {
+	t.Parallel()
+
+	jar := writeSignerJAR(t)
+	sourceDecl := FunctionDecl{
+		ID:       FunctionID{Package: "com.vendor.sdk", Type: "Signer", Name: "sign#1"},
+		FilePath: "Signer.java",
+	}
+	parser := &artifactStubParser{stubParser: stubParser{
+		sep:      ".",
+		analyses: map[string][]*FileAnalysis{"src": {{Functions: []FunctionDecl{sourceDecl}}}},
+	}}
+
+	graph, err := NewBuilder(parser).BuildFromDirectories(
+		[]PackageDir{{Dir: "src", ImportPath: "com.vendor.sdk"}},
+		[]PackageDir{{ImportPath: "com.vendor:sdk", Version: "1.0", CompiledArtifactPath: jar}},
+	)
+	if err != nil {
+		t.Fatalf("BuildFromDirectories: %v", err)
+	}
+	if got := graph.Functions[sourceDecl.ID.String()].FilePath; got != "Signer.java" {
+		t.Fatalf("sign FilePath = %q, want source declaration to win", got)
+	}
+	if _, ok := graph.Functions["com.vendor.sdk.(Signer).pick#1"]; !ok {
+		t.Fatal("expected artifact-only declarations to be added")
+	}
+}
+
+// artifactStubParser layers the bytecode artifact reader over stubParser.
+type artifactStubParser struct {
+	stubParser
+}
+
+func (p *artifactStubParser) ParseArtifact(pkg PackageDir) ([]*FileAnalysis, error) {
+	return NewJavaParser().ParseArtifact(pkg)
+}
@@ -105,6 +105,19 @@ func (p *JavaParser) PackageSeparator() string {
 
 // ParseDirectory parses all .java files in a directory.
 func (p *JavaParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, error) {
+	return p.parseDirectory(dir, packagePath, p.parseFile)
+}
+
+// ParseImports reads the package declaration and imports of all .java files
+// in a directory, without extracting declarations.
+func (p *JavaParser) ParseImports(dir, packagePath string) ([]*FileAnalysis, error) {
+	return p.parseDirectory(dir, packagePath, p.parseFileImports)
+}
+
+func (p *JavaParser) parseDirectory(
+	dir, packagePath string,
+	parseFile func(filePath, packagePath string) (*FileAnalysis, error),
+) ([]*FileAnalysis, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("reading directory %s: %w", dir, err)
@@ -124,7 +137,7 @@ func (p *JavaParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, e
 		}
 
 		fullPath := filepath.Join(dir, name)
-		analysis, err := p.parseFile(fullPath, packagePath)
+		analysis, err := parseFile(fullPath, packagePath)
 		if err != nil {
 			continue
 		}
@@ -134,6 +147,34 @@ func (p *JavaParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, e
 	return analyses, nil
 }
 
+// parseFileImports extracts the package declaration and imports of a single
+// Java file.
+func (p *JavaParser) parseFileImports(filePath, packagePath string) (*FileAnalysis, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filePath, err)
+	}
+
+	tree, err := p.parser.ParseCtx(context.TODO(), nil, src)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filePath, err)
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	analysis := &FileAnalysis{
+		FilePath:    filePath,
+		PackagePath: packagePath,
+		Imports:     make(map[string]string),
+	}
+	analysis.PackageName = p.extractPackageName(root, src)
+	if analysis.PackageName != "" {
+		analysis.PackagePath = analysis.PackageName
+	}
+	p.extractImports(root, src, analysis)
+	return analysis, nil
+}
+
 // parseFile extracts declarations, imports, and calls from a single Java file.
 func (p *JavaParser) parseFile(filePath, packagePath string) (*FileAnalysis, error) {
 	src, err := os.ReadFile(filePath)
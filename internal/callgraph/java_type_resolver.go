@@ -1314,7 +1314,15 @@ func parseClassFile(data []byte, _ string) (*classFileInfo, error) {
 type cpEntry struct {
 	tag      uint8
 	strValue string
+	// intValue holds the raw 4-byte value of Integer/Float entries, the first
+	// index of single- and two-index entries, or a MethodHandle reference kind.
 	intValue int
+	// refIndex holds the second index of two-index entries (member refs,
+	// NameAndType, Dynamic, InvokeDynamic) and the reference index of a
+	// MethodHandle.
+	refIndex int
+	// wideValue holds the raw 8-byte value of Long/Double entries.
+	wideValue uint64
 }
 
 func parseConstantPool(data []byte, offset, count int) ([]cpEntry, int, error) {
@@ -1565,9 +1573,9 @@ func parseConstantPoolEntry(data []byte, offset, index int) (cpEntry, int, bool,
 	case 7, 8, 16, 19, 20:
 		return parseShortIndexCPEntry(data, offset, tag, index)
 	case 9, 10, 11, 12, 17, 18:
-		return parseFixedWidthCPEntry(data, offset, tag, index, 4)
+		return parseTwoIndexCPEntry(data, offset, tag, index)
 	case 15:
-		return parseFixedWidthCPEntry(data, offset, tag, index, 3)
+		return parseMethodHandleCPEntry(data, offset, tag, index)
 	default:
 		return cpEntry{}, offset, false, fmt.Errorf("unknown constant pool tag %d at index %d", tag, index)
 	}
@@ -1596,7 +1604,7 @@ func parseLongCPEntry(data []byte, offset int, tag uint8, index int) (cpEntry, i
 	if offset+8 > len(data) {
 		return cpEntry{}, offset, false, fmt.Errorf("truncated long/double at index %d", index)
 	}
-	return cpEntry{tag: tag}, offset + 8, true, nil
+	return cpEntry{tag: tag, wideValue: binary.BigEndian.Uint64(data[offset:])}, offset + 8, true, nil
 }
 
 func parseShortIndexCPEntry(data []byte, offset int, tag uint8, index int) (cpEntry, int, bool, error) {
@@ -1606,11 +1614,26 @@ func parseShortIndexCPEntry(data []byte, offset int, tag uint8, index int) (cpEn
 	return cpEntry{tag: tag, intValue: int(binary.BigEndian.Uint16(data[offset:]))}, offset + 2, false, nil
 }
 
-func parseFixedWidthCPEntry(data []byte, offset int, tag uint8, index, width int) (cpEntry, int, bool, error) {
-	if offset+width > len(data) {
+func parseTwoIndexCPEntry(data []byte, offset int, tag uint8, index int) (cpEntry, int, bool, error) {
+	if offset+4 > len(data) {
+		return cpEntry{}, offset, false, fmt.Errorf("truncated constant pool entry at index %d", index)
+	}
+	return cpEntry{
+		tag:      tag,
+		intValue: int(binary.BigEndian.Uint16(data[offset:])),
+		refIndex: int(binary.BigEndian.Uint16(data[offset+2:])),
+	}, offset + 4, false, nil
+}
+
+func parseMethodHandleCPEntry(data []byte, offset int, tag uint8, index int) (cpEntry, int, bool, error) {
+	if offset+3 > len(data) {
 		return cpEntry{}, offset, false, fmt.Errorf("truncated constant pool entry at index %d", index)
 	}
-	return cpEntry{tag: tag}, offset + width, false, nil
+	return cpEntry{
+		tag:      tag,
+		intValue: int(data[offset]),
+		refIndex: int(binary.BigEndian.Uint16(data[offset+1:])),
+	}, offset + 3, false, nil
 }
 
 func skipFieldsOrMethods(data []byte, offset int, _ []cpEntry) (int, error) {
@@ -90,3 +90,49 @@ public class %s {
 		t.Fatalf("parallel parse produced a different graph than serial parse:\nserial:\n%s\nparallel:\n%s", serialDump, parallelDump)
 	}
 }
+
+func TestBuilder_ParseImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":        "package main\n\nimport (\n\t\"crypto/aes\"\n\tx \"golang.org/x/crypto/chacha20\"\n)\n\nfunc main() { aes.NewCipher(nil) }\n",
+		"sub/sub.go":     "package sub\n\nimport \"golang.org/x/sys/cpu\"\n",
+		"vendor/v/v.go":  "package v\n\nimport \"example.com/vendored\"\n",
+		"main_test.go":   "package main\n\nimport \"testing\"\n",
+		"sub/README.txt": "not go\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analyses, ok := NewBuilder(NewGoParser()).ParseImports(PackageDir{Dir: dir, ImportPath: "example.com/app"})
+	if !ok {
+		t.Fatal("GoParser should implement ImportParser")
+	}
+	imports := make(map[string]string)
+	for _, analysis := range analyses {
+		if len(analysis.Functions) != 0 {
+			t.Errorf("%s: ParseImports should not extract declarations", analysis.FilePath)
+		}
+		for _, importPath := range analysis.Imports {
+			imports[importPath] = analysis.PackagePath
+		}
+	}
+	want := map[string]string{
+		"crypto/aes":                   "example.com/app",
+		"golang.org/x/crypto/chacha20": "example.com/app",
+		"golang.org/x/sys/cpu":         "example.com/app/sub",
+	}
+	if fmt.Sprint(imports) != fmt.Sprint(want) {
+		t.Fatalf("imports = %v, want %v", imports, want)
+	}
+
+	if _, ok := NewBuilder(NewRustParser()).ParseImports(PackageDir{Dir: dir}); ok {
+		t.Fatal("RustParser does not read imports on their own")
+	}
+}
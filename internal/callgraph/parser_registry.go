@@ -3,6 +3,7 @@ package callgraph
 import "github.com/scanoss/crypto-finder/internal/javaruntime"
 
 const (
+	ecosystemC           = "c"
 	ecosystemCPP         = "cpp"
 	ecosystemJava        = "java"
 	lambdaExpressionNode = "lambda_expression"
@@ -12,7 +13,7 @@ const (
 // Returns nil if no parser is available for the ecosystem.
 func NewParserForEcosystem(ecosystem string, opts ...ParserOption) Parser {
 	switch ecosystem {
-	case "c":
+	case ecosystemC:
 		return NewCParser(opts...)
 	case ecosystemCPP, "c++":
 		return NewCPPParser(opts...)
@@ -35,7 +36,7 @@ func NewParserForEcosystem(ecosystem string, opts ...ParserOption) Parser {
 // Returns nil if no type resolver is available (tree-sitter-only resolution).
 func NewTypeResolverForEcosystem(ecosystem string, javaRuntime javaruntime.Config) TypeResolver {
 	switch ecosystem {
-	case "c":
+	case ecosystemC:
 		return NewCContractTypeResolverFromEmbedded()
 	case ecosystemCPP, "c++":
 		return NewCPPContractTypeResolverFromEmbedded()
@@ -73,6 +73,19 @@ func (p *PythonParser) PackageSeparator() string {
 
 // ParseDirectory parses all .py files in a directory.
 func (p *PythonParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis, error) {
+	return p.parseDirectory(dir, packagePath, p.parseFile)
+}
+
+// ParseImports reads the imports of all .py files in a directory, without
+// extracting declarations.
+func (p *PythonParser) ParseImports(dir, packagePath string) ([]*FileAnalysis, error) {
+	return p.parseDirectory(dir, packagePath, p.parseFileImports)
+}
+
+func (p *PythonParser) parseDirectory(
+	dir, packagePath string,
+	parseFile func(filePath, packagePath string) (*FileAnalysis, error),
+) ([]*FileAnalysis, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("reading directory %s: %w", dir, err)
@@ -92,7 +105,7 @@ func (p *PythonParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis,
 		}
 
 		fullPath := filepath.Join(dir, name)
-		analysis, err := p.parseFile(fullPath, packagePath)
+		analysis, err := parseFile(fullPath, packagePath)
 		if err != nil {
 			continue
 		}
@@ -102,6 +115,30 @@ func (p *PythonParser) ParseDirectory(dir, packagePath string) ([]*FileAnalysis,
 	return analyses, nil
 }
 
+// parseFileImports extracts the imports of a single Python file.
+func (p *PythonParser) parseFileImports(filePath, packagePath string) (*FileAnalysis, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filePath, err)
+	}
+
+	tree, err := p.parser.ParseCtx(context.TODO(), nil, src)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filePath, err)
+	}
+	defer tree.Close()
+
+	analysis := &FileAnalysis{
+		FilePath:      filePath,
+		PackagePath:   packagePath,
+		Imports:       make(map[string]string),
+		ImportedTypes: make(map[string]bool),
+		FromImports:   make(map[string]bool),
+	}
+	p.extractImports(tree.RootNode(), src, analysis)
+	return analysis, nil
+}
+
 // parseFile extracts declarations, imports, and calls from a single Python file.
 func (p *PythonParser) parseFile(filePath, packagePath string) (*FileAnalysis, error) {
 	src, err := os.ReadFile(filePath)
@@ -220,6 +220,14 @@ func findCallLine(callerFn *FunctionDecl, calleeKey string) int {
 			return call.Line
 		}
 	}
+	// C/C++ calls to free functions in other packages are linked by name.
+	for i := range callerFn.Calls {
+		call := callerFn.Calls[i]
+		if err == nil && call.Callee.Linkage != LinkageInternal &&
+			call.Callee.Type == "" && calleeID.Type == "" && call.Callee.Name == calleeID.Name {
+			return call.Line
+		}
+	}
 	// Fallback to function start line if we can't find the specific call
 	return callerFn.StartLine
 }
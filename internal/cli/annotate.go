@@ -35,6 +35,7 @@ import (
 	"github.com/scanoss/crypto-finder/internal/rules"
 	scanutil "github.com/scanoss/crypto-finder/internal/scan"
 	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/scanner/native"
 	"github.com/scanoss/crypto-finder/internal/scanner/opengrep"
 	"github.com/scanoss/crypto-finder/internal/scanner/semgrep"
 	"github.com/scanoss/crypto-finder/internal/skip"
@@ -47,6 +48,8 @@ var (
 	annotateOutput           string
 	annotateRules            []string
 	annotateRuleDirs         []string
+	annotateRulesGit         []string
+	annotateRulesOCI         []string
 	annotateScanner          string
 	annotateTimeout          string
 	annotateNoRemoteRules    bool
@@ -87,6 +90,8 @@ func init() {
 	annotateCmd.Flags().StringVarP(&annotateOutput, "output", "o", "", "Output file path for the annotation JSON (default: stdout)")
 	annotateCmd.Flags().StringArrayVarP(&annotateRules, "rules", "r", []string{}, "Rule file path (repeatable)")
 	annotateCmd.Flags().StringArrayVar(&annotateRuleDirs, "rules-dir", []string{}, "Rule directory path (repeatable)")
+	annotateCmd.Flags().StringArrayVar(&annotateRulesGit, "rules-git", []string{}, "Rules from a git repository at a pinned ref: <repo>@<ref>[#subdir] (repeatable)")
+	annotateCmd.Flags().StringArrayVar(&annotateRulesOCI, "rules-oci", []string{}, "Rules from an OCI registry artifact: <registry>/<repository>[:tag][@digest] (repeatable)")
 	annotateCmd.Flags().StringVar(&annotateScanner, "scanner", defaultScanner, fmt.Sprintf("Scanner to use (default: %s)", defaultScanner))
 	annotateCmd.Flags().StringVarP(&annotateTimeout, "timeout", "t", defaultTimeout, "Detection timeout (e.g., 10m, 1h)")
 	annotateCmd.Flags().BoolVar(&annotateNoRemoteRules, "no-remote-rules", false, "Disable the default remote ruleset")
@@ -180,6 +185,8 @@ func runAnnotateDetection(ctx context.Context, timeout time.Duration) (*entities
 	normalizedLanguages, err := scanutil.ValidateFlags(target, scanutil.ValidationOptions{
 		RuleFiles:        annotateRules,
 		RuleDirs:         annotateRuleDirs,
+		RuleGitRepos:     annotateRulesGit,
+		RuleOCIArtifacts: annotateRulesOCI,
 		NoRemoteRules:    annotateNoRemoteRules,
 		Scanner:          annotateScanner,
 		AllowedScanners:  AllowedScanners,
@@ -224,6 +231,7 @@ func runAnnotateDetection(ctx context.Context, timeout time.Duration) (*entities
 	scannerRegistry := scanner.NewRegistry()
 	scannerRegistry.Register(opengrep.ScannerName, opengrep.NewScanner())
 	scannerRegistry.Register(semgrep.ScannerName, semgrep.NewScanner())
+	scannerRegistry.Register(native.ScannerName, native.NewScanner())
 
 	orchestrator := engine.NewOrchestrator(langDetector, rulesManager, scannerRegistry)
 
@@ -253,6 +261,11 @@ func buildAnnotateRulesManager(ctx context.Context, cfg *config.Config) (*rules.
 			return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to create cache manager")
 		}
 		cacheManager.SetNoCache(annotateNoCache)
+		trustedKeys, err := resolveTrustedRulesetKeys(cfg, nil)
+		if err != nil {
+			return nil, err
+		}
+		cacheManager.SetTrustedKeys(trustedKeys)
 		remoteSource := rules.NewRemoteRuleSource(ctx, defaultRulesetName, defaultRulesetVersion, cacheManager)
 		ruleSources = append(ruleSources, remoteSource)
 	}
@@ -261,13 +274,19 @@ func buildAnnotateRulesManager(ctx context.Context, cfg *config.Config) (*rules.
 		ruleSources = append(ruleSources, rules.NewLocalRuleSource(annotateRules, annotateRuleDirs))
 	}
 
+	fetchedSources, err := newFetchedRuleSources(ctx, annotateRulesGit, annotateRulesOCI)
+	if err != nil {
+		return nil, err
+	}
+	ruleSources = append(ruleSources, fetchedSources...)
+
 	switch len(ruleSources) {
 	case 0:
 		return nil, failure.New(failure.CodeRulesLoadFailed, failure.StageRules,
-			"no rule sources configured (use --rules, --rules-dir, or enable remote rules)")
+			"no rule sources configured (use --rules, --rules-dir, --rules-git, --rules-oci, or enable remote rules)")
 	case 1:
 		return rules.NewManager(ruleSources[0]), nil
 	default:
-		return rules.NewManager(rules.NewMultiSource(ruleSources...)), nil
+		return rules.NewManager(ruleSources...), nil
 	}
 }
@@ -0,0 +1,187 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/cache"
+	"github.com/scanoss/crypto-finder/internal/callgraph"
+	"github.com/scanoss/crypto-finder/internal/config"
+	"github.com/scanoss/crypto-finder/internal/dependency"
+	"github.com/scanoss/crypto-finder/internal/engine"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+// Names of the on-disk caches accepted by --cache.
+const (
+	cacheNameRulesets = "rulesets"
+	cacheNameSources  = "sources"
+	cacheNameBytecode = "bytecode"
+	cacheNameFindings = "findings"
+	cacheNameGitRules = config.GitRulesDirName
+	cacheNameOCIRules = config.OCIRulesDirName
+)
+
+var allCacheNames = []string{
+	cacheNameRulesets, cacheNameSources, cacheNameBytecode, cacheNameFindings, cacheNameGitRules, cacheNameOCIRules,
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and evict the local caches",
+	Long: `Inspect and evict the caches crypto-finder keeps under
+~/.scanoss/crypto-finder/cache:
+
+  rulesets   remote rulesets downloaded from the SCANOSS API or imported
+             with "rules import"
+  sources    dependency sources extracted by --scan-dependencies
+  bytecode   bytecode method indexes built for Java call graphs
+  findings   per-dependency findings reused by --scan-dependencies
+  git-rules  rules checked out by --rules-git
+  oci-rules  rules pulled by --rules-oci
+
+Every cache is rebuilt on demand, so removing entries only costs the time
+to download or recompute them on the next scan.`,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cacheInfoCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+// addCacheSelectionFlag registers --cache on a cache subcommand.
+func addCacheSelectionFlag(cmd *cobra.Command, target *[]string) {
+	cmd.Flags().StringSliceVar(target, "cache", []string{},
+		fmt.Sprintf("Caches to include (comma-separated: %s; default: all)", strings.Join(allCacheNames, ", ")))
+}
+
+// localCaches are the caches a cache subcommand works on. rulesets is nil
+// when the ruleset cache is not selected.
+type localCaches struct {
+	rulesets *cache.Manager
+	stores   []cache.Store
+}
+
+// openLocalCaches opens the selected caches; an empty selection opens all.
+func openLocalCaches(selected []string) (*localCaches, error) {
+	if len(selected) == 0 {
+		selected = allCacheNames
+	}
+	for _, name := range selected {
+		if !slices.Contains(allCacheNames, name) {
+			return nil, failure.New(failure.CodeInvalidArguments, failure.StageInput,
+				fmt.Sprintf("unknown cache '%s' (supported: %s)", name, strings.Join(allCacheNames, ", ")))
+		}
+	}
+
+	caches := &localCaches{}
+	var err error
+	if slices.Contains(selected, cacheNameRulesets) {
+		if caches.rulesets, err = cache.NewManager(nil); err != nil {
+			return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to open ruleset cache")
+		}
+	}
+	if slices.Contains(selected, cacheNameSources) {
+		sources, err := dependency.NewSourceCache()
+		if err != nil {
+			return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to open source cache")
+		}
+		caches.stores = append(caches.stores, cache.Store{Name: cacheNameSources, Dir: sources.Dir(), Depth: 2})
+	}
+	if slices.Contains(selected, cacheNameBytecode) {
+		bytecode, err := callgraph.NewDiskBytecodeIndexCache()
+		if err != nil {
+			return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to open bytecode cache")
+		}
+		caches.stores = append(caches.stores, cache.Store{Name: cacheNameBytecode, Dir: bytecode.Dir(), Depth: 1})
+	}
+	if slices.Contains(selected, cacheNameFindings) {
+		findings, err := engine.NewDiskFindingsCache()
+		if err != nil {
+			return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to open findings cache")
+		}
+		caches.stores = append(caches.stores, cache.Store{Name: cacheNameFindings, Dir: findings.Dir(), Depth: 1})
+	}
+	// Fetched rules are kept as <source>/<revision> directories.
+	for _, name := range []string{cacheNameGitRules, cacheNameOCIRules} {
+		if !slices.Contains(selected, name) {
+			continue
+		}
+		cacheDir, err := config.GetCacheDir()
+		if err != nil {
+			return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig,
+				fmt.Sprintf("failed to open %s cache", name))
+		}
+		caches.stores = append(caches.stores, cache.Store{Name: name, Dir: filepath.Join(cacheDir, name), Depth: 2})
+	}
+	return caches, nil
+}
+
+// parseRulesetRef splits a name@version reference.
+func parseRulesetRef(ref string) (string, string, error) {
+	name, version, ok := strings.Cut(ref, "@")
+	if !ok || name == "" || version == "" {
+		return "", "", failure.New(failure.CodeInvalidArguments, failure.StageInput,
+			fmt.Sprintf("invalid ruleset '%s' (want name@version, e.g. %s@%s)", ref, defaultRulesetName, defaultRulesetVersion))
+	}
+	return name, version, nil
+}
+
+// rulesetStatus summarizes the state of a cached ruleset for listings.
+func rulesetStatus(ruleset *cache.CachedRuleset) string {
+	switch {
+	case ruleset.Metadata == nil:
+		return "incomplete"
+	case ruleset.Metadata.Pinned:
+		return "pinned"
+	case ruleset.Metadata.IsExpired():
+		return "expired"
+	default:
+		return "valid"
+	}
+}
+
+// formatBytes renders a size with a binary unit, e.g. "12.3 MiB".
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// formatCacheTime renders a cache timestamp, or "-" when it is unknown.
+func formatCacheTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/cache"
+)
+
+var (
+	cacheClearCaches []string
+	cacheClearDryRun bool
+)
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every entry of the local caches",
+	Long: `Remove every entry of the selected caches, including pinned rulesets
+installed with "rules import". Without a remote ruleset in the cache, the
+next scan needs API access (or another "rules import").
+
+Examples:
+  crypto-finder cache clear
+  crypto-finder cache clear --cache findings`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClear,
+}
+
+func init() {
+	addCacheSelectionFlag(cacheClearCmd, &cacheClearCaches)
+	cacheClearCmd.Flags().BoolVar(&cacheClearDryRun, "dry-run", false, "Report what would be removed without removing it")
+}
+
+func runCacheClear(cmd *cobra.Command, _ []string) error {
+	caches, err := openLocalCaches(cacheClearCaches)
+	if err != nil {
+		return err
+	}
+	return evictCaches(cmd.OutOrStdout(), caches, cacheClearDryRun,
+		func(*cache.CachedRuleset) bool { return true },
+		func(*cache.StoreEntry) bool { return true })
+}
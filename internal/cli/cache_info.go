@@ -0,0 +1,106 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/cache"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info <name@version>",
+	Short: "Show the cache metadata of a ruleset",
+	Long: `Show where a cached ruleset is stored, its size, and its cache metadata:
+concrete version, checksum, download and last-use times, TTL and expiry,
+and signature verification result. The version is the one the ruleset is
+cached under, as shown by "cache list".
+
+Examples:
+  crypto-finder cache info dca@latest`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCacheInfo,
+}
+
+func runCacheInfo(cmd *cobra.Command, args []string) error {
+	name, version, err := parseRulesetRef(args[0])
+	if err != nil {
+		return err
+	}
+	caches, err := openLocalCaches([]string{cacheNameRulesets})
+	if err != nil {
+		return err
+	}
+	ruleset, err := caches.rulesets.RulesetInfo(name, version)
+	if err != nil {
+		if errors.Is(err, cache.ErrRulesetNotCached) {
+			return failure.New(failure.CodeInvalidArguments, failure.StageInput,
+				fmt.Sprintf("ruleset '%s' is not cached (see \"cache list\")", args[0]))
+		}
+		return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to read ruleset cache")
+	}
+	if err := writeRulesetInfo(cmd.OutOrStdout(), ruleset); err != nil {
+		return failure.WrapUnknown(err, failure.CodeOutputWriteFailed, failure.StageOutput, "failed to write ruleset info")
+	}
+	return nil
+}
+
+func writeRulesetInfo(w io.Writer, ruleset *cache.CachedRuleset) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := [][2]string{
+		{"Ruleset", ruleset.Name + "@" + ruleset.Version},
+		{"Path", ruleset.Path},
+		{"Size", formatBytes(ruleset.SizeBytes)},
+		{"Status", rulesetStatus(ruleset)},
+	}
+	if metadata := ruleset.Metadata; metadata != nil {
+		ttl := time.Duration(metadata.TTLSeconds) * time.Second
+		expires := formatCacheTime(metadata.DownloadedAt.Add(ttl))
+		if metadata.Pinned {
+			expires = "never (pinned)"
+		}
+		signature := metadata.SignatureStatus
+		if signature == "" {
+			signature = "-"
+		}
+		if metadata.SignatureKeyID != "" {
+			signature += " (key " + metadata.SignatureKeyID + ")"
+		}
+		rows = append(rows,
+			[2]string{"Version", metadata.Version},
+			[2]string{"Checksum", "sha256:" + metadata.ChecksumSHA256},
+			[2]string{"Downloaded", formatCacheTime(metadata.DownloadedAt)},
+			[2]string{"Last used", formatCacheTime(ruleset.LastUsed())},
+			[2]string{"TTL", ttl.String()},
+			[2]string{"Expires", expires},
+			[2]string{"Signature", signature},
+		)
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1]); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
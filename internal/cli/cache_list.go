@@ -0,0 +1,126 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+var cacheListCaches []string
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached rulesets and the size of the other caches",
+	Long: `List every cached ruleset version with its size, download and last-use
+times and status, followed by the number of entries, total size and oldest
+entry of the other caches.
+
+A ruleset's status is one of:
+  valid       within its TTL
+  expired     past its TTL; the next scan downloads it again
+  pinned      installed with "rules import"; never expires
+  incomplete  no cache metadata, e.g. an interrupted download
+
+Examples:
+  crypto-finder cache list
+  crypto-finder cache list --cache sources,findings`,
+	Args: cobra.NoArgs,
+	RunE: runCacheList,
+}
+
+func init() {
+	addCacheSelectionFlag(cacheListCmd, &cacheListCaches)
+}
+
+func runCacheList(cmd *cobra.Command, _ []string) error {
+	caches, err := openLocalCaches(cacheListCaches)
+	if err != nil {
+		return err
+	}
+	if err := writeCacheList(cmd.OutOrStdout(), caches); err != nil {
+		return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to list caches")
+	}
+	return nil
+}
+
+func writeCacheList(w io.Writer, caches *localCaches) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if caches.rulesets != nil {
+		rulesets, err := caches.rulesets.ListRulesets()
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(tw, "RULESET\tVERSION\tSIZE\tDOWNLOADED\tLAST USED\tSTATUS"); err != nil {
+			return err
+		}
+		for i := range rulesets {
+			ruleset := &rulesets[i]
+			version, downloaded := "-", time.Time{}
+			if ruleset.Metadata != nil {
+				version, downloaded = ruleset.Metadata.Version, ruleset.Metadata.DownloadedAt
+			}
+			if _, err := fmt.Fprintf(tw, "%s@%s\t%s\t%s\t%s\t%s\t%s\n",
+				ruleset.Name, ruleset.Version, version, formatBytes(ruleset.SizeBytes),
+				formatCacheTime(downloaded), formatCacheTime(ruleset.LastUsed()), rulesetStatus(ruleset)); err != nil {
+				return err
+			}
+		}
+		if len(rulesets) == 0 {
+			if _, err := fmt.Fprintln(tw, "(no cached rulesets)"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(caches.stores) > 0 {
+		if caches.rulesets != nil {
+			if _, err := fmt.Fprintln(tw); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(tw, "CACHE\tENTRIES\tSIZE\tOLDEST\tPATH"); err != nil {
+			return err
+		}
+		for _, store := range caches.stores {
+			entries, err := store.Entries()
+			if err != nil {
+				return err
+			}
+			var size int64
+			var oldest time.Time
+			for _, entry := range entries {
+				size += entry.SizeBytes
+				if oldest.IsZero() || entry.ModTime.Before(oldest) {
+					oldest = entry.ModTime
+				}
+			}
+			if _, err := fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n",
+				store.Name, len(entries), formatBytes(size), formatCacheTime(oldest), store.Dir); err != nil {
+				return err
+			}
+		}
+	}
+	return tw.Flush()
+}
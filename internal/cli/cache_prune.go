@@ -0,0 +1,120 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/cache"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	scanutil "github.com/scanoss/crypto-finder/internal/scan"
+)
+
+var (
+	cachePruneOlderThan string
+	cachePruneCaches    []string
+	cachePruneDryRun    bool
+)
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove cache entries that have not been used recently",
+	Long: `Remove cache entries last used before --older-than.
+
+A ruleset's last use is the last-accessed time in its cache metadata, which
+every scan that uses it refreshes. Entries of the other caches are aged by
+their modification time, which scans refresh when they reuse an entry.
+Pinned rulesets, installed with "rules import", are kept; remove them with
+"cache clear".
+
+Examples:
+  crypto-finder cache prune --older-than 30d
+  crypto-finder cache prune --older-than 2w --cache sources,findings --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runCachePrune,
+}
+
+func init() {
+	cachePruneCmd.Flags().StringVar(&cachePruneOlderThan, "older-than", "", "Remove entries last used longer ago than this (e.g., 30d, 2w, 72h)")
+	addCacheSelectionFlag(cachePruneCmd, &cachePruneCaches)
+	cachePruneCmd.Flags().BoolVar(&cachePruneDryRun, "dry-run", false, "Report what would be removed without removing it")
+	_ = cachePruneCmd.MarkFlagRequired("older-than")
+}
+
+func runCachePrune(cmd *cobra.Command, _ []string) error {
+	olderThan, err := scanutil.ParseDuration(cachePruneOlderThan)
+	if err != nil {
+		return failure.Wrap(err, failure.CodeInvalidTimeout, failure.StageInput,
+			fmt.Sprintf("invalid --older-than '%s' (use format like '30d', '2w', '72h')", cachePruneOlderThan))
+	}
+	if olderThan <= 0 {
+		// A cutoff of now or later would select every entry.
+		return failure.New(failure.CodeInvalidArguments, failure.StageInput,
+			fmt.Sprintf("--older-than must be positive, got '%s'", cachePruneOlderThan))
+	}
+	caches, err := openLocalCaches(cachePruneCaches)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	return evictCaches(cmd.OutOrStdout(), caches, cachePruneDryRun,
+		func(ruleset *cache.CachedRuleset) bool {
+			return (ruleset.Metadata == nil || !ruleset.Metadata.Pinned) && ruleset.LastUsed().Before(cutoff)
+		},
+		func(entry *cache.StoreEntry) bool {
+			return entry.ModTime.Before(cutoff)
+		})
+}
+
+// evictCaches removes the rulesets and store entries the predicates select
+// and reports what was removed, or only reports it when dryRun is set.
+func evictCaches(w io.Writer, caches *localCaches, dryRun bool, evictRuleset func(*cache.CachedRuleset) bool, evictEntry func(*cache.StoreEntry) bool) error {
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+
+	if caches.rulesets != nil {
+		eviction, err := caches.rulesets.EvictRulesets(dryRun, evictRuleset, func(ruleset *cache.CachedRuleset) error {
+			_, err := fmt.Fprintf(w, "%s ruleset %s@%s (%s)\n", verb, ruleset.Name, ruleset.Version, formatBytes(ruleset.SizeBytes))
+			return err
+		})
+		if err != nil {
+			return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to prune ruleset cache")
+		}
+		if _, err := fmt.Fprintf(w, "%s %d of %d cached rulesets (%s)\n", verb, eviction.Removed, eviction.Total, formatBytes(eviction.FreedBytes)); err != nil {
+			return err
+		}
+	}
+
+	for _, store := range caches.stores {
+		eviction, err := store.Evict(dryRun, evictEntry)
+		if err != nil {
+			return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig,
+				fmt.Sprintf("failed to prune %s cache", store.Name))
+		}
+		if _, err := fmt.Fprintf(w, "%s %d of %d %s cache entries (%s)\n", verb, eviction.Removed, eviction.Total, store.Name, formatBytes(eviction.FreedBytes)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
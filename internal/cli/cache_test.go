@@ -0,0 +1,160 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/cache"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+func TestEvictCaches(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	caches, err := openLocalCaches([]string{cacheNameSources, cacheNameFindings})
+	if err != nil {
+		t.Fatalf("openLocalCaches() error = %v", err)
+	}
+	if caches.rulesets != nil || len(caches.stores) != 2 {
+		t.Fatalf("openLocalCaches() = %+v, want the sources and findings stores only", caches)
+	}
+
+	sources, findings := caches.stores[0], caches.stores[1]
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	writeCacheTestFile(t, filepath.Join(sources.Dir, "org.bc-bcprov", "1.70", "A.java"), old)
+	writeCacheTestFile(t, filepath.Join(sources.Dir, "org.bc-bcprov", "1.78", "A.java"), time.Now())
+	writeCacheTestFile(t, filepath.Join(findings.Dir, "old.json"), old)
+	if err := os.Chtimes(filepath.Join(sources.Dir, "org.bc-bcprov", "1.70"), old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	cutoff := time.Now().Add(-30 * 24 * time.Hour)
+	olderThanCutoff := func(entry *cache.StoreEntry) bool { return entry.ModTime.Before(cutoff) }
+
+	var out bytes.Buffer
+	if err := evictCaches(&out, caches, true, nil, olderThanCutoff); err != nil {
+		t.Fatalf("evictCaches(dryRun) error = %v", err)
+	}
+	if !strings.Contains(out.String(), "Would remove 1 of 2 sources cache entries") {
+		t.Errorf("dry run output = %q", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(sources.Dir, "org.bc-bcprov", "1.70")); err != nil {
+		t.Errorf("dry run removed an entry: %v", err)
+	}
+
+	out.Reset()
+	if err := evictCaches(&out, caches, false, nil, olderThanCutoff); err != nil {
+		t.Fatalf("evictCaches() error = %v", err)
+	}
+	want := "Removed 1 of 2 sources cache entries (4 B)\nRemoved 1 of 1 findings cache entries (4 B)\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if _, err := os.Stat(filepath.Join(sources.Dir, "org.bc-bcprov", "1.70")); !os.IsNotExist(err) {
+		t.Errorf("old source entry still exists: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sources.Dir, "org.bc-bcprov", "1.78")); err != nil {
+		t.Errorf("recent source entry removed: %v", err)
+	}
+
+	out.Reset()
+	if err := writeCacheList(&out, caches); err != nil {
+		t.Fatalf("writeCacheList() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "sources   1        4 B") || !strings.Contains(out.String(), "findings  0        0 B   -") {
+		t.Errorf("writeCacheList() = %q", out.String())
+	}
+}
+
+func writeCacheTestFile(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+}
+
+func TestRunCachePrune_RejectsNonPositiveOlderThan(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	caches, err := openLocalCaches([]string{cacheNameFindings})
+	if err != nil {
+		t.Fatalf("openLocalCaches() error = %v", err)
+	}
+	entry := filepath.Join(caches.stores[0].Dir, "entry.json")
+	writeCacheTestFile(t, entry, time.Now().Add(-time.Hour))
+
+	oldOlderThan, oldCaches := cachePruneOlderThan, cachePruneCaches
+	t.Cleanup(func() { cachePruneOlderThan, cachePruneCaches = oldOlderThan, oldCaches })
+	cachePruneCaches = []string{cacheNameFindings}
+
+	for _, olderThan := range []string{"0", "0d", "-1h"} {
+		cachePruneOlderThan = olderThan
+		err := runCachePrune(cachePruneCmd, nil)
+		typed, ok := failure.As(err)
+		if !ok || typed.Code != failure.CodeInvalidArguments {
+			t.Errorf("runCachePrune(--older-than %s) error = %v, want %s", olderThan, err, failure.CodeInvalidArguments)
+		}
+	}
+	if _, err := os.Stat(entry); err != nil {
+		t.Errorf("cache entry removed: %v", err)
+	}
+}
+
+func TestOpenLocalCaches_UnknownCache(t *testing.T) {
+	_, err := openLocalCaches([]string{"sources", "bogus"})
+	typed, ok := failure.As(err)
+	if !ok || typed.Code != failure.CodeInvalidArguments {
+		t.Fatalf("openLocalCaches() error = %v, want %s", err, failure.CodeInvalidArguments)
+	}
+}
+
+func TestParseRulesetRef(t *testing.T) {
+	name, version, err := parseRulesetRef("dca@latest")
+	if err != nil || name != "dca" || version != "latest" {
+		t.Errorf("parseRulesetRef(dca@latest) = %q, %q, %v", name, version, err)
+	}
+	for _, ref := range []string{"dca", "@latest", "dca@", ""} {
+		if _, _, err := parseRulesetRef(ref); err == nil {
+			t.Errorf("parseRulesetRef(%q) succeeded", ref)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for size, want := range map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KiB",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	} {
+		if got := formatBytes(size); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -21,21 +21,26 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"github.com/scanoss/crypto-finder/internal/converter"
 	"github.com/scanoss/crypto-finder/internal/enricher"
 	"github.com/scanoss/crypto-finder/internal/entities"
 	"github.com/scanoss/crypto-finder/internal/output"
 )
 
-var convertOutput string
+var (
+	convertOutput           string
+	convertCycloneDXVersion string
+)
 
 var convertCmd = &cobra.Command{
 	Use:   "convert [input-file]",
 	Short: "Convert interim JSON format to CycloneDX CBOM",
-	Long: `Convert crypto-finder interim format to CycloneDX 1.6 CBOM format.
+	Long: `Convert crypto-finder interim format to CycloneDX CBOM format.
 
 The convert command transforms scan results from the interim JSON format to
 CycloneDX CBOM (Cryptography Bill of Materials) format. It applies strict
@@ -50,9 +55,17 @@ Output Destinations:
   - Stdout (default): Output goes to stdout
   - File: --output cbom.json
 
+Spec Versions (--cyclonedx-version):
+  - 1.6 (default): full cryptoProperties
+  - 1.7: adds the 1.7 fields (algorithmFamily, certificate serialNumber,
+    new protocol types such as quic and dtls)
+  - 1.5: no cryptographic-asset type; each asset becomes an application
+    component with its cryptoProperties flattened into scanoss:crypto:*
+    properties
+
 Validation:
-  The converter always validates output against CycloneDX 1.6 schema.
-  Conversion fails if the generated CBOM is invalid.
+  The converter always validates output against the schema of the selected
+  CycloneDX version. Conversion fails if the generated CBOM is invalid.
 
 Examples:
   # Convert from file to stdout
@@ -68,7 +81,10 @@ Examples:
   crypto-finder convert < results.json
 
   # Convert with verbose validation output
-  crypto-finder convert results.json --verbose --output cbom.json`,
+  crypto-finder convert results.json --verbose --output cbom.json
+
+  # Convert to CycloneDX 1.7
+  crypto-finder convert results.json --cyclonedx-version 1.7`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConvert,
 }
@@ -76,6 +92,8 @@ Examples:
 func init() {
 	// Add flags
 	convertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", "Output file path (default: stdout)")
+	convertCmd.Flags().StringVar(&convertCycloneDXVersion, "cyclonedx-version", converter.DefaultSpecVersion.String(),
+		fmt.Sprintf("CycloneDX spec version: %s", strings.Join(converter.SupportedSpecVersions(), ", ")))
 }
 
 // getInputReader determines the input source and returns a reader.
@@ -115,6 +133,11 @@ func getInputReader(args []string) (io.Reader, string, func(), error) {
 }
 
 func runConvert(_ *cobra.Command, args []string) error {
+	specVersion, err := converter.ParseSpecVersion(convertCycloneDXVersion)
+	if err != nil {
+		return err
+	}
+
 	// Determine input source
 	reader, inputSource, closeFunc, err := getInputReader(args)
 	if err != nil {
@@ -141,7 +164,7 @@ func runConvert(_ *cobra.Command, args []string) error {
 	oidEnricher.EnrichReport(&report)
 
 	// Convert to CycloneDX using the writer
-	factory := output.NewWriterFactory()
+	factory := output.NewWriterFactory(output.WithCycloneDXSpecVersion(specVersion))
 	writer, err := factory.GetWriter("cyclonedx")
 	if err != nil {
 		return fmt.Errorf("failed to get CycloneDX writer: %w", err)
@@ -0,0 +1,106 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/engine"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/language"
+	"github.com/scanoss/crypto-finder/internal/rules"
+	scanutil "github.com/scanoss/crypto-finder/internal/scan"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/scanner/native"
+	"github.com/scanoss/crypto-finder/internal/scanner/opengrep"
+	"github.com/scanoss/crypto-finder/internal/scanner/semgrep"
+	"github.com/scanoss/crypto-finder/internal/skip"
+)
+
+var (
+	depWorkerSpool    string
+	depWorkerWorkers  int
+	depWorkerIdleExit string
+)
+
+var depWorkerCmd = &cobra.Command{
+	Use:   "dep-worker",
+	Short: "Scan dependencies queued in a spool directory by other scans",
+	Long: `Scan the dependency jobs that "scan --scan-dependencies --dep-spool" queues
+in a spool directory, and write the results back for that scan to merge.
+
+Start any number of workers, on this machine or on others that mount the
+spool directory. Jobs carry the coordinating scan's rules and scan
+settings; the scanner itself is the one installed where the worker runs.
+Jobs name dependency sources by the path the coordinating scan
+resolved them to, so other machines must see those sources at the same path
+(e.g. a shared ~/.m2 or Go module cache). A job whose worker fails it or
+stops is handed to another worker, up to three attempts.
+
+Examples:
+  crypto-finder dep-worker --spool /mnt/shared/crypto-finder-spool
+  crypto-finder dep-worker --spool ./spool --workers 4 --idle-exit 10m`,
+	Args: cobra.NoArgs,
+	RunE: runDepWorker,
+}
+
+func init() {
+	depWorkerCmd.Flags().StringVar(&depWorkerSpool, "spool", "", "Spool directory shared with the coordinating scans (--dep-spool)")
+	depWorkerCmd.Flags().IntVar(&depWorkerWorkers, "workers", 0, "Number of concurrent dependency scans (default: half of CPU cores, max 8)")
+	depWorkerCmd.Flags().StringVar(&depWorkerIdleExit, "idle-exit", "", "Exit after finding no job for this long (e.g., 10m, 1h; default: run until interrupted)")
+	_ = depWorkerCmd.MarkFlagRequired("spool")
+}
+
+func runDepWorker(cmd *cobra.Command, _ []string) error {
+	var idleExit time.Duration
+	if depWorkerIdleExit != "" {
+		parsed, err := scanutil.ParseDuration(depWorkerIdleExit)
+		if err != nil {
+			return failure.Wrap(err, failure.CodeInvalidTimeout, failure.StageInput,
+				fmt.Sprintf("invalid --idle-exit '%s' (use format like '10m', '1h')", depWorkerIdleExit))
+		}
+		idleExit = parsed
+	}
+	if depWorkerWorkers < 0 {
+		msg := "--workers must not be negative"
+		return failure.WrapUnknown(errors.New(msg), failure.CodeInvalidArguments, failure.StageInput, msg)
+	}
+	if err := os.MkdirAll(depWorkerSpool, 0o750); err != nil {
+		return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput,
+			fmt.Sprintf("invalid --spool: %v", err))
+	}
+
+	// Jobs carry their rules and language, so the rules manager and the
+	// detector are never consulted.
+	scannerRegistry := scanner.NewRegistry()
+	scannerRegistry.Register(opengrep.ScannerName, opengrep.NewScanner())
+	scannerRegistry.Register(semgrep.ScannerName, semgrep.NewScanner())
+	scannerRegistry.Register(native.ScannerName, native.NewScanner())
+	orchestrator := engine.NewOrchestrator(language.NewEnryDetector(skip.NewGitIgnoreMatcher(nil)), rules.NewManager(), scannerRegistry)
+
+	worker := engine.NewDependencySpoolWorker(engine.NewDependencySpool(depWorkerSpool), orchestrator)
+	if err := worker.Run(cmd.Context(), depWorkerWorkers, idleExit); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/converter"
+	"github.com/scanoss/crypto-finder/internal/enricher"
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/output"
+)
+
+var (
+	mergeBOMSBOM   string
+	mergeBOMCBOM   string
+	mergeBOMOutput string
+)
+
+var mergeBOMCmd = &cobra.Command{
+	Use:   "merge-bom",
+	Short: "Enrich an existing CycloneDX SBOM with crypto-finder results",
+	Long: `Inject crypto-finder's cryptographic assets into an externally produced
+CycloneDX SBOM (e.g. from syft, cdxgen or the CycloneDX Maven plugin).
+
+The cryptographic-asset components are added to the SBOM, and every SBOM
+component whose package URL matches a scanned library version gets a
+"provides" relationship to the assets found in that library. Assets found in
+project code are provided by the SBOM's metadata component. The SBOM keeps its
+serial number, components and dependency graph; SBOMs older than CycloneDX 1.6
+are raised to 1.6, the first version with cryptographic assets.
+
+Inputs:
+  --sbom  CycloneDX JSON SBOM to enrich
+  --cbom  crypto-finder results: an interim report (scan --format json, best
+          with --scan-dependencies so assets can be attributed to libraries)
+          or a CBOM produced by scan --format cyclonedx / convert
+
+Examples:
+  # Enrich a syft SBOM with a dependency scan
+  crypto-finder scan --scan-dependencies --output findings.json /path/to/code
+  crypto-finder merge-bom --sbom sbom.cdx.json --cbom findings.json --output enriched.cdx.json
+
+  # Merge an existing CBOM
+  crypto-finder merge-bom --sbom sbom.cdx.json --cbom cbom.json`,
+	Args: cobra.NoArgs,
+	RunE: runMergeBOM,
+}
+
+func init() {
+	mergeBOMCmd.Flags().StringVar(&mergeBOMSBOM, "sbom", "", "CycloneDX JSON SBOM to enrich (required)")
+	mergeBOMCmd.Flags().StringVar(&mergeBOMCBOM, "cbom", "", "crypto-finder interim report or CycloneDX CBOM (required)")
+	mergeBOMCmd.Flags().StringVarP(&mergeBOMOutput, "output", "o", "", "Output file path (default: stdout)")
+	_ = mergeBOMCmd.MarkFlagRequired("sbom")
+	_ = mergeBOMCmd.MarkFlagRequired("cbom")
+}
+
+func runMergeBOM(_ *cobra.Command, _ []string) error {
+	sbom, err := readCycloneDXFile(mergeBOMSBOM)
+	if err != nil {
+		return fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	cbom, err := readCBOMInput(mergeBOMCBOM, converter.MergeSpecVersion(sbom))
+	if err != nil {
+		return fmt.Errorf("failed to read CBOM: %w", err)
+	}
+
+	result, err := converter.MergeCBOM(sbom, cbom)
+	if err != nil {
+		return fmt.Errorf("merge failed: %w", err)
+	}
+	for _, packageURL := range result.Unmatched {
+		log.Warn().Str("purl", packageURL).Msg("No SBOM component matches this library; its assets were added without a provides relationship")
+	}
+
+	outputDest := mergeBOMOutput
+	if outputDest == "" {
+		outputDest = "-" // stdout
+	}
+	if err := output.NewCycloneDXWriter().WriteBOM(sbom, outputDest); err != nil {
+		return fmt.Errorf("failed to write merged BOM: %w", err)
+	}
+	return nil
+}
+
+// readCycloneDXFile decodes a CycloneDX JSON document.
+func readCycloneDXFile(path string) (*cdx.BOM, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCycloneDX(data, path)
+}
+
+func decodeCycloneDX(data []byte, source string) (*cdx.BOM, error) {
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(&bom); err != nil {
+		return nil, fmt.Errorf("failed to parse CycloneDX JSON from %s: %w", source, err)
+	}
+	if bom.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("%s is not a CycloneDX document", source)
+	}
+	return &bom, nil
+}
+
+// readCBOMInput loads crypto-finder results as a CBOM. CycloneDX input is
+// used as is; an interim report is enriched and converted at specVersion.
+func readCBOMInput(path string, specVersion cdx.SpecVersion) (*cdx.BOM, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		BOMFormat string `json:"bomFormat"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON from %s: %w", path, err)
+	}
+	if probe.BOMFormat != "" {
+		return decodeCycloneDX(data, path)
+	}
+
+	var report entities.InterimReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse interim JSON from %s: %w", path, err)
+	}
+	enricher.NewOIDEnricher().EnrichReport(&report)
+
+	return converter.NewConverterForSpecVersion(specVersion).Convert(&report)
+}
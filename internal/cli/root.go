@@ -82,7 +82,11 @@ func init() {
 	// Subcommands
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(annotateCmd)
+	rootCmd.AddCommand(depWorkerCmd)
 	rootCmd.AddCommand(convertCmd)
+	rootCmd.AddCommand(mergeBOMCmd)
+	rootCmd.AddCommand(rulesCmd)
+	rootCmd.AddCommand(cacheCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(configureCmd)
 }
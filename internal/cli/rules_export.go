@@ -0,0 +1,115 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/scanoss/crypto-finder/internal/api"
+	"github.com/scanoss/crypto-finder/internal/cache"
+	"github.com/scanoss/crypto-finder/internal/config"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+var (
+	rulesExportOutput  string
+	rulesExportRuleset string
+	rulesExportVersion string
+	rulesExportAPIKey  string
+	rulesExportAPIURL  string
+)
+
+var rulesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Package the cached remote ruleset as an offline bundle",
+	Long: `Package a remote ruleset, its cache metadata and manifest into a
+zstd-compressed tarball that "rules import" installs on a machine without
+API access. Every file is listed with its SHA-256 in the bundle's index.
+
+The ruleset is taken from the local cache; if it is not cached or has
+expired, it is downloaded first.
+
+Examples:
+  # Writes dca-<version>.tar.zst to the current directory
+  crypto-finder rules export
+
+  crypto-finder rules export --output /media/usb/dca.tar.zst`,
+	Args: cobra.NoArgs,
+	RunE: runRulesExport,
+}
+
+func init() {
+	rulesExportCmd.Flags().StringVarP(&rulesExportOutput, "output", "o", "", "Bundle file path (default: <ruleset>-<version>.tar.zst)")
+	rulesExportCmd.Flags().StringVar(&rulesExportRuleset, "ruleset", defaultRulesetName, "Remote ruleset name")
+	rulesExportCmd.Flags().StringVar(&rulesExportVersion, "version", defaultRulesetVersion, "Remote ruleset version")
+	rulesExportCmd.Flags().StringVar(&rulesExportAPIKey, "api-key", "", "SCANOSS API key")
+	rulesExportCmd.Flags().StringVar(&rulesExportAPIURL, "api-url", "", "SCANOSS API base URL")
+}
+
+func runRulesExport(cmd *cobra.Command, _ []string) error {
+	cfg := config.GetInstance()
+	if err := cfg.Initialize(config.InitOptions{APIKey: rulesExportAPIKey, APIURL: rulesExportAPIURL}); err != nil {
+		return failure.WrapUnknown(err, failure.CodeConfigInitializationFailed, failure.StageConfig, "failed to initialize config")
+	}
+	cacheManager, err := cache.NewManager(api.NewClient(cfg.GetAPIURL(), cfg.GetAPIKey()))
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to create cache manager")
+	}
+	trustedKeys, err := resolveTrustedRulesetKeys(cfg, nil)
+	if err != nil {
+		return err
+	}
+	cacheManager.SetTrustedKeys(trustedKeys)
+
+	if _, err := cacheManager.GetRulesetPath(cmd.Context(), rulesExportRuleset, rulesExportVersion); err != nil {
+		return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules,
+			fmt.Sprintf("failed to get ruleset '%s@%s'", rulesExportRuleset, rulesExportVersion))
+	}
+
+	// The bundle is written next to its destination and renamed into place
+	// once complete, so an interrupted export leaves no truncated bundle.
+	tempFile, err := os.CreateTemp(filepath.Dir(rulesExportOutput), ".rules-export-*")
+	if err != nil {
+		return failure.Wrap(err, failure.CodeOutputWriteFailed, failure.StageOutput, "failed to create bundle file")
+	}
+	defer func() { _ = os.Remove(tempFile.Name()) }()
+
+	info, err := cacheManager.ExportBundle(rulesExportRuleset, rulesExportVersion, tempFile)
+	if closeErr := tempFile.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeOutputWriteFailed, failure.StageOutput, "failed to write ruleset bundle")
+	}
+
+	output := rulesExportOutput
+	if output == "" {
+		output = info.FileName()
+	}
+	if err := os.Rename(tempFile.Name(), output); err != nil {
+		return failure.Wrap(err, failure.CodeOutputWriteFailed, failure.StageOutput,
+			fmt.Sprintf("failed to write ruleset bundle '%s'", output))
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Exported %s %s (%d files) to %s\n",
+		info.RulesetName, info.Version, len(info.Files), output)
+	return err
+}
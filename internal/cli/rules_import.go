@@ -0,0 +1,101 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/cache"
+	"github.com/scanoss/crypto-finder/internal/config"
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+var rulesImportCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Install an offline ruleset bundle into the local cache",
+	Long: `Install a bundle written by "rules export" into the local ruleset cache,
+under the ruleset name and version it was exported from, without API access.
+
+Every file is checked against the checksums in the bundle's index before the
+cache is replaced. The signed tarball the bundle carries is verified against
+the trusted public keys and the rules are extracted from it again; the
+signature status recorded on the exporting machine is ignored. With --strict,
+a bundle that does not verify against a trusted key is refused.
+
+Imported rulesets are pinned: they never expire, so scans use them as is and
+do not try to refresh them from the API. Importing a newer bundle replaces the
+pinned ruleset.
+
+Examples:
+  crypto-finder rules import dca-v1.2.0.tar.zst
+  crypto-finder rules import --strict --rules-public-key scanoss.pub dca-v1.2.0.tar.zst
+  crypto-finder scan /path/to/code`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRulesImport,
+}
+
+var (
+	rulesImportStrict     bool
+	rulesImportPublicKeys []string
+)
+
+func init() {
+	rulesImportCmd.Flags().BoolVar(&rulesImportStrict, "strict", false, "Refuse the bundle unless its ruleset is signed by a trusted key")
+	rulesImportCmd.Flags().StringArrayVar(&rulesImportPublicKeys, "rules-public-key", []string{}, "Trusted ed25519 public key for ruleset signatures: key file, PEM, or base64 (repeatable)")
+}
+
+func runRulesImport(cmd *cobra.Command, args []string) error {
+	bundlePath := args[0]
+	bundle, err := os.Open(bundlePath)
+	if err != nil {
+		return failure.Wrap(err, failure.CodeInvalidArguments, failure.StageInput,
+			fmt.Sprintf("failed to open ruleset bundle '%s'", bundlePath))
+	}
+	defer func() { _ = bundle.Close() }()
+
+	cfg := config.GetInstance()
+	if err := cfg.Initialize(config.InitOptions{}); err != nil {
+		return failure.WrapUnknown(err, failure.CodeConfigInitializationFailed, failure.StageConfig, "failed to initialize config")
+	}
+	cacheManager, err := cache.NewManager(nil)
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to create cache manager")
+	}
+	cacheManager.SetStrictMode(rulesImportStrict)
+	trustedKeys, err := resolveTrustedRulesetKeys(cfg, rulesImportPublicKeys)
+	if err != nil {
+		return err
+	}
+	cacheManager.SetTrustedKeys(trustedKeys)
+
+	info, err := cacheManager.ImportBundle(bundle)
+	if err != nil {
+		if errors.Is(err, cache.ErrInvalidBundle) {
+			return failure.Wrap(err, failure.CodeRulesetBundleInvalid, failure.StageRules,
+				fmt.Sprintf("invalid ruleset bundle '%s'", bundlePath))
+		}
+		return failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to install ruleset bundle")
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Imported %s %s (%d files) as %s@%s, pinned\n",
+		info.RulesetName, info.Version, len(info.Files), info.RulesetName, info.CacheVersion)
+	return err
+}
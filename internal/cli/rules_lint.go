@@ -0,0 +1,113 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/rulelint"
+	"github.com/scanoss/crypto-finder/internal/rules"
+)
+
+var (
+	rulesLintRules          []string
+	rulesLintRuleDirs       []string
+	rulesLintFailOnWarnings bool
+)
+
+var rulesLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check rule YAML for incomplete or invalid crypto metadata",
+	Long: `Check local rules for problems that otherwise only surface when a scan runs
+or when its findings are converted to a CBOM.
+
+Errors:
+  - metadata.crypto missing, or missing the fields its assetType requires
+    (algorithm: algorithmPrimitive and algorithmFamily; related-crypto-material:
+    materialType; protocol: protocolType)
+  - unknown assetType or algorithmPrimitive
+  - malformed parameterCondition predicate
+  - missing or unknown languages, regex rules without pattern-regex
+  - rule without any pattern
+  - rule ID defined more than once across all --rules and --rules-dir sources
+
+Warnings:
+  - algorithm that resolves to no OID
+  - rule ID language prefix that does not match languages
+  - code patterns shared by languages with different syntax
+
+Metadata values that interpolate metavariables (e.g. "SHA-$BITS") are only
+known at scan time and are not checked against the allowed values.
+
+Examples:
+  crypto-finder rules lint --rules-dir ./rules
+  crypto-finder rules lint --rules-dir ./rules --rules-dir ./custom-rules --fail-on-warnings`,
+	Args: cobra.NoArgs,
+	RunE: runRulesLint,
+}
+
+func init() {
+	rulesLintCmd.Flags().StringArrayVarP(&rulesLintRules, "rules", "r", []string{}, "Rule file path (repeatable)")
+	rulesLintCmd.Flags().StringArrayVar(&rulesLintRuleDirs, "rules-dir", []string{}, "Rule directory path (repeatable)")
+	rulesLintCmd.Flags().BoolVar(&rulesLintFailOnWarnings, "fail-on-warnings", false, "Exit with an error when only warnings are found")
+}
+
+func runRulesLint(cmd *cobra.Command, _ []string) error {
+	if len(rulesLintRules) == 0 && len(rulesLintRuleDirs) == 0 {
+		return failure.New(failure.CodeInvalidArguments, failure.StageInput, "--rules or --rules-dir is required")
+	}
+
+	rulePaths, err := rules.NewLocalRuleSource(rulesLintRules, rulesLintRuleDirs).Load()
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules, "failed to load rules")
+	}
+
+	result := rulelint.Lint(relativeRulePaths(rulePaths))
+	if err := rulelint.WriteText(cmd.OutOrStdout(), result); err != nil {
+		return failure.WrapUnknown(err, failure.CodeOutputWriteFailed, failure.StageOutput, "failed to write lint results")
+	}
+
+	if result.Errors() > 0 || (rulesLintFailOnWarnings && result.Warnings() > 0) {
+		return failure.New(failure.CodeRuleLintFailed, failure.StageRules,
+			fmt.Sprintf("rule lint found %d errors and %d warnings", result.Errors(), result.Warnings()))
+	}
+	return nil
+}
+
+// relativeRulePaths shortens the absolute paths returned by the rule source
+// to paths relative to the working directory, when they are below it, so
+// issues point at the paths the user typed.
+func relativeRulePaths(rulePaths []string) []string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return rulePaths
+	}
+	paths := make([]string, len(rulePaths))
+	for i, path := range rulePaths {
+		paths[i] = path
+		if rel, err := filepath.Rel(wd, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			paths[i] = rel
+		}
+	}
+	return paths
+}
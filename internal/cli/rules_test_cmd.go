@@ -0,0 +1,200 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scanoss/crypto-finder/internal/config"
+	"github.com/scanoss/crypto-finder/internal/engine"
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/javaruntime"
+	"github.com/scanoss/crypto-finder/internal/rules"
+	"github.com/scanoss/crypto-finder/internal/ruletest"
+	scanutil "github.com/scanoss/crypto-finder/internal/scan"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/scanner/native"
+	"github.com/scanoss/crypto-finder/internal/scanner/opengrep"
+	"github.com/scanoss/crypto-finder/internal/scanner/semgrep"
+)
+
+var (
+	rulesTestRules     []string
+	rulesTestRuleDirs  []string
+	rulesTestFixtures  string
+	rulesTestScanner   string
+	rulesTestTimeout   string
+	rulesTestLanguages []string
+)
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Run rules against annotated fixture files",
+	Long: `Run local rules against fixture files annotated with expectation comments
+and report missing, unexpected and wrong-metadata matches.
+
+An expectation comment names the rule expected to match the next line of
+code (or its own line, when it trails code) and, optionally, metadata the
+finding must carry:
+
+  // expect: java.crypto.jca.cipher-aes algorithm=AES keySize=256 mode=GCM
+  Cipher cipher = Cipher.getInstance("AES/GCM/NoPadding");
+
+Any of //, /*, #, -- and ; start an expectation comment. Metadata keys are
+compared with the finding's metadata; the shorthands algorithm, family,
+keySize, mode, padding, primitive and curve also match the corresponding
+algorithm* keys, and oid matches the enriched OID. Every match without an
+expectation is reported as unexpected.
+
+parameterCondition predicates are validated first. Rules with predicates
+only produce findings once the call graph resolves their selector, so the
+fixtures' call graph is built and conditioned findings are materialized the
+same way scan does before expectations are checked.
+
+Examples:
+  # Fixtures live next to the rules
+  crypto-finder rules test --rules-dir ./rules/java
+
+  # Separate fixture tree
+  crypto-finder rules test --rules-dir ./rules --fixtures ./tests`,
+	Args: cobra.NoArgs,
+	RunE: runRulesTest,
+}
+
+func init() {
+	rulesTestCmd.Flags().StringArrayVarP(&rulesTestRules, "rules", "r", []string{}, "Rule file path (repeatable)")
+	rulesTestCmd.Flags().StringArrayVar(&rulesTestRuleDirs, "rules-dir", []string{}, "Rule directory path (repeatable)")
+	rulesTestCmd.Flags().StringVar(&rulesTestFixtures, "fixtures", "", "Fixture directory (default: the --rules-dir when exactly one is given)")
+	rulesTestCmd.Flags().StringVar(&rulesTestScanner, "scanner", defaultScanner, fmt.Sprintf("Scanner to use (default: %s)", defaultScanner))
+	rulesTestCmd.Flags().StringVarP(&rulesTestTimeout, "timeout", "t", defaultTimeout, "Detection timeout (e.g., 10m, 1h)")
+	rulesTestCmd.Flags().StringSliceVar(&rulesTestLanguages, "languages", []string{}, "Override language detection (comma-separated)")
+}
+
+func runRulesTest(cmd *cobra.Command, _ []string) error {
+	if len(rulesTestRules) == 0 && len(rulesTestRuleDirs) == 0 {
+		return failure.New(failure.CodeInvalidArguments, failure.StageInput, "--rules or --rules-dir is required")
+	}
+	fixturesDir := rulesTestFixtures
+	if fixturesDir == "" {
+		if len(rulesTestRuleDirs) != 1 {
+			return failure.New(failure.CodeInvalidArguments, failure.StageInput,
+				"--fixtures is required unless exactly one --rules-dir is given")
+		}
+		fixturesDir = rulesTestRuleDirs[0]
+	}
+	languages, err := scanutil.ValidateFlags(fixturesDir, scanutil.ValidationOptions{
+		RuleFiles:        rulesTestRules,
+		RuleDirs:         rulesTestRuleDirs,
+		NoRemoteRules:    true,
+		Scanner:          rulesTestScanner,
+		AllowedScanners:  AllowedScanners,
+		Format:           formatJSON,
+		SupportedFormats: SupportedFormats,
+		Languages:        rulesTestLanguages,
+	})
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput, "failed to validate rules test flags")
+	}
+
+	timeout, err := scanutil.ParseDuration(rulesTestTimeout)
+	if err != nil {
+		return failure.Wrap(err, failure.CodeInvalidTimeout, failure.StageInput,
+			fmt.Sprintf("invalid timeout format '%s' (use format like '10m', '1h')", rulesTestTimeout))
+	}
+
+	rulesManager := rules.NewManager(rules.NewLocalRuleSource(rulesTestRules, rulesTestRuleDirs))
+	rulePaths, err := rulesManager.Load()
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules, "failed to load rules")
+	}
+	tested, err := ruletest.LoadRules(rulePaths)
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules, "failed to read rules")
+	}
+	fixtures, err := ruletest.LoadFixtures(fixturesDir)
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput, "failed to read fixtures")
+	}
+
+	// The scan itself refuses malformed predicates, so they are reported on
+	// their own before any scanner runs.
+	result := &ruletest.Result{Fixtures: len(fixtures), Failures: ruletest.CheckParameterConditions(rulePaths)}
+	if result.OK() {
+		scannerRegistry := scanner.NewRegistry()
+		scannerRegistry.Register(opengrep.ScannerName, opengrep.NewScanner())
+		scannerRegistry.Register(semgrep.ScannerName, semgrep.NewScanner())
+		scannerRegistry.Register(native.ScannerName, native.NewScanner())
+		opts := ruletest.ScanOptions{
+			FixturesDir:  fixturesDir,
+			Languages:    languages,
+			Scanners:     scannerRegistry,
+			Scanner:      rulesTestScanner,
+			Timeout:      timeout,
+			RulesManager: rulesManager,
+			RulePaths:    rulePaths,
+			Rules:        tested,
+		}
+		if ruletest.HasConditionedRule(tested) {
+			javaRuntime, err := rulesTestJavaRuntime()
+			if err != nil {
+				return err
+			}
+			opts.Ecosystems = reportOccurrenceKeyEcosystems
+			opts.CallGraph = func(target string, report *entities.InterimReport, ecosystem string) (*engine.DepScanResult, error) {
+				return buildStandaloneCallGraphResultForEcosystem(target, report, ecosystem, javaRuntime, true, "", true)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		report, materialized, err := ruletest.Scan(ctx, opts)
+		if err != nil {
+			return err
+		}
+		result = ruletest.Compare(report, fixtures, tested)
+		result.Materialized = materialized
+	}
+
+	if err := ruletest.WriteText(cmd.OutOrStdout(), result); err != nil {
+		return failure.WrapUnknown(err, failure.CodeOutputWriteFailed, failure.StageOutput, "failed to write rule test results")
+	}
+	if !result.OK() {
+		return failure.New(failure.CodeRuleTestsFailed, failure.StageRules,
+			fmt.Sprintf("%d rule test failures", len(result.Failures)))
+	}
+	return nil
+}
+
+// rulesTestJavaRuntime resolves the Java runtime the fixtures' call graph is
+// built with.
+func rulesTestJavaRuntime() (javaruntime.Config, error) {
+	cfg := config.GetInstance()
+	if err := cfg.Initialize(config.InitOptions{}); err != nil {
+		return javaruntime.Config{}, failure.WrapUnknown(err, failure.CodeConfigInitializationFailed, failure.StageConfig, "failed to initialize config")
+	}
+	javaRuntime, err := resolveJavaRuntimeConfig(cfg)
+	if err != nil {
+		return javaruntime.Config{}, failure.WrapUnknown(err, failure.CodeJavaRuntimeConfigInvalid, failure.StageConfig,
+			"failed to resolve Java runtime configuration")
+	}
+	return javaRuntime, nil
+}
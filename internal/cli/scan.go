@@ -23,6 +23,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/scanoss/crypto-finder/internal/cache"
 	"github.com/scanoss/crypto-finder/internal/callgraph"
 	"github.com/scanoss/crypto-finder/internal/config"
+	"github.com/scanoss/crypto-finder/internal/converter"
 	"github.com/scanoss/crypto-finder/internal/dependency"
 	"github.com/scanoss/crypto-finder/internal/engine"
 	"github.com/scanoss/crypto-finder/internal/enricher"
@@ -45,6 +47,7 @@ import (
 	"github.com/scanoss/crypto-finder/internal/rules"
 	scanutil "github.com/scanoss/crypto-finder/internal/scan"
 	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/scanner/native"
 	"github.com/scanoss/crypto-finder/internal/scanner/opengrep"
 	"github.com/scanoss/crypto-finder/internal/scanner/semgrep"
 	"github.com/scanoss/crypto-finder/internal/skip"
@@ -72,16 +75,19 @@ var AllowedFindingsCacheBackends = []string{findingsCacheBackendDisk, findingsCa
 
 // AllowedScanners lists the scanners supported by the tool.
 // TODO: We'll support more scanners in the future (e.g., cbom-toolkit).
-var AllowedScanners = []string{opengrep.ScannerName, semgrep.ScannerName}
+var AllowedScanners = []string{opengrep.ScannerName, semgrep.ScannerName, native.ScannerName}
 
 // SupportedFormats lists the output formats supported by the tool.
-var SupportedFormats = []string{formatJSON, "cyclonedx"}
+var SupportedFormats = []string{formatJSON, "cyclonedx", "spdx"}
 
 var (
 	scanRules                []string
 	scanRuleDirs             []string
+	scanRulesGit             []string
+	scanRulesOCI             []string
 	scanScanner              string
 	scanFormat               string
+	scanCycloneDXVersion     string
 	scanOutput               string
 	scanLanguages            []string
 	scanFailOnFind           bool
@@ -92,6 +98,11 @@ var (
 	scanAPIURL               string
 	scanStrict               bool
 	scanMaxStaleAge          string
+	scanRulesPublicKeys      []string
+	scanWriteLock            bool
+	scanLocked               bool
+	scanLockFile             string
+	scanRulesProfile         string
 	scanNoDedup              bool
 	scanInterfile            bool
 	scanDependencies         bool
@@ -104,6 +115,16 @@ var (
 	scanExportGraphFragment  string
 	scanExportGfFormat       string
 	scanDepWorkers           int
+	scanDepPythonMode        string
+	scanDepPythonDistDir     string
+	scanDepPythonIndexURL    string
+	scanDepBazelQuery        string
+	scanDepBazelOutputBase   string
+	scanDepSBOM              string
+	scanDepScopes            []string
+	scanDepPrune             bool
+	scanDepSpool             string
+	scanDepSpoolCoordinate   bool
 	scanJavaJDKMajor         string
 	scanJavaJDKHomes         []string
 	scanJavaCompiledArtifact string
@@ -137,7 +158,10 @@ var scanCmd = &cobra.Command{
 	  crypto-finder scan --languages java,python --rules-dir ./rules/ /path/to/code
 
 	  # Fail on findings (for CI/CD)
-	  crypto-finder scan --fail-on-findings --rules-dir ./rules/ /path/to/code`,
+	  crypto-finder scan --fail-on-findings --rules-dir ./rules/ /path/to/code
+
+	  # Emit a CycloneDX 1.7 CBOM
+	  crypto-finder scan --format cyclonedx --cyclonedx-version 1.7 /path/to/code`,
 	Args: func(_ *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return fmt.Errorf("you must specify a target directory to scan")
@@ -151,8 +175,12 @@ func init() {
 	// Add flags
 	scanCmd.Flags().StringArrayVarP(&scanRules, "rules", "r", []string{}, "Rule file path (repeatable)")
 	scanCmd.Flags().StringArrayVar(&scanRuleDirs, "rules-dir", []string{}, "Rule directory path (repeatable)")
+	scanCmd.Flags().StringArrayVar(&scanRulesGit, "rules-git", []string{}, "Rules from a git repository at a pinned ref: <repo>@<ref>[#subdir] (repeatable)")
+	scanCmd.Flags().StringArrayVar(&scanRulesOCI, "rules-oci", []string{}, "Rules from an OCI registry artifact: <registry>/<repository>[:tag][@digest] (repeatable)")
 	scanCmd.Flags().StringVar(&scanScanner, "scanner", defaultScanner, fmt.Sprintf("Scanner to use (default: %s)", defaultScanner))
-	scanCmd.Flags().StringVarP(&scanFormat, "format", "f", defaultFormat, "Output format: json, cyclonedx (default: json)")
+	scanCmd.Flags().StringVarP(&scanFormat, "format", "f", defaultFormat, "Output format: json, cyclonedx, spdx (default: json)")
+	scanCmd.Flags().StringVar(&scanCycloneDXVersion, "cyclonedx-version", converter.DefaultSpecVersion.String(),
+		fmt.Sprintf("CycloneDX spec version for --format cyclonedx: %s", strings.Join(converter.SupportedSpecVersions(), ", ")))
 	scanCmd.Flags().StringVarP(&scanOutput, "output", "o", "", "Output file path (default: stdout)")
 	scanCmd.Flags().StringSliceVar(&scanLanguages, "languages", []string{}, "Override language detection (comma-separated)")
 	scanCmd.Flags().BoolVar(&scanFailOnFind, "fail-on-findings", false, "Exit with error if findings detected")
@@ -161,8 +189,13 @@ func init() {
 	scanCmd.Flags().BoolVar(&scanNoCache, "no-cache", false, "Force fresh download of remote rules, bypass cache")
 	scanCmd.Flags().StringVar(&scanAPIKey, "api-key", "", "SCANOSS API key")
 	scanCmd.Flags().StringVar(&scanAPIURL, "api-url", "", "SCANOSS API base URL")
-	scanCmd.Flags().BoolVar(&scanStrict, "strict", false, "Fail if cache expired and API unreachable (no stale cache fallback)")
+	scanCmd.Flags().BoolVar(&scanStrict, "strict", false, "Fail if cache expired and API unreachable (no stale cache fallback), or if the remote ruleset is not signed by a trusted key")
+	scanCmd.Flags().StringArrayVar(&scanRulesPublicKeys, "rules-public-key", []string{}, "Trusted ed25519 public key for remote ruleset signatures: key file, PEM, or base64 (repeatable)")
 	scanCmd.Flags().StringVar(&scanMaxStaleAge, "max-stale-age", "30d", "Maximum age for stale cache fallback (e.g., 30d, 720h, 2w, max: 90d)")
+	scanCmd.Flags().BoolVar(&scanWriteLock, "write-lock", false, "Write the resolved rules (remote ruleset version and checksum, local rule hashes) to the rules lockfile")
+	scanCmd.Flags().BoolVar(&scanLocked, "locked", false, "Scan with the rules pinned in the rules lockfile; fail if the resolved rules differ")
+	scanCmd.Flags().StringVar(&scanLockFile, "lock-file", "", fmt.Sprintf("Rules lockfile path (default: %s in the scan target)", scanutil.RulesLockFileName))
+	scanCmd.Flags().StringVar(&scanRulesProfile, "rules-profile", "", "Write per-rule match counts, scanner times, never-matched and language-filtered rules to a JSON file")
 	scanCmd.Flags().BoolVar(&scanNoDedup, "no-dedup", false, "Disable per-line deduplication of findings")
 	scanCmd.Flags().BoolVar(&scanInterfile, "interfile", false, "Enable cross-file analysis (Semgrep Pro only, adds --pro flag)")
 	scanCmd.Flags().BoolVar(&scanDependencies, "scan-dependencies", false, "Enable recursive dependency scanning for cryptographic usage")
@@ -177,9 +210,30 @@ func init() {
 			"Same gitignore-style syntax as scanoss.json settings.skip.patterns.scanning. "+
 			"Patterns are added on top of the built-in defaults unless --no-default-exclusions is also set. "+
 			"Duplicates are removed automatically.")
-	scanCmd.Flags().StringVar(&scanDepEcosystem, "dep-ecosystem", "auto", "Dependency ecosystem: auto, go, java, python, rust")
+	scanCmd.Flags().StringVar(&scanDepEcosystem, "dep-ecosystem", "auto", "Dependency ecosystem: auto, go, java, python, rust, c, cpp")
 
 	scanCmd.Flags().IntVar(&scanDepWorkers, "dep-workers", 0, "Number of parallel dependency scan workers (default: half of CPU cores, max 8; Java max 2)")
+	scanCmd.Flags().StringVar(&scanDepPythonMode, "dep-python-mode", string(dependency.PythonResolveAuto),
+		"Python dependency resolution: auto (virtualenv if present, else lockfile), env (pip in the project's environment), "+
+			"lockfile (uv.lock, poetry.lock, Pipfile.lock or pinned requirements*.txt)")
+	scanCmd.Flags().StringVar(&scanDepPythonDistDir, "dep-python-dist-dir", "", "Directory of wheels and sdists to extract locked Python dependency sources from")
+	scanCmd.Flags().StringVar(&scanDepPythonIndexURL, "dep-python-index-url", "",
+		"PEP 503 simple index (e.g. a PyPI mirror) to download locked Python dependency sources from (default: $PIP_INDEX_URL)")
+	scanCmd.Flags().StringVar(&scanDepBazelQuery, "dep-bazel-query", "",
+		"Pre-exported bazel query --output=streamed_jsonproto (or buck2 uquery --output-format json) result to resolve Bazel/Buck dependencies from offline")
+	scanCmd.Flags().StringVar(&scanDepBazelOutputBase, "dep-bazel-output-base", "",
+		"Bazel output base holding the external repositories (default: bazel info output_base)")
+	scanCmd.Flags().StringVar(&scanDepSBOM, "dep-sbom", "",
+		"CycloneDX or SPDX SBOM to take dependencies and their graph from instead of running build tools")
+	scanCmd.Flags().StringSliceVar(&scanDepScopes, "dep-scopes", nil,
+		"Only scan dependencies of these scopes (comma-separated: "+strings.Join(dependency.Scopes, ", ")+"); "+
+			"dependencies whose build tool records no scope are always scanned (default: all)")
+	scanCmd.Flags().BoolVar(&scanDepPrune, "dep-prune", false,
+		"Skip dependencies that no import of the project, or of a dependency it imports, refers to (Go, Java, Python)")
+	scanCmd.Flags().StringVar(&scanDepSpool, "dep-spool", "",
+		"Spool directory to queue dependency scans in for \"crypto-finder dep-worker\" processes; this scan also works through the queue")
+	scanCmd.Flags().BoolVar(&scanDepSpoolCoordinate, "dep-spool-coordinate-only", false,
+		"Leave every --dep-spool job to dep-worker processes instead of also scanning in this process")
 	scanCmd.Flags().StringVar(&scanFindingsCache, "findings-cache", "", fmt.Sprintf("FindingsCache backend: %v (default: %s; can also be set via SCANOSS_FINDINGS_CACHE_BACKEND)", AllowedFindingsCacheBackends, config.DefaultFindingsCacheBackend))
 	scanCmd.Flags().StringVar(&scanExportCallgraph, "export-callgraph", "", "Export the crypto-scoped call graph to a file")
 	scanCmd.Flags().StringVar(&scanExportCgFormat, "export-callgraph-format", "json", "Call graph export format (only json is supported)")
@@ -202,6 +256,29 @@ func callGraphTargetDir(target string) (string, error) {
 	return filepath.Dir(target), nil
 }
 
+// pythonResolveOptions returns the Python dependency resolution settings
+// from the --dep-python-* flags.
+func pythonResolveOptions() dependency.PythonResolveOptions {
+	indexURL := scanDepPythonIndexURL
+	if indexURL == "" {
+		indexURL = strings.TrimSpace(os.Getenv("PIP_INDEX_URL"))
+	}
+	return dependency.PythonResolveOptions{
+		Mode:     dependency.PythonResolveMode(scanDepPythonMode),
+		DistDir:  scanDepPythonDistDir,
+		IndexURL: indexURL,
+	}
+}
+
+// bazelResolveOptions returns the Bazel/Buck build graph settings from the
+// --dep-bazel-* flags.
+func bazelResolveOptions() dependency.BazelResolveOptions {
+	return dependency.BazelResolveOptions{
+		QueryFile:  scanDepBazelQuery,
+		OutputBase: scanDepBazelOutputBase,
+	}
+}
+
 func ecosystemFromHints(target string, languageHints []string) string {
 	// Pick the first supported ecosystem from the hints. Auto-detected hints
 	// arrive ordered by dominance (file count, see EnryDetector.Detect), so the
@@ -252,6 +329,9 @@ func ecosystemFromHint(target, hint string) string {
 		return hint
 	case "go", ecosystemJava, "python", "rust":
 		return hint
+	case "scala", "clojure":
+		// sbt and Leiningen builds resolve through the Java resolver.
+		return ecosystemJava
 	case ecosystemCPP, "c++":
 		return ecosystemCPP
 	case ecosystemNode, "javascript", "typescript":
@@ -321,6 +401,113 @@ func resolveJavaRuntimeConfig(cfg *config.Config) (javaruntime.Config, error) {
 	return javaruntime.NewConfig(major, homes)
 }
 
+// applyRulesLock resolves the scan's rules and either writes them to the
+// lockfile (rulesLock nil, --write-lock) or checks them against rulesLock
+// (--locked). Git and OCI sources are locked to the commit or manifest
+// digest their ref resolves to; they keep what they loaded here, so the
+// scan runs the rules that were checked even if the ref moves meanwhile.
+func applyRulesLock(remoteSource *rules.RemoteRuleSource, fetchedSources []rules.RuleSource, lockPath string, rulesLock *scanutil.RulesLock) error {
+	var remoteInfo *entities.RulesInfo
+	if remoteSource != nil {
+		if _, err := remoteSource.Load(); err != nil {
+			return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules, "failed to load remote rules")
+		}
+		info := remoteSource.Info()
+		remoteInfo = &info
+	}
+	fetched := make([]scanutil.LockedSource, 0, len(fetchedSources))
+	for _, source := range fetchedSources {
+		if _, err := source.Load(); err != nil {
+			return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules,
+				fmt.Sprintf("failed to load rules from %s", source.Name()))
+		}
+		info := source.Info()
+		fetched = append(fetched, scanutil.LockedSource{Source: source.Name(), Revision: info.Revision, ChecksumSHA256: info.ChecksumSHA256})
+	}
+	localPaths := append(append([]string{}, scanRules...), scanRuleDirs...)
+	resolved, err := scanutil.BuildRulesLock(remoteInfo, fetched, localPaths, filepath.Dir(lockPath))
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules, "failed to resolve rules for the lockfile")
+	}
+
+	if rulesLock == nil {
+		if err := resolved.Write(lockPath); err != nil {
+			return failure.Wrap(err, failure.CodeOutputWriteFailed, failure.StageOutput,
+				fmt.Sprintf("failed to write rules lockfile '%s'", lockPath))
+		}
+		log.Info().Str("path", lockPath).Str("rules", resolved.String()).Msg("Rules lockfile written")
+		return nil
+	}
+
+	if diffs := rulesLock.Diff(resolved); len(diffs) > 0 {
+		return failure.New(
+			failure.CodeRulesLoadFailed,
+			failure.StageRules,
+			fmt.Sprintf("rules differ from lockfile %s: %s", lockPath, strings.Join(diffs, "; ")),
+			failure.WithDetail("lock_file", lockPath),
+		)
+	}
+	log.Info().Str("path", lockPath).Str("rules", resolved.String()).Msg("Rules match lockfile")
+	return nil
+}
+
+// resolveTrustedRulesetKeys collects the public keys remote ruleset
+// signatures are verified against: the key pinned in the binary, keys from
+// the config file or SCANOSS_RULES_PUBLIC_KEYS, and --rules-public-key flags.
+func resolveTrustedRulesetKeys(cfg *config.Config, flagKeys []string) ([]cache.TrustedKey, error) {
+	values := make([]string, 0, 1+len(flagKeys))
+	if cache.PinnedPublicKey != "" {
+		values = append(values, cache.PinnedPublicKey)
+	}
+	values = append(values, cfg.GetRulesPublicKeys()...)
+	values = append(values, flagKeys...)
+
+	keys := make([]cache.TrustedKey, 0, len(values))
+	for _, value := range values {
+		key, err := cache.LoadPublicKey(value)
+		if err != nil {
+			return nil, failure.Wrap(err, failure.CodeInvalidArguments, failure.StageInput, "invalid ruleset public key")
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// newFetchedRuleSources builds the rule sources for --rules-git and
+// --rules-oci references, cached under the crypto-finder cache directory.
+func newFetchedRuleSources(ctx context.Context, gitRefs, ociRefs []string) ([]rules.RuleSource, error) {
+	if len(gitRefs) == 0 && len(ociRefs) == 0 {
+		return nil, nil
+	}
+	cacheDir, err := config.GetCacheDir()
+	if err != nil {
+		return nil, failure.WrapUnknown(err, failure.CodeCacheInitializationFailed, failure.StageConfig, "failed to resolve cache directory")
+	}
+
+	sources := make([]rules.RuleSource, 0, len(gitRefs)+len(ociRefs))
+	for _, value := range gitRefs {
+		repoURL, ref, subdir, err := rules.ParseGitRulesRef(value)
+		if err != nil {
+			return nil, failure.Wrap(err, failure.CodeInvalidArguments, failure.StageInput,
+				fmt.Sprintf("invalid --rules-git reference '%s'", value))
+		}
+		source := rules.NewGitRuleSource(ctx, repoURL, ref, subdir, filepath.Join(cacheDir, config.GitRulesDirName))
+		log.Info().Msgf("Git rules enabled: %s", source.Name())
+		sources = append(sources, source)
+	}
+	for _, value := range ociRefs {
+		ref, err := rules.ParseOCIReference(value)
+		if err != nil {
+			return nil, failure.Wrap(err, failure.CodeInvalidArguments, failure.StageInput,
+				fmt.Sprintf("invalid --rules-oci reference '%s'", value))
+		}
+		source := rules.NewOCIRuleSource(ctx, ref, filepath.Join(cacheDir, config.OCIRulesDirName))
+		log.Info().Msgf("OCI rules enabled: %s", source.Name())
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
 func newCallGraphBuilder(ecosystem string, javaRuntime javaruntime.Config, includeTests bool) (*callgraph.Builder, error) {
 	cgParser := callgraph.NewParserForEcosystem(ecosystem, callgraph.WithIncludeTests(includeTests))
 	if cgParser == nil {
@@ -579,6 +766,8 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 	normalizedLanguages, err := scanutil.ValidateFlags(target, scanutil.ValidationOptions{
 		RuleFiles:        scanRules,
 		RuleDirs:         scanRuleDirs,
+		RuleGitRepos:     scanRulesGit,
+		RuleOCIArtifacts: scanRulesOCI,
 		NoRemoteRules:    scanNoRemoteRules,
 		Scanner:          scanScanner,
 		AllowedScanners:  AllowedScanners,
@@ -595,6 +784,61 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 	}
 	scanLanguages = normalizedLanguages
 
+	if scanWriteLock && scanLocked {
+		msg := "--write-lock and --locked are mutually exclusive"
+		return failure.WrapUnknown(errors.New(msg), failure.CodeInvalidArguments, failure.StageInput, msg)
+	}
+	lockPath := scanLockFile
+	if lockPath == "" {
+		lockPath = scanutil.DefaultRulesLockPath(target)
+	}
+	var rulesLock *scanutil.RulesLock
+	if scanLocked {
+		rulesLock, err = scanutil.ReadRulesLock(lockPath)
+		if err != nil {
+			return failure.WrapUnknown(err, failure.CodeRulesLoadFailed, failure.StageRules, "failed to read rules lockfile")
+		}
+	}
+
+	cycloneDXVersion, err := converter.ParseSpecVersion(scanCycloneDXVersion)
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput, err.Error())
+	}
+	if cmd.Flags().Changed("cyclonedx-version") && scanFormat != "cyclonedx" {
+		msg := "--cyclonedx-version requires --format cyclonedx"
+		return failure.WrapUnknown(errors.New(msg), failure.CodeInvalidArguments, failure.StageInput, msg)
+	}
+
+	if !slices.Contains(dependency.PythonResolveModes, dependency.PythonResolveMode(scanDepPythonMode)) {
+		msg := fmt.Sprintf("invalid --dep-python-mode %q (use auto, env or lockfile)", scanDepPythonMode)
+		return failure.WrapUnknown(errors.New(msg), failure.CodeInvalidArguments, failure.StageInput, msg)
+	}
+
+	if scanDepBazelQuery != "" {
+		if _, err := os.Stat(scanDepBazelQuery); err != nil {
+			msg := fmt.Sprintf("invalid --dep-bazel-query: %v", err)
+			return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput, msg)
+		}
+	}
+
+	if scanDepSBOM != "" {
+		if _, err := os.Stat(scanDepSBOM); err != nil {
+			msg := fmt.Sprintf("invalid --dep-sbom: %v", err)
+			return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput, msg)
+		}
+	}
+
+	if scanDepSpoolCoordinate && scanDepSpool == "" {
+		msg := "--dep-spool-coordinate-only requires --dep-spool"
+		return failure.WrapUnknown(errors.New(msg), failure.CodeInvalidArguments, failure.StageInput, msg)
+	}
+
+	depScopes, err := dependency.ParseScopes(scanDepScopes)
+	if err != nil {
+		msg := fmt.Sprintf("invalid --dep-scopes: %v", err)
+		return failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput, msg)
+	}
+
 	// Parse timeout
 	timeout, err := scanutil.ParseDuration(scanTimeout)
 	if err != nil {
@@ -670,11 +914,18 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 	}
 
 	ruleSources := make([]rules.RuleSource, 0)
+	var remoteSource *rules.RemoteRuleSource
 
 	if !scanNoRemoteRules {
+		// A locked scan requests the locked version, not "latest", so it
+		// keeps resolving the same ruleset after upstream releases.
+		rulesetName, rulesetVersion := defaultRulesetName, defaultRulesetVersion
+		if rulesLock != nil && rulesLock.Remote != nil {
+			rulesetName, rulesetVersion = rulesLock.Remote.Name, rulesLock.Remote.Version
+		}
 		log.Info().
-			Str("ruleset", defaultRulesetName).
-			Str("version", defaultRulesetVersion).
+			Str("ruleset", rulesetName).
+			Str("version", rulesetVersion).
 			Bool("no-cache", scanNoCache).
 			Msg("Remote rules enabled")
 
@@ -692,6 +943,12 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		cacheManager.SetNoCache(scanNoCache)
 		cacheManager.SetStrictMode(scanStrict)
 
+		trustedKeys, err := resolveTrustedRulesetKeys(cfg, scanRulesPublicKeys)
+		if err != nil {
+			return err
+		}
+		cacheManager.SetTrustedKeys(trustedKeys)
+
 		// Parse and validate max stale age
 		maxStaleAge, err := scanutil.ParseDuration(scanMaxStaleAge)
 		if err != nil {
@@ -713,10 +970,10 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		}
 		cacheManager.SetMaxStaleCacheAge(maxStaleAge)
 
-		remoteSource := rules.NewRemoteRuleSource(
+		remoteSource = rules.NewRemoteRuleSource(
 			ctx,
-			defaultRulesetName,
-			defaultRulesetVersion,
+			rulesetName,
+			rulesetVersion,
 			cacheManager,
 		)
 		ruleSources = append(ruleSources, remoteSource)
@@ -729,6 +986,18 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		log.Info().Msgf("Local rules enabled: %s", localSource.Name())
 	}
 
+	fetchedSources, err := newFetchedRuleSources(ctx, scanRulesGit, scanRulesOCI)
+	if err != nil {
+		return err
+	}
+	ruleSources = append(ruleSources, fetchedSources...)
+
+	if scanWriteLock || scanLocked {
+		if err := applyRulesLock(remoteSource, fetchedSources, lockPath, rulesLock); err != nil {
+			return err
+		}
+	}
+
 	// Create rules manager with all sources
 	var rulesManager *rules.Manager
 	switch len(ruleSources) {
@@ -736,14 +1005,13 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		return failure.New(
 			failure.CodeRulesLoadFailed,
 			failure.StageRules,
-			"no rule sources configured (use --rules, --rules-dir, or enable remote rules)",
+			"no rule sources configured (use --rules, --rules-dir, --rules-git, --rules-oci, or enable remote rules)",
 		)
 	case 1:
 		rulesManager = rules.NewManager(ruleSources[0])
 		log.Info().Msgf("Rules manager configured with source: %s", ruleSources[0].Name())
 	default:
-		multiSource := rules.NewMultiSource(ruleSources...)
-		rulesManager = rules.NewManager(multiSource)
+		rulesManager = rules.NewManager(ruleSources...)
 		log.Info().Msgf("Rules manager configured with %d sources", len(ruleSources))
 	}
 
@@ -814,6 +1082,7 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 	// Register scanners
 	scannerRegistry.Register(opengrep.ScannerName, opengrep.NewScanner())
 	scannerRegistry.Register(semgrep.ScannerName, semgrep.NewScanner())
+	scannerRegistry.Register(native.ScannerName, native.NewScanner())
 
 	orchestrator := engine.NewOrchestrator(langDetector, rulesManager, scannerRegistry)
 
@@ -834,6 +1103,9 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		scanOpts.Progress = newProgressReporter(progress, "")
 		scanOpts.ProgressDetectionStarted = detectionStarted
 	}
+	if scanRulesProfile != "" {
+		scanOpts.RulesProfile = &engine.RulesProfile{}
+	}
 
 	log.Info().Msgf("Starting scan of %s with scanner '%s'...", target, scanScanner)
 
@@ -841,6 +1113,18 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 	if err != nil {
 		return err
 	}
+	if scanOpts.RulesProfile != nil {
+		if err := scanOpts.RulesProfile.Write(scanRulesProfile); err != nil {
+			return failure.Wrap(err, failure.CodeOutputWriteFailed, failure.StageOutput,
+				fmt.Sprintf("failed to write rules profile '%s'", scanRulesProfile))
+		}
+		log.Info().
+			Str("file", scanRulesProfile).
+			Int("rules", len(scanOpts.RulesProfile.Rules)).
+			Int("never_matched", len(scanOpts.RulesProfile.NeverMatched)).
+			Int("filtered_out", len(scanOpts.RulesProfile.FilteredOut)).
+			Msg("Rules profile written")
+	}
 	var callGraphResult *engine.DepScanResult
 
 	// Dependency scanning phase.
@@ -872,6 +1156,9 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 			if ecosystem == "" {
 				ecosystem = scanutil.DetectEcosystem(target)
 			}
+			if ecosystem == "" && scanDepSBOM != "" {
+				ecosystem = dependency.SBOMEcosystem(scanDepSBOM)
+			}
 		}
 
 		if ecosystem != "" {
@@ -880,8 +1167,17 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 			depRegistry.Register("java", dependency.NewJavaResolver())
 			depRegistry.Register("python", dependency.NewPipResolver())
 			depRegistry.Register("rust", dependency.NewCargoResolver())
+			depRegistry.Register("c", dependency.NewCResolver())
+			depRegistry.Register(ecosystemCPP, dependency.NewCPPResolver())
 
 			resolver, resolverErr := depRegistry.Get(ecosystem)
+			if targetDir, dirErr := callGraphTargetDir(target); resolverErr == nil && dirErr == nil &&
+				dependency.UseBazelResolver(targetDir, ecosystem, bazelResolveOptions()) {
+				resolver = dependency.NewBazelResolver(ecosystem, bazelResolveOptions())
+			}
+			if resolverErr == nil && scanDepSBOM != "" {
+				resolver = dependency.NewSBOMResolver(ecosystem, scanDepSBOM)
+			}
 			if resolverErr != nil {
 				log.Warn().Err(resolverErr).Str("ecosystem", ecosystem).Msg("No resolver for ecosystem, skipping dependency scan")
 				if progress != nil {
@@ -901,6 +1197,12 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 				if javaResolver, ok := resolver.(dependency.JavaRuntimeConfigurer); ok {
 					javaResolver.SetJavaRuntime(javaRuntime)
 				}
+				if pythonResolver, ok := resolver.(dependency.PythonResolveConfigurer); ok {
+					pythonResolver.SetPythonResolveOptions(pythonResolveOptions())
+				}
+				if scopeResolver, ok := resolver.(dependency.DependencyScopeConfigurer); ok {
+					scopeResolver.SetDependencyScopes(depScopes)
+				}
 				cgParser := callgraph.NewParserForEcosystem(ecosystem, callgraph.WithIncludeTests(scanIncludeTests))
 				if cgParser == nil {
 					log.Warn().Str("ecosystem", ecosystem).Msg("No call graph parser for ecosystem, skipping dependency scan")
@@ -929,8 +1231,14 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 
 						depScanner := engine.NewDependencyScanner(orchestrator, resolver, cgBuilder, findingsCache)
 						depOptions := engine.DepScanOptions{
-							Workers:     scanDepWorkers,
-							ScanOptions: scanOpts,
+							Workers:         scanDepWorkers,
+							ScanOptions:     scanOpts,
+							Scopes:          depScopes,
+							PruneUnimported: scanDepPrune,
+						}
+						if scanDepSpool != "" {
+							depOptions.Spool = engine.NewDependencySpool(scanDepSpool)
+							depOptions.Spool.CoordinateOnly = scanDepSpoolCoordinate
 						}
 						if progress != nil {
 							depOptions.ScanOptions.Progress = newProgressReporter(progress, "dependencies")
@@ -1065,6 +1373,10 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		}
 	}
 
+	// Dependency findings restored from the findings cache and findings
+	// materialized above did not pass through the orchestrator's attribution.
+	rulesManager.AttributeRules(report)
+
 	if scanExportCallgraph != "" || scanExportGraphFragment != "" {
 		engine.AssignFindingIDs(report)
 		if callGraphResult != nil {
@@ -1143,7 +1455,7 @@ func runScan(cmd *cobra.Command, args []string) (runErr error) {
 		Dur("duration", time.Since(oidStart)).
 		Msg("OID enrichment finished")
 
-	factory := output.NewWriterFactory()
+	factory := output.NewWriterFactory(output.WithCycloneDXSpecVersion(cycloneDXVersion))
 	writer, err := factory.GetWriter(scanFormat)
 	if err != nil {
 		return failure.WrapUnknown(
@@ -20,13 +20,17 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/scanoss/crypto-finder/internal/config"
 	"github.com/scanoss/crypto-finder/internal/engine"
 	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
 	"github.com/scanoss/crypto-finder/internal/javaruntime"
+	"github.com/scanoss/crypto-finder/internal/rules"
 	scanutil "github.com/scanoss/crypto-finder/internal/scan"
 	"github.com/scanoss/crypto-finder/internal/scanner/semgrep"
 )
@@ -621,7 +625,7 @@ func TestAllowedScannersAndFormats(t *testing.T) {
 			t.Error("AllowedScanners should not be empty")
 		}
 
-		expectedScanners := []string{"opengrep", "semgrep"}
+		expectedScanners := []string{"opengrep", "semgrep", "native"}
 		for _, scanner := range expectedScanners {
 			found := false
 			for _, allowed := range AllowedScanners {
@@ -641,7 +645,7 @@ func TestAllowedScannersAndFormats(t *testing.T) {
 			t.Error("SupportedFormats should not be empty")
 		}
 
-		expectedFormats := []string{"json", "cyclonedx"}
+		expectedFormats := []string{"json", "cyclonedx", "spdx"}
 		for _, format := range expectedFormats {
 			found := false
 			for _, supported := range SupportedFormats {
@@ -711,3 +715,87 @@ func TestParseDuration(t *testing.T) {
 		})
 	}
 }
+
+// TestApplyRulesLock_MovedGitRef locks a --rules-git branch, moves the
+// branch, and checks that a --locked scan refuses the new commit.
+func TestApplyRulesLock_MovedGitRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Rules Author")
+	t.Setenv("GIT_AUTHOR_EMAIL", "rules@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Rules Author")
+	t.Setenv("GIT_COMMITTER_EMAIL", "rules@example.com")
+
+	root := t.TempDir()
+	bare := filepath.Join(root, "rules.git")
+	work := filepath.Join(root, "work")
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	commitRule := func(id string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(work, "crypto.yaml"), []byte("rules:\n  - id: "+id+"\n"), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		git(work, "add", "-A")
+		git(work, "commit", "--quiet", "-m", id)
+		git(work, "push", "--quiet", bare, "main")
+	}
+	git(root, "init", "--quiet", "--bare", "--initial-branch=main", bare)
+	git(root, "init", "--quiet", "--initial-branch=main", work)
+	commitRule("crypto.v1")
+
+	cacheDir := t.TempDir()
+	lockPath := filepath.Join(t.TempDir(), scanutil.RulesLockFileName)
+	source := func() []rules.RuleSource {
+		return []rules.RuleSource{rules.NewGitRuleSource(context.Background(), bare, "main", "", cacheDir)}
+	}
+	if err := applyRulesLock(nil, source(), lockPath, nil); err != nil {
+		t.Fatalf("applyRulesLock(--write-lock) error = %v", err)
+	}
+	lock, err := scanutil.ReadRulesLock(lockPath)
+	if err != nil {
+		t.Fatalf("ReadRulesLock() error = %v", err)
+	}
+	if len(lock.Fetched) != 1 || len(lock.Fetched[0].Revision) != 40 {
+		t.Fatalf("locked rule sources = %+v, want the commit main resolved to", lock.Fetched)
+	}
+	checked := source()
+	if err := applyRulesLock(nil, checked, lockPath, lock); err != nil {
+		t.Fatalf("applyRulesLock(--locked) of an unmoved branch error = %v", err)
+	}
+
+	// The branch moves after the check: the scan, which loads the checked
+	// sources again, must still run the locked commit.
+	commitRule("crypto.v2")
+	paths, err := rules.NewManager(checked...).Load()
+	if err != nil {
+		t.Fatalf("Manager.Load() error = %v", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if !strings.Contains(string(data), "crypto.v1") {
+			t.Errorf("scanned rule file %s = %q, want the locked crypto.v1 rules", path, data)
+		}
+	}
+	if revision := checked[0].Info().Revision; revision != lock.Fetched[0].Revision {
+		t.Errorf("scanned revision = %s, want the locked %s", revision, lock.Fetched[0].Revision)
+	}
+
+	err = applyRulesLock(nil, source(), lockPath, lock)
+	structured, ok := failure.As(err)
+	if !ok || structured.Code != failure.CodeRulesLoadFailed || !strings.Contains(err.Error(), "locked "+lock.Fetched[0].Revision) {
+		t.Fatalf("applyRulesLock(--locked) of a moved branch error = %v, want the locked commit reported", err)
+	}
+}
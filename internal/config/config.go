@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
@@ -59,6 +61,7 @@ type Config struct {
 	findingsCacheBackend string
 	findingsCacheDSN     string
 	findingsCacheTable   string
+	rulesPublicKeys      []string
 	mu                   sync.RWMutex
 }
 
@@ -133,6 +136,14 @@ func (c *Config) GetFindingsCacheTable() string {
 	return c.findingsCacheTable
 }
 
+// GetRulesPublicKeys returns the configured public keys (base64, PEM, or key
+// file paths) that remote ruleset signatures are verified against.
+func (c *Config) GetRulesPublicKeys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.rulesPublicKeys...)
+}
+
 // SetAPIKey updates the API key and persists to config file.
 func (c *Config) SetAPIKey(key string) error {
 	c.mu.Lock()
@@ -170,7 +181,7 @@ type InitOptions struct {
 //  1. Set() calls (used for CLI flags) - highest
 //  2. Environment variables (SCANOSS_API_KEY, SCANOSS_API_URL,
 //     SCANOSS_FINDINGS_CACHE_BACKEND, SCANOSS_FINDINGS_CACHE_DSN,
-//     SCANOSS_FINDINGS_CACHE_TABLE)
+//     SCANOSS_FINDINGS_CACHE_TABLE, SCANOSS_RULES_PUBLIC_KEYS)
 //  3. Config file (~/.scanoss/crypto-finder/config.json)
 //  4. Defaults (SetDefault) - lowest
 //
@@ -204,11 +215,61 @@ func (c *Config) Initialize(opts InitOptions) error {
 	c.findingsCacheBackend = viper.GetString("findings_cache_backend")
 	c.findingsCacheDSN = viper.GetString("findings_cache_dsn")
 	c.findingsCacheTable = viper.GetString("findings_cache_table")
+	c.rulesPublicKeys = rulesPublicKeysSetting()
 	c.mu.Unlock()
 
 	return nil
 }
 
+// PEM block markers, as they open and close a PEM public key.
+const (
+	pemBeginMarker = "-----BEGIN "
+	pemEndMarker   = "-----END "
+)
+
+// rulesPublicKeysSetting returns rules_public_keys: a list in the config
+// file, or a single SCANOSS_RULES_PUBLIC_KEYS string that is split by
+// splitPublicKeys.
+func rulesPublicKeysSetting() []string {
+	if raw, ok := viper.Get("rules_public_keys").(string); ok {
+		return splitPublicKeys(raw)
+	}
+	return viper.GetStringSlice("rules_public_keys")
+}
+
+// splitPublicKeys splits a list of public keys on commas and whitespace.
+// PEM blocks are kept whole, since their header, body and footer are
+// themselves separated by spaces or newlines.
+func splitPublicKeys(raw string) []string {
+	isSeparator := func(r rune) bool { return r == ',' || unicode.IsSpace(r) }
+
+	var keys []string
+	for raw != "" {
+		begin := strings.Index(raw, pemBeginMarker)
+		if begin < 0 {
+			keys = append(keys, strings.FieldsFunc(raw, isSeparator)...)
+			break
+		}
+		keys = append(keys, strings.FieldsFunc(raw[:begin], isSeparator)...)
+		raw = raw[begin:]
+
+		end := strings.Index(raw, pemEndMarker)
+		if end < 0 {
+			// An unterminated block is passed on whole, so it fails to parse
+			// with a message naming it.
+			keys = append(keys, strings.TrimSpace(raw))
+			break
+		}
+		end += len(pemEndMarker)
+		if closing := strings.Index(raw[end:], "-----"); closing >= 0 {
+			end += closing + len("-----")
+		}
+		keys = append(keys, raw[:end])
+		raw = raw[end:]
+	}
+	return keys
+}
+
 func (c *Config) setupViperConfig() error {
 	if viper.ConfigFileUsed() == "" {
 		configPath, err := GetConfigFilePath()
@@ -247,6 +308,7 @@ func (c *Config) bindEnvVars() error {
 		"findings_cache_backend": "FindingsCache backend",
 		"findings_cache_dsn":     "FindingsCache DSN",
 		"findings_cache_table":   "FindingsCache table",
+		"rules_public_keys":      "rules public keys",
 	}
 	for key, label := range envBindings {
 		if err := viper.BindEnv(key); err != nil {
@@ -19,6 +19,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -152,6 +153,23 @@ func TestInitialize_Priority_EnvVars(t *testing.T) {
 	}
 }
 
+func TestInitialize_RulesPublicKeys_PEMFromEnv(t *testing.T) {
+	defer setupTest(t)()
+
+	pem := "-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=\n-----END PUBLIC KEY-----"
+	t.Setenv("SCANOSS_RULES_PUBLIC_KEYS", "/etc/scanoss/rules.pub, "+pem+"\nGoSx/zi+bKfbwIgAfq0GWINOF/3oJmNrNs3sTWpIfro=")
+
+	cfg := GetInstance()
+	if err := cfg.Initialize(InitOptions{}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	want := []string{"/etc/scanoss/rules.pub", pem, "GoSx/zi+bKfbwIgAfq0GWINOF/3oJmNrNs3sTWpIfro="}
+	if got := cfg.GetRulesPublicKeys(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetRulesPublicKeys() = %q, want %q", got, want)
+	}
+}
+
 func TestInitialize_Priority_ConfigFile(t *testing.T) {
 	defer setupTest(t)()
 
@@ -37,6 +37,14 @@ const (
 	// RulesetsDirName is the rulesets cache directory name.
 	RulesetsDirName = "rulesets"
 
+	// GitRulesDirName is the cache directory name for rules checked out
+	// from git repositories (--rules-git).
+	GitRulesDirName = "git-rules"
+
+	// OCIRulesDirName is the cache directory name for rules pulled from OCI
+	// registries (--rules-oci).
+	OCIRulesDirName = "oci-rules"
+
 	// FilteredRulesDirName is the directory, created beside ruleset version
 	// roots, where language-filtered working copies are materialized. Rule-tree
 	// walks also exclude this name to ignore copies left by older versions.
@@ -18,6 +18,7 @@
 package converter
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
@@ -47,6 +48,20 @@ type AggregatedAsset struct {
 	// ReferenceAsset's single value.
 	CryptoFunctions []string
 
+	// ProtocolVersion is the normalized version this entry was grouped under.
+	// Protocol assets only; a configuration call that enables several TLS
+	// versions contributes to one entry per version.
+	ProtocolVersion string
+
+	// CipherSuites collects the distinct cipher suites configured across every
+	// protocol asset grouped into this entry, in first-seen order.
+	CipherSuites []string
+
+	// Owners collects the distinct software components whose code contains an
+	// occurrence of this asset, in first-seen order: the dependency key
+	// ("module@version") for dependency findings and "" for project code.
+	Owners []string
+
 	// ReferenceAsset holds one representative asset for extracting common metadata
 	ReferenceAsset *entities.CryptographicAsset
 
@@ -101,6 +116,7 @@ type AssetIdentity struct {
 type Aggregator struct {
 	algorithmMapper     *AlgorithmMapper
 	relatedCryptoMapper *RelatedCryptoMapper
+	protocolMapper      *ProtocolMapper
 }
 
 // NewAggregator creates a new asset aggregator.
@@ -108,9 +124,17 @@ func NewAggregator() *Aggregator {
 	return &Aggregator{
 		algorithmMapper:     NewAlgorithmMapper(),
 		relatedCryptoMapper: NewRelatedCryptoMapper(),
+		protocolMapper:      NewProtocolMapper(),
 	}
 }
 
+// aggregationTarget identifies one aggregated entry an asset contributes to.
+type aggregationTarget struct {
+	name            string
+	key             string
+	protocolVersion string
+}
+
 // AggregateAssets groups cryptographic assets by their identity (CDX component name).
 // Assets are grouped such that multiple occurrences of the same crypto asset
 // (e.g., SHA-256 used in multiple files) are combined into a single aggregated entry.
@@ -131,46 +155,51 @@ func (a *Aggregator) AggregateAssets(report *entities.InterimReport) ([]Aggregat
 				continue // Skip assets without type
 			}
 
-			// Generate the asset key (CDX component name) based on asset type
-			assetName := a.getAssetKey(asset)
-			assetKey := a.getAggregationKey(asset, finding.FilePath)
-
-			// Create or retrieve aggregated asset
-			aggregated, exists := assetMap[assetKey]
-			if !exists {
-				aggregated = &AggregatedAsset{
-					Name:             assetName,
-					AssetType:        assetType,
-					Occurrences:      []AssetOccurrence{},
-					Identities:       []AssetIdentity{},
-					ReferenceAsset:   asset,
-					ReferenceFinding: finding,
+			for _, target := range a.getAggregationTargets(asset, finding.FilePath) {
+				// Create or retrieve aggregated asset
+				aggregated, exists := assetMap[target.key]
+				if !exists {
+					aggregated = &AggregatedAsset{
+						Name:             target.name,
+						AssetType:        assetType,
+						Occurrences:      []AssetOccurrence{},
+						Identities:       []AssetIdentity{},
+						ProtocolVersion:  target.protocolVersion,
+						ReferenceAsset:   asset,
+						ReferenceFinding: finding,
+					}
+					assetMap[target.key] = aggregated
 				}
-				assetMap[assetKey] = aggregated
-			}
 
-			// Add occurrence
-			// Collect all rule IDs from the asset's rules
-			ruleIDs := make([]string, len(asset.Rules))
-			for i, rule := range asset.Rules {
-				ruleIDs[i] = rule.ID
-			}
+				// Add occurrence
+				// Collect all rule IDs from the asset's rules
+				ruleIDs := make([]string, len(asset.Rules))
+				for i, rule := range asset.Rules {
+					ruleIDs[i] = rule.ID
+				}
 
-			occurrence := AssetOccurrence{
-				FilePath:  finding.FilePath,
-				StartLine: asset.StartLine,
-				EndLine:   asset.EndLine,
-				RuleIDs:   ruleIDs,
-				API:       asset.Metadata["api"],
-				Match:     asset.Match,
-			}
-			aggregated.Occurrences = append(aggregated.Occurrences, occurrence)
+				occurrence := AssetOccurrence{
+					FilePath:  finding.FilePath,
+					StartLine: asset.StartLine,
+					EndLine:   asset.EndLine,
+					RuleIDs:   ruleIDs,
+					API:       asset.Metadata["api"],
+					Match:     asset.Match,
+				}
+				aggregated.Occurrences = append(aggregated.Occurrences, occurrence)
+
+				// Add identity if it's a new rule
+				a.addIdentityIfNew(aggregated, asset)
+
+				// Merge this asset's crypto function into the group (deduped).
+				a.addCryptoFunctionIfNew(aggregated, asset)
 
-			// Add identity if it's a new rule
-			a.addIdentityIfNew(aggregated, asset)
+				// Merge this asset's configured cipher suites into the group (deduped).
+				a.addCipherSuitesIfNew(aggregated, asset)
 
-			// Merge this asset's crypto function into the group (deduped).
-			a.addCryptoFunctionIfNew(aggregated, asset)
+				// Record which software component brings this asset in (deduped).
+				a.addOwnerIfNew(aggregated, asset)
+			}
 		}
 	}
 
@@ -183,6 +212,31 @@ func (a *Aggregator) AggregateAssets(report *entities.InterimReport) ([]Aggregat
 	return result, nil
 }
 
+// getAggregationTargets returns the aggregated entries an asset contributes to.
+// Every asset maps to exactly one entry except TLS protocol assets, which are
+// grouped by protocol type and version (so differently configured TLS 1.3
+// contexts share one component) and contribute to one entry per enabled version.
+func (a *Aggregator) getAggregationTargets(asset *entities.CryptographicAsset, filePath string) []aggregationTarget {
+	protocolType := a.protocolMapper.getProtocolType(asset)
+	if asset.Metadata["assetType"] != AssetTypeProtocol || protocolType == "" {
+		return []aggregationTarget{{
+			name: a.getAssetKey(asset),
+			key:  a.getAggregationKey(asset, filePath),
+		}}
+	}
+
+	versions := a.protocolMapper.getProtocolVersions(asset)
+	targets := make([]aggregationTarget, 0, len(versions))
+	for _, version := range versions {
+		targets = append(targets, aggregationTarget{
+			name:            a.protocolMapper.getProtocolName(protocolType, version),
+			key:             "protocol:" + protocolType + ":" + version,
+			protocolVersion: version,
+		})
+	}
+	return targets
+}
+
 // getAggregationKey scopes serial-less certificate identities to their source file.
 func (a *Aggregator) getAggregationKey(asset *entities.CryptographicAsset, filePath string) string {
 	key := a.getAssetKey(asset)
@@ -259,6 +313,38 @@ func (a *Aggregator) addCryptoFunctionIfNew(aggregated *AggregatedAsset, asset *
 	aggregated.CryptoFunctions = append(aggregated.CryptoFunctions, raw)
 }
 
+// addCipherSuitesIfNew appends the cipher suites the asset configures for the
+// aggregated protocol version, preserving first-seen order.
+func (a *Aggregator) addCipherSuitesIfNew(aggregated *AggregatedAsset, asset *entities.CryptographicAsset) {
+	if aggregated.AssetType != AssetTypeProtocol {
+		return
+	}
+	for _, suite := range a.protocolMapper.getCipherSuites(asset, aggregated.ProtocolVersion) {
+		if !slices.Contains(aggregated.CipherSuites, suite) {
+			aggregated.CipherSuites = append(aggregated.CipherSuites, suite)
+		}
+	}
+}
+
+// addOwnerIfNew appends the software component that contains the asset to
+// aggregated.Owners when it is not already present, preserving first-seen order.
+func (a *Aggregator) addOwnerIfNew(aggregated *AggregatedAsset, asset *entities.CryptographicAsset) {
+	owner := assetOwnerKey(asset)
+	if !slices.Contains(aggregated.Owners, owner) {
+		aggregated.Owners = append(aggregated.Owners, owner)
+	}
+}
+
+// assetOwnerKey returns the dependency key of the component whose code
+// contains the asset, or "" when the asset was found in project code.
+func assetOwnerKey(asset *entities.CryptographicAsset) string {
+	info := asset.DependencyInfo
+	if info == nil || info.Module == "" {
+		return ""
+	}
+	return entities.DependencyComponent{Module: info.Module, Version: info.Version}.Key()
+}
+
 // SortAssets sorts aggregated assets alphabetically by Name for deterministic output.
 func (a *Aggregator) SortAssets(assets []AggregatedAsset) {
 	sort.Slice(assets, func(i, j int) bool {
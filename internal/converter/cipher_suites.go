@@ -0,0 +1,322 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"strings"
+)
+
+// tlsCipherSuite is a TLS cipher suite decoded from a configuration argument.
+type tlsCipherSuite struct {
+	// Name is the IANA registry name (e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256").
+	// Unrecognized values keep the name as written in the source.
+	Name string
+
+	// Identifier is the two-byte IANA code point (e.g. "0xC0,0x2F") when known.
+	Identifier string
+
+	// Algorithms are the algorithm assets the suite is built from, in
+	// key exchange, authentication, cipher, MAC/PRF order.
+	Algorithms []cipherSuiteAlgorithm
+}
+
+// cipherSuiteAlgorithm describes one algorithm used by a cipher suite with the
+// same metadata keys a rule would emit for an algorithm asset.
+type cipherSuiteAlgorithm struct {
+	Name      string
+	Family    string
+	Primitive string
+	Parameter string
+	Mode      string
+}
+
+// metadata returns the asset metadata for the algorithm so it can be mapped by
+// AlgorithmMapper exactly like a rule-detected algorithm.
+func (a cipherSuiteAlgorithm) metadata() map[string]string {
+	metadata := map[string]string{
+		"assetType":          AssetTypeAlgorithm,
+		"algorithmName":      a.Name,
+		"algorithmFamily":    a.Family,
+		"algorithmPrimitive": a.Primitive,
+	}
+	if a.Parameter != "" {
+		metadata["algorithmParameterSetIdentifier"] = a.Parameter
+	}
+	if a.Mode != "" {
+		metadata["algorithmMode"] = a.Mode
+	}
+	return metadata
+}
+
+// cipherSuiteIdentifiers maps IANA cipher suite names to their code points.
+// Only suites seen in real-world TLS configuration are listed; unknown but
+// well-formed names are still decoded, just without an identifier.
+var cipherSuiteIdentifiers = map[string]string{
+	"TLS_AES_128_GCM_SHA256":                        "0x13,0x01",
+	"TLS_AES_256_GCM_SHA384":                        "0x13,0x02",
+	"TLS_CHACHA20_POLY1305_SHA256":                  "0x13,0x03",
+	"TLS_AES_128_CCM_SHA256":                        "0x13,0x04",
+	"TLS_AES_128_CCM_8_SHA256":                      "0x13,0x05",
+	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":                 "0x00,0x0A",
+	"TLS_RSA_WITH_AES_128_CBC_SHA":                  "0x00,0x2F",
+	"TLS_RSA_WITH_AES_256_CBC_SHA":                  "0x00,0x35",
+	"TLS_RSA_WITH_AES_128_CBC_SHA256":               "0x00,0x3C",
+	"TLS_RSA_WITH_AES_256_CBC_SHA256":               "0x00,0x3D",
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":               "0x00,0x9C",
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":               "0x00,0x9D",
+	"TLS_RSA_WITH_RC4_128_SHA":                      "0x00,0x05",
+	"TLS_DHE_RSA_WITH_AES_128_CBC_SHA":              "0x00,0x33",
+	"TLS_DHE_RSA_WITH_AES_256_CBC_SHA":              "0x00,0x39",
+	"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256":           "0x00,0x9E",
+	"TLS_DHE_RSA_WITH_AES_256_GCM_SHA384":           "0x00,0x9F",
+	"TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256":     "0xCC,0xAA",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":          "0xC0,0x09",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":          "0xC0,0x0A",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256":       "0xC0,0x23",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384":       "0xC0,0x24",
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256":       "0xC0,0x2B",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384":       "0xC0,0x2C",
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": "0xCC,0xA9",
+	"TLS_ECDHE_ECDSA_WITH_RC4_128_SHA":              "0xC0,0x07",
+	"TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA":           "0xC0,0x12",
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":            "0xC0,0x13",
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":            "0xC0,0x14",
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256":         "0xC0,0x27",
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384":         "0xC0,0x28",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":         "0xC0,0x2F",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":         "0xC0,0x30",
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256":   "0xCC,0xA8",
+	"TLS_ECDHE_RSA_WITH_RC4_128_SHA":                "0xC0,0x11",
+}
+
+// openSSLCipherSuiteNames maps OpenSSL cipher names (as passed to
+// SSLContext.set_ciphers or SSL_CTX_set_cipher_list) to IANA names.
+var openSSLCipherSuiteNames = map[string]string{
+	"AES128-SHA":                    "TLS_RSA_WITH_AES_128_CBC_SHA",
+	"AES256-SHA":                    "TLS_RSA_WITH_AES_256_CBC_SHA",
+	"AES128-SHA256":                 "TLS_RSA_WITH_AES_128_CBC_SHA256",
+	"AES256-SHA256":                 "TLS_RSA_WITH_AES_256_CBC_SHA256",
+	"AES128-GCM-SHA256":             "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	"AES256-GCM-SHA384":             "TLS_RSA_WITH_AES_256_GCM_SHA384",
+	"DES-CBC3-SHA":                  "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+	"RC4-SHA":                       "TLS_RSA_WITH_RC4_128_SHA",
+	"DHE-RSA-AES128-SHA":            "TLS_DHE_RSA_WITH_AES_128_CBC_SHA",
+	"DHE-RSA-AES256-SHA":            "TLS_DHE_RSA_WITH_AES_256_CBC_SHA",
+	"DHE-RSA-AES128-GCM-SHA256":     "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+	"DHE-RSA-AES256-GCM-SHA384":     "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384",
+	"DHE-RSA-CHACHA20-POLY1305":     "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-ECDSA-AES128-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-ECDSA-AES256-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+	"ECDHE-ECDSA-AES128-SHA256":     "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-ECDSA-AES256-SHA384":     "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384",
+	"ECDHE-ECDSA-AES128-GCM-SHA256": "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-ECDSA-AES256-GCM-SHA384": "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-ECDSA-CHACHA20-POLY1305": "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-RSA-AES128-SHA":          "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-RSA-AES256-SHA":          "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+	"ECDHE-RSA-AES128-SHA256":       "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-RSA-AES256-SHA384":       "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384",
+	"ECDHE-RSA-AES128-GCM-SHA256":   "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-RSA-AES256-GCM-SHA384":   "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-RSA-CHACHA20-POLY1305":   "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-RSA-DES-CBC3-SHA":        "TLS_ECDHE_RSA_WITH_3DES_EDE_CBC_SHA",
+}
+
+// cipherSuiteKeyExchange maps the key exchange and authentication tokens of an
+// IANA suite name to algorithms. "anon" and "PSK" authenticate without a
+// public-key algorithm and therefore contribute nothing.
+var cipherSuiteKeyExchange = map[string]cipherSuiteAlgorithm{
+	"ECDHE": {Name: "ECDH", Family: "ECDH", Primitive: "key-agree"},
+	"ECDH":  {Name: "ECDH", Family: "ECDH", Primitive: "key-agree"},
+	"DHE":   {Name: "DH", Family: "DH", Primitive: "key-agree"},
+	"DH":    {Name: "DH", Family: "DH", Primitive: "key-agree"},
+	"RSA":   {Name: "RSA", Family: "RSA", Primitive: "pke"},
+}
+
+var cipherSuiteAuthentication = map[string]cipherSuiteAlgorithm{
+	"RSA":   {Name: "RSA", Family: "RSA", Primitive: "signature"},
+	"ECDSA": {Name: "ECDSA", Family: "ECDSA", Primitive: "signature"},
+	"DSS":   {Name: "DSA", Family: "DSA", Primitive: "signature"},
+}
+
+// cipherSuiteHashes maps the trailing MAC/PRF token of a suite name to its hash.
+var cipherSuiteHashes = map[string]cipherSuiteAlgorithm{
+	"MD5":    {Name: "MD5", Family: "MD5", Primitive: "hash"},
+	"SHA":    {Name: "SHA-1", Family: "SHA-1", Primitive: "hash"},
+	"SHA256": {Name: "SHA-256", Family: "SHA-2", Primitive: "hash", Parameter: "256"},
+	"SHA384": {Name: "SHA-384", Family: "SHA-2", Primitive: "hash", Parameter: "384"},
+	"SM3":    {Name: "SM3", Family: "SM3", Primitive: "hash"},
+}
+
+// parseCipherSuite decodes a cipher suite as written in TLS configuration code.
+// It accepts IANA/JSSE names ("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+// "SSL_RSA_WITH_3DES_EDE_CBC_SHA"), qualified constants
+// ("tls.TLS_AES_128_GCM_SHA256", "CipherSuite.TLS_AES_256_GCM_SHA384") and
+// OpenSSL names ("ECDHE-RSA-AES128-GCM-SHA256"). It returns false for values
+// that are cipher selection expressions rather than a single suite
+// (e.g. "HIGH", "ECDHE+AESGCM", "!aNULL").
+func parseCipherSuite(raw string) (tlsCipherSuite, bool) {
+	name := strings.TrimSpace(raw)
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	if name == "" || strings.ContainsAny(name, "+!@ ") {
+		return tlsCipherSuite{}, false
+	}
+
+	if ianaName, ok := openSSLCipherSuiteNames[strings.ToUpper(name)]; ok {
+		name = ianaName
+	}
+
+	upper := strings.ToUpper(name)
+	if strings.HasPrefix(upper, "SSL_") {
+		upper = "TLS_" + strings.TrimPrefix(upper, "SSL_")
+	}
+	if !strings.HasPrefix(upper, "TLS_") {
+		return tlsCipherSuite{}, false
+	}
+	if upper == "TLS_EMPTY_RENEGOTIATION_INFO_SCSV" || upper == "TLS_FALLBACK_SCSV" {
+		return tlsCipherSuite{}, false
+	}
+
+	// JSSE names are the IANA names with the original-case "anon" token.
+	ianaName := strings.ReplaceAll(upper, "_ANON_", "_anon_")
+	suite := tlsCipherSuite{
+		Name:       ianaName,
+		Identifier: cipherSuiteIdentifiers[ianaName],
+	}
+
+	body := strings.TrimPrefix(upper, "TLS_")
+	bulk := body
+	if kx, rest, found := strings.Cut(body, "_WITH_"); found {
+		suite.Algorithms = append(suite.Algorithms, cipherSuiteKeyAlgorithms(kx)...)
+		bulk = rest
+	}
+	suite.Algorithms = append(suite.Algorithms, cipherSuiteBulkAlgorithms(bulk)...)
+
+	return suite, true
+}
+
+// isTLS13 reports whether the suite uses the TLS 1.3 naming scheme, which
+// carries no key exchange or authentication component.
+func (s tlsCipherSuite) isTLS13() bool {
+	return strings.HasPrefix(s.Name, "TLS_") && !strings.Contains(s.Name, "_WITH_")
+}
+
+// cipherSuiteKeyAlgorithms decodes the "<kx>[_<auth>]" half of a TLS 1.2
+// suite name (e.g. "ECDHE_RSA", "RSA", "DHE_DSS", "ECDH_anon").
+func cipherSuiteKeyAlgorithms(kx string) []cipherSuiteAlgorithm {
+	tokens := strings.Split(kx, "_")
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var algorithms []cipherSuiteAlgorithm
+	if algorithm, ok := cipherSuiteKeyExchange[tokens[0]]; ok {
+		algorithms = append(algorithms, algorithm)
+	}
+
+	auth := tokens[0]
+	if len(tokens) > 1 {
+		auth = tokens[1]
+	}
+	if algorithm, ok := cipherSuiteAuthentication[auth]; ok {
+		if len(algorithms) == 0 || algorithms[0].Name != algorithm.Name {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	return algorithms
+}
+
+// cipherSuiteBulkAlgorithms decodes the "<cipher>_<mac>" half of a suite name
+// (e.g. "AES_128_GCM_SHA256", "CHACHA20_POLY1305_SHA256", "3DES_EDE_CBC_SHA").
+func cipherSuiteBulkAlgorithms(bulk string) []cipherSuiteAlgorithm {
+	tokens := strings.Split(bulk, "_")
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	var hash *cipherSuiteAlgorithm
+	if algorithm, ok := cipherSuiteHashes[tokens[len(tokens)-1]]; ok && len(tokens) > 1 {
+		hash = &algorithm
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	var algorithms []cipherSuiteAlgorithm
+	if cipher, ok := cipherSuiteCipher(tokens); ok {
+		algorithms = append(algorithms, cipher)
+	}
+	if hash != nil {
+		algorithms = append(algorithms, *hash)
+	}
+	return algorithms
+}
+
+// cipherSuiteCipher decodes the bulk cipher tokens of a suite name.
+func cipherSuiteCipher(tokens []string) (cipherSuiteAlgorithm, bool) {
+	if len(tokens) == 0 {
+		return cipherSuiteAlgorithm{}, false
+	}
+
+	switch tokens[0] {
+	case "NULL":
+		return cipherSuiteAlgorithm{}, false
+	case "CHACHA20":
+		return cipherSuiteAlgorithm{Name: "ChaCha20-Poly1305", Family: "ChaCha20", Primitive: "ae"}, true
+	case "3DES":
+		return cipherSuiteAlgorithm{Name: "3DES-CBC", Family: "3DES", Primitive: "block-cipher", Mode: "cbc"}, true
+	case "RC4":
+		return cipherSuiteAlgorithm{Name: "RC4", Family: "RC4", Primitive: "stream-cipher"}, true
+	}
+
+	family := tokens[0]
+	if family == "CAMELLIA" {
+		family = "Camellia"
+	}
+	algorithm := cipherSuiteAlgorithm{Family: family, Primitive: "block-cipher"}
+	parts := []string{family}
+	rest := tokens[1:]
+	if len(rest) > 0 && isDecimal(rest[0]) {
+		algorithm.Parameter = rest[0]
+		parts = append(parts, rest[0])
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		mode := rest[0]
+		algorithm.Mode = strings.ToLower(mode)
+		parts = append(parts, mode)
+		if mode == "GCM" || mode == "CCM" {
+			algorithm.Primitive = "ae"
+		}
+	}
+	algorithm.Name = strings.Join(parts, "-")
+
+	return algorithm, true
+}
+
+func isDecimal(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
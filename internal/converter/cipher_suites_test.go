@@ -0,0 +1,137 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func TestParseCipherSuite(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            string
+		wantOK         bool
+		wantName       string
+		wantIdentifier string
+		wantAlgorithms []string
+	}{
+		{
+			name:           "IANA TLS 1.2 suite",
+			raw:            "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			wantOK:         true,
+			wantName:       "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+			wantIdentifier: "0xC0,0x2F",
+			wantAlgorithms: []string{"ECDH", "RSA", "AES-128-GCM", "SHA-256"},
+		},
+		{
+			name:           "TLS 1.3 suite",
+			raw:            "TLS_CHACHA20_POLY1305_SHA256",
+			wantOK:         true,
+			wantName:       "TLS_CHACHA20_POLY1305_SHA256",
+			wantIdentifier: "0x13,0x03",
+			wantAlgorithms: []string{"ChaCha20-Poly1305", "SHA-256"},
+		},
+		{
+			name:           "Go crypto/tls constant",
+			raw:            "tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+			wantOK:         true,
+			wantName:       "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+			wantIdentifier: "0xC0,0x2C",
+			wantAlgorithms: []string{"ECDH", "ECDSA", "AES-256-GCM", "SHA-384"},
+		},
+		{
+			name:           "JSSE SSL_ prefix with RSA key transport",
+			raw:            "SSL_RSA_WITH_3DES_EDE_CBC_SHA",
+			wantOK:         true,
+			wantName:       "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+			wantIdentifier: "0x00,0x0A",
+			wantAlgorithms: []string{"RSA", "3DES-CBC", "SHA-1"},
+		},
+		{
+			name:           "OpenSSL name",
+			raw:            "ECDHE-RSA-AES256-GCM-SHA384",
+			wantOK:         true,
+			wantName:       "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			wantIdentifier: "0xC0,0x30",
+			wantAlgorithms: []string{"ECDH", "RSA", "AES-256-GCM", "SHA-384"},
+		},
+		{
+			name:           "Unlisted suite is still decoded",
+			raw:            "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA",
+			wantOK:         true,
+			wantName:       "TLS_DHE_DSS_WITH_CAMELLIA_128_CBC_SHA",
+			wantAlgorithms: []string{"DH", "DSA", "Camellia-128-CBC", "SHA-1"},
+		},
+		{
+			name:           "Anonymous suite keeps IANA casing",
+			raw:            "TLS_ECDH_anon_WITH_NULL_SHA",
+			wantOK:         true,
+			wantName:       "TLS_ECDH_anon_WITH_NULL_SHA",
+			wantAlgorithms: []string{"ECDH", "SHA-1"},
+		},
+		{name: "OpenSSL selection keyword", raw: "HIGH"},
+		{name: "OpenSSL exclusion", raw: "!aNULL"},
+		{name: "OpenSSL combination", raw: "ECDHE+AESGCM"},
+		{name: "Signalling suite", raw: "TLS_EMPTY_RENEGOTIATION_INFO_SCSV"},
+		{name: "Empty", raw: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suite, ok := parseCipherSuite(tt.raw)
+			if ok != tt.wantOK {
+				t.Fatalf("parseCipherSuite(%q) ok = %v, want %v", tt.raw, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if suite.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", suite.Name, tt.wantName)
+			}
+			if suite.Identifier != tt.wantIdentifier {
+				t.Errorf("Identifier = %q, want %q", suite.Identifier, tt.wantIdentifier)
+			}
+			var names []string
+			for _, algorithm := range suite.Algorithms {
+				names = append(names, algorithm.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantAlgorithms) {
+				t.Errorf("Algorithms = %v, want %v", names, tt.wantAlgorithms)
+			}
+		})
+	}
+}
+
+func TestCipherSuiteAlgorithmMetadata_MapsToAlgorithmComponent(t *testing.T) {
+	suite, ok := parseCipherSuite("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+	if !ok {
+		t.Fatal("parseCipherSuite() failed")
+	}
+
+	mapper := NewAlgorithmMapper()
+	for _, algorithm := range suite.Algorithms {
+		component, err := mapper.MapToComponentWithEvidence(&entities.CryptographicAsset{Metadata: algorithm.metadata()})
+		if err != nil {
+			t.Fatalf("MapToComponentWithEvidence(%s) error = %v", algorithm.Name, err)
+		}
+		if component.Name != algorithm.Name {
+			t.Errorf("component name = %q, want %q", component.Name, algorithm.Name)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package converter
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -47,26 +48,43 @@ const (
 	scanossCertificateSerialNumberPropertyName = "scanoss:certificateSerialNumber"
 	scanossCertificateTypePropertyName         = "scanoss:certificateType"
 	scanossProtocolTypePropertyName            = "scanoss:protocolType"
+	scanossCipherSuitePropertyName             = "scanoss:cipherSuite"
 )
 
 // Converter transforms interim reports to CycloneDX BOM format.
 type Converter struct {
 	algorithmMapper     *AlgorithmMapper
 	relatedCryptoMapper *RelatedCryptoMapper
+	protocolMapper      *ProtocolMapper
 	validator           *Validator
 	aggregator          *Aggregator
+	specVersion         cdx.SpecVersion
 }
 
-// NewConverter creates a new CBOM converter with all required mappers.
+// NewConverter creates a new CBOM converter with all required mappers,
+// emitting DefaultSpecVersion.
 func NewConverter() *Converter {
+	return NewConverterForSpecVersion(DefaultSpecVersion)
+}
+
+// NewConverterForSpecVersion creates a CBOM converter emitting the given
+// CycloneDX version. Use ParseSpecVersion to obtain a supported version.
+func NewConverterForSpecVersion(specVersion cdx.SpecVersion) *Converter {
 	return &Converter{
 		algorithmMapper:     NewAlgorithmMapper(),
 		relatedCryptoMapper: NewRelatedCryptoMapper(),
+		protocolMapper:      NewProtocolMapper(),
 		validator:           NewValidator(),
 		aggregator:          NewAggregator(),
+		specVersion:         specVersion,
 	}
 }
 
+// SpecVersion returns the CycloneDX version the converter emits.
+func (c *Converter) SpecVersion() cdx.SpecVersion {
+	return c.specVersion
+}
+
 // Convert transforms an interim report to a CycloneDX BOM.
 // It aggregates assets by identity and builds evidence for each occurrence.
 // Returns the BOM and any validation errors.
@@ -74,8 +92,11 @@ func (c *Converter) Convert(report *entities.InterimReport) (*cdx.BOM, error) {
 	if report == nil {
 		return nil, fmt.Errorf("report cannot be nil")
 	}
+	if !isSupportedSpecVersion(c.specVersion) {
+		return nil, fmt.Errorf("unsupported CycloneDX version %s (supported: %s)", c.specVersion, strings.Join(SupportedSpecVersions(), ", "))
+	}
 
-	log.Info().Msg("Starting conversion to CycloneDX CBOM format")
+	log.Info().Str("spec_version", c.specVersion.String()).Msg("Starting conversion to CycloneDX CBOM format")
 
 	// Aggregate assets by identity
 	aggregatedAssets, err := c.aggregator.AggregateAssets(report)
@@ -93,7 +114,7 @@ func (c *Converter) Convert(report *entities.InterimReport) (*cdx.BOM, error) {
 	// Create BOM with metadata
 	bom := &cdx.BOM{
 		BOMFormat:    "CycloneDX",
-		SpecVersion:  cdx.SpecVersion1_6,
+		SpecVersion:  c.specVersion,
 		SerialNumber: generateSerialNumber(),
 		Version:      1,
 		Metadata:     c.buildMetadata(report),
@@ -101,6 +122,7 @@ func (c *Converter) Convert(report *entities.InterimReport) (*cdx.BOM, error) {
 
 	// Convert aggregated assets to components
 	components := []cdx.Component{}
+	owned := make(map[string][]string)
 	skippedCount := 0
 
 	for _, aggregated := range aggregatedAssets {
@@ -120,13 +142,38 @@ func (c *Converter) Convert(report *entities.InterimReport) (*cdx.BOM, error) {
 		}
 
 		components = append(components, *component)
+		for _, owner := range aggregated.Owners {
+			owned[owner] = append(owned[owner], component.BOMRef)
+		}
 	}
 
+	converted := len(components)
+	components, cipherSuiteDependencies := c.linkCipherSuiteAlgorithms(components)
+	derived := len(components) - converted
+
+	// Add the software components that bring the assets in, so the document
+	// answers which library version uses which algorithm.
+	var dependencies []cdx.Dependency
+	libraries := 0
+	if inventory := buildDependencyInventory(report); inventory != nil {
+		bom.Metadata.Component = inventory.root
+		components = append(components, inventory.libraries...)
+		dependencies = inventory.dependencies(owned)
+		libraries = len(inventory.libraries)
+	}
+	dependencies = append(dependencies, cipherSuiteDependencies...)
+
 	bom.Components = &components
+	if len(dependencies) > 0 {
+		bom.Dependencies = &dependencies
+	}
+	adaptToSpecVersion(bom, c.specVersion)
 
 	log.Info().
 		Int("unique_assets", len(aggregatedAssets)).
-		Int("converted", len(components)).
+		Int("converted", converted).
+		Int("cipher_suite_algorithms", derived).
+		Int("libraries", libraries).
 		Int("skipped", skippedCount).
 		Msg("Conversion complete")
 
@@ -159,44 +206,17 @@ func (c *Converter) convertAggregatedAsset(aggregated *AggregatedAsset) (*cdx.Co
 		)
 
 	case AssetTypeProtocol:
-		asset := aggregated.ReferenceAsset
-		rawProtocolType := asset.Metadata["protocolType"]
-		protocolType := strings.ToLower(strings.TrimSpace(rawProtocolType))
-		if protocolType == "" {
-			return nil, fmt.Errorf("converter: missing required field 'protocolType'")
-		}
-		protocolVersion := strings.TrimSpace(asset.Metadata["protocolVersion"])
-		protocolProperties := &cdx.CryptoProtocolProperties{Version: protocolVersion}
-
-		switch cdx.CryptoProtocolType(protocolType) {
-		case cdx.CryptoProtocolTypeTLS,
-			cdx.CryptoProtocolTypeSSH,
-			cdx.CryptoProtocolTypeIPSec,
-			cdx.CryptoProtocolTypeIKE,
-			cdx.CryptoProtocolTypeSSTP,
-			cdx.CryptoProtocolTypeWPA,
-			cdx.CryptoProtocolTypeOther,
-			cdx.CryptoProtocolTypeUnknown:
-			protocolProperties.Type = cdx.CryptoProtocolType(protocolType)
-		default:
-			// CycloneDX 1.6 has no enum value for this protocol, so preserve the
-			// source value as a SCANOSS property instead of inventing an enum.
+		version := aggregated.ProtocolVersion
+		if version == "" {
+			version = c.protocolMapper.getProtocolVersions(aggregated.ReferenceAsset)[0]
 		}
-
-		baseComponent = &cdx.Component{
-			Type:   cdx.ComponentTypeCryptographicAsset,
-			BOMRef: generateBOMRef(),
-			CryptoProperties: &cdx.CryptoProperties{
-				AssetType:          cdx.CryptoAssetTypeProtocol,
-				ProtocolProperties: protocolProperties,
-			},
-		}
-		if protocolProperties.Type == "" {
-			addCustomProperty(baseComponent, scanossProtocolTypePropertyName, rawProtocolType)
-		}
-		componentName = protocolType
-		if protocolVersion != "" {
-			componentName += "-" + protocolVersion
+		baseComponent, err = c.protocolMapper.MapToComponentWithEvidence(
+			aggregated.ReferenceAsset,
+			version,
+			aggregated.CipherSuites,
+		)
+		if err == nil {
+			componentName = baseComponent.Name
 		}
 
 	case AssetTypeCertificate:
@@ -213,6 +233,7 @@ func (c *Converter) convertAggregatedAsset(aggregated *AggregatedAsset) (*cdx.Co
 			CryptoProperties: &cdx.CryptoProperties{
 				AssetType: cdx.CryptoAssetTypeCertificate,
 				CertificateProperties: &cdx.CertificateProperties{
+					SerialNumber:      serialNumber,
 					CertificateFormat: asset.Metadata["certificateFormat"],
 				},
 			},
@@ -238,6 +259,91 @@ func (c *Converter) convertAggregatedAsset(aggregated *AggregatedAsset) (*cdx.Co
 	return baseComponent, nil
 }
 
+// linkCipherSuiteAlgorithms resolves the algorithms every protocol cipher suite
+// is built from to algorithm components, reusing a detected component with the
+// same name (e.g. an "AES-128-GCM" found by a rule) and otherwise appending a
+// component derived from the suite. Components are matched on name and
+// primitive, so the RSA of an RSA key exchange (pke) and the RSA that signs
+// an ECDHE_RSA handshake (signature) stay distinct; a detected component
+// without a known primitive matches on name alone. Each suite lists its
+// algorithms by bom-ref
+// and each protocol gets a dependency entry on the algorithms its suites use,
+// so the TLS configuration inventory links to the algorithm inventory.
+func (c *Converter) linkCipherSuiteAlgorithms(components []cdx.Component) ([]cdx.Component, []cdx.Dependency) {
+	algorithmRefs := make(map[string]string)
+	for _, component := range components {
+		if component.CryptoProperties != nil && component.CryptoProperties.AssetType == cdx.CryptoAssetTypeAlgorithm {
+			key := algorithmRefKey(component.Name, componentPrimitive(component))
+			if _, exists := algorithmRefs[key]; !exists {
+				algorithmRefs[key] = component.BOMRef
+			}
+		}
+	}
+
+	var derived []cdx.Component
+	var dependencies []cdx.Dependency
+
+	for i := range components {
+		props := components[i].CryptoProperties
+		if props == nil || props.ProtocolProperties == nil || props.ProtocolProperties.CipherSuites == nil {
+			continue
+		}
+
+		var dependsOn []string
+		suites := *props.ProtocolProperties.CipherSuites
+		for j := range suites {
+			parsed, ok := parseCipherSuite(suites[j].Name)
+			if !ok {
+				continue
+			}
+
+			var refs []cdx.BOMReference
+			for _, algorithm := range parsed.Algorithms {
+				key := algorithmRefKey(algorithm.Name, algorithm.Primitive)
+				ref, exists := algorithmRefs[key]
+				if !exists {
+					ref, exists = algorithmRefs[algorithmRefKey(algorithm.Name, "")]
+				}
+				if !exists {
+					component, err := c.algorithmMapper.MapToComponentWithEvidence(&entities.CryptographicAsset{
+						Metadata: algorithm.metadata(),
+					})
+					if err != nil {
+						log.Debug().
+							Err(err).
+							Str("cipherSuite", parsed.Name).
+							Str("algorithm", algorithm.Name).
+							Msg("Skipping cipher suite algorithm - conversion failed")
+						continue
+					}
+					addCustomProperty(component, scanossCipherSuitePropertyName, parsed.Name)
+					derived = append(derived, *component)
+					ref = component.BOMRef
+					algorithmRefs[key] = ref
+				}
+				if !slices.Contains(refs, cdx.BOMReference(ref)) {
+					refs = append(refs, cdx.BOMReference(ref))
+				}
+				if !slices.Contains(dependsOn, ref) {
+					dependsOn = append(dependsOn, ref)
+				}
+			}
+			if len(refs) > 0 {
+				suites[j].Algorithms = &refs
+			}
+		}
+
+		if len(dependsOn) > 0 {
+			dependencies = append(dependencies, cdx.Dependency{
+				Ref:          components[i].BOMRef,
+				Dependencies: &dependsOn,
+			})
+		}
+	}
+
+	return append(components, derived...), dependencies
+}
+
 // mergeCryptoFunctions overwrites the component's CycloneDX CryptoFunctions
 // array and scanoss:cryptoFunction property with the full set of raw function
 // values collected across every asset in the aggregated group, when that set
@@ -346,7 +452,7 @@ func (c *Converter) buildEvidence(aggregated *AggregatedAsset) *cdx.Evidence {
 	}
 
 	if len(identities) > 0 {
-		evidence.Identity = &identities
+		evidence.Identity = &cdx.EvidenceIdentityChoice{Identities: &identities}
 	}
 
 	return evidence
@@ -376,6 +482,21 @@ func generateSerialNumber() string {
 	return fmt.Sprintf("urn:uuid:%s", uuid.New().String())
 }
 
+// algorithmRefKey keys an algorithm component by name and primitive.
+func algorithmRefKey(name, primitive string) string {
+	return name + "\x00" + primitive
+}
+
+// componentPrimitive returns the primitive of an algorithm component, or ""
+// when it is not known.
+func componentPrimitive(component cdx.Component) string {
+	props := component.CryptoProperties.AlgorithmProperties
+	if props == nil || props.Primitive == cdx.CryptoPrimitiveUnknown {
+		return ""
+	}
+	return string(props.Primitive)
+}
+
 // generateBOMRef creates a unique BOM reference for a component.
 // For now we are using UUIDs. Leaving this function if we decide to use a different approach.
 func generateBOMRef() string {
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
@@ -507,6 +508,242 @@ func TestConverter_ConvertCertificateFallbackName(t *testing.T) {
 	}
 }
 
+func TestConverter_ConvertTLSCipherSuites(t *testing.T) {
+	bom, err := NewConverter().Convert(loadFixture(t, "protocol_tls_cipher_suites.json"))
+	if err != nil {
+		t.Fatalf("Convert() unexpected error: %v", err)
+	}
+
+	components := make(map[string]cdx.Component, lenOrZero(bom.Components))
+	refs := make(map[string]string, lenOrZero(bom.Components))
+	for _, component := range *bom.Components {
+		if _, dup := components[component.Name]; dup {
+			t.Errorf("duplicate component %q", component.Name)
+		}
+		components[component.Name] = component
+		refs[component.BOMRef] = component.Name
+	}
+
+	tls12, ok := components["tls-1.2"]
+	if !ok {
+		t.Fatal("missing tls-1.2 component")
+	}
+	if got := len(*tls12.Evidence.Occurrences); got != 3 {
+		t.Errorf("tls-1.2 occurrences = %d, want 3 (netty, go, python)", got)
+	}
+	suites := *tls12.CryptoProperties.ProtocolProperties.CipherSuites
+	wantSuites := map[string][]string{
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":         {"ECDH", "RSA", "AES-128-GCM", "SHA-256"},
+		"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": {"ECDH", "ECDSA", "ChaCha20-Poly1305", "SHA-256"},
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":         {"ECDH", "RSA", "AES-256-GCM", "SHA-384"},
+	}
+	if len(suites) != len(wantSuites) {
+		t.Fatalf("tls-1.2 cipher suites = %d, want %d", len(suites), len(wantSuites))
+	}
+	for _, suite := range suites {
+		want, ok := wantSuites[suite.Name]
+		if !ok {
+			t.Errorf("unexpected cipher suite %q", suite.Name)
+			continue
+		}
+		if suite.Algorithms == nil {
+			t.Errorf("cipher suite %q has no algorithm references", suite.Name)
+			continue
+		}
+		var got []string
+		for _, ref := range *suite.Algorithms {
+			got = append(got, refs[string(ref)])
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("cipher suite %q algorithms = %v, want %v", suite.Name, got, want)
+		}
+	}
+
+	tls13, ok := components["tls-1.3"]
+	if !ok {
+		t.Fatal("missing tls-1.3 component")
+	}
+	tls13Suites := *tls13.CryptoProperties.ProtocolProperties.CipherSuites
+	if len(tls13Suites) != 1 || tls13Suites[0].Name != "TLS_AES_128_GCM_SHA256" {
+		t.Errorf("tls-1.3 cipher suites = %v, want [TLS_AES_128_GCM_SHA256]", tls13Suites)
+	}
+
+	// The rule-detected AES-128-GCM component is reused rather than duplicated,
+	// and keeps its evidence; suite-only algorithms are derived without evidence.
+	aes := components["AES-128-GCM"]
+	if aes.Evidence == nil || aes.Evidence.Occurrences == nil {
+		t.Error("detected AES-128-GCM component lost its evidence")
+	}
+	ecdh, ok := components["ECDH"]
+	if !ok {
+		t.Fatal("missing derived ECDH component")
+	}
+	if got := ecdh.CryptoProperties.AlgorithmProperties.Primitive; got != cdx.CryptoPrimitiveKeyAgree {
+		t.Errorf("ECDH primitive = %q, want %q", got, cdx.CryptoPrimitiveKeyAgree)
+	}
+	if got := propertyValues(&ecdh)[scanossCipherSuitePropertyName]; got == "" {
+		t.Error("derived ECDH component missing scanoss:cipherSuite property")
+	}
+
+	if bom.Dependencies == nil {
+		t.Fatal("expected dependencies linking protocols to algorithms")
+	}
+	dependsOn := make(map[string][]string)
+	for _, dependency := range *bom.Dependencies {
+		for _, ref := range *dependency.Dependencies {
+			dependsOn[refs[dependency.Ref]] = append(dependsOn[refs[dependency.Ref]], refs[ref])
+		}
+	}
+	if got := strings.Join(dependsOn["tls-1.3"], ","); got != "AES-128-GCM,SHA-256" {
+		t.Errorf("tls-1.3 dependsOn = %s, want AES-128-GCM,SHA-256", got)
+	}
+	if got := len(dependsOn["tls-1.2"]); got != 8 {
+		t.Errorf("tls-1.2 dependsOn %d algorithms, want 8 (%v)", got, dependsOn["tls-1.2"])
+	}
+}
+
+func TestConverter_LinkCipherSuiteAlgorithms_KeepsRSAPrimitivesApart(t *testing.T) {
+	signature := cdx.Component{
+		BOMRef: "rsa-signature",
+		Name:   "RSA",
+		CryptoProperties: &cdx.CryptoProperties{
+			AssetType:           cdx.CryptoAssetTypeAlgorithm,
+			AlgorithmProperties: &cdx.CryptoAlgorithmProperties{Primitive: cdx.CryptoPrimitiveSignature},
+		},
+	}
+	suites := []cdx.CipherSuite{
+		{Name: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		{Name: "TLS_RSA_WITH_AES_128_GCM_SHA256"},
+	}
+	protocol := cdx.Component{
+		BOMRef: "tls-1.2",
+		Name:   "tls-1.2",
+		CryptoProperties: &cdx.CryptoProperties{
+			AssetType:          cdx.CryptoAssetTypeProtocol,
+			ProtocolProperties: &cdx.CryptoProtocolProperties{CipherSuites: &suites},
+		},
+	}
+
+	components, _ := NewConverter().linkCipherSuiteAlgorithms([]cdx.Component{signature, protocol})
+
+	primitives := make(map[string]cdx.CryptoPrimitive, len(components))
+	for _, component := range components {
+		if props := component.CryptoProperties; props != nil && props.AlgorithmProperties != nil {
+			primitives[component.BOMRef] = props.AlgorithmProperties.Primitive
+		}
+	}
+	rsaRef := func(suite cdx.CipherSuite) string {
+		t.Helper()
+		if suite.Algorithms == nil || len(*suite.Algorithms) < 2 {
+			t.Fatalf("cipher suite %s algorithms = %v, want key exchange and authentication", suite.Name, suite.Algorithms)
+		}
+		for _, ref := range *suite.Algorithms {
+			if ref == "rsa-signature" || primitives[string(ref)] == cdx.CryptoPrimitivePKE {
+				return string(ref)
+			}
+		}
+		t.Fatalf("cipher suite %s references no RSA component", suite.Name)
+		return ""
+	}
+
+	// ECDHE_RSA signs the handshake with RSA: the detected signature
+	// component is reused. The RSA key exchange suite encrypts the premaster
+	// secret with RSA and gets its own pke component.
+	if got := rsaRef(suites[0]); got != "rsa-signature" {
+		t.Errorf("ECDHE_RSA RSA ref = %q, want the detected signature component", got)
+	}
+	kx := rsaRef(suites[1])
+	if kx == "rsa-signature" || primitives[kx] != cdx.CryptoPrimitivePKE {
+		t.Errorf("RSA key exchange ref = %q (primitive %q), want a separate pke component", kx, primitives[kx])
+	}
+}
+
+func TestConverter_ConvertDependencyGraph(t *testing.T) {
+	bom, err := NewConverter().Convert(loadFixture(t, "dependency_graph.json"))
+	if err != nil {
+		t.Fatalf("Convert() unexpected error: %v", err)
+	}
+
+	root := bom.Metadata.Component
+	if root == nil || root.Type != cdx.ComponentTypeApplication || root.Name != "com.example:app" {
+		t.Fatalf("metadata.component = %+v, want application com.example:app", root)
+	}
+
+	names := map[string]string{root.BOMRef: root.Name}
+	libraries := make(map[string]cdx.Component)
+	for _, component := range *bom.Components {
+		names[component.BOMRef] = component.Name
+		if component.Type == cdx.ComponentTypeLibrary {
+			libraries[component.Name] = component
+		}
+	}
+
+	wantLibraries := map[string]struct{ purl, scope, skipReason string }{
+		"org.bouncycastle:bcpkix-jdk18on": {"pkg:maven/org.bouncycastle/bcpkix-jdk18on@1.78", "direct", ""},
+		"org.bouncycastle:bcprov-jdk18on": {"pkg:maven/org.bouncycastle/bcprov-jdk18on@1.78", "transitive", ""},
+		"org.bouncycastle:bcpg-jdk18on":   {"pkg:maven/org.bouncycastle/bcpg-jdk18on@1.78", "direct", "not_imported"},
+		// Attributed by a finding but absent from the graph: no scope is known.
+		"org.bouncycastle:bcutil-jdk18on": {"pkg:maven/org.bouncycastle/bcutil-jdk18on@1.78", "", ""},
+	}
+	if len(libraries) != len(wantLibraries) {
+		t.Fatalf("library components = %d, want %d", len(libraries), len(wantLibraries))
+	}
+	for name, want := range wantLibraries {
+		library, ok := libraries[name]
+		if !ok {
+			t.Errorf("missing library component %q", name)
+			continue
+		}
+		if library.Version != "1.78" || library.PackageURL != want.purl {
+			t.Errorf("library %q = %s %s, want 1.78 %s", name, library.Version, library.PackageURL, want.purl)
+		}
+		if got := propertyValues(&library)[scanossDependencyScopePropertyName]; got != want.scope {
+			t.Errorf("library %q scope = %q, want %q", name, got, want.scope)
+		}
+		if got := propertyValues(&library)[scanossDependencySkipReasonPropertyName]; got != want.skipReason {
+			t.Errorf("library %q skip reason = %q, want %q", name, got, want.skipReason)
+		}
+	}
+
+	if bom.Dependencies == nil {
+		t.Fatal("expected a dependency graph")
+	}
+	dependsOn := make(map[string]string)
+	for _, dependency := range *bom.Dependencies {
+		var targets []string
+		if dependency.Dependencies != nil {
+			for _, ref := range *dependency.Dependencies {
+				targets = append(targets, names[ref])
+			}
+		}
+		dependsOn[names[dependency.Ref]] = strings.Join(targets, ",")
+	}
+	wantDependsOn := map[string]string{
+		"com.example:app":                 "org.bouncycastle:bcpkix-jdk18on,org.bouncycastle:bcpg-jdk18on,AES-256-GCM",
+		"org.bouncycastle:bcpkix-jdk18on": "org.bouncycastle:bcprov-jdk18on",
+		"org.bouncycastle:bcprov-jdk18on": "AES-256-GCM,SHA-256",
+		"org.bouncycastle:bcutil-jdk18on": "SHA-256",
+	}
+	for name, want := range wantDependsOn {
+		if got := dependsOn[name]; got != want {
+			t.Errorf("%s dependsOn = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestConverter_ConvertWithoutDependencyGraph(t *testing.T) {
+	bom, err := NewConverter().Convert(loadFixture(t, "algorithm_aes256_gcm.json"))
+	if err != nil {
+		t.Fatalf("Convert() unexpected error: %v", err)
+	}
+	if bom.Metadata.Component != nil {
+		t.Errorf("metadata.component = %+v, want nil without a dependency graph", bom.Metadata.Component)
+	}
+	if bom.Dependencies != nil {
+		t.Errorf("dependencies = %+v, want nil without a dependency graph", *bom.Dependencies)
+	}
+}
+
 func lenOrZero(components *[]cdx.Component) int {
 	if components == nil {
 		return 0
@@ -560,11 +797,11 @@ func TestConverter_MultipleRulesOnSameLine(t *testing.T) {
 	}
 
 	// Verify identity contains rule IDs (not code)
-	if component.Evidence.Identity == nil || len(*component.Evidence.Identity) == 0 {
+	if component.Evidence.Identity == nil || component.Evidence.Identity.Identities == nil || len(*component.Evidence.Identity.Identities) == 0 {
 		t.Fatal("Component missing Evidence.Identity")
 	}
 
-	identities := *component.Evidence.Identity
+	identities := *component.Evidence.Identity.Identities
 	if len(identities) != 2 {
 		t.Errorf("Expected 2 identity entries (one per rule), got %d", len(identities))
 	}
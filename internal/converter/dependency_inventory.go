@@ -0,0 +1,157 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+const scanossDependencyScopePropertyName = "scanoss:dependencyScope"
+
+// scanossDependencySkipReasonPropertyName records why a dependency's code was
+// not scanned, so a library without assets is not read as scanned and clean.
+const scanossDependencySkipReasonPropertyName = "scanoss:dependencySkipReason"
+
+// dependencyInventory holds the software components of a dependency scan: the
+// scanned project (root) and one library component per dependency version.
+// Components are keyed by dependency key ("module@version"); the root uses "".
+type dependencyInventory struct {
+	root      *cdx.Component
+	libraries []cdx.Component
+	refs      map[string]string
+	dependsOn map[string][]string
+	order     []string
+}
+
+// buildDependencyInventory builds the software components for a report. The
+// report's dependency graph supplies the libraries, their scope and their
+// edges; dependency findings from reports without a graph (e.g. interim
+// reports older than 1.7) still yield a library component from their
+// dependency_info so every dependency asset has an owner. Returns nil when the
+// report has neither.
+func buildDependencyInventory(report *entities.InterimReport) *dependencyInventory {
+	inventory := &dependencyInventory{
+		refs:      make(map[string]string),
+		dependsOn: make(map[string][]string),
+	}
+
+	if graph := report.Dependencies; graph != nil {
+		if graph.RootModule != "" {
+			inventory.root = &cdx.Component{
+				Type:   cdx.ComponentTypeApplication,
+				BOMRef: generateBOMRef(),
+				Name:   graph.RootModule,
+			}
+			inventory.refs[""] = inventory.root.BOMRef
+		}
+		for _, dep := range graph.Components {
+			key := dep.Key()
+			if !inventory.addLibrary(key, dep.Module, dep.Version, dep.PURL, dep.Scope, dep.DependencyScope, dep.SkipReason) {
+				continue
+			}
+			inventory.dependsOn[key] = dep.DependsOn
+			if dep.Scope == entities.DependencyScopeDirect {
+				inventory.dependsOn[""] = append(inventory.dependsOn[""], key)
+			}
+		}
+	}
+
+	for _, finding := range report.Findings {
+		for _, asset := range finding.CryptographicAssets {
+			info := asset.DependencyInfo
+			if info == nil || info.Module == "" {
+				continue
+			}
+			inventory.addLibrary(assetOwnerKey(&asset), info.Module, info.Version, info.PURL, "", info.DependencyScope, "")
+		}
+	}
+
+	if inventory.root == nil && len(inventory.libraries) == 0 {
+		return nil
+	}
+	return inventory
+}
+
+// addLibrary appends a library component unless one already exists for key.
+// scope is the direct/transitive scope; dependencyScope the build-tool scope;
+// skipReason why the dependency was not scanned, if it was not.
+func (inv *dependencyInventory) addLibrary(key, module, version, packageURL, scope, dependencyScope, skipReason string) bool {
+	if _, exists := inv.refs[key]; exists {
+		return false
+	}
+	component := cdx.Component{
+		Type:       cdx.ComponentTypeLibrary,
+		BOMRef:     generateBOMRef(),
+		Name:       module,
+		Version:    version,
+		PackageURL: packageURL,
+		Scope:      cycloneDXComponentScope(dependencyScope),
+	}
+	addCustomProperty(&component, scanossDependencyScopePropertyName, scope)
+	addCustomProperty(&component, scanossDependencySkipReasonPropertyName, skipReason)
+	inv.libraries = append(inv.libraries, component)
+	inv.refs[key] = component.BOMRef
+	inv.order = append(inv.order, key)
+	return true
+}
+
+// cycloneDXComponentScope maps a build-tool dependency scope to the
+// CycloneDX component scope: test and dev dependencies are excluded from the
+// runtime, optional ones optional, and the rest required.
+func cycloneDXComponentScope(dependencyScope string) cdx.Scope {
+	switch dependencyScope {
+	case "":
+		return ""
+	case "test", "dev":
+		return cdx.ScopeExcluded
+	case "optional":
+		return cdx.ScopeOptional
+	default:
+		return cdx.ScopeRequired
+	}
+}
+
+// dependencies builds the CycloneDX dependency graph between the software
+// components and links each component to the cryptographic assets found in its
+// code. owned maps an owner key to the bom-refs of the assets it contains.
+// Assets found in project code are only linked when the root is known.
+func (inv *dependencyInventory) dependencies(owned map[string][]string) []cdx.Dependency {
+	keys := inv.order
+	if inv.root != nil {
+		keys = append([]string{""}, keys...)
+	}
+
+	dependencies := make([]cdx.Dependency, 0, len(keys))
+	for _, key := range keys {
+		var dependsOn []string
+		for _, child := range inv.dependsOn[key] {
+			if ref, ok := inv.refs[child]; ok {
+				dependsOn = append(dependsOn, ref)
+			}
+		}
+		dependsOn = append(dependsOn, owned[key]...)
+
+		dependency := cdx.Dependency{Ref: inv.refs[key]}
+		if len(dependsOn) > 0 {
+			dependency.Dependencies = &dependsOn
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies
+}
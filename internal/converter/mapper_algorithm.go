@@ -66,6 +66,8 @@ func (m *AlgorithmMapper) MapToComponentWithEvidence(asset *entities.Cryptograph
 	m.addCryptoFunctions(algorithmProps, asset)
 
 	algorithmName := m.getAlgorithmName(asset)
+	// CycloneDX 1.7 field; the converter drops it for older versions.
+	algorithmProps.AlgorithmFamily = algorithmFamily(asset.Metadata["algorithmFamily"], algorithmName)
 
 	assetType := cdx.CryptoAssetTypeAlgorithm
 	cryptoProps := &cdx.CryptoProperties{
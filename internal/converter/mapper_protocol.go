@@ -0,0 +1,249 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// cipherSuiteMetadataKeys lists the metadata keys that carry cipher suite
+// arguments, in lookup order. "cipher" is what the okhttp-tls contract
+// contributes for ConnectionSpec.Builder.cipherSuites / CipherSuite.forJavaName.
+var cipherSuiteMetadataKeys = []string{"cipherSuites", "cipherSuite", "cipher"}
+
+// protocolVersionMetadataKeys lists the metadata keys that carry protocol
+// versions, in lookup order.
+var protocolVersionMetadataKeys = []string{"protocolVersion", "tlsVersion"}
+
+// tlsVersionPattern matches TLS version spellings used across ecosystems:
+// "TLSv1.3" (JSSE, Python ssl), "TLS_1_2" (okhttp TlsVersion),
+// "VersionTLS12" (Go crypto/tls), "PROTOCOL_TLSv1_2" (Python ssl).
+var tlsVersionPattern = regexp.MustCompile(`(?i)tls\D*?(\d)[._]?(\d)?$`)
+
+var numericVersionPattern = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+// ProtocolMapper converts cryptographic protocol assets to CycloneDX components.
+type ProtocolMapper struct{}
+
+// NewProtocolMapper creates a new protocol mapper.
+func NewProtocolMapper() *ProtocolMapper {
+	return &ProtocolMapper{}
+}
+
+// MapToComponentWithEvidence converts a protocol asset to a CycloneDX component.
+// The version and cipher suites are the values aggregated across every
+// occurrence of the protocol; cipher suites are recorded by name and
+// identifier here and linked to algorithm components by the converter.
+// This method does NOT build evidence - that is handled by the converter.
+func (m *ProtocolMapper) MapToComponentWithEvidence(asset *entities.CryptographicAsset, version string, cipherSuites []string) (*cdx.Component, error) {
+	rawProtocolType := asset.Metadata["protocolType"]
+	protocolType := m.getProtocolType(asset)
+	if protocolType == "" {
+		return nil, fmt.Errorf("converter: missing required field 'protocolType'")
+	}
+
+	protocolProperties := &cdx.CryptoProtocolProperties{Version: version}
+
+	switch cdx.CryptoProtocolType(protocolType) {
+	case cdx.CryptoProtocolTypeTLS,
+		cdx.CryptoProtocolTypeSSH,
+		cdx.CryptoProtocolTypeIPSec,
+		cdx.CryptoProtocolTypeIKE,
+		cdx.CryptoProtocolTypeSSTP,
+		cdx.CryptoProtocolTypeWPA,
+		cdx.CryptoProtocolTypeDTLS,
+		cdx.CryptoProtocolTypeQUIC,
+		cdx.CryptoProtocolTypeEAPAKA,
+		cdx.CryptoProtocolTypeEAPAKAPrime,
+		cdx.CryptoProtocolTypePRINS,
+		cdx.CryptoProtocolType5GAKA,
+		cdx.CryptoProtocolTypeOther,
+		cdx.CryptoProtocolTypeUnknown:
+		// Types added in CycloneDX 1.7 are moved to a property by the
+		// converter when an older version is requested.
+		protocolProperties.Type = cdx.CryptoProtocolType(protocolType)
+	default:
+		// CycloneDX has no enum value for this protocol, so preserve the
+		// source value as a SCANOSS property instead of inventing an enum.
+	}
+
+	suites := make([]cdx.CipherSuite, 0, len(cipherSuites))
+	for _, name := range cipherSuites {
+		suite := cdx.CipherSuite{Name: name}
+		if parsed, ok := parseCipherSuite(name); ok && parsed.Identifier != "" {
+			suite.Identifiers = &[]string{parsed.Identifier}
+		}
+		suites = append(suites, suite)
+	}
+	if len(suites) > 0 {
+		protocolProperties.CipherSuites = &suites
+	}
+
+	component := &cdx.Component{
+		Type:   cdx.ComponentTypeCryptographicAsset,
+		BOMRef: generateBOMRef(),
+		Name:   m.getProtocolName(protocolType, version),
+		CryptoProperties: &cdx.CryptoProperties{
+			AssetType:          cdx.CryptoAssetTypeProtocol,
+			ProtocolProperties: protocolProperties,
+		},
+	}
+	if protocolProperties.Type == "" {
+		addCustomProperty(component, scanossProtocolTypePropertyName, rawProtocolType)
+	}
+
+	return component, nil
+}
+
+// getProtocolType returns the normalized (lowercase, trimmed) protocol type.
+func (m *ProtocolMapper) getProtocolType(asset *entities.CryptographicAsset) string {
+	return strings.ToLower(strings.TrimSpace(asset.Metadata["protocolType"]))
+}
+
+// getProtocolName builds the component name {protocolType}[-{version}].
+func (m *ProtocolMapper) getProtocolName(protocolType, version string) string {
+	if version == "" {
+		return protocolType
+	}
+	return protocolType + "-" + version
+}
+
+// getProtocolVersions returns the distinct normalized versions configured by
+// the asset, in first-seen order. A single TLS configuration call can enable
+// several versions (e.g. SslContextBuilder.protocols("TLSv1.2", "TLSv1.3")),
+// each of which becomes its own protocol component. Other protocols keep
+// their version verbatim. Returns a single empty version when the asset does
+// not name one.
+func (m *ProtocolMapper) getProtocolVersions(asset *entities.CryptographicAsset) []string {
+	protocolType := m.getProtocolType(asset)
+	if protocolType != string(cdx.CryptoProtocolTypeTLS) {
+		for _, key := range protocolVersionMetadataKeys {
+			if version := strings.TrimSpace(asset.Metadata[key]); version != "" {
+				return []string{version}
+			}
+		}
+		return []string{""}
+	}
+
+	var versions []string
+	for _, key := range protocolVersionMetadataKeys {
+		for _, raw := range splitMetadataList(asset.Metadata[key]) {
+			version := normalizeProtocolVersion(raw)
+			if version == "" || slices.Contains(versions, version) {
+				continue
+			}
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return []string{""}
+	}
+	return versions
+}
+
+// getCipherSuites returns the distinct cipher suites configured by the asset
+// that apply to the given protocol version, in first-seen order. Known suites
+// are reported by their IANA name; selection expressions such as "HIGH" or
+// "ECDHE+AESGCM" are not individual suites and are dropped.
+func (m *ProtocolMapper) getCipherSuites(asset *entities.CryptographicAsset, version string) []string {
+	var suites []string
+	for _, key := range cipherSuiteMetadataKeys {
+		for _, raw := range splitMetadataList(asset.Metadata[key]) {
+			suite, ok := parseCipherSuite(raw)
+			if !ok {
+				log.Debug().
+					Str("value", raw).
+					Msg("Ignoring cipher selection value that is not a single TLS cipher suite")
+				continue
+			}
+			if !cipherSuiteAppliesToVersion(suite, version) || slices.Contains(suites, suite.Name) {
+				continue
+			}
+			suites = append(suites, suite.Name)
+		}
+	}
+	return suites
+}
+
+// cipherSuiteAppliesToVersion reports whether a suite can be negotiated with the
+// given TLS version. TLS 1.3 only negotiates the TLS 1.3 suites and earlier
+// versions never do; an unknown version accepts every suite.
+func cipherSuiteAppliesToVersion(suite tlsCipherSuite, version string) bool {
+	switch {
+	case version == "":
+		return true
+	case version == "1.3":
+		return suite.isTLS13()
+	case strings.HasPrefix(version, "1."):
+		return !suite.isTLS13()
+	default:
+		return true
+	}
+}
+
+// normalizeProtocolVersion reduces a TLS version as written in source code to
+// its dotted number ("TLSv1.2" -> "1.2", "VersionTLS13" -> "1.3"). Values that
+// name no version (e.g. Python's "PROTOCOL_TLS_CLIENT") yield "".
+func normalizeProtocolVersion(raw string) string {
+	value := strings.TrimSpace(raw)
+	if value == "" || numericVersionPattern.MatchString(value) {
+		return value
+	}
+
+	match := tlsVersionPattern.FindStringSubmatch(value)
+	if match == nil {
+		return ""
+	}
+	minor := match[2]
+	if minor == "" {
+		minor = "0"
+	}
+	return match[1] + "." + minor
+}
+
+// splitMetadataList splits a metadata value holding one or more items as
+// captured from source: comma, colon or whitespace separated, optionally
+// wrapped in brackets/braces and quoted (e.g. `["TLSv1.2", "TLSv1.3"]`,
+// `{tls.TLS_AES_128_GCM_SHA256, tls.TLS_AES_256_GCM_SHA384}`).
+func splitMetadataList(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		switch r {
+		case ',', ':', ';', ' ', '\t', '\n', '\r':
+			return true
+		default:
+			return false
+		}
+	})
+
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		item := strings.Trim(field, "[](){}\"'`")
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
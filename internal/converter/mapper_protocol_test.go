@@ -0,0 +1,162 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+package converter
+
+import (
+	"reflect"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func TestNormalizeProtocolVersion(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{raw: "1.3", want: "1.3"},
+		{raw: " TLSv1.2 ", want: "1.2"},
+		{raw: "TLSv1", want: "1.0"},
+		{raw: "TLSv1_1", want: "1.1"},
+		{raw: "TLS_1_3", want: "1.3"},
+		{raw: "tls.VersionTLS12", want: "1.2"},
+		{raw: "ssl.TLSVersion.TLSv1_3", want: "1.3"},
+		{raw: "PROTOCOL_TLSv1_2", want: "1.2"},
+		{raw: "PROTOCOL_TLS_CLIENT", want: ""},
+		{raw: "TLS", want: ""},
+		{raw: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := normalizeProtocolVersion(tt.raw); got != tt.want {
+				t.Errorf("normalizeProtocolVersion(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtocolMapper_GetProtocolVersions(t *testing.T) {
+	mapper := NewProtocolMapper()
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		want     []string
+	}{
+		{
+			name:     "TLS versions are split, normalized and deduplicated",
+			metadata: map[string]string{"protocolType": "tls", "protocolVersion": "[\"TLSv1.2\", \"TLSv1.3\", \"TLSv1.2\"]"},
+			want:     []string{"1.2", "1.3"},
+		},
+		{
+			name:     "tlsVersion key",
+			metadata: map[string]string{"protocolType": "TLS", "tlsVersion": "VersionTLS13"},
+			want:     []string{"1.3"},
+		},
+		{
+			name:     "TLS without a version",
+			metadata: map[string]string{"protocolType": "tls", "protocolVersion": "PROTOCOL_TLS_CLIENT"},
+			want:     []string{""},
+		},
+		{
+			name:     "Other protocols keep the version verbatim",
+			metadata: map[string]string{"protocolType": "ssh", "protocolVersion": " OpenSSH 9.6 "},
+			want:     []string{"OpenSSH 9.6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapper.getProtocolVersions(&entities.CryptographicAsset{Metadata: tt.metadata})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getProtocolVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtocolMapper_GetCipherSuites(t *testing.T) {
+	mapper := NewProtocolMapper()
+	asset := &entities.CryptographicAsset{Metadata: map[string]string{
+		"protocolType": "tls",
+		"cipherSuites": "TLS_AES_256_GCM_SHA384, ECDHE-RSA-AES128-GCM-SHA256:HIGH:!aNULL",
+		"cipher":       "TLS_AES_256_GCM_SHA384",
+	}}
+
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{version: "", want: []string{"TLS_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		{version: "1.3", want: []string{"TLS_AES_256_GCM_SHA384"}},
+		{version: "1.2", want: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("version "+tt.version, func(t *testing.T) {
+			got := mapper.getCipherSuites(asset, tt.version)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getCipherSuites(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtocolMapper_MapToComponentWithEvidence(t *testing.T) {
+	component, err := NewProtocolMapper().MapToComponentWithEvidence(
+		&entities.CryptographicAsset{Metadata: map[string]string{
+			"assetType":    AssetTypeProtocol,
+			"protocolType": " TLS ",
+		}},
+		"1.2",
+		[]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_PRIVATE_SUITE"},
+	)
+	if err != nil {
+		t.Fatalf("MapToComponentWithEvidence() unexpected error: %v", err)
+	}
+	if component.Name != "tls-1.2" {
+		t.Errorf("component name = %q, want %q", component.Name, "tls-1.2")
+	}
+
+	props := component.CryptoProperties.ProtocolProperties
+	if props.Type != cdx.CryptoProtocolTypeTLS || props.Version != "1.2" {
+		t.Errorf("protocol = %q/%q, want tls/1.2", props.Type, props.Version)
+	}
+	if props.CipherSuites == nil || len(*props.CipherSuites) != 2 {
+		t.Fatalf("cipher suites = %v, want 2", props.CipherSuites)
+	}
+	known := (*props.CipherSuites)[0]
+	if known.Identifiers == nil || !reflect.DeepEqual(*known.Identifiers, []string{"0xC0,0x2F"}) {
+		t.Errorf("identifiers = %v, want [0xC0,0x2F]", known.Identifiers)
+	}
+	if unknown := (*props.CipherSuites)[1]; unknown.Identifiers != nil {
+		t.Errorf("unlisted suite identifiers = %v, want nil", *unknown.Identifiers)
+	}
+}
+
+func TestProtocolMapper_MissingProtocolType(t *testing.T) {
+	_, err := NewProtocolMapper().MapToComponentWithEvidence(
+		&entities.CryptographicAsset{Metadata: map[string]string{"assetType": AssetTypeProtocol}},
+		"",
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected error for missing protocolType")
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/pkg/purl"
+)
+
+// MergeResult summarizes how a CBOM was merged into an SBOM.
+type MergeResult struct {
+	// Assets is the number of cryptographic-asset components added.
+	Assets int
+	// Libraries is the number of SBOM components that received provides
+	// relationships, including the SBOM's metadata component.
+	Libraries int
+	// Unmatched lists the package URLs of CBOM libraries that have no
+	// matching SBOM component. Their assets are added without an owner.
+	Unmatched []string
+}
+
+// MergeSpecVersion returns the CycloneDX version of an SBOM enriched with
+// crypto components: the SBOM's own version, raised to 1.6 when older because
+// cryptographic assets and provides relationships need 1.6.
+func MergeSpecVersion(sbom *cdx.BOM) cdx.SpecVersion {
+	if sbom.SpecVersion < cdx.SpecVersion1_6 {
+		return cdx.SpecVersion1_6
+	}
+	return sbom.SpecVersion
+}
+
+// MergeCBOM injects the cryptographic-asset components of a crypto-finder
+// CBOM into an externally produced SBOM. Each SBOM component whose package URL
+// matches a CBOM library (same package and version) gets a provides
+// relationship to the assets found in that library; assets found in project
+// code are provided by the SBOM's metadata component. The SBOM is modified in
+// place and keeps its serial number, components, and dependency graph.
+//
+// The CBOM must use CycloneDX 1.6 or later and should be converted at
+// MergeSpecVersion(sbom); 1.7 fields are dropped when the SBOM is older.
+func MergeCBOM(sbom, cbom *cdx.BOM) (*MergeResult, error) {
+	if sbom == nil || cbom == nil {
+		return nil, fmt.Errorf("SBOM and CBOM cannot be nil")
+	}
+	if sbom.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("SBOM bomFormat must be 'CycloneDX', got '%s'", sbom.BOMFormat)
+	}
+	if cbom.SpecVersion < cdx.SpecVersion1_6 {
+		return nil, fmt.Errorf("CBOM must use CycloneDX 1.6 or later, got %s", cbom.SpecVersion)
+	}
+
+	version := MergeSpecVersion(sbom)
+	if cbom.SpecVersion > version {
+		adaptToSpecVersion(cbom, version)
+	}
+	sbom.SpecVersion = version
+
+	assets, owners := cbomAssetOwners(cbom)
+	result := &MergeResult{Assets: len(assets)}
+	if len(assets) == 0 {
+		return result, nil
+	}
+
+	existing := make(map[string]bool)
+	walkComponents(sbom, func(component *cdx.Component) {
+		if component.BOMRef != "" {
+			existing[component.BOMRef] = true
+		}
+	})
+
+	validator := NewValidator()
+	components := []cdx.Component{}
+	if sbom.Components != nil {
+		components = *sbom.Components
+	}
+	for i := range assets {
+		if existing[assets[i].BOMRef] {
+			return nil, fmt.Errorf("CBOM bom-ref %q already exists in the SBOM", assets[i].BOMRef)
+		}
+		if err := validator.validateComponent(&assets[i], version); err != nil {
+			return nil, fmt.Errorf("CBOM component validation failed: %w", err)
+		}
+		components = append(components, assets[i])
+	}
+	sbom.Components = &components
+
+	targets := sbomPackageRefs(sbom)
+	provides := make(map[string][]string)
+	var order []string
+	for _, owner := range owners.list {
+		ref := ""
+		if owner.purl == "" {
+			ref = sbomRootRef(sbom)
+		} else if key, ok := purl.VersionedIdentity(owner.purl); ok {
+			ref = targets[key]
+		}
+		if ref == "" {
+			if owner.purl != "" {
+				result.Unmatched = append(result.Unmatched, owner.purl)
+			}
+			continue
+		}
+		if _, seen := provides[ref]; !seen {
+			order = append(order, ref)
+		}
+		provides[ref] = append(provides[ref], owner.assets...)
+	}
+
+	dependencies := []cdx.Dependency{}
+	if sbom.Dependencies != nil {
+		dependencies = *sbom.Dependencies
+	}
+	for _, ref := range order {
+		dependencies = addProvides(dependencies, ref, provides[ref])
+	}
+	// Cipher suites reference their algorithms through the CBOM dependency
+	// graph; keep those edges.
+	if cbom.Dependencies != nil {
+		for _, dependency := range *cbom.Dependencies {
+			if _, isAsset := owners.assetRefs[dependency.Ref]; isAsset && dependency.Dependencies != nil {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+	if len(dependencies) > 0 {
+		sbom.Dependencies = &dependencies
+	}
+	result.Libraries = len(order)
+
+	addMergeTools(sbom, cbom)
+
+	log.Info().
+		Int("assets", result.Assets).
+		Int("libraries", result.Libraries).
+		Int("unmatched", len(result.Unmatched)).
+		Msg("CBOM merged into SBOM")
+
+	return result, nil
+}
+
+// assetOwner is a software component of the CBOM and the cryptographic assets
+// found in its code. An empty purl denotes the scanned project.
+type assetOwner struct {
+	purl   string
+	assets []string
+}
+
+// assetOwners lists owners in CBOM order together with the set of all
+// cryptographic-asset bom-refs.
+type assetOwners struct {
+	list      []assetOwner
+	assetRefs map[string]struct{}
+}
+
+// cbomAssetOwners returns the cryptographic-asset components of a CBOM and
+// the software component owning each of them. Ownership comes from the CBOM
+// dependency graph: libraries and the metadata component (the scanned
+// project) depend on the assets found in their code. Assets reached only
+// through another asset (the algorithms of a cipher suite) inherit the owner
+// of that asset, and assets without any owner belong to the scanned project.
+func cbomAssetOwners(cbom *cdx.BOM) ([]cdx.Component, assetOwners) {
+	owners := assetOwners{assetRefs: make(map[string]struct{})}
+	var assets []cdx.Component
+	software := make(map[string]string)
+	if cbom.Metadata != nil && cbom.Metadata.Component != nil && cbom.Metadata.Component.BOMRef != "" {
+		software[cbom.Metadata.Component.BOMRef] = ""
+	}
+	if cbom.Components != nil {
+		for _, component := range *cbom.Components {
+			switch component.Type {
+			case cdx.ComponentTypeCryptographicAsset:
+				assets = append(assets, component)
+				owners.assetRefs[component.BOMRef] = struct{}{}
+			case cdx.ComponentTypeLibrary:
+				if component.PackageURL != "" {
+					software[component.BOMRef] = component.PackageURL
+				}
+			}
+		}
+	}
+	if len(assets) == 0 {
+		return nil, owners
+	}
+
+	assetDependencies := make(map[string][]string)
+	owned := make(map[string]bool)
+	project := -1
+	if cbom.Dependencies != nil {
+		for _, dependency := range *cbom.Dependencies {
+			if dependency.Dependencies == nil {
+				continue
+			}
+			if _, isAsset := owners.assetRefs[dependency.Ref]; isAsset {
+				assetDependencies[dependency.Ref] = *dependency.Dependencies
+				continue
+			}
+			packageURL, isSoftware := software[dependency.Ref]
+			if !isSoftware {
+				continue
+			}
+			owner := assetOwner{purl: packageURL}
+			for _, ref := range *dependency.Dependencies {
+				if _, isAsset := owners.assetRefs[ref]; isAsset {
+					owner.assets = append(owner.assets, ref)
+					owned[ref] = true
+				}
+			}
+			if packageURL == "" {
+				project = len(owners.list)
+			}
+			owners.list = append(owners.list, owner)
+		}
+	}
+
+	for i := range owners.list {
+		for _, ref := range owners.list[i].assets {
+			for _, child := range assetDependencies[ref] {
+				if _, isAsset := owners.assetRefs[child]; isAsset && !owned[child] {
+					owned[child] = true
+					owners.list[i].assets = append(owners.list[i].assets, child)
+				}
+			}
+		}
+	}
+
+	if project < 0 {
+		owners.list = append([]assetOwner{{}}, owners.list...)
+		project = 0
+	}
+	for _, asset := range assets {
+		if !owned[asset.BOMRef] {
+			owners.list[project].assets = append(owners.list[project].assets, asset.BOMRef)
+		}
+	}
+
+	list := owners.list[:0]
+	for _, owner := range owners.list {
+		if len(owner.assets) > 0 {
+			list = append(list, owner)
+		}
+	}
+	owners.list = list
+	return assets, owners
+}
+
+// sbomPackageRefs maps the versioned package identity of every SBOM component
+// with a package URL to its bom-ref. Components without a bom-ref get one so
+// they can be referenced by provides relationships.
+func sbomPackageRefs(sbom *cdx.BOM) map[string]string {
+	refs := make(map[string]string)
+	walkComponents(sbom, func(component *cdx.Component) {
+		key, ok := purl.VersionedIdentity(component.PackageURL)
+		if !ok {
+			return
+		}
+		if _, exists := refs[key]; exists {
+			return
+		}
+		if component.BOMRef == "" {
+			component.BOMRef = generateBOMRef()
+		}
+		refs[key] = component.BOMRef
+	})
+	return refs
+}
+
+// sbomRootRef returns the bom-ref of the SBOM's metadata component, assigning
+// one when missing, or "" when the SBOM does not describe a root component.
+func sbomRootRef(sbom *cdx.BOM) string {
+	if sbom.Metadata == nil || sbom.Metadata.Component == nil {
+		return ""
+	}
+	if sbom.Metadata.Component.BOMRef == "" {
+		sbom.Metadata.Component.BOMRef = generateBOMRef()
+	}
+	return sbom.Metadata.Component.BOMRef
+}
+
+// walkComponents calls fn for the metadata component and every component of
+// the BOM, including nested components.
+func walkComponents(bom *cdx.BOM, fn func(*cdx.Component)) {
+	var walk func(components *[]cdx.Component)
+	walk = func(components *[]cdx.Component) {
+		if components == nil {
+			return
+		}
+		for i := range *components {
+			fn(&(*components)[i])
+			walk((*components)[i].Components)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		fn(bom.Metadata.Component)
+		walk(bom.Metadata.Component.Components)
+	}
+	walk(bom.Components)
+}
+
+// addProvides records that ref provides the given assets, extending the
+// existing dependency entry for ref when the SBOM already has one.
+func addProvides(dependencies []cdx.Dependency, ref string, assets []string) []cdx.Dependency {
+	index := -1
+	for i := range dependencies {
+		if dependencies[i].Ref == ref {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		dependencies = append(dependencies, cdx.Dependency{Ref: ref})
+		index = len(dependencies) - 1
+	}
+
+	dependency := &dependencies[index]
+	var provides []string
+	seen := make(map[string]bool)
+	if dependency.Provides != nil {
+		provides = *dependency.Provides
+		for _, existing := range provides {
+			seen[existing] = true
+		}
+	}
+	for _, asset := range assets {
+		if !seen[asset] {
+			seen[asset] = true
+			provides = append(provides, asset)
+		}
+	}
+	dependency.Provides = &provides
+	return dependencies
+}
+
+// addMergeTools credits the CBOM's tools in the SBOM metadata.
+func addMergeTools(sbom, cbom *cdx.BOM) {
+	if cbom.Metadata == nil || cbom.Metadata.Tools == nil || cbom.Metadata.Tools.Components == nil {
+		return
+	}
+	if sbom.Metadata == nil {
+		sbom.Metadata = &cdx.Metadata{}
+	}
+	if sbom.Metadata.Tools == nil {
+		sbom.Metadata.Tools = &cdx.ToolsChoice{}
+	}
+	tools := sbom.Metadata.Tools
+
+	//nolint:staticcheck // Legacy tool arrays from CycloneDX 1.4 SBOMs are extended in their own form
+	if tools.Tools != nil {
+		for _, component := range *cbom.Metadata.Tools.Components {
+			//nolint:staticcheck // See above
+			*tools.Tools = append(*tools.Tools, cdx.Tool{Vendor: component.Group, Name: component.Name, Version: component.Version})
+		}
+		return
+	}
+
+	var components []cdx.Component
+	if tools.Components != nil {
+		components = *tools.Components
+	}
+	components = append(components, *cbom.Metadata.Tools.Components...)
+	tools.Components = &components
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func loadSBOMFixture(t *testing.T, filename string) *cdx.BOM {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("testdata", filename))
+	if err != nil {
+		t.Fatalf("Failed to open fixture %s: %v", filename, err)
+	}
+	defer file.Close()
+
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(file, cdx.BOMFileFormatJSON).Decode(&bom); err != nil {
+		t.Fatalf("Failed to decode fixture %s: %v", filename, err)
+	}
+	return &bom
+}
+
+func TestMergeCBOM(t *testing.T) {
+	sbom := loadSBOMFixture(t, "sbom_maven.cdx.json")
+	cbom, err := NewConverterForSpecVersion(MergeSpecVersion(sbom)).Convert(loadFixture(t, "dependency_graph.json"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	result, err := MergeCBOM(sbom, cbom)
+	if err != nil {
+		t.Fatalf("MergeCBOM() error = %v", err)
+	}
+
+	if sbom.SpecVersion != cdx.SpecVersion1_6 {
+		t.Errorf("SpecVersion = %s, want 1.6", sbom.SpecVersion)
+	}
+	if sbom.SerialNumber != "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79" {
+		t.Errorf("SerialNumber changed to %s", sbom.SerialNumber)
+	}
+	if result.Assets != 2 {
+		t.Errorf("Assets = %d, want 2", result.Assets)
+	}
+	if result.Libraries != 2 {
+		t.Errorf("Libraries = %d, want 2 (project and bcprov)", result.Libraries)
+	}
+	if !slices.Equal(result.Unmatched, []string{"pkg:maven/org.bouncycastle/bcutil-jdk18on@1.78"}) {
+		t.Errorf("Unmatched = %v", result.Unmatched)
+	}
+
+	assetRefs := make(map[string]string)
+	libraries := 0
+	for _, component := range *sbom.Components {
+		switch component.Type {
+		case cdx.ComponentTypeCryptographicAsset:
+			assetRefs[component.Name] = component.BOMRef
+		case cdx.ComponentTypeLibrary:
+			libraries++
+		}
+	}
+	if libraries != 2 {
+		t.Errorf("SBOM libraries = %d, want the original 2", libraries)
+	}
+	aes, sha := assetRefs["AES-256-GCM"], assetRefs["SHA-256"]
+	if aes == "" || sha == "" {
+		t.Fatalf("merged asset components = %v, want AES-256-GCM and SHA-256", assetRefs)
+	}
+
+	provides := make(map[string][]string)
+	for _, dependency := range *sbom.Dependencies {
+		if dependency.Provides != nil {
+			provides[dependency.Ref] = *dependency.Provides
+		}
+	}
+	app := "pkg:maven/com.example/app@1.0.0?type=jar"
+	bcprov := "pkg:maven/org.bouncycastle/bcprov-jdk18on@1.78?type=jar"
+	if !slices.Equal(provides[app], []string{aes}) {
+		t.Errorf("project provides %v, want [AES-256-GCM]", provides[app])
+	}
+	got := slices.Clone(provides[bcprov])
+	want := []string{aes, sha}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("bcprov provides %v, want AES-256-GCM and SHA-256", provides[bcprov])
+	}
+	if len(provides) != 2 {
+		t.Errorf("provides entries = %d, want 2", len(provides))
+	}
+
+	tools := *sbom.Metadata.Tools.Components
+	if len(tools) != 2 || tools[1].Name != "crypto-finder" {
+		t.Errorf("tools = %+v, want syft followed by crypto-finder", tools)
+	}
+}
+
+func TestMergeCBOM_ProjectCodeOnly(t *testing.T) {
+	sbom := loadSBOMFixture(t, "sbom_maven.cdx.json")
+	cbom, err := NewConverterForSpecVersion(cdx.SpecVersion1_7).Convert(loadFixture(t, "algorithm_aes256_gcm.json"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	result, err := MergeCBOM(sbom, cbom)
+	if err != nil {
+		t.Fatalf("MergeCBOM() error = %v", err)
+	}
+	if result.Assets != 1 || result.Libraries != 1 || len(result.Unmatched) != 0 {
+		t.Errorf("result = %+v, want one asset provided by the project", result)
+	}
+
+	for _, component := range *sbom.Components {
+		if component.CryptoProperties != nil && component.CryptoProperties.AlgorithmProperties.AlgorithmFamily != "" {
+			t.Error("1.7 algorithmFamily should be dropped when merging into a 1.6 SBOM")
+		}
+	}
+}
+
+func TestMergeCBOM_RejectsOldCBOM(t *testing.T) {
+	sbom := loadSBOMFixture(t, "sbom_maven.cdx.json")
+	cbom, err := NewConverterForSpecVersion(cdx.SpecVersion1_5).Convert(loadFixture(t, "algorithm_aes256_gcm.json"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if _, err := MergeCBOM(sbom, cbom); err == nil {
+		t.Error("MergeCBOM() should reject a CycloneDX 1.5 CBOM")
+	}
+}
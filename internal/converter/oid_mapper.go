@@ -273,6 +273,25 @@ func (m *OIDMapper) addFamilyMapping(family, oid string) {
 // 3. Fall back to algorithmFamily parent OID (case-insensitive).
 // 4. Return empty string if not found (logs warning).
 func (m *OIDMapper) ResolveOID(asset *entities.CryptographicAsset) string {
+	if oid := m.LookupOID(asset); oid != "" {
+		return oid
+	}
+
+	if family := asset.Metadata["algorithmFamily"]; family != "" {
+		log.Warn().
+			Str("algorithmFamily", family).
+			Str("algorithmName", asset.Metadata["algorithmName"]).
+			Str("parameterSet", asset.Metadata["algorithmParameterSetIdentifier"]).
+			Str("mode", asset.Metadata["algorithmMode"]).
+			Msg("Unknown algorithm detected, no OID assigned")
+	}
+
+	return ""
+}
+
+// LookupOID resolves the OID like ResolveOID but does not log unknown
+// algorithms. It returns an empty string when no mapping matches.
+func (m *OIDMapper) LookupOID(asset *entities.CryptographicAsset) string {
 	// Priority 1: Check explicit algorithmName from metadata.
 	if algoName, ok := asset.Metadata["algorithmName"]; ok && algoName != "" {
 		normalized := normalizeAlgorithmName(algoName)
@@ -302,15 +321,6 @@ func (m *OIDMapper) ResolveOID(asset *entities.CryptographicAsset) string {
 		}
 	}
 
-	if family != "" {
-		log.Warn().
-			Str("algorithmFamily", family).
-			Str("algorithmName", asset.Metadata["algorithmName"]).
-			Str("parameterSet", paramSet).
-			Str("mode", mode).
-			Msg("Unknown algorithm detected, no OID assigned")
-	}
-
 	return ""
 }
 
@@ -0,0 +1,125 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// Checks reported by CheckRuleMetadata.
+const (
+	RuleMetadataCheckRequired  = "required"
+	RuleMetadataCheckAssetType = "asset-type"
+	RuleMetadataCheckPrimitive = "primitive"
+	RuleMetadataCheckOID       = "oid"
+)
+
+// RuleMetadataIssue is a problem in a rule's metadata.crypto block that
+// surfaces when the rule's findings are converted to a CBOM.
+type RuleMetadataIssue struct {
+	// Check is one of the RuleMetadataCheck* constants.
+	Check   string
+	Message string
+	// Warning marks issues that do not make conversion fail, such as an
+	// algorithm without a known OID.
+	Warning bool
+}
+
+var lintOIDMapper = sync.OnceValue(NewOIDMapper)
+
+// CheckRuleMetadata applies the conversion-time requirements to the crypto
+// metadata a rule declares, so a broken rule is caught when it is written
+// rather than when its findings are converted. It checks the required fields
+// per asset type (the mappers' validateRequiredFields), the algorithm
+// primitive (mapPrimitiveToCycloneDX) and that an algorithm resolves to an
+// OID. Values that interpolate metavariables ("SHA-$BITS") are only known at
+// scan time; they count as present but are not checked further.
+func CheckRuleMetadata(metadata map[string]string) []RuleMetadataIssue {
+	asset := &entities.CryptographicAsset{Metadata: metadata}
+	var issues []RuleMetadataIssue
+	required := func(err error) {
+		if err != nil {
+			issues = append(issues, RuleMetadataIssue{Check: RuleMetadataCheckRequired, Message: err.Error()})
+		}
+	}
+
+	assetType := strings.ToLower(strings.TrimSpace(metadata["assetType"]))
+	switch assetType {
+	case "":
+		required(fmt.Errorf("missing required field 'assetType'"))
+
+	case AssetTypeAlgorithm:
+		required(NewAlgorithmMapper().validateRequiredFields(asset))
+		if primitive := strings.TrimSpace(metadata["algorithmPrimitive"]); primitive != "" && !interpolatesMetavariable(primitive) {
+			if _, err := mapPrimitiveToCycloneDX(primitive); err != nil {
+				issues = append(issues, RuleMetadataIssue{Check: RuleMetadataCheckPrimitive, Message: err.Error()})
+			}
+		}
+		if issue, ok := checkRuleOID(asset); ok {
+			issues = append(issues, issue)
+		}
+
+	case AssetTypeRelatedCryptoMaterial:
+		required(NewRelatedCryptoMapper().validateRequiredFields(asset))
+
+	case AssetTypeProtocol:
+		if NewProtocolMapper().getProtocolType(asset) == "" {
+			required(fmt.Errorf("missing required field 'protocolType' (required for assetType='protocol')"))
+		}
+
+	case AssetTypeCertificate:
+		// Certificates have no required fields beyond the asset type.
+
+	default:
+		issues = append(issues, RuleMetadataIssue{
+			Check: RuleMetadataCheckAssetType,
+			Message: fmt.Sprintf("unsupported assetType '%s' (supported: %s, %s, %s, %s)", metadata["assetType"],
+				AssetTypeAlgorithm, AssetTypeCertificate, AssetTypeProtocol, AssetTypeRelatedCryptoMaterial),
+		})
+	}
+	return issues
+}
+
+// checkRuleOID reports an algorithm the OID mapper cannot resolve. Rules
+// whose naming fields interpolate metavariables are skipped.
+func checkRuleOID(asset *entities.CryptographicAsset) (RuleMetadataIssue, bool) {
+	family := strings.TrimSpace(asset.Metadata["algorithmFamily"])
+	if family == "" {
+		return RuleMetadataIssue{}, false
+	}
+	for _, key := range []string{"algorithmName", "algorithmFamily", "algorithmParameterSetIdentifier", "algorithmMode"} {
+		if interpolatesMetavariable(asset.Metadata[key]) {
+			return RuleMetadataIssue{}, false
+		}
+	}
+	if lintOIDMapper().LookupOID(asset) != "" {
+		return RuleMetadataIssue{}, false
+	}
+	message := fmt.Sprintf("no OID resolves for algorithmFamily '%s'", family)
+	if name := strings.TrimSpace(asset.Metadata["algorithmName"]); name != "" {
+		message += fmt.Sprintf(" (algorithmName '%s')", name)
+	}
+	return RuleMetadataIssue{Check: RuleMetadataCheckOID, Message: message, Warning: true}, true
+}
+
+func interpolatesMetavariable(value string) bool {
+	return strings.Contains(value, "$")
+}
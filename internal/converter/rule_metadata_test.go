@@ -0,0 +1,106 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckRuleMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		want     []string // "check" or "check!" for warnings, in order
+		contains string
+	}{
+		{
+			name:     "complete algorithm",
+			metadata: map[string]string{"assetType": "algorithm", "algorithmPrimitive": "ae", "algorithmFamily": "AES", "algorithmName": "AES-256-GCM"},
+		},
+		{
+			name:     "primitive typo",
+			metadata: map[string]string{"assetType": "algorithm", "algorithmPrimitive": "block-chiper", "algorithmFamily": "AES"},
+			want:     []string{RuleMetadataCheckPrimitive},
+			contains: "unknown primitive type: block-chiper",
+		},
+		{
+			name:     "missing family",
+			metadata: map[string]string{"assetType": "algorithm", "algorithmPrimitive": "hash"},
+			want:     []string{RuleMetadataCheckRequired},
+			contains: "'algorithmFamily'",
+		},
+		{
+			name:     "unknown algorithm",
+			metadata: map[string]string{"assetType": "algorithm", "algorithmPrimitive": "hash", "algorithmFamily": "NOTAHASH"},
+			want:     []string{RuleMetadataCheckOID + "!"},
+			contains: "NOTAHASH",
+		},
+		{
+			name:     "interpolated values are not checked",
+			metadata: map[string]string{"assetType": "algorithm", "algorithmPrimitive": "$PRIM", "algorithmFamily": "$ALG"},
+		},
+		{
+			name:     "related material without material type",
+			metadata: map[string]string{"assetType": "related-crypto-material"},
+			want:     []string{RuleMetadataCheckRequired},
+			contains: "'materialType'",
+		},
+		{
+			name:     "protocol without type",
+			metadata: map[string]string{"assetType": "protocol"},
+			want:     []string{RuleMetadataCheckRequired},
+			contains: "'protocolType'",
+		},
+		{
+			name:     "certificate",
+			metadata: map[string]string{"assetType": "certificate"},
+		},
+		{
+			name:     "missing asset type",
+			metadata: map[string]string{"algorithmFamily": "AES"},
+			want:     []string{RuleMetadataCheckRequired},
+		},
+		{
+			name:     "unknown asset type",
+			metadata: map[string]string{"assetType": "algorithim"},
+			want:     []string{RuleMetadataCheckAssetType},
+			contains: "algorithim",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := CheckRuleMetadata(tt.metadata)
+			var got []string
+			var messages []string
+			for _, issue := range issues {
+				check := issue.Check
+				if issue.Warning {
+					check += "!"
+				}
+				got = append(got, check)
+				messages = append(messages, issue.Message)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("checks = %v, want %v (%v)", got, tt.want, messages)
+			}
+			if tt.contains != "" && !strings.Contains(strings.Join(messages, "\n"), tt.contains) {
+				t.Errorf("messages %v do not mention %q", messages, tt.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// DefaultSpecVersion is the CycloneDX version emitted when none is requested.
+const DefaultSpecVersion = cdx.SpecVersion1_6
+
+// scanossCryptoPropertyPrefix prefixes the properties that carry flattened
+// cryptoProperties in CycloneDX 1.5 output, e.g.
+// "scanoss:crypto:algorithmProperties.primitive".
+const scanossCryptoPropertyPrefix = "scanoss:crypto:"
+
+// supportedSpecVersions lists the CycloneDX versions the converter can emit.
+var supportedSpecVersions = []cdx.SpecVersion{cdx.SpecVersion1_5, cdx.SpecVersion1_6, cdx.SpecVersion1_7}
+
+// SupportedSpecVersions returns the CycloneDX versions accepted by
+// ParseSpecVersion, oldest first (e.g. "1.5", "1.6", "1.7").
+func SupportedSpecVersions() []string {
+	versions := make([]string, 0, len(supportedSpecVersions))
+	for _, version := range supportedSpecVersions {
+		versions = append(versions, version.String())
+	}
+	return versions
+}
+
+// ParseSpecVersion parses a CycloneDX version such as "1.6". An empty value
+// selects DefaultSpecVersion.
+func ParseSpecVersion(value string) (cdx.SpecVersion, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if value == "" {
+		return DefaultSpecVersion, nil
+	}
+	for _, version := range supportedSpecVersions {
+		if version.String() == value {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported CycloneDX version %q (supported: %s)", value, strings.Join(SupportedSpecVersions(), ", "))
+}
+
+// isSupportedSpecVersion reports whether the converter can emit the version.
+func isSupportedSpecVersion(version cdx.SpecVersion) bool {
+	for _, supported := range supportedSpecVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
+
+// cryptoProtocolTypes1_7 are the protocol types CycloneDX 1.7 added to the
+// protocolProperties.type enum.
+var cryptoProtocolTypes1_7 = []cdx.CryptoProtocolType{
+	cdx.CryptoProtocolTypeDTLS,
+	cdx.CryptoProtocolTypeQUIC,
+	cdx.CryptoProtocolTypeEAPAKA,
+	cdx.CryptoProtocolTypeEAPAKAPrime,
+	cdx.CryptoProtocolTypePRINS,
+	cdx.CryptoProtocolType5GAKA,
+}
+
+// supportsProtocolType reports whether the protocol type is an enum value of
+// the given CycloneDX version.
+func supportsProtocolType(version cdx.SpecVersion, protocolType cdx.CryptoProtocolType) bool {
+	for _, added := range cryptoProtocolTypes1_7 {
+		if protocolType == added {
+			return version >= cdx.SpecVersion1_7
+		}
+	}
+	return version >= cdx.SpecVersion1_6
+}
+
+// adaptToSpecVersion rewrites a BOM built with every field the converter
+// knows (the CycloneDX 1.7 model) so it only uses what the target version
+// defines. Information without a native field in the target version is kept
+// as SCANOSS properties rather than dropped.
+func adaptToSpecVersion(bom *cdx.BOM, version cdx.SpecVersion) {
+	bom.SpecVersion = version
+	if bom.Components == nil {
+		return
+	}
+	for i := range *bom.Components {
+		component := &(*bom.Components)[i]
+		if version < cdx.SpecVersion1_6 {
+			downgradeEvidence1_5(component.Evidence)
+		}
+		if component.CryptoProperties == nil {
+			continue
+		}
+		if version < cdx.SpecVersion1_7 {
+			downgradeCryptoProperties1_6(component)
+		}
+		if version < cdx.SpecVersion1_6 {
+			flattenCryptoProperties(component)
+		}
+	}
+}
+
+// downgradeCryptoProperties1_6 removes the cryptographic fields CycloneDX 1.7
+// introduced. Protocol types without a 1.6 enum value move to the
+// scanoss:protocolType property; certificate serial numbers are already
+// carried by scanoss:certificateSerialNumber.
+func downgradeCryptoProperties1_6(component *cdx.Component) {
+	props := component.CryptoProperties
+	if algorithm := props.AlgorithmProperties; algorithm != nil {
+		algorithm.AlgorithmFamily = ""
+		algorithm.EllipticCurve = ""
+	}
+	if certificate := props.CertificateProperties; certificate != nil {
+		certificate.SerialNumber = ""
+	}
+	if protocol := props.ProtocolProperties; protocol != nil && protocol.Type != "" &&
+		!supportsProtocolType(cdx.SpecVersion1_6, protocol.Type) {
+		addCustomProperty(component, scanossProtocolTypePropertyName, string(protocol.Type))
+		protocol.Type = ""
+	}
+}
+
+// flattenCryptoProperties is the CycloneDX 1.5 fallback: 1.5 has neither the
+// cryptographic-asset component type nor cryptoProperties, so every crypto
+// property becomes a scanoss:crypto:<path> property and the component is
+// typed as an application, matching how CycloneDX tooling downgrades
+// component types 1.5 does not know.
+func flattenCryptoProperties(component *cdx.Component) {
+	data, err := json.Marshal(component.CryptoProperties)
+	if err != nil {
+		return
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return
+	}
+
+	flattened := make(map[string]string)
+	flattenJSON("", tree, flattened)
+	keys := make([]string, 0, len(flattened))
+	for key := range flattened {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		addCustomProperty(component, scanossCryptoPropertyPrefix+key, flattened[key])
+	}
+
+	component.CryptoProperties = nil
+	component.Type = cdx.ComponentTypeApplication
+}
+
+// downgradeEvidence1_5 rewrites evidence to the CycloneDX 1.5 shape: a single
+// identity object without concludedValue, and occurrences carrying only their
+// location. The identity with the highest confidence is kept.
+func downgradeEvidence1_5(evidence *cdx.Evidence) {
+	if evidence == nil {
+		return
+	}
+	if identity := evidence.Identity; identity != nil && identity.Identities != nil {
+		evidence.Identity = nil
+		var best *cdx.EvidenceIdentity
+		for i, candidate := range *identity.Identities {
+			if best == nil || identityConfidence(candidate) > identityConfidence(*best) {
+				best = &(*identity.Identities)[i]
+			}
+		}
+		if best != nil {
+			kept := *best
+			kept.ConcludedValue = ""
+			evidence.Identity = &cdx.EvidenceIdentityChoice{Identity: &kept}
+		}
+	}
+	if evidence.Occurrences != nil {
+		for i := range *evidence.Occurrences {
+			occurrence := &(*evidence.Occurrences)[i]
+			occurrence.Line = nil
+			occurrence.Offset = nil
+			occurrence.Symbol = ""
+			occurrence.AdditionalContext = ""
+		}
+	}
+}
+
+// identityConfidence returns the identity's confidence, 0 when unset.
+func identityConfidence(identity cdx.EvidenceIdentity) float32 {
+	if identity.Confidence == nil {
+		return 0
+	}
+	return *identity.Confidence
+}
+
+// flattenJSON collects the scalar leaves of a decoded JSON value keyed by
+// their dotted path. Arrays of scalars are joined with commas; arrays of
+// objects are indexed (e.g. "protocolProperties.cipherSuites[0].name").
+func flattenJSON(path string, value any, out map[string]string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, child := range typed {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenJSON(childPath, child, out)
+		}
+	case []any:
+		scalars := make([]string, 0, len(typed))
+		for i, child := range typed {
+			switch child.(type) {
+			case map[string]any, []any:
+				flattenJSON(path+"["+strconv.Itoa(i)+"]", child, out)
+			default:
+				scalars = append(scalars, fmt.Sprint(child))
+			}
+		}
+		if len(scalars) > 0 {
+			out[path] = strings.Join(scalars, ",")
+		}
+	case nil:
+	default:
+		out[path] = fmt.Sprint(typed)
+	}
+}
+
+// algorithmFamilies maps upper-cased family spellings found in rule metadata
+// to the CycloneDX 1.7 algorithmFamily registry name. Families outside the
+// registry are left out of algorithmFamily.
+var algorithmFamilies = map[string]string{
+	"3DES":          "3DES",
+	"DESEDE":        "3DES",
+	"TRIPLEDES":     "3DES",
+	"TDEA":          "3DES",
+	"AES":           "AES",
+	"ARIA":          "ARIA",
+	"BLAKE2":        "BLAKE2",
+	"BLAKE3":        "BLAKE3",
+	"BLOWFISH":      "Blowfish",
+	"CAMELLIA":      "CAMELLIA",
+	"CAST5":         "CAST5",
+	"CHACHA20":      "ChaCha20",
+	"CMAC":          "CMAC",
+	"DES":           "DES",
+	"DSA":           "DSA",
+	"ECDH":          "ECDH",
+	"ECDHE":         "ECDH",
+	"ECDSA":         "ECDSA",
+	"EDDSA":         "EdDSA",
+	"ED25519":       "EdDSA",
+	"ED448":         "EdDSA",
+	"ELGAMAL":       "ElGamal",
+	"DH":            "FFDH",
+	"DHE":           "FFDH",
+	"DIFFIEHELLMAN": "FFDH",
+	"FFDH":          "FFDH",
+	"HKDF":          "HKDF",
+	"HMAC":          "HMAC",
+	"IDEA":          "IDEA",
+	"KMAC":          "KMAC",
+	"MD2":           "MD2",
+	"MD4":           "MD4",
+	"MD5":           "MD5",
+	"ML-DSA":        "ML-DSA",
+	"MLDSA":         "ML-DSA",
+	"DILITHIUM":     "ML-DSA",
+	"ML-KEM":        "ML-KEM",
+	"MLKEM":         "ML-KEM",
+	"KYBER":         "ML-KEM",
+	"PBKDF1":        "PBKDF1",
+	"PBKDF2":        "PBKDF2",
+	"RC2":           "RC2",
+	"RC4":           "RC4",
+	"RC5":           "RC5",
+	"RIPEMD":        "RIPEMD",
+	"SALSA20":       "Salsa20",
+	"SEED":          "SEED",
+	"SERPENT":       "Serpent",
+	"SHA-1":         "SHA-1",
+	"SHA1":          "SHA-1",
+	"SHA-2":         "SHA-2",
+	"SHA2":          "SHA-2",
+	"SHA-3":         "SHA-3",
+	"SHA3":          "SHA-3",
+	"SIPHASH":       "SipHash",
+	"SLH-DSA":       "SLH-DSA",
+	"SPHINCS+":      "SLH-DSA",
+	"SM2":           "SM2",
+	"SM3":           "SM3",
+	"SM4":           "SM4",
+	"TWOFISH":       "Twofish",
+	"WHIRLPOOL":     "Whirlpool",
+	"BCRYPT":        "bcrypt",
+	"SCRYPT":        "scrypt",
+}
+
+// algorithmFamily returns the CycloneDX 1.7 registry family for an algorithm,
+// or "" when it is not in the registry. Generic "SHA" families are resolved
+// from the algorithm name (SHA-256 -> SHA-2, SHA3-256 -> SHA-3).
+func algorithmFamily(family, name string) string {
+	key := strings.ToUpper(strings.TrimSpace(family))
+	if canonical, ok := algorithmFamilies[key]; ok {
+		return canonical
+	}
+	if key != "SHA" {
+		return ""
+	}
+
+	upperName := strings.ToUpper(strings.ReplaceAll(name, "_", "-"))
+	switch {
+	case strings.HasPrefix(upperName, "SHA3") || strings.HasPrefix(upperName, "SHA-3"):
+		return "SHA-3"
+	case upperName == "SHA" || strings.HasPrefix(upperName, "SHA1") || strings.HasPrefix(upperName, "SHA-1"):
+		return "SHA-1"
+	case strings.HasPrefix(upperName, "SHA-2") || strings.HasPrefix(upperName, "SHA2") ||
+		strings.HasPrefix(upperName, "SHA-384") || strings.HasPrefix(upperName, "SHA384") ||
+		strings.HasPrefix(upperName, "SHA-512") || strings.HasPrefix(upperName, "SHA512"):
+		return "SHA-2"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package converter
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestParseSpecVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    cdx.SpecVersion
+		wantErr bool
+	}{
+		{input: "", want: DefaultSpecVersion},
+		{input: "1.5", want: cdx.SpecVersion1_5},
+		{input: "1.6", want: cdx.SpecVersion1_6},
+		{input: "v1.7", want: cdx.SpecVersion1_7},
+		{input: " 1.7 ", want: cdx.SpecVersion1_7},
+		{input: "1.4", wantErr: true},
+		{input: "2.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSpecVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSpecVersion(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseSpecVersion(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_ConvertSpecVersions(t *testing.T) {
+	report := loadFixture(t, "algorithm_aes256_gcm.json")
+
+	t.Run("1.7 sets algorithmFamily", func(t *testing.T) {
+		bom, err := NewConverterForSpecVersion(cdx.SpecVersion1_7).Convert(report)
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if bom.SpecVersion != cdx.SpecVersion1_7 {
+			t.Errorf("SpecVersion = %s, want 1.7", bom.SpecVersion)
+		}
+		props := (*bom.Components)[0].CryptoProperties.AlgorithmProperties
+		if props.AlgorithmFamily != "AES" {
+			t.Errorf("AlgorithmFamily = %q, want AES", props.AlgorithmFamily)
+		}
+	})
+
+	t.Run("1.6 omits algorithmFamily", func(t *testing.T) {
+		bom, err := NewConverter().Convert(report)
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if bom.SpecVersion != cdx.SpecVersion1_6 {
+			t.Errorf("SpecVersion = %s, want 1.6", bom.SpecVersion)
+		}
+		props := (*bom.Components)[0].CryptoProperties.AlgorithmProperties
+		if props.AlgorithmFamily != "" {
+			t.Errorf("AlgorithmFamily = %q, want empty", props.AlgorithmFamily)
+		}
+	})
+
+	t.Run("1.5 flattens cryptoProperties", func(t *testing.T) {
+		bom, err := NewConverterForSpecVersion(cdx.SpecVersion1_5).Convert(report)
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		component := (*bom.Components)[0]
+		if component.Type != cdx.ComponentTypeApplication {
+			t.Errorf("Type = %s, want application", component.Type)
+		}
+		if component.CryptoProperties != nil {
+			t.Error("CryptoProperties should be nil for CycloneDX 1.5")
+		}
+		want := map[string]string{
+			"scanoss:crypto:assetType":                                  "algorithm",
+			"scanoss:crypto:algorithmProperties.primitive":              "ae",
+			"scanoss:crypto:algorithmProperties.parameterSetIdentifier": "256",
+		}
+		got := make(map[string]string)
+		for _, property := range *component.Properties {
+			got[property.Name] = property.Value
+		}
+		for name, value := range want {
+			if got[name] != value {
+				t.Errorf("property %s = %q, want %q", name, got[name], value)
+			}
+		}
+		if _, ok := got["scanoss:crypto:algorithmProperties.algorithmFamily"]; ok {
+			t.Error("1.7 algorithmFamily should not be flattened into 1.5 output")
+		}
+		if component.Evidence == nil || component.Evidence.Identity == nil || component.Evidence.Identity.Identity == nil {
+			t.Fatal("1.5 evidence should carry a single identity object")
+		}
+		if component.Evidence.Identity.Identities != nil {
+			t.Error("1.5 evidence should not carry an identity array")
+		}
+		for _, occurrence := range *component.Evidence.Occurrences {
+			if occurrence.Line != nil || occurrence.AdditionalContext != "" {
+				t.Error("1.5 occurrences should only carry a location")
+			}
+		}
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		if _, err := NewConverterForSpecVersion(cdx.SpecVersion1_4).Convert(report); err == nil {
+			t.Error("Convert() should reject CycloneDX 1.4")
+		}
+	})
+}
+
+func TestAdaptToSpecVersion_ProtocolType(t *testing.T) {
+	newBOM := func() *cdx.BOM {
+		return &cdx.BOM{Components: &[]cdx.Component{{
+			Type:   cdx.ComponentTypeCryptographicAsset,
+			BOMRef: "crypto-asset/quic",
+			Name:   "QUIC",
+			CryptoProperties: &cdx.CryptoProperties{
+				AssetType:          cdx.CryptoAssetTypeProtocol,
+				ProtocolProperties: &cdx.CryptoProtocolProperties{Type: cdx.CryptoProtocolTypeQUIC},
+			},
+		}}}
+	}
+
+	bom := newBOM()
+	adaptToSpecVersion(bom, cdx.SpecVersion1_7)
+	if got := (*bom.Components)[0].CryptoProperties.ProtocolProperties.Type; got != cdx.CryptoProtocolTypeQUIC {
+		t.Errorf("1.7 protocol type = %q, want quic", got)
+	}
+
+	bom = newBOM()
+	adaptToSpecVersion(bom, cdx.SpecVersion1_6)
+	component := (*bom.Components)[0]
+	if got := component.CryptoProperties.ProtocolProperties.Type; got != "" {
+		t.Errorf("1.6 protocol type = %q, want empty", got)
+	}
+	if component.Properties == nil || !hasProperty(&component, scanossProtocolTypePropertyName) {
+		t.Errorf("1.6 should keep the protocol type as %s", scanossProtocolTypePropertyName)
+	}
+}
+
+func TestAlgorithmFamily(t *testing.T) {
+	tests := []struct {
+		family string
+		name   string
+		want   string
+	}{
+		{family: "aes", name: "AES-256-GCM", want: "AES"},
+		{family: "Ed25519", name: "Ed25519", want: "EdDSA"},
+		{family: "SHA", name: "SHA-256", want: "SHA-2"},
+		{family: "SHA", name: "SHA3-512", want: "SHA-3"},
+		{family: "SHA", name: "SHA1", want: "SHA-1"},
+		{family: "CustomCipher", name: "Custom", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := algorithmFamily(tt.family, tt.name); got != tt.want {
+			t.Errorf("algorithmFamily(%q, %q) = %q, want %q", tt.family, tt.name, got, tt.want)
+		}
+	}
+}
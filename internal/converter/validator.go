@@ -21,12 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/rs/zerolog/log"
 )
 
-// Validator validates CycloneDX BOMs against the 1.6 schema.
+// Validator validates CycloneDX BOMs against the schema of their specVersion
+// (1.5, 1.6 or 1.7).
 type Validator struct{}
 
 // NewValidator creates a new BOM validator.
@@ -34,13 +36,14 @@ func NewValidator() *Validator {
 	return &Validator{}
 }
 
-// Validate checks if a BOM conforms to the CycloneDX 1.6 schema.
+// Validate checks if a BOM conforms to the CycloneDX schema named by its
+// specVersion.
 func (v *Validator) Validate(bom *cdx.BOM) error {
 	if bom == nil {
 		return fmt.Errorf("BOM cannot be nil")
 	}
 
-	log.Debug().Msg("Starting BOM validation against CycloneDX 1.6 schema")
+	log.Debug().Str("spec_version", bom.SpecVersion.String()).Msg("Starting BOM validation against CycloneDX schema")
 
 	// Marshal BOM to JSON for validation
 	var buf bytes.Buffer
@@ -59,16 +62,75 @@ func (v *Validator) Validate(bom *cdx.BOM) error {
 	// Validate component requirements
 	if bom.Components != nil {
 		for i := range *bom.Components {
-			if err := v.validateComponent(&(*bom.Components)[i]); err != nil {
+			if err := v.validateComponent(&(*bom.Components)[i], bom.SpecVersion); err != nil {
 				return fmt.Errorf("component validation failed: %w", err)
 			}
 		}
 	}
 
+	if err := v.validateReferences(bom); err != nil {
+		return fmt.Errorf("reference validation failed: %w", err)
+	}
+
 	log.Debug().Msg("BOM validation successful")
 	return nil
 }
 
+// validateReferences checks that every bom-ref used by the dependency graph and
+// by protocol cipher suites points at a component in the BOM or at the
+// metadata component describing the scanned project.
+func (v *Validator) validateReferences(bom *cdx.BOM) error {
+	refs := make(map[string]struct{})
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		refs[bom.Metadata.Component.BOMRef] = struct{}{}
+	}
+	if bom.Components != nil {
+		for _, component := range *bom.Components {
+			if _, dup := refs[component.BOMRef]; dup {
+				return fmt.Errorf("duplicate bom-ref %q", component.BOMRef)
+			}
+			refs[component.BOMRef] = struct{}{}
+		}
+	}
+
+	if bom.Dependencies != nil {
+		for i, dependency := range *bom.Dependencies {
+			if _, ok := refs[dependency.Ref]; !ok {
+				return fmt.Errorf("dependencies[%d].ref %q does not match any component", i, dependency.Ref)
+			}
+			if dependency.Dependencies == nil {
+				continue
+			}
+			for _, ref := range *dependency.Dependencies {
+				if _, ok := refs[ref]; !ok {
+					return fmt.Errorf("dependencies[%d].dependsOn %q does not match any component", i, ref)
+				}
+			}
+		}
+	}
+
+	if bom.Components != nil {
+		for _, component := range *bom.Components {
+			props := component.CryptoProperties
+			if props == nil || props.ProtocolProperties == nil || props.ProtocolProperties.CipherSuites == nil {
+				continue
+			}
+			for _, suite := range *props.ProtocolProperties.CipherSuites {
+				if suite.Algorithms == nil {
+					continue
+				}
+				for _, ref := range *suite.Algorithms {
+					if _, ok := refs[string(ref)]; !ok {
+						return fmt.Errorf("component %q: cipher suite %q algorithm %q does not match any component", component.Name, suite.Name, ref)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // validateStructure checks basic BOM structure requirements.
 func (v *Validator) validateStructure(bom *cdx.BOM) error {
 	// Check BOM format
@@ -77,8 +139,8 @@ func (v *Validator) validateStructure(bom *cdx.BOM) error {
 	}
 
 	// Check spec version
-	if bom.SpecVersion != cdx.SpecVersion1_6 {
-		return fmt.Errorf("specVersion must be 1.6, got '%s'", bom.SpecVersion)
+	if !isSupportedSpecVersion(bom.SpecVersion) {
+		return fmt.Errorf("specVersion must be one of %s, got '%s'", strings.Join(SupportedSpecVersions(), ", "), bom.SpecVersion)
 	}
 
 	// Check serial number format
@@ -94,11 +156,18 @@ func (v *Validator) validateStructure(bom *cdx.BOM) error {
 	return nil
 }
 
-// validateComponent checks component-specific requirements.
-func (v *Validator) validateComponent(component *cdx.Component) error {
+// validateComponent checks component-specific requirements for the given
+// CycloneDX version.
+func (v *Validator) validateComponent(component *cdx.Component, version cdx.SpecVersion) error {
+	// CycloneDX 1.5 has no cryptographic-asset type; assets are flattened into
+	// application components carrying scanoss:crypto:* properties.
+	if version < cdx.SpecVersion1_6 {
+		return v.validateFlattenedComponent(component)
+	}
+
 	// Check component type
-	if component.Type != cdx.ComponentTypeCryptographicAsset {
-		return fmt.Errorf("component type must be 'cryptographic-asset', got '%s'", component.Type)
+	if component.Type != cdx.ComponentTypeCryptographicAsset && component.Type != cdx.ComponentTypeLibrary {
+		return fmt.Errorf("component type must be 'cryptographic-asset' or 'library', got '%s'", component.Type)
 	}
 
 	// Check BOM ref
@@ -111,6 +180,11 @@ func (v *Validator) validateComponent(component *cdx.Component) error {
 		return fmt.Errorf("name is required")
 	}
 
+	// Libraries that contain cryptographic assets carry no crypto properties
+	if component.Type == cdx.ComponentTypeLibrary {
+		return nil
+	}
+
 	// Check crypto properties
 	if component.CryptoProperties == nil {
 		return fmt.Errorf("cryptoProperties is required for cryptographic assets")
@@ -121,6 +195,12 @@ func (v *Validator) validateComponent(component *cdx.Component) error {
 		return fmt.Errorf("cryptoProperties: %w", err)
 	}
 
+	if version < cdx.SpecVersion1_7 {
+		if err := v.validateNoCycloneDX1_7Fields(component.CryptoProperties); err != nil {
+			return fmt.Errorf("cryptoProperties: %w", err)
+		}
+	}
+
 	if err := v.validateEvidence(component.Evidence); err != nil {
 		return err
 	}
@@ -128,6 +208,62 @@ func (v *Validator) validateComponent(component *cdx.Component) error {
 	return nil
 }
 
+// validateFlattenedComponent checks a CycloneDX 1.5 component: libraries as
+// in later versions, and application components holding a flattened
+// cryptographic asset.
+func (v *Validator) validateFlattenedComponent(component *cdx.Component) error {
+	if component.Type != cdx.ComponentTypeApplication && component.Type != cdx.ComponentTypeLibrary {
+		return fmt.Errorf("component type must be 'application' or 'library' in CycloneDX 1.5, got '%s'", component.Type)
+	}
+	if component.BOMRef == "" {
+		return fmt.Errorf("bom-ref is required")
+	}
+	if component.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if component.CryptoProperties != nil {
+		return fmt.Errorf("cryptoProperties is not supported in CycloneDX 1.5")
+	}
+	if component.Type == cdx.ComponentTypeApplication && !hasProperty(component, scanossCryptoPropertyPrefix+"assetType") {
+		return fmt.Errorf("property %sassetType is required for cryptographic assets", scanossCryptoPropertyPrefix)
+	}
+	return nil
+}
+
+// validateNoCycloneDX1_7Fields rejects cryptographic fields that only exist in
+// CycloneDX 1.7.
+func (v *Validator) validateNoCycloneDX1_7Fields(props *cdx.CryptoProperties) error {
+	if algorithm := props.AlgorithmProperties; algorithm != nil {
+		if algorithm.AlgorithmFamily != "" {
+			return fmt.Errorf("algorithmProperties.algorithmFamily requires CycloneDX 1.7")
+		}
+		if algorithm.EllipticCurve != "" {
+			return fmt.Errorf("algorithmProperties.ellipticCurve requires CycloneDX 1.7")
+		}
+	}
+	if certificate := props.CertificateProperties; certificate != nil && certificate.SerialNumber != "" {
+		return fmt.Errorf("certificateProperties.serialNumber requires CycloneDX 1.7")
+	}
+	if protocol := props.ProtocolProperties; protocol != nil && protocol.Type != "" &&
+		!supportsProtocolType(cdx.SpecVersion1_6, protocol.Type) {
+		return fmt.Errorf("protocolProperties.type %q requires CycloneDX 1.7", protocol.Type)
+	}
+	return nil
+}
+
+// hasProperty reports whether the component carries a property with the name.
+func hasProperty(component *cdx.Component, name string) bool {
+	if component.Properties == nil {
+		return false
+	}
+	for _, property := range *component.Properties {
+		if property.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // validateCryptoProperties validates cryptographic properties.
 func (v *Validator) validateCryptoProperties(props *cdx.CryptoProperties) error {
 	// Check asset type
@@ -217,11 +353,11 @@ func (v *Validator) validatePrimitive(primitive cdx.CryptoPrimitive) error {
 
 // validateEvidence validates evidence structures used by generated cryptographic assets.
 func (v *Validator) validateEvidence(evidence *cdx.Evidence) error {
-	if evidence == nil || evidence.Identity == nil {
+	if evidence == nil || evidence.Identity == nil || evidence.Identity.Identities == nil {
 		return nil
 	}
 
-	for i, identity := range *evidence.Identity {
+	for i, identity := range *evidence.Identity.Identities {
 		if identity.Field == "" {
 			return fmt.Errorf("evidence.identity[%d].field is required", i)
 		}
@@ -132,13 +132,33 @@ func TestValidator_ValidateStructure(t *testing.T) {
 			name: "Invalid spec version",
 			bom: &cdx.BOM{
 				BOMFormat:    "CycloneDX",
-				SpecVersion:  cdx.SpecVersion1_5,
+				SpecVersion:  cdx.SpecVersion1_4,
 				SerialNumber: "urn:uuid:test-123",
 				Version:      1,
 			},
 			wantErr:     true,
 			errContains: "specVersion",
 		},
+		{
+			name: "Valid 1.5 structure",
+			bom: &cdx.BOM{
+				BOMFormat:    "CycloneDX",
+				SpecVersion:  cdx.SpecVersion1_5,
+				SerialNumber: "urn:uuid:test-123",
+				Version:      1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Valid 1.7 structure",
+			bom: &cdx.BOM{
+				BOMFormat:    "CycloneDX",
+				SpecVersion:  cdx.SpecVersion1_7,
+				SerialNumber: "urn:uuid:test-123",
+				Version:      1,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Missing serial number",
 			bom: &cdx.BOM{
@@ -217,10 +237,21 @@ func TestValidator_ValidateComponent(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Valid library component",
+			component: &cdx.Component{
+				Type:       cdx.ComponentTypeLibrary,
+				BOMRef:     "library-ref",
+				Name:       "golang.org/x/crypto",
+				Version:    "v0.17.0",
+				PackageURL: "pkg:golang/golang.org/x/crypto@v0.17.0",
+			},
+			wantErr: false,
+		},
 		{
 			name: "Invalid component type",
 			component: &cdx.Component{
-				Type:   cdx.ComponentTypeLibrary,
+				Type:   cdx.ComponentTypeFramework,
 				BOMRef: "test-ref",
 				Name:   "Test",
 			},
@@ -271,7 +302,7 @@ func TestValidator_ValidateComponent(t *testing.T) {
 					},
 				},
 				Evidence: &cdx.Evidence{
-					Identity: &[]cdx.EvidenceIdentity{
+					Identity: &cdx.EvidenceIdentityChoice{Identities: &[]cdx.EvidenceIdentity{
 						{
 							Confidence: func() *float32 {
 								value := float32(1)
@@ -288,7 +319,7 @@ func TestValidator_ValidateComponent(t *testing.T) {
 								},
 							},
 						},
-					},
+					}},
 				},
 			},
 			wantErr:     true,
@@ -298,7 +329,7 @@ func TestValidator_ValidateComponent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validator.validateComponent(tt.component)
+			err := validator.validateComponent(tt.component, cdx.SpecVersion1_6)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validateComponent() error = %v, wantErr %v", err, tt.wantErr)
@@ -314,6 +345,77 @@ func TestValidator_ValidateComponent(t *testing.T) {
 	}
 }
 
+func TestValidator_ValidateComponentSpecVersion(t *testing.T) {
+	validator := NewValidator()
+	algorithm := func(family string) *cdx.Component {
+		return &cdx.Component{
+			Type:   cdx.ComponentTypeCryptographicAsset,
+			BOMRef: "crypto-asset/test",
+			Name:   "AES",
+			CryptoProperties: &cdx.CryptoProperties{
+				AssetType: cdx.CryptoAssetTypeAlgorithm,
+				AlgorithmProperties: &cdx.CryptoAlgorithmProperties{
+					Primitive:       cdx.CryptoPrimitiveBlockCipher,
+					AlgorithmFamily: family,
+				},
+			},
+		}
+	}
+	flattened := &cdx.Component{
+		Type:       cdx.ComponentTypeApplication,
+		BOMRef:     "crypto-asset/test",
+		Name:       "AES",
+		Properties: &[]cdx.Property{{Name: "scanoss:crypto:assetType", Value: "algorithm"}},
+	}
+
+	tests := []struct {
+		name        string
+		component   *cdx.Component
+		version     cdx.SpecVersion
+		wantErr     bool
+		errContains string
+	}{
+		{name: "1.7 accepts algorithmFamily", component: algorithm("AES"), version: cdx.SpecVersion1_7},
+		{name: "1.6 rejects algorithmFamily", component: algorithm("AES"), version: cdx.SpecVersion1_6, wantErr: true, errContains: "algorithmFamily"},
+		{
+			name: "1.6 rejects 1.7 protocol type",
+			component: &cdx.Component{
+				Type:   cdx.ComponentTypeCryptographicAsset,
+				BOMRef: "crypto-asset/quic",
+				Name:   "QUIC",
+				CryptoProperties: &cdx.CryptoProperties{
+					AssetType:          cdx.CryptoAssetTypeProtocol,
+					ProtocolProperties: &cdx.CryptoProtocolProperties{Type: cdx.CryptoProtocolTypeQUIC},
+				},
+			},
+			version:     cdx.SpecVersion1_6,
+			wantErr:     true,
+			errContains: "requires CycloneDX 1.7",
+		},
+		{name: "1.5 accepts flattened asset", component: flattened, version: cdx.SpecVersion1_5},
+		{name: "1.5 rejects cryptoProperties", component: algorithm(""), version: cdx.SpecVersion1_5, wantErr: true, errContains: "component type"},
+		{
+			name:        "1.5 requires flattened assetType",
+			component:   &cdx.Component{Type: cdx.ComponentTypeApplication, BOMRef: "app", Name: "app"},
+			version:     cdx.SpecVersion1_5,
+			wantErr:     true,
+			errContains: "scanoss:crypto:assetType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.validateComponent(tt.component, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateComponent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !contains(err.Error(), tt.errContains) {
+				t.Errorf("Error should contain %q, got %q", tt.errContains, err.Error())
+			}
+		})
+	}
+}
+
 func TestValidator_ValidateCryptoProperties(t *testing.T) {
 	validator := NewValidator()
 
@@ -482,3 +584,95 @@ func TestValidator_ValidatePrimitive(t *testing.T) {
 		}
 	})
 }
+
+func TestValidator_ValidateReferences(t *testing.T) {
+	validator := NewValidator()
+	newBOM := func() *cdx.BOM {
+		suites := []cdx.CipherSuite{{Name: "TLS_AES_128_GCM_SHA256", Algorithms: &[]cdx.BOMReference{"aes"}}}
+		components := []cdx.Component{
+			{
+				Type:   cdx.ComponentTypeCryptographicAsset,
+				BOMRef: "tls",
+				Name:   "tls-1.3",
+				CryptoProperties: &cdx.CryptoProperties{
+					AssetType:          cdx.CryptoAssetTypeProtocol,
+					ProtocolProperties: &cdx.CryptoProtocolProperties{Type: cdx.CryptoProtocolTypeTLS, CipherSuites: &suites},
+				},
+			},
+			{
+				Type:   cdx.ComponentTypeCryptographicAsset,
+				BOMRef: "aes",
+				Name:   "AES-128-GCM",
+				CryptoProperties: &cdx.CryptoProperties{
+					AssetType:           cdx.CryptoAssetTypeAlgorithm,
+					AlgorithmProperties: &cdx.CryptoAlgorithmProperties{Primitive: cdx.CryptoPrimitiveAE},
+				},
+			},
+		}
+		dependencies := []cdx.Dependency{{Ref: "tls", Dependencies: &[]string{"aes"}}}
+		return &cdx.BOM{
+			BOMFormat:    "CycloneDX",
+			SpecVersion:  cdx.SpecVersion1_6,
+			SerialNumber: "urn:uuid:test",
+			Version:      1,
+			Components:   &components,
+			Dependencies: &dependencies,
+		}
+	}
+
+	if err := validator.Validate(newBOM()); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	// The metadata component describing the scanned project is a valid target.
+	withRoot := newBOM()
+	withRoot.Metadata = &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeApplication, BOMRef: "app", Name: "app"}}
+	*withRoot.Dependencies = append(*withRoot.Dependencies, cdx.Dependency{Ref: "app", Dependencies: &[]string{"tls"}})
+	if err := validator.Validate(withRoot); err != nil {
+		t.Fatalf("Validate() with metadata component unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(bom *cdx.BOM)
+		errContains string
+	}{
+		{
+			name:        "dangling dependency ref",
+			mutate:      func(bom *cdx.BOM) { (*bom.Dependencies)[0].Ref = "missing" },
+			errContains: "dependencies[0].ref",
+		},
+		{
+			name:        "dangling dependsOn",
+			mutate:      func(bom *cdx.BOM) { (*bom.Dependencies)[0].Dependencies = &[]string{"missing"} },
+			errContains: "dependencies[0].dependsOn",
+		},
+		{
+			name: "dangling cipher suite algorithm",
+			mutate: func(bom *cdx.BOM) {
+				suites := (*bom.Components)[0].CryptoProperties.ProtocolProperties.CipherSuites
+				(*suites)[0].Algorithms = &[]cdx.BOMReference{"missing"}
+			},
+			errContains: "cipher suite",
+		},
+		{
+			name:        "duplicate bom-ref",
+			mutate:      func(bom *cdx.BOM) { (*bom.Components)[1].BOMRef = "tls" },
+			errContains: "duplicate bom-ref",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bom := newBOM()
+			tt.mutate(bom)
+			err := validator.Validate(bom)
+			if err == nil {
+				t.Fatal("Validate() expected error")
+			}
+			if !contains(err.Error(), tt.errContains) {
+				t.Errorf("Validate() error = %q, want it to contain %q", err, tt.errContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package dependency
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// buildTarget is one rule target from a Bazel or Buck query, with its
+// string and label attributes. Single-valued attributes hold one element;
+// a set Bazel boolean attribute holds "true".
+type buildTarget struct {
+	Label     string
+	RuleClass string
+	Attrs     map[string][]string
+}
+
+// attr returns the first value of a single- or multi-valued attribute.
+func (t buildTarget) attr(name string) string {
+	if values := t.Attrs[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// name returns the target name: the part of the label after ':'.
+func (t buildTarget) name() string {
+	_, name := splitBuildLabel(t.Label)
+	return name
+}
+
+// bazelQueryMessage covers the query output formats: a streamed_jsonproto
+// line is one Target ("type"/"rule"), jsonproto wraps them in "target", and
+// cquery jsonproto in "results"[].target.
+type bazelQueryMessage struct {
+	Type    string              `json:"type"`
+	Rule    *bazelQueryRule     `json:"rule"`
+	Target  []bazelQueryMessage `json:"target"`
+	Results []struct {
+		Target bazelQueryMessage `json:"target"`
+	} `json:"results"`
+}
+
+type bazelQueryRule struct {
+	Name      string `json:"name"`
+	RuleClass string `json:"ruleClass"`
+	Attribute []struct {
+		Name            string   `json:"name"`
+		Type            string   `json:"type"`
+		IntValue        int      `json:"intValue"`
+		BooleanValue    bool     `json:"booleanValue"`
+		StringValue     string   `json:"stringValue"`
+		StringListValue []string `json:"stringListValue"`
+	} `json:"attribute"`
+}
+
+// parseBuildQueryOutput reads the rule targets of `bazel query`/`cquery`
+// JSON proto output (streamed or not) or of `buck2 uquery --output-format
+// json --output-all-attributes`, which maps each label to its attributes.
+// Several outputs may be concatenated.
+func parseBuildQueryOutput(data []byte) ([]buildTarget, error) {
+	var targets []buildTarget
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var raw map[string]json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if isBazelQueryMessage(raw) {
+			var message bazelQueryMessage
+			if err := remarshal(raw, &message); err != nil {
+				return nil, err
+			}
+			targets = appendBazelQueryTargets(targets, message)
+			continue
+		}
+
+		buckTargets, err := parseBuckQueryTargets(raw)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, buckTargets...)
+	}
+	return targets, nil
+}
+
+func isBazelQueryMessage(raw map[string]json.RawMessage) bool {
+	for _, key := range []string{"rule", "type", "target", "results"} {
+		if _, ok := raw[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func remarshal(raw map[string]json.RawMessage, v any) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func appendBazelQueryTargets(targets []buildTarget, message bazelQueryMessage) []buildTarget {
+	if message.Rule != nil {
+		target := buildTarget{
+			Label:     message.Rule.Name,
+			RuleClass: message.Rule.RuleClass,
+			Attrs:     make(map[string][]string),
+		}
+		for _, attr := range message.Rule.Attribute {
+			switch {
+			case len(attr.StringListValue) > 0:
+				target.Attrs[attr.Name] = attr.StringListValue
+			case attr.StringValue != "":
+				target.Attrs[attr.Name] = []string{attr.StringValue}
+			case attr.BooleanValue || (attr.Type == "BOOLEAN" && attr.IntValue != 0):
+				target.Attrs[attr.Name] = []string{"true"}
+			}
+		}
+		targets = append(targets, target)
+	}
+	for _, nested := range message.Target {
+		targets = appendBazelQueryTargets(targets, nested)
+	}
+	for _, result := range message.Results {
+		targets = appendBazelQueryTargets(targets, result.Target)
+	}
+	return targets
+}
+
+// parseBuckQueryTargets converts a buck2 uquery JSON object. The rule type is
+// the "buck.type" attribute; attributes that are neither strings nor string
+// lists are dropped.
+func parseBuckQueryTargets(raw map[string]json.RawMessage) ([]buildTarget, error) {
+	labels := make([]string, 0, len(raw))
+	for label := range raw {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	targets := make([]buildTarget, 0, len(labels))
+	for _, label := range labels {
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(raw[label], &attrs); err != nil {
+			return nil, fmt.Errorf("target %s: %w", label, err)
+		}
+		target := buildTarget{Label: label, Attrs: make(map[string][]string)}
+		for name, value := range attrs {
+			var single string
+			var list []string
+			switch {
+			case json.Unmarshal(value, &single) == nil:
+				if single != "" {
+					target.Attrs[name] = []string{single}
+				}
+			case json.Unmarshal(value, &list) == nil:
+				if len(list) > 0 {
+					target.Attrs[name] = list
+				}
+			}
+		}
+		ruleType := target.attr("buck.type")
+		if index := strings.LastIndex(ruleType, ":"); index >= 0 {
+			ruleType = ruleType[index+1:]
+		}
+		target.RuleClass = ruleType
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// splitBuildLabel splits "[@repo|cell]//pkg:name" into the repository (or
+// cell) part with its package, and the target name. A label without ':'
+// names the target after its last package component.
+func splitBuildLabel(label string) (string, string) {
+	offset := 0
+	if slashes := strings.Index(label, "//"); slashes >= 0 {
+		offset = slashes + 2
+	}
+	if index := strings.Index(label[offset:], ":"); index >= 0 {
+		return label[:offset+index], label[offset+index+1:]
+	}
+	return label, label[strings.LastIndex(label, "/")+1:]
+}
+
+// buildLabelResolver maps labels of the build graph to files: labels of
+// external Bazel repositories live under <outputBase>/external, main
+// repository and Buck cell labels under targetDir.
+type buildLabelResolver struct {
+	targetDir  string
+	outputBase string
+}
+
+// path returns the file a source label (or a path relative to the package
+// of owner) refers to, or "" when it is not on disk.
+func (l buildLabelResolver) path(owner buildTarget, label string) string {
+	if label == "" {
+		return ""
+	}
+	if !strings.Contains(label, "//") {
+		ownerPkg, _ := splitBuildLabel(owner.Label)
+		_, pkg, _ := strings.Cut(ownerPkg, "//")
+		return existingPath(filepath.Join(l.targetDir, filepath.FromSlash(pkg), filepath.FromSlash(label)))
+	}
+
+	repoPkg, name := splitBuildLabel(label)
+	repo, pkg, _ := strings.Cut(repoPkg, "//")
+	base := l.targetDir
+	if strings.HasPrefix(repo, "@") {
+		base = bazelRepoDir(l.outputBase, strings.TrimLeft(repo, "@"))
+		if base == "" {
+			return ""
+		}
+	}
+	if !strings.Contains(label, ":") {
+		// A Buck source path ("cell//dir/file.jar") or "@repo//pkg".
+		if path := existingPath(filepath.Join(base, filepath.FromSlash(pkg))); path != "" && !isDir(path) {
+			return path
+		}
+	}
+	return existingPath(filepath.Join(base, filepath.FromSlash(pkg), filepath.FromSlash(name)))
+}
+
+// archivePath returns the archive a label refers to. rules_jvm_external
+// pins artifacts in http_file repositories ("@repo//file"), whose file sits
+// below the package under its download path.
+func (l buildLabelResolver) archivePath(owner buildTarget, label, suffix string) string {
+	path := l.path(owner, label)
+	if path == "" || !isDir(path) && !strings.HasSuffix(path, suffix) {
+		repoPkg, _ := splitBuildLabel(label)
+		repo, pkg, _ := strings.Cut(repoPkg, "//")
+		if !strings.HasPrefix(repo, "@") {
+			return path
+		}
+		repoDir := bazelRepoDir(l.outputBase, strings.TrimLeft(repo, "@"))
+		if repoDir == "" {
+			return ""
+		}
+		path = filepath.Join(repoDir, filepath.FromSlash(pkg))
+	}
+	if isDir(path) {
+		return findFileWithSuffix(path, suffix)
+	}
+	return path
+}
+
+// bazelRepoDir returns the directory of an external repository under
+// outputBase: external/<name>, or the Bzlmod canonical directory whose name
+// ends in "~<name>" or "+<name>".
+func bazelRepoDir(outputBase, name string) string {
+	if outputBase == "" || name == "" {
+		return ""
+	}
+	external := filepath.Join(outputBase, "external")
+	if dir := filepath.Join(external, name); isDir(dir) {
+		return dir
+	}
+	entries, err := os.ReadDir(external)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if entry.IsDir() && (strings.HasSuffix(entryName, "~"+name) || strings.HasSuffix(entryName, "+"+name)) {
+			return filepath.Join(external, entryName)
+		}
+	}
+	return ""
+}
+
+func existingPath(path string) string {
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// findFileWithSuffix returns the first file below dir, in lexical order,
+// whose name ends in suffix.
+func findFileWithSuffix(dir, suffix string) string {
+	found := ""
+	_ = filepath.WalkDir(dir, func(path string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil || found != "" {
+			return nil
+		}
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), suffix) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
@@ -0,0 +1,363 @@
+package dependency
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+const (
+	bazelTool = "bazel"
+	buckTool  = "buck2"
+)
+
+// bazelWorkspaceFiles mark the root of a Bazel workspace (Bzlmod or legacy).
+var bazelWorkspaceFiles = []string{"MODULE.bazel", "WORKSPACE.bazel", "WORKSPACE"}
+
+// bazelQueries lists, per ecosystem, the `bazel query` expressions whose
+// targets describe the external repositories of that ecosystem. %s in a
+// Java expression is replaced by the union of the Maven repositories.
+var bazelQueries = map[string][]string{
+	ecosystemGo:     {`kind("go_repository", //external:*)`},
+	ecosystemPython: {`kind("whl_library", //external:*)`},
+	ecosystemJava:   {`kind("jvm_import|aar_import|java_import", %s)`},
+}
+
+// buckQueries lists, per ecosystem, the `buck2 uquery` expressions whose
+// targets describe the project's vendored third-party artifacts.
+var buckQueries = map[string][]string{
+	ecosystemJava:   {`kind("prebuilt_jar", //...)`},
+	ecosystemPython: {`kind("prebuilt_python_library", //...)`},
+}
+
+// BazelResolveOptions configures where BazelResolver reads the build graph
+// from.
+type BazelResolveOptions struct {
+	// QueryFile is a pre-exported `bazel query --output=streamed_jsonproto`
+	// (or jsonproto / cquery jsonproto, or `buck2 uquery --output-format json
+	// --output-all-attributes`) result to read instead of running the tool.
+	QueryFile string
+	// OutputBase is the Bazel output base holding external/<repo>; empty
+	// asks `bazel info output_base`.
+	OutputBase string
+}
+
+// BazelResolveConfigurer configures how a resolver reads a Bazel or Buck
+// build graph.
+type BazelResolveConfigurer interface {
+	SetBazelResolveOptions(opts BazelResolveOptions)
+}
+
+// BazelResolver resolves the Go, Java and Python dependencies of a Bazel
+// (or Buck2) workspace from its build graph: rules_go go_repository,
+// rules_jvm_external jvm_import and rules_python whl_library repositories,
+// and Buck prebuilt_jar / prebuilt_python_library targets.
+type BazelResolver struct {
+	ecosystem string
+	options   BazelResolveOptions
+}
+
+// NewBazelResolver creates a Bazel/Buck resolver for the given ecosystem
+// ("go", "java" or "python").
+func NewBazelResolver(ecosystem string, opts BazelResolveOptions) *BazelResolver {
+	return &BazelResolver{ecosystem: ecosystem, options: opts}
+}
+
+// SetBazelResolveOptions configures the query file and output base.
+func (r *BazelResolver) SetBazelResolveOptions(opts BazelResolveOptions) {
+	r.options = opts
+}
+
+// Ecosystem returns the ecosystem whose dependencies the resolver reports.
+func (r *BazelResolver) Ecosystem() string {
+	return r.ecosystem
+}
+
+// IsBazelWorkspace reports whether targetDir is the root of a Bazel workspace.
+func IsBazelWorkspace(targetDir string) bool {
+	for _, name := range bazelWorkspaceFiles {
+		if fileExists(filepath.Join(targetDir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBuckProject reports whether targetDir is the root of a Buck2 project.
+func IsBuckProject(targetDir string) bool {
+	return fileExists(filepath.Join(targetDir, ".buckconfig"))
+}
+
+// UseBazelResolver reports whether the dependencies of ecosystem at
+// targetDir should come from the Bazel/Buck build graph: always when a query
+// file is given, otherwise for Bazel and Buck workspaces that have no native
+// manifest (go.mod, a Maven/Gradle build, Python project files) at the root.
+func UseBazelResolver(targetDir, ecosystem string, opts BazelResolveOptions) bool {
+	if _, ok := bazelQueries[ecosystem]; !ok {
+		return false
+	}
+	if opts.QueryFile != "" {
+		return true
+	}
+	if !IsBazelWorkspace(targetDir) && !IsBuckProject(targetDir) {
+		return false
+	}
+	switch ecosystem {
+	case ecosystemGo:
+		return !fileExists(filepath.Join(targetDir, "go.mod"))
+	case ecosystemJava:
+		return !HasJavaManifest(targetDir)
+	default:
+		for _, manifest := range []string{"pyproject.toml", "requirements.txt", "Pipfile", "setup.py"} {
+			if fileExists(filepath.Join(targetDir, manifest)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Resolve reads the build graph (the query file, or `bazel query` /
+// `buck2 uquery` run in targetDir) and maps the external repositories of
+// the resolver's ecosystem to dependencies and sources.
+func (r *BazelResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	tool := bazelTool
+	if !IsBazelWorkspace(targetDir) && IsBuckProject(targetDir) {
+		tool = buckTool
+	}
+
+	targets, err := r.loadTargets(ctx, targetDir, tool)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := buildLabelResolver{targetDir: targetDir}
+	if tool == bazelTool {
+		labels.outputBase = r.outputBase(ctx, targetDir)
+	}
+
+	result := &ResolveResult{
+		RootModule:     bazelRootModule(targetDir, r.ecosystem),
+		Graph:          make(map[string][]string),
+		VersionedGraph: make(map[string][]Ref),
+	}
+	switch r.ecosystem {
+	case ecosystemGo:
+		addBazelGoRepositories(result, targets, labels)
+	case ecosystemJava:
+		addBazelMavenArtifacts(result, targets, labels)
+	case ecosystemPython:
+		addBazelPythonPackages(result, targets, labels)
+	}
+
+	log.Info().
+		Int("count", len(result.Dependencies)).
+		Str("root", result.RootModule).
+		Str("tool", tool).
+		Str("output_base", labels.outputBase).
+		Msg("Resolved Bazel dependencies")
+
+	return result, nil
+}
+
+// loadTargets returns the rule targets of the query file, or of the
+// ecosystem's queries run with tool.
+func (r *BazelResolver) loadTargets(ctx context.Context, targetDir, tool string) ([]buildTarget, error) {
+	if r.options.QueryFile != "" {
+		data, err := os.ReadFile(r.options.QueryFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Bazel query file %s: %w", r.options.QueryFile, err)
+		}
+		targets, err := parseBuildQueryOutput(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Bazel query file %s: %w", r.options.QueryFile, err)
+		}
+		return targets, nil
+	}
+
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, failure.New(
+			failure.CodeDependencyBuildToolUnknown,
+			failure.StageDependency,
+			fmt.Sprintf("%s not found in PATH; export the build graph with --dep-bazel-query to resolve offline", tool),
+			failure.WithDetail("target_dir", targetDir),
+			failure.WithDetail("tool", tool),
+		)
+	}
+
+	queries := buckQueries[r.ecosystem]
+	if tool == bazelTool {
+		queries = bazelQueries[r.ecosystem]
+	}
+
+	var targets []buildTarget
+	for _, expr := range queries {
+		if r.ecosystem == ecosystemJava && tool == bazelTool {
+			expr = fmt.Sprintf(expr, r.mavenRepositories(ctx, targetDir))
+		}
+		args := []string{"query", "--output=streamed_jsonproto", expr}
+		if tool == buckTool {
+			args = []string{"uquery", "--output-format", "json", "--output-all-attributes", expr}
+		}
+		out, err := runBuildTool(ctx, targetDir, tool, args...)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseBuildQueryOutput(out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s query output: %w", tool, err)
+		}
+		targets = append(targets, parsed...)
+	}
+	return targets, nil
+}
+
+// mavenRepositories returns the query pattern of every rules_jvm_external
+// repository (maven_install names), defaulting to @maven. Under Bzlmod
+// //external is not queryable and the default is used.
+func (r *BazelResolver) mavenRepositories(ctx context.Context, targetDir string) string {
+	names := []string{"maven"}
+	out, err := runBuildTool(ctx, targetDir, bazelTool,
+		"query", "--output=streamed_jsonproto", `kind("coursier_fetch|pinned_coursier_fetch", //external:*)`)
+	if err == nil {
+		if targets, parseErr := parseBuildQueryOutput(out); parseErr == nil && len(targets) > 0 {
+			names = names[:0]
+			for _, target := range targets {
+				names = appendUniqueStrings(names, target.name())
+			}
+		}
+	}
+	patterns := make([]string, 0, len(names))
+	for _, name := range names {
+		patterns = append(patterns, "@"+name+"//:*")
+	}
+	return strings.Join(patterns, " + ")
+}
+
+// outputBase returns the configured output base, or asks Bazel for it.
+// Without one, dependencies are reported without sources.
+func (r *BazelResolver) outputBase(ctx context.Context, targetDir string) string {
+	if r.options.OutputBase != "" {
+		return r.options.OutputBase
+	}
+	if _, err := exec.LookPath(bazelTool); err != nil {
+		log.Debug().Msg("bazel not found in PATH, dependency sources under the output base are unavailable")
+		return ""
+	}
+	out, err := runBuildTool(ctx, targetDir, bazelTool, "info", "output_base")
+	if err != nil {
+		log.Debug().Err(err).Msg("bazel info output_base failed")
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func runBuildTool(ctx context.Context, dir, tool string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, tool, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w: %s", tool, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+var (
+	bazelModuleNamePattern    = regexp.MustCompile(`(?s)\bmodule\s*\([^)]*?\bname\s*=\s*["']([^"']+)["']`)
+	bazelWorkspaceNamePattern = regexp.MustCompile(`(?s)\bworkspace\s*\([^)]*?\bname\s*=\s*["']([^"']+)["']`)
+	gazellePrefixPattern      = regexp.MustCompile(`(?m)^#\s*gazelle:prefix\s+(\S+)`)
+	javaPackagePattern        = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)\s*;`)
+)
+
+// bazelRootModule names the workspace the way the ecosystem's call graph
+// names user code: the gazelle prefix for Go, the common Java package for
+// Java, otherwise the MODULE.bazel / WORKSPACE name or the directory name.
+func bazelRootModule(targetDir, ecosystem string) string {
+	switch ecosystem {
+	case ecosystemGo:
+		for _, name := range []string{"BUILD.bazel", "BUILD"} {
+			if data, err := os.ReadFile(filepath.Join(targetDir, name)); err == nil {
+				if match := gazellePrefixPattern.FindSubmatch(data); match != nil {
+					return string(match[1])
+				}
+			}
+		}
+	case ecosystemJava:
+		if pkg := commonJavaPackage(targetDir); pkg != "" {
+			return pkg
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(targetDir, "MODULE.bazel")); err == nil {
+		if match := bazelModuleNamePattern.FindSubmatch(data); match != nil {
+			return string(match[1])
+		}
+	}
+	for _, name := range []string{"WORKSPACE.bazel", "WORKSPACE"} {
+		if data, err := os.ReadFile(filepath.Join(targetDir, name)); err == nil {
+			if match := bazelWorkspaceNamePattern.FindSubmatch(data); match != nil {
+				return string(match[1])
+			}
+		}
+	}
+	return filepath.Base(targetDir)
+}
+
+// commonJavaPackage returns the longest package prefix shared by the Java
+// sources of the workspace, skipping hidden directories and the bazel-*
+// convenience symlinks.
+func commonJavaPackage(targetDir string) string {
+	var common []string
+	found := false
+	_ = filepath.WalkDir(targetDir, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if filePath != targetDir && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "bazel-") ||
+				name == "buck-out" || name == "third-party" || name == "third_party") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(entry.Name(), ".java") {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil
+		}
+		match := javaPackagePattern.FindSubmatch(data)
+		if match == nil {
+			return nil
+		}
+		parts := strings.Split(string(match[1]), ".")
+		if !found {
+			common, found = parts, true
+			return nil
+		}
+		n := 0
+		for n < len(common) && n < len(parts) && common[n] == parts[n] {
+			n++
+		}
+		common = common[:n]
+		if n == 0 {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return strings.Join(common, ".")
+}
@@ -0,0 +1,336 @@
+package dependency
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const guavaJarPath = "v1/https/repo1.maven.org/maven2/com/google/guava/guava/31.1-jre/guava-31.1-jre.jar"
+
+func TestParseBuildQueryOutput(t *testing.T) {
+	data := `{"type":"RULE","rule":{"name":"//external:org_golang_x_crypto","ruleClass":"go_repository","attribute":[{"name":"importpath","type":"STRING","stringValue":"golang.org/x/crypto"},{"name":"build_tags","type":"STRING_LIST"}]}}
+{"type":"SOURCE_FILE","sourceFile":{"name":"//:BUILD.bazel"}}
+{"target":[{"type":"RULE","rule":{"name":"@maven//:junit_junit","ruleClass":"jvm_import","attribute":[{"name":"tags","type":"STRING_LIST","stringListValue":["maven_coordinates=junit:junit:4.13.2"]}]}}]}
+{"results":[{"target":{"type":"RULE","rule":{"name":"@maven//:org_hamcrest_hamcrest_core","ruleClass":"jvm_import"}},"configuration":{"checksum":"abc"}}]}
+{"root//third-party/java:guava":{"buck.type":"prelude//rules.bzl:prebuilt_jar","maven_coords":"com.google.guava:guava:jar:31.1-jre","deps":["root//third-party/java:failureaccess"],"visibility":["PUBLIC"],"licenses":[],"neverlink":false}}
+`
+	targets, err := parseBuildQueryOutput([]byte(data))
+	if err != nil {
+		t.Fatalf("parseBuildQueryOutput: %v", err)
+	}
+
+	want := []buildTarget{
+		{Label: "//external:org_golang_x_crypto", RuleClass: "go_repository", Attrs: map[string][]string{"importpath": {"golang.org/x/crypto"}}},
+		{Label: "@maven//:junit_junit", RuleClass: "jvm_import", Attrs: map[string][]string{"tags": {"maven_coordinates=junit:junit:4.13.2"}}},
+		{Label: "@maven//:org_hamcrest_hamcrest_core", RuleClass: "jvm_import", Attrs: map[string][]string{}},
+		{Label: "root//third-party/java:guava", RuleClass: "prebuilt_jar", Attrs: map[string][]string{
+			"buck.type":    {"prelude//rules.bzl:prebuilt_jar"},
+			"maven_coords": {"com.google.guava:guava:jar:31.1-jre"},
+			"deps":         {"root//third-party/java:failureaccess"},
+			"visibility":   {"PUBLIC"},
+		}},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("targets = %+v\nwant %+v", targets, want)
+	}
+
+	if _, err := parseBuildQueryOutput([]byte("not json")); err == nil {
+		t.Fatal("parseBuildQueryOutput(invalid) error = nil, want error")
+	}
+}
+
+func TestSplitBuildLabel(t *testing.T) {
+	tests := []struct {
+		label, repoPkg, name string
+	}{
+		{"@maven//:" + guavaJarPath, "@maven//", guavaJarPath},
+		{"//external:pip_requests", "//external", "pip_requests"},
+		{"@com_google_guava_guava_31_1_jre//file", "@com_google_guava_guava_31_1_jre//file", "file"},
+		{"root//third-party/java:guava", "root//third-party/java", "guava"},
+	}
+	for _, tt := range tests {
+		repoPkg, name := splitBuildLabel(tt.label)
+		if repoPkg != tt.repoPkg || name != tt.name {
+			t.Errorf("splitBuildLabel(%q) = (%q, %q), want (%q, %q)", tt.label, repoPkg, name, tt.repoPkg, tt.name)
+		}
+	}
+}
+
+func TestBazelResolver_ResolveJavaQueryFile(t *testing.T) {
+	setTestHome(t, t.TempDir())
+
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"MODULE.bazel": `module(name = "mono", version = "1.0")` + "\n",
+		"app/src/main/java/com/corp/app/App.java": "package com.corp.app;\n\nclass App {}\n",
+		"lib/src/main/java/com/corp/lib/Lib.java": "// Copyright\npackage com.corp.lib;\n",
+		"bazel-out/Gen.java":                      "package generated;\n",
+	})
+
+	outputBase := t.TempDir()
+	maven := filepath.Join(outputBase, "external", "rules_jvm_external~~maven~maven")
+	writeProjectFiles(t, maven, map[string]string{guavaJarPath: "jar"})
+	sourcesJar := filepath.Join(maven, "v1", "guava-31.1-jre-sources.jar")
+	createZipArchive(t, sourcesJar, map[string]string{"com/google/common/base/Strings.java": "package com.google.common.base;\n"})
+	pinned := filepath.Join(outputBase, "external", "rules_jvm_external~~maven~com_google_guava_failureaccess_1_0_1")
+	writeProjectFiles(t, pinned, map[string]string{
+		"file/BUILD.bazel": "",
+		"file/v1/https/repo1.maven.org/maven2/com/google/guava/failureaccess/1.0.1/failureaccess-1.0.1.jar": "jar",
+	})
+
+	queryFile := filepath.Join(t.TempDir(), "query.json")
+	writeProjectFiles(t, filepath.Dir(queryFile), map[string]string{"query.json": `{"type":"RULE","rule":{"name":"@maven//:com_google_guava_guava","ruleClass":"jvm_import","attribute":[` +
+		`{"name":"tags","stringListValue":["maven_coordinates=com.google.guava:guava:31.1-jre"]},` +
+		`{"name":"jars","stringListValue":["@maven//:` + guavaJarPath + `"]},` +
+		`{"name":"srcjar","stringValue":"@maven//:v1/guava-31.1-jre-sources.jar"},` +
+		`{"name":"testonly","type":"BOOLEAN","intValue":0,"booleanValue":false},` +
+		`{"name":"deps","stringListValue":["@maven//:com_google_guava_failureaccess"]}]}}
+{"type":"RULE","rule":{"name":"@maven//:com_google_guava_failureaccess","ruleClass":"jvm_import","attribute":[` +
+		`{"name":"tags","stringListValue":["maven_coordinates=com.google.guava:failureaccess:1.0.1"]},` +
+		`{"name":"jars","stringListValue":["@com_google_guava_failureaccess_1_0_1//file"]},` +
+		`{"name":"testonly","type":"BOOLEAN","intValue":1}]}}
+{"type":"RULE","rule":{"name":"//lib:local","ruleClass":"java_import","attribute":[{"name":"jars","stringListValue":["//lib:local.jar"]}]}}
+`})
+
+	resolver := NewBazelResolver(ecosystemJava, BazelResolveOptions{QueryFile: queryFile, OutputBase: outputBase})
+	result, err := resolver.Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "com.corp" {
+		t.Fatalf("RootModule = %q, want com.corp", result.RootModule)
+	}
+	if len(result.Dependencies) != 2 {
+		t.Fatalf("Dependencies = %+v, want guava and failureaccess", result.Dependencies)
+	}
+	guava := result.Dependencies[0]
+	if guava.Module != "com.google.guava:guava" || guava.Version != "31.1-jre" {
+		t.Fatalf("Dependencies[0] = %+v, want com.google.guava:guava 31.1-jre", guava)
+	}
+	if guava.CompiledArtifactPath != filepath.Join(maven, guavaJarPath) || guava.SourceArchivePath != sourcesJar {
+		t.Fatalf("guava artifacts = (%q, %q)", guava.CompiledArtifactPath, guava.SourceArchivePath)
+	}
+	if !fileExists(filepath.Join(guava.Dir, "com", "google", "common", "base", "Strings.java")) {
+		t.Fatalf("guava Dir %q does not hold the extracted sources", guava.Dir)
+	}
+	failureAccess := result.Dependencies[1]
+	wantJar := filepath.Join(pinned, "file", "v1", "https", "repo1.maven.org", "maven2", "com", "google", "guava", "failureaccess", "1.0.1", "failureaccess-1.0.1.jar")
+	if failureAccess.CompiledArtifactPath != wantJar || failureAccess.Dir != "" {
+		t.Fatalf("failureaccess = %+v, want jar %s and no sources", failureAccess, wantJar)
+	}
+	if guava.Scope != ScopeCompile || failureAccess.Scope != ScopeTest {
+		t.Fatalf("scopes = %q, %q, want compile and test (testonly)", guava.Scope, failureAccess.Scope)
+	}
+
+	wantGraph := map[string][]Ref{
+		"com.corp":                        {{Module: "com.google.guava:guava", Version: "31.1-jre"}},
+		"com.google.guava:guava@31.1-jre": {{Module: "com.google.guava:failureaccess", Version: "1.0.1"}},
+	}
+	if !reflect.DeepEqual(result.VersionedGraph, wantGraph) {
+		t.Fatalf("VersionedGraph = %+v, want %+v", result.VersionedGraph, wantGraph)
+	}
+}
+
+func TestBazelResolver_ResolveGoWithBazel(t *testing.T) {
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"WORKSPACE":   `workspace(name = "mono")` + "\n",
+		"BUILD.bazel": "# gazelle:prefix github.com/corp/mono\n",
+	})
+	outputBase := t.TempDir()
+	writeProjectFiles(t, outputBase, map[string]string{"external/org_golang_x_crypto/go.mod": "module golang.org/x/crypto\n"})
+
+	tmpBin := t.TempDir()
+	writeExecutable(t, tmpBin, "bazel", `#!/bin/sh
+case "$1" in
+info)
+  echo "`+outputBase+`"
+  ;;
+query)
+  [ "$3" = 'kind("go_repository", //external:*)' ] || exit 2
+  cat <<'JSON'
+{"type":"RULE","rule":{"name":"//external:org_golang_x_crypto","ruleClass":"go_repository","attribute":[{"name":"name","stringValue":"org_golang_x_crypto"},{"name":"importpath","stringValue":"golang.org/x/crypto"},{"name":"version","stringValue":"v0.17.0"}]}}
+{"type":"RULE","rule":{"name":"//external:com_github_pkg_errors","ruleClass":"go_repository","attribute":[{"name":"importpath","stringValue":"github.com/pkg/errors"},{"name":"commit","stringValue":"614d223910a1"}]}}
+JSON
+  ;;
+*)
+  exit 1
+  ;;
+esac
+`)
+	prependPath(t, tmpBin)
+
+	result, err := NewBazelResolver(ecosystemGo, BazelResolveOptions{}).Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "github.com/corp/mono" {
+		t.Fatalf("RootModule = %q, want github.com/corp/mono", result.RootModule)
+	}
+	want := []Dependency{
+		{Module: "golang.org/x/crypto", Version: "v0.17.0", Dir: filepath.Join(outputBase, "external", "org_golang_x_crypto")},
+		{Module: "github.com/pkg/errors", Version: "614d223910a1"},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+	wantGraph := map[string][]Ref{"github.com/corp/mono": {
+		{Module: "golang.org/x/crypto", Version: "v0.17.0"},
+		{Module: "github.com/pkg/errors", Version: "614d223910a1"},
+	}}
+	if !reflect.DeepEqual(result.VersionedGraph, wantGraph) {
+		t.Fatalf("VersionedGraph = %+v, want %+v", result.VersionedGraph, wantGraph)
+	}
+}
+
+func TestBazelResolver_ResolvePythonQueryFile(t *testing.T) {
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{"MODULE.bazel": "module(\n    name = \"mono\",\n)\n"})
+	outputBase := t.TempDir()
+	sitePackages := filepath.Join(outputBase, "external", "rules_python++pip+pypi_311_requests", "site-packages")
+	writeProjectFiles(t, sitePackages, map[string]string{"requests/__init__.py": ""})
+
+	queryFile := filepath.Join(t.TempDir(), "query.json")
+	writeProjectFiles(t, filepath.Dir(queryFile), map[string]string{"query.json": `{"target":[
+{"type":"RULE","rule":{"name":"//external:pypi_311_requests","ruleClass":"whl_library","attribute":[{"name":"requirement","stringValue":"requests==2.31.0 --hash=sha256:abc"}]}},
+{"type":"RULE","rule":{"name":"//external:pypi_311_idna","ruleClass":"whl_library","attribute":[{"name":"requirement","stringValue":"idna==3.6"}]}},
+{"type":"RULE","rule":{"name":"//external:pypi_311_loose","ruleClass":"whl_library","attribute":[{"name":"requirement","stringValue":"loose>=1.0"}]}}
+]}`})
+
+	result, err := NewBazelResolver(ecosystemPython, BazelResolveOptions{QueryFile: queryFile, OutputBase: outputBase}).
+		Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "mono" {
+		t.Fatalf("RootModule = %q, want mono", result.RootModule)
+	}
+	want := []Dependency{
+		{Module: "requests", Version: "2.31.0", Dir: sitePackages},
+		{Module: "idna", Version: "3.6"},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+	if got := len(result.VersionedGraph["mono"]); got != 2 {
+		t.Fatalf("VersionedGraph[mono] has %d edges, want 2", got)
+	}
+}
+
+func TestBazelResolver_ResolveBuck(t *testing.T) {
+	setTestHome(t, t.TempDir())
+
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		".buckconfig":                            "[cells]\nroot = .\n",
+		"third-party/java/guava-31.1-jre.jar":    "jar",
+		"third-party/java/failureaccess-1.0.jar": "jar",
+		"third-party/python/TARGETS":             "",
+	})
+	createZipArchive(t, filepath.Join(target, "third-party", "java", "guava-31.1-jre-sources.jar"),
+		map[string]string{"com/google/common/base/Strings.java": "package com.google.common.base;\n"})
+	createZipArchive(t, filepath.Join(target, "third-party", "python", "six-1.16.0-py2.py3-none-any.whl"),
+		map[string]string{"six.py": "", "six-1.16.0.dist-info/METADATA": ""})
+
+	tmpBin := t.TempDir()
+	writeExecutable(t, tmpBin, "buck2", `#!/bin/sh
+[ "$1" = "uquery" ] || exit 1
+cat <<'JSON'
+{
+  "root//third-party/java:guava": {"buck.type": "prebuilt_jar", "maven_coords": "com.google.guava:guava:jar:31.1-jre",
+    "binary_jar": "guava-31.1-jre.jar", "source_jar": "root//third-party/java/guava-31.1-jre-sources.jar",
+    "deps": ["root//third-party/java:failureaccess"]},
+  "root//third-party/java:failureaccess": {"buck.type": "prebuilt_jar", "maven_coords": "com.google.guava:failureaccess:1.0",
+    "binary_jar": "failureaccess-1.0.jar"},
+  "root//third-party/python:six": {"buck.type": "prebuilt_python_library", "binary_src": "six-1.16.0-py2.py3-none-any.whl"}
+}
+JSON
+`)
+	prependPath(t, tmpBin)
+
+	result, err := NewBazelResolver(ecosystemJava, BazelResolveOptions{}).Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve java: %v", err)
+	}
+	if len(result.Dependencies) != 2 {
+		t.Fatalf("java Dependencies = %+v, want 2", result.Dependencies)
+	}
+	failureAccess, guava := result.Dependencies[0], result.Dependencies[1]
+	if failureAccess.CompiledArtifactPath != filepath.Join(target, "third-party", "java", "failureaccess-1.0.jar") {
+		t.Fatalf("failureaccess = %+v", failureAccess)
+	}
+	if !fileExists(filepath.Join(guava.Dir, "com", "google", "common", "base", "Strings.java")) {
+		t.Fatalf("guava = %+v, want extracted sources", guava)
+	}
+	wantGraph := map[string][]Ref{
+		filepath.Base(target):             {{Module: "com.google.guava:guava", Version: "31.1-jre"}},
+		"com.google.guava:guava@31.1-jre": {{Module: "com.google.guava:failureaccess", Version: "1.0"}},
+	}
+	if !reflect.DeepEqual(result.VersionedGraph, wantGraph) {
+		t.Fatalf("java VersionedGraph = %+v, want %+v", result.VersionedGraph, wantGraph)
+	}
+
+	result, err = NewBazelResolver(ecosystemPython, BazelResolveOptions{}).Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve python: %v", err)
+	}
+	if len(result.Dependencies) != 1 || result.Dependencies[0].Module != "six" || result.Dependencies[0].Version != "1.16.0" {
+		t.Fatalf("python Dependencies = %+v, want six 1.16.0", result.Dependencies)
+	}
+	if !fileExists(filepath.Join(result.Dependencies[0].Dir, "six.py")) {
+		t.Fatalf("six Dir %q does not hold the extracted wheel", result.Dependencies[0].Dir)
+	}
+}
+
+func TestBazelResolver_ResolveWithoutBazel(t *testing.T) {
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{"MODULE.bazel": ""})
+	t.Setenv("PATH", t.TempDir())
+
+	if _, err := NewBazelResolver(ecosystemGo, BazelResolveOptions{}).Resolve(context.Background(), target); err == nil {
+		t.Fatal("Resolve without bazel error = nil, want error")
+	}
+}
+
+func TestUseBazelResolver(t *testing.T) {
+	bazel := t.TempDir()
+	writeProjectFiles(t, bazel, map[string]string{"WORKSPACE.bazel": ""})
+	bazelWithGoMod := t.TempDir()
+	writeProjectFiles(t, bazelWithGoMod, map[string]string{"MODULE.bazel": "", "go.mod": "module x\n", "requirements.txt": ""})
+	buck := t.TempDir()
+	writeProjectFiles(t, buck, map[string]string{".buckconfig": ""})
+	plain := t.TempDir()
+	if err := os.WriteFile(filepath.Join(plain, "go.mod"), []byte("module x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		dir       string
+		ecosystem string
+		opts      BazelResolveOptions
+		want      bool
+	}{
+		{"bazel go", bazel, ecosystemGo, BazelResolveOptions{}, true},
+		{"bazel java", bazel, ecosystemJava, BazelResolveOptions{}, true},
+		{"bazel with go.mod", bazelWithGoMod, ecosystemGo, BazelResolveOptions{}, false},
+		{"bazel with requirements", bazelWithGoMod, ecosystemPython, BazelResolveOptions{}, false},
+		{"bazel unsupported ecosystem", bazel, "rust", BazelResolveOptions{}, false},
+		{"buck python", buck, ecosystemPython, BazelResolveOptions{}, true},
+		{"plain go", plain, ecosystemGo, BazelResolveOptions{}, false},
+		{"query file", plain, ecosystemGo, BazelResolveOptions{QueryFile: "query.json"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UseBazelResolver(tt.dir, tt.ecosystem, tt.opts); got != tt.want {
+				t.Fatalf("UseBazelResolver = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
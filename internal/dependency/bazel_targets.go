@@ -0,0 +1,240 @@
+package dependency
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const mavenCoordinatesTag = "maven_coordinates="
+
+// addBazelGoRepositories reports each rules_go go_repository as a direct
+// dependency, with the repository directory under the output base as its
+// sources. go_repository keeps no edges between modules.
+func addBazelGoRepositories(result *ResolveResult, targets []buildTarget, labels buildLabelResolver) {
+	rootRef := Ref{Module: result.RootModule}
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		module := target.attr("importpath")
+		if target.RuleClass != "go_repository" || module == "" || seen[module] {
+			continue
+		}
+		seen[module] = true
+		version := target.attr("version")
+		for _, fallback := range []string{"tag", "commit"} {
+			if version == "" {
+				version = target.attr(fallback)
+			}
+		}
+		result.Dependencies = append(result.Dependencies, Dependency{
+			Module:  module,
+			Version: version,
+			Dir:     bazelRepoDir(labels.outputBase, bazelRepositoryName(target)),
+		})
+		addCEdge(result, rootRef, Ref{Module: module, Version: version})
+	}
+}
+
+// addBazelPythonPackages reports each rules_python whl_library (and Buck
+// prebuilt_python_library) as a direct dependency. rules_python unpacks
+// wheels into external/<repo>/site-packages (the repository root in older
+// releases); Buck wheels are extracted into the source cache.
+func addBazelPythonPackages(result *ResolveResult, targets []buildTarget, labels buildLabelResolver) {
+	cache := bazelSourceCache()
+	rootRef := Ref{Module: result.RootModule}
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		var dep Dependency
+		switch target.RuleClass {
+		case "whl_library":
+			name, version, ok := pinnedRequirement(target.attr("requirement"))
+			if !ok {
+				continue
+			}
+			dep = Dependency{Module: name, Version: version}
+			if repoDir := bazelRepoDir(labels.outputBase, bazelRepositoryName(target)); repoDir != "" {
+				dep.Dir = repoDir
+				if sitePackages := filepath.Join(repoDir, "site-packages"); isDir(sitePackages) {
+					dep.Dir = sitePackages
+				}
+			}
+		case "prebuilt_python_library":
+			wheel := labels.path(target, target.attr("binary_src"))
+			name, version, ok := wheelNameVersion(filepath.Base(target.attr("binary_src")))
+			if !ok {
+				continue
+			}
+			dep = Dependency{Module: name, Version: version, SourceArchivePath: wheel}
+			if wheel != "" && cache != nil {
+				dir, err := cache.ExtractZip(wheel, name, version, []string{".py"})
+				if err != nil {
+					log.Debug().Err(err).Str("module", name).Str("archive", wheel).Msg("Failed to extract Buck wheel")
+				}
+				dep.Dir = dir
+			}
+		default:
+			continue
+		}
+		key := normalizePackageName(dep.Module)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result.Dependencies = append(result.Dependencies, dep)
+		addCEdge(result, rootRef, Ref{Module: dep.Module, Version: dep.Version})
+	}
+}
+
+// bazelMavenArtifact is a Maven artifact target and the coordinates it
+// provides.
+type bazelMavenArtifact struct {
+	target buildTarget
+	ref    Ref
+}
+
+// addBazelMavenArtifacts reports the Maven artifacts imported by
+// rules_jvm_external (jvm_import/aar_import, or java_import tagged with
+// maven_coordinates) and Buck prebuilt_jar targets. Binary jars become the
+// compiled artifact; source jars are extracted into the source cache. Edges
+// follow the targets' deps and exports; artifacts no other artifact depends
+// on are the root's direct dependencies. rules_jvm_external artifacts
+// declared testonly have test scope and the others compile scope; Buck
+// records no scope for prebuilt_jar.
+func addBazelMavenArtifacts(result *ResolveResult, targets []buildTarget, labels buildLabelResolver) {
+	cache := bazelSourceCache()
+
+	var artifacts []bazelMavenArtifact
+	byLabel := make(map[string]Ref)
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		ref, ok := mavenArtifactRef(target)
+		if !ok {
+			continue
+		}
+		byLabel[strings.TrimLeft(target.Label, "@")] = ref
+		if seen[ref.Module] {
+			continue
+		}
+		seen[ref.Module] = true
+		artifacts = append(artifacts, bazelMavenArtifact{target: target, ref: ref})
+
+		dep := Dependency{Module: ref.Module, Version: ref.Version, Scope: bazelArtifactScope(target)}
+		for _, attr := range []string{"jars", "binary_jar", "aar"} {
+			if label := target.attr(attr); label != "" {
+				suffix := ".jar"
+				if attr == "aar" {
+					suffix = ".aar"
+				}
+				dep.CompiledArtifactPath = labels.archivePath(target, label, suffix)
+				break
+			}
+		}
+		for _, attr := range []string{"srcjar", "source_jar"} {
+			if label := target.attr(attr); label != "" {
+				dep.SourceArchivePath = labels.archivePath(target, label, ".jar")
+				break
+			}
+		}
+		if dep.SourceArchivePath != "" && cache != nil {
+			dir, err := cache.ExtractZip(dep.SourceArchivePath, dep.Module, dep.Version, []string{".java"})
+			if err != nil {
+				log.Debug().Err(err).Str("module", dep.Module).Str("archive", dep.SourceArchivePath).Msg("Failed to extract Bazel source jar")
+			}
+			dep.Dir = dir
+		}
+		result.Dependencies = append(result.Dependencies, dep)
+	}
+
+	hasParent := make(map[Ref]bool)
+	for _, artifact := range artifacts {
+		for _, attr := range []string{"deps", "exports"} {
+			for _, label := range artifact.target.Attrs[attr] {
+				if child, ok := byLabel[strings.TrimLeft(label, "@")]; ok && child != artifact.ref {
+					addCEdge(result, artifact.ref, child)
+					hasParent[child] = true
+				}
+			}
+		}
+	}
+	rootRef := Ref{Module: result.RootModule}
+	for _, artifact := range artifacts {
+		if !hasParent[artifact.ref] {
+			addCEdge(result, rootRef, artifact.ref)
+		}
+	}
+}
+
+// mavenArtifactRef returns the Maven coordinates of a Java import target:
+// the maven_coordinates tag of rules_jvm_external targets, or the
+// maven_coords attribute of Buck prebuilt_jar.
+func mavenArtifactRef(target buildTarget) (Ref, bool) {
+	coordinates := ""
+	switch target.RuleClass {
+	case "jvm_import", "aar_import", "java_import":
+		for _, tag := range target.Attrs["tags"] {
+			if value, ok := strings.CutPrefix(tag, mavenCoordinatesTag); ok {
+				coordinates = value
+				break
+			}
+		}
+	case "prebuilt_jar":
+		coordinates = target.attr("maven_coords")
+	default:
+		return Ref{}, false
+	}
+	return parseMavenCoordinates(coordinates)
+}
+
+// bazelArtifactScope returns test for a rules_jvm_external import target
+// declared testonly, compile for the other ones, and no scope for Buck
+// prebuilt_jar, which has no test-only marker.
+func bazelArtifactScope(target buildTarget) string {
+	switch {
+	case target.RuleClass == "prebuilt_jar":
+		return ""
+	case target.attr("testonly") == "true":
+		return ScopeTest
+	default:
+		return ScopeCompile
+	}
+}
+
+// parseMavenCoordinates parses group:artifact[:packaging[:classifier]]:version.
+func parseMavenCoordinates(coordinates string) (Ref, bool) {
+	parts := strings.Split(coordinates, ":")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[len(parts)-1] == "" {
+		return Ref{}, false
+	}
+	return Ref{Module: parts[0] + ":" + parts[1], Version: parts[len(parts)-1]}, true
+}
+
+// bazelRepositoryName returns the name of the repository a //external rule
+// defines.
+func bazelRepositoryName(target buildTarget) string {
+	if name := target.attr("name"); name != "" {
+		return name
+	}
+	return target.name()
+}
+
+// wheelNameVersion parses {name}-{version}(-{build})?-{python}-{abi}-{platform}.whl.
+func wheelNameVersion(filename string) (string, string, bool) {
+	if !strings.HasSuffix(strings.ToLower(filename), ".whl") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(filename, filepath.Ext(filename)), "-")
+	if len(parts) < 5 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func bazelSourceCache() *SourceCache {
+	cache, err := NewSourceCache()
+	if err != nil {
+		log.Warn().Err(err).Msg("Source cache unavailable, resolving Bazel dependencies without source jars")
+		return nil
+	}
+	return cache
+}
@@ -0,0 +1,220 @@
+package dependency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+const (
+	ecosystemC   = "c"
+	ecosystemCPP = "cpp"
+
+	cToolConan        = "conan"
+	cToolVcpkg        = "vcpkg"
+	cToolFetchContent = "fetchcontent"
+)
+
+// cToolResolveFunc resolves the dependencies one C/C++ package manager or
+// build system records for the project at targetDir. root is the project's
+// module name, used as the parent of its direct dependencies.
+type cToolResolveFunc func(ctx context.Context, targetDir, root string) (*ResolveResult, error)
+
+// CResolver resolves C and C++ dependencies from the package managers and
+// build systems found at the project root: Conan (conan.lock), vcpkg
+// (vcpkg.json and its installed tree) and CMake FetchContent (_deps sources
+// in a build directory). A project that uses several gets their merged result.
+type CResolver struct {
+	ecosystem string
+	tools     map[string]cToolResolveFunc
+}
+
+// NewCResolver creates a dependency resolver for C projects.
+func NewCResolver() *CResolver {
+	return newCResolver(ecosystemC)
+}
+
+// NewCPPResolver creates a dependency resolver for C++ projects.
+func NewCPPResolver() *CResolver {
+	return newCResolver(ecosystemCPP)
+}
+
+func newCResolver(ecosystem string) *CResolver {
+	return &CResolver{
+		ecosystem: ecosystem,
+		tools: map[string]cToolResolveFunc{
+			cToolConan:        resolveConan,
+			cToolVcpkg:        resolveVcpkg,
+			cToolFetchContent: resolveFetchContent,
+		},
+	}
+}
+
+// Ecosystem returns "c" or "cpp".
+func (r *CResolver) Ecosystem() string {
+	return r.ecosystem
+}
+
+// Resolve merges the dependencies of every C/C++ dependency source detected
+// at targetDir. A source that fails is logged and skipped; Resolve fails only
+// when none is detected or all of them fail. conan.lock "requires", vcpkg
+// manifests and FetchContent sources do not tell test-only dependencies
+// apart, so the dependencies have no scope.
+func (r *CResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	tools := DetectCDependencyTools(targetDir)
+	if len(tools) == 0 {
+		return nil, failure.New(
+			failure.CodeDependencyBuildToolUnknown,
+			failure.StageDependency,
+			fmt.Sprintf("could not detect conan.lock, vcpkg.json or CMake FetchContent sources in %s", targetDir),
+			failure.WithDetail("target_dir", targetDir),
+		)
+	}
+
+	result := &ResolveResult{
+		RootModule:     cProjectName(targetDir),
+		Graph:          make(map[string][]string),
+		VersionedGraph: make(map[string][]Ref),
+	}
+
+	var errs []error
+	for _, tool := range tools {
+		toolResult, err := r.tools[tool](ctx, targetDir, result.RootModule)
+		if err != nil {
+			log.Warn().Err(err).Str("tool", tool).Msg("Failed to resolve C/C++ dependencies")
+			errs = append(errs, fmt.Errorf("%s: %w", tool, err))
+			continue
+		}
+		mergeCResolveResult(result, toolResult)
+	}
+	if len(errs) == len(tools) {
+		return nil, fmt.Errorf("failed to resolve C/C++ dependencies in %s: %w", targetDir, errors.Join(errs...))
+	}
+
+	log.Info().
+		Int("count", len(result.Dependencies)).
+		Str("root", result.RootModule).
+		Strs("tools", tools).
+		Msg("Resolved C/C++ dependencies")
+
+	return result, nil
+}
+
+// DetectCDependencyTools lists the C/C++ dependency sources present at
+// targetDir, in resolution order.
+func DetectCDependencyTools(targetDir string) []string {
+	var tools []string
+	if fileExists(filepath.Join(targetDir, conanLockFile)) {
+		tools = append(tools, cToolConan)
+	}
+	if fileExists(filepath.Join(targetDir, vcpkgManifestFile)) {
+		tools = append(tools, cToolVcpkg)
+	}
+	if len(fetchContentBaseDirs(targetDir)) > 0 {
+		tools = append(tools, cToolFetchContent)
+	}
+	return tools
+}
+
+// mergeCResolveResult adds one tool's dependencies and edges to result. When
+// two tools provide the same module, the first one wins.
+func mergeCResolveResult(result, toolResult *ResolveResult) {
+	seen := make(map[string]bool, len(result.Dependencies))
+	for _, dep := range result.Dependencies {
+		seen[dep.Module] = true
+	}
+	for _, dep := range toolResult.Dependencies {
+		if seen[dep.Module] {
+			continue
+		}
+		seen[dep.Module] = true
+		result.Dependencies = append(result.Dependencies, dep)
+	}
+
+	for parent, children := range toolResult.Graph {
+		result.Graph[parent] = appendUniqueStrings(result.Graph[parent], children...)
+	}
+	for parent, children := range toolResult.VersionedGraph {
+		for _, child := range children {
+			if !containsRef(result.VersionedGraph[parent], child) {
+				result.VersionedGraph[parent] = append(result.VersionedGraph[parent], child)
+			}
+		}
+	}
+}
+
+// addCEdge records parent -> child in both graphs. The parent key is the
+// versioned coordinate when parent has a version.
+func addCEdge(result *ResolveResult, parent, child Ref) {
+	if parent.Module == "" || child.Module == "" || parent.Module == child.Module {
+		return
+	}
+	result.Graph[parent.Module] = appendUniqueStrings(result.Graph[parent.Module], child.Module)
+	key := parent.Key()
+	if !containsRef(result.VersionedGraph[key], child) {
+		result.VersionedGraph[key] = append(result.VersionedGraph[key], child)
+	}
+}
+
+func appendUniqueStrings(values []string, additions ...string) []string {
+	for _, addition := range additions {
+		found := false
+		for _, value := range values {
+			if value == addition {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, addition)
+		}
+	}
+	return values
+}
+
+func containsRef(refs []Ref, ref Ref) bool {
+	for _, existing := range refs {
+		if existing == ref {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	conanfileNamePattern = regexp.MustCompile(`(?m)^\s*name\s*=\s*["']([^"']+)["']`)
+	cmakeProjectPattern  = regexp.MustCompile(`(?i)\bproject\s*\(\s*([A-Za-z0-9_.+-]+)`)
+)
+
+// cProjectName returns the project's name from vcpkg.json, conanfile.py or
+// the top-level CMakeLists.txt, falling back to the directory name.
+func cProjectName(targetDir string) string {
+	if data, err := os.ReadFile(filepath.Join(targetDir, vcpkgManifestFile)); err == nil {
+		var manifest struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(data, &manifest) == nil && manifest.Name != "" {
+			return manifest.Name
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(targetDir, "conanfile.py")); err == nil {
+		if match := conanfileNamePattern.FindSubmatch(data); match != nil {
+			return string(match[1])
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(targetDir, "CMakeLists.txt")); err == nil {
+		if match := cmakeProjectPattern.FindSubmatch(data); match != nil {
+			return strings.Trim(string(match[1]), `"`)
+		}
+	}
+	return filepath.Base(targetDir)
+}
@@ -0,0 +1,89 @@
+package dependency
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+func TestCResolver_MergesTools(t *testing.T) {
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"vcpkg.json":     `{"name": "firmware", "dependencies": ["mbedtls"]}`,
+		"conan.lock":     `{"requires": ["mbedtls/3.4.0", "zlib/1.3"]}`,
+		"CMakeLists.txt": "project(other)\n",
+	})
+	r := NewCResolver()
+	r.tools[cToolFetchContent] = func(context.Context, string, string) (*ResolveResult, error) {
+		t.Fatal("fetchcontent must not run without _deps")
+		return nil, nil
+	}
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("CONAN_USER_HOME", t.TempDir())
+	t.Setenv("VCPKG_ROOT", "")
+
+	result, err := r.Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if result.RootModule != "firmware" {
+		t.Fatalf("RootModule = %q, want firmware (vcpkg.json name)", result.RootModule)
+	}
+	want := []Dependency{{Module: "mbedtls", Version: "3.4.0"}, {Module: "zlib", Version: "1.3"}}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v (conan wins on duplicates)", result.Dependencies, want)
+	}
+	if got := result.Graph["firmware"]; !reflect.DeepEqual(got, []string{"mbedtls", "zlib"}) {
+		t.Fatalf("Graph[firmware] = %v", got)
+	}
+	wantRefs := []Ref{{Module: "mbedtls", Version: "3.4.0"}, {Module: "zlib", Version: "1.3"}, {Module: "mbedtls"}}
+	if got := result.VersionedGraph["firmware"]; !reflect.DeepEqual(got, wantRefs) {
+		t.Fatalf("VersionedGraph[firmware] = %v, want %v", got, wantRefs)
+	}
+}
+
+func TestCResolver_ToolFailures(t *testing.T) {
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{"conan.lock": "{", "vcpkg.json": `{"dependencies": ["zlib"]}`})
+	t.Setenv("VCPKG_ROOT", "")
+
+	result, err := NewCPPResolver().Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve with one failing tool: %v", err)
+	}
+	if len(result.Dependencies) != 1 || result.Dependencies[0].Module != "zlib" {
+		t.Fatalf("Dependencies = %+v, want zlib from vcpkg.json", result.Dependencies)
+	}
+
+	writeProjectFiles(t, target, map[string]string{"vcpkg.json": "["})
+	if _, err := NewCPPResolver().Resolve(context.Background(), target); err == nil {
+		t.Fatal("Resolve with all tools failing: want error")
+	}
+}
+
+func TestCResolver_NoTools(t *testing.T) {
+	_, err := NewCResolver().Resolve(context.Background(), t.TempDir())
+	var typed *failure.Error
+	if !errors.As(err, &typed) || typed.Code != failure.CodeDependencyBuildToolUnknown {
+		t.Fatalf("Resolve without manifests error = %v, want %s", err, failure.CodeDependencyBuildToolUnknown)
+	}
+	if NewCResolver().Ecosystem() != "c" || NewCPPResolver().Ecosystem() != "cpp" {
+		t.Fatal("unexpected resolver ecosystems")
+	}
+}
+
+func TestCProjectName(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{"conanfile.py": "class Pkg(ConanFile):\n    name = \"signer\"\n"})
+	if got := cProjectName(dir); got != "signer" {
+		t.Fatalf("cProjectName(conanfile.py) = %q, want signer", got)
+	}
+	dir = t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{"CMakeLists.txt": "cmake_minimum_required(VERSION 3.20)\nproject(bootloader VERSION 1.0 LANGUAGES C)\n"})
+	if got := cProjectName(dir); got != "bootloader" {
+		t.Fatalf("cProjectName(CMakeLists.txt) = %q, want bootloader", got)
+	}
+}
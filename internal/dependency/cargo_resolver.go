@@ -43,7 +43,28 @@ type cargoResolveNode struct {
 
 // cargoResolveDep represents a dependency edge in the resolve graph.
 type cargoResolveDep struct {
-	Pkg string `json:"pkg"`
+	Pkg      string         `json:"pkg"`
+	DepKinds []cargoDepKind `json:"dep_kinds"`
+}
+
+// cargoDepKind is one way a package depends on another: a normal (nil),
+// "build" or "dev" dependency, possibly for a specific target.
+type cargoDepKind struct {
+	Kind *string `json:"kind"`
+}
+
+// devOnly reports whether the edge exists only as a dev-dependency. Edges
+// without dep_kinds (Cargo before 1.41) count as normal dependencies.
+func (d cargoResolveDep) devOnly() bool {
+	if len(d.DepKinds) == 0 {
+		return false
+	}
+	for _, kind := range d.DepKinds {
+		if kind.Kind == nil || *kind.Kind != "dev" {
+			return false
+		}
+	}
+	return true
 }
 
 // CargoResolver resolves Rust/Cargo dependencies using `cargo metadata`.
@@ -91,14 +112,58 @@ func newCargoResolveResult(meta *cargoMetadata) *ResolveResult {
 		result.RootModule = cargoFallbackRootModule(meta.WorkspaceMembers, meta.Packages)
 	}
 
+	scopes := cargoScopes(meta)
 	for _, pkg := range meta.Packages {
-		appendCargoPackage(result, pkg, workspaceMemberIDs, workspaceMemberFallbacks)
+		appendCargoPackage(result, pkg, scopes[pkg.ID], workspaceMemberIDs, workspaceMemberFallbacks)
 	}
 
 	return result
 }
 
-func appendCargoPackage(result *ResolveResult, pkg cargoPackage, workspaceMemberIDs, workspaceMemberFallbacks map[string]struct{}) {
+// cargoScopes maps package IDs to their scope: compile for the packages the
+// workspace members reach through normal or build dependencies, dev for the
+// rest, which only dev-dependencies pull in. It returns nil when the metadata
+// has no resolve graph.
+func cargoScopes(meta *cargoMetadata) map[string]string {
+	if len(meta.Resolve.Nodes) == 0 {
+		return nil
+	}
+	nodes := make(map[string]cargoResolveNode, len(meta.Resolve.Nodes))
+	for _, node := range meta.Resolve.Nodes {
+		nodes[node.ID] = node
+	}
+
+	stack := append([]string(nil), meta.WorkspaceMembers...)
+	if len(stack) == 0 && meta.Resolve.Root != "" {
+		stack = append(stack, meta.Resolve.Root)
+	}
+	reached := make(map[string]bool, len(nodes))
+	for len(stack) > 0 {
+		last := len(stack) - 1
+		id := stack[last]
+		stack = stack[:last]
+		if reached[id] {
+			continue
+		}
+		reached[id] = true
+		for _, dep := range nodes[id].Deps {
+			if !dep.devOnly() {
+				stack = append(stack, dep.Pkg)
+			}
+		}
+	}
+
+	scopes := make(map[string]string, len(nodes))
+	for id := range nodes {
+		scopes[id] = ScopeDev
+		if reached[id] {
+			scopes[id] = ScopeCompile
+		}
+	}
+	return scopes
+}
+
+func appendCargoPackage(result *ResolveResult, pkg cargoPackage, scope string, workspaceMemberIDs, workspaceMemberFallbacks map[string]struct{}) {
 	dir := filepath.Dir(pkg.ManifestPath)
 	if isCargoWorkspacePackage(pkg, workspaceMemberIDs, workspaceMemberFallbacks) {
 		result.WorkspaceMembers = append(result.WorkspaceMembers, WorkspaceMember{
@@ -112,6 +177,7 @@ func appendCargoPackage(result *ResolveResult, pkg cargoPackage, workspaceMember
 		Module:  pkg.Name,
 		Version: pkg.Version,
 		Dir:     dir,
+		Scope:   scope,
 	})
 }
 
@@ -2,6 +2,7 @@ package dependency
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -231,3 +232,45 @@ func TestNewCargoResolveResult_FallsBackWhenResolveRootMissing(t *testing.T) {
 		t.Fatalf("RootModule = %q, want app", result.RootModule)
 	}
 }
+
+func TestCargoScopes_DevOnlyEdges(t *testing.T) {
+	t.Parallel()
+
+	var meta cargoMetadata
+	if err := json.Unmarshal([]byte(`{
+  "packages": [],
+  "workspace_members": ["app"],
+  "resolve": {"root": "app", "nodes": [
+    {"id": "app", "deps": [
+      {"pkg": "ring", "dep_kinds": [{"kind": null}]},
+      {"pkg": "cc", "dep_kinds": [{"kind": "build"}]},
+      {"pkg": "proptest", "dep_kinds": [{"kind": "dev"}]},
+      {"pkg": "hex", "dep_kinds": [{"kind": "dev"}, {"kind": null, "target": "cfg(unix)"}]}
+    ]},
+    {"id": "ring", "deps": [{"pkg": "untrusted"}]},
+    {"id": "cc", "deps": []},
+    {"id": "proptest", "deps": [{"pkg": "rand", "dep_kinds": [{"kind": null}]}]},
+    {"id": "hex", "deps": []},
+    {"id": "untrusted", "deps": []},
+    {"id": "rand", "deps": []}
+  ]}
+}`), &meta); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := map[string]string{
+		"app":       ScopeCompile,
+		"ring":      ScopeCompile,
+		"cc":        ScopeCompile,
+		"hex":       ScopeCompile,
+		"untrusted": ScopeCompile,
+		"proptest":  ScopeDev,
+		"rand":      ScopeDev,
+	}
+	if got := cargoScopes(&meta); !reflect.DeepEqual(got, want) {
+		t.Fatalf("cargoScopes() = %#v, want %#v", got, want)
+	}
+	if got := cargoScopes(&cargoMetadata{}); got != nil {
+		t.Fatalf("cargoScopes() without a resolve graph = %#v, want nil", got)
+	}
+}
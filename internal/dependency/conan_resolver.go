@@ -0,0 +1,186 @@
+package dependency
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const conanLockFile = "conan.lock"
+
+// conanLock covers both lockfile formats: Conan 2 lists every locked
+// reference in "requires"; Conan 1 records a node graph in "graph_lock".
+type conanLock struct {
+	Requires  []string `json:"requires"`
+	GraphLock struct {
+		Nodes map[string]conanLockNode `json:"nodes"`
+	} `json:"graph_lock"`
+}
+
+type conanLockNode struct {
+	Ref      string   `json:"ref"`
+	Requires []string `json:"requires"`
+}
+
+// conanRef is a parsed reference: name/version[@user/channel][#revision].
+type conanRef struct {
+	Name     string
+	Version  string
+	User     string
+	Channel  string
+	Revision string
+}
+
+// String returns the reference in the form the conan CLI accepts.
+func (r conanRef) String() string {
+	ref := r.Name + "/" + r.Version
+	if r.User != "" {
+		ref += "@" + r.User + "/" + r.Channel
+	}
+	if r.Revision != "" {
+		ref += "#" + r.Revision
+	}
+	return ref
+}
+
+// resolveConan reads conan.lock and locates each locked package's sources in
+// the Conan cache. Conan 2 lockfiles carry no edges, so every locked package
+// becomes a direct dependency of root; Conan 1 lockfiles keep their graph.
+func resolveConan(ctx context.Context, targetDir, root string) (*ResolveResult, error) {
+	lockPath := filepath.Join(targetDir, conanLockFile)
+	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", lockPath, err)
+	}
+	var lock conanLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", lockPath, err)
+	}
+
+	result := &ResolveResult{
+		RootModule:     root,
+		Graph:          make(map[string][]string),
+		VersionedGraph: make(map[string][]Ref),
+	}
+	rootRef := Ref{Module: root}
+
+	if len(lock.GraphLock.Nodes) > 0 {
+		appendConan1Graph(ctx, result, rootRef, lock.GraphLock.Nodes)
+		return result, nil
+	}
+
+	for _, raw := range lock.Requires {
+		ref, ok := parseConanRef(raw)
+		if !ok {
+			continue
+		}
+		appendConanDependency(ctx, result, ref)
+		addCEdge(result, rootRef, Ref{Module: ref.Name, Version: ref.Version})
+	}
+	return result, nil
+}
+
+// appendConan1Graph adds the packages and edges of a Conan 1 graph_lock.
+// Node "0" is the consumer project itself.
+func appendConan1Graph(ctx context.Context, result *ResolveResult, rootRef Ref, nodes map[string]conanLockNode) {
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	refs := make(map[string]Ref, len(nodes))
+	for _, id := range ids {
+		if id == "0" {
+			refs[id] = rootRef
+			continue
+		}
+		ref, ok := parseConanRef(nodes[id].Ref)
+		if !ok {
+			continue
+		}
+		refs[id] = Ref{Module: ref.Name, Version: ref.Version}
+		appendConanDependency(ctx, result, ref)
+	}
+
+	for _, id := range ids {
+		parent, ok := refs[id]
+		if !ok {
+			continue
+		}
+		for _, childID := range nodes[id].Requires {
+			if child, ok := refs[childID]; ok {
+				addCEdge(result, parent, child)
+			}
+		}
+	}
+}
+
+func appendConanDependency(ctx context.Context, result *ResolveResult, ref conanRef) {
+	dir := conanSourceDir(ctx, ref)
+	if dir == "" {
+		log.Debug().Str("package", ref.String()).Msg("No Conan source folder found in the cache")
+	}
+	result.Dependencies = append(result.Dependencies, Dependency{
+		Module:  ref.Name,
+		Version: ref.Version,
+		Dir:     dir,
+	})
+}
+
+// parseConanRef parses "name/version[@user/channel][#revision[%timestamp]]".
+func parseConanRef(raw string) (conanRef, bool) {
+	var ref conanRef
+	raw, revision, _ := strings.Cut(strings.TrimSpace(raw), "#")
+	ref.Revision, _, _ = strings.Cut(revision, "%")
+	raw, userChannel, _ := strings.Cut(raw, "@")
+	ref.User, ref.Channel, _ = strings.Cut(userChannel, "/")
+	ref.Name, ref.Version, _ = strings.Cut(raw, "/")
+	if ref.Name == "" || ref.Version == "" {
+		return conanRef{}, false
+	}
+	return ref, true
+}
+
+// conanSourceDir returns the cached source folder of ref: the Conan 2 cache
+// as reported by `conan cache path`, or the Conan 1 data layout under
+// $CONAN_USER_HOME (default $HOME). It returns "" when neither has sources,
+// e.g. for packages installed as prebuilt binaries.
+func conanSourceDir(ctx context.Context, ref conanRef) string {
+	if _, err := exec.LookPath("conan"); err == nil {
+		cmd := exec.CommandContext(ctx, "conan", "cache", "path", ref.String(), "--folder", "source")
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			log.Debug().Err(err).Str("package", ref.String()).Str("stderr", stderr.String()).Msg("conan cache path failed")
+		} else if dir := strings.TrimSpace(stdout.String()); dir != "" && isDir(dir) {
+			return dir
+		}
+	}
+
+	home := os.Getenv("CONAN_USER_HOME")
+	if home == "" {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return ""
+		}
+	}
+	user, channel := ref.User, ref.Channel
+	if user == "" {
+		user, channel = "_", "_"
+	}
+	dir := filepath.Join(home, ".conan", "data", ref.Name, ref.Version, user, channel, "source")
+	if isDir(dir) {
+		return dir
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package dependency
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseConanRef(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want conanRef
+		ok   bool
+	}{
+		{raw: "zlib/1.3", want: conanRef{Name: "zlib", Version: "1.3"}, ok: true},
+		{
+			raw:  "mbedtls/3.5.0#0c8bd4a4d2a4d5d1e9bd21d2a6b6c8f0%1700000000.123",
+			want: conanRef{Name: "mbedtls", Version: "3.5.0", Revision: "0c8bd4a4d2a4d5d1e9bd21d2a6b6c8f0"},
+			ok:   true,
+		},
+		{raw: "openssl/1.1.1w@corp/stable", want: conanRef{Name: "openssl", Version: "1.1.1w", User: "corp", Channel: "stable"}, ok: true},
+		{raw: "conanfile", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseConanRef(tt.raw)
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("parseConanRef(%q) = %+v, %v; want %+v, %v", tt.raw, got, ok, tt.want, tt.ok)
+		}
+	}
+	if got := (conanRef{Name: "openssl", Version: "3.2.0", User: "corp", Channel: "stable", Revision: "abc"}).String(); got != "openssl/3.2.0@corp/stable#abc" {
+		t.Fatalf("conanRef.String() = %q", got)
+	}
+}
+
+func TestResolveConan_Conan2LockWithCacheCLI(t *testing.T) {
+	target := t.TempDir()
+	cache := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"conan.lock": `{
+  "version": "0.5",
+  "requires": ["mbedtls/3.5.0#rev1%1700000000.1", "zlib/1.3#rev2"],
+  "build_requires": ["cmake/3.28.1#rev3"]
+}`,
+	})
+	writeProjectFiles(t, cache, map[string]string{"mbedtlsabc/s/library/aes.c": "int mbedtls_aes_setkey_enc(void) { return 0; }\n"})
+
+	tmpBin := t.TempDir()
+	writeExecutable(t, tmpBin, "conan", `#!/bin/sh
+if [ "$1" = "cache" ] && [ "$2" = "path" ] && [ "$3" = "mbedtls/3.5.0#rev1" ] && [ "$5" = "source" ]; then
+  echo "`+filepath.Join(cache, "mbedtlsabc", "s")+`"
+  exit 0
+fi
+echo "ERROR: no source folder for $3" >&2
+exit 1
+`)
+	t.Setenv("PATH", tmpBin)
+	t.Setenv("CONAN_USER_HOME", t.TempDir())
+
+	result, err := resolveConan(context.Background(), target, "firmware")
+	if err != nil {
+		t.Fatalf("resolveConan: %v", err)
+	}
+
+	want := []Dependency{
+		{Module: "mbedtls", Version: "3.5.0", Dir: filepath.Join(cache, "mbedtlsabc", "s")},
+		{Module: "zlib", Version: "1.3"},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+	if got := result.Graph["firmware"]; !reflect.DeepEqual(got, []string{"mbedtls", "zlib"}) {
+		t.Fatalf("Graph[firmware] = %v", got)
+	}
+	wantRefs := []Ref{{Module: "mbedtls", Version: "3.5.0"}, {Module: "zlib", Version: "1.3"}}
+	if got := result.VersionedGraph["firmware"]; !reflect.DeepEqual(got, wantRefs) {
+		t.Fatalf("VersionedGraph[firmware] = %v, want %v", got, wantRefs)
+	}
+}
+
+func TestResolveConan_Conan1GraphLockAndDataLayout(t *testing.T) {
+	target := t.TempDir()
+	home := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"conan.lock": `{
+  "graph_lock": {
+    "nodes": {
+      "0": {"ref": "", "requires": ["1"]},
+      "1": {"ref": "openssl/1.1.1w@corp/stable#rev", "requires": ["2"]},
+      "2": {"ref": "zlib/1.2.13"}
+    }
+  },
+  "version": "0.4"
+}`,
+	})
+	writeProjectFiles(t, home, map[string]string{
+		".conan/data/openssl/1.1.1w/corp/stable/source/crypto/evp.c": "",
+		".conan/data/zlib/1.2.13/_/_/source/deflate.c":               "",
+	})
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("CONAN_USER_HOME", home)
+
+	result, err := resolveConan(context.Background(), target, "app")
+	if err != nil {
+		t.Fatalf("resolveConan: %v", err)
+	}
+
+	want := []Dependency{
+		{Module: "openssl", Version: "1.1.1w", Dir: filepath.Join(home, ".conan", "data", "openssl", "1.1.1w", "corp", "stable", "source")},
+		{Module: "zlib", Version: "1.2.13", Dir: filepath.Join(home, ".conan", "data", "zlib", "1.2.13", "_", "_", "source")},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+	wantGraph := map[string][]string{"app": {"openssl"}, "openssl": {"zlib"}}
+	if !reflect.DeepEqual(result.Graph, wantGraph) {
+		t.Fatalf("Graph = %v, want %v", result.Graph, wantGraph)
+	}
+	if got := result.VersionedGraph["openssl@1.1.1w"]; !reflect.DeepEqual(got, []Ref{{Module: "zlib", Version: "1.2.13"}}) {
+		t.Fatalf("VersionedGraph[openssl@1.1.1w] = %v", got)
+	}
+}
+
+func TestResolveConan_InvalidLock(t *testing.T) {
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{"conan.lock": "{"})
+	if _, err := resolveConan(context.Background(), target, "app"); err == nil {
+		t.Fatal("resolveConan with invalid lock: want error")
+	}
+}
@@ -0,0 +1,229 @@
+package dependency
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	cmakeCacheFile        = "CMakeCache.txt"
+	fetchContentDepsDir   = "_deps"
+	fetchContentSrcSuffix = "-src"
+)
+
+// fetchContentDecl is one FetchContent_Declare call found in the project's
+// CMake files.
+type fetchContentDecl struct {
+	Name    string
+	Version string
+}
+
+var (
+	fetchContentDeclarePattern = regexp.MustCompile(`(?is)FetchContent_Declare\s*\(\s*([A-Za-z0-9_.+-]+)([^)]*)\)`)
+	fetchContentGitTagPattern  = regexp.MustCompile(`(?i)\bGIT_TAG\s+"?([^\s")]+)`)
+	fetchContentURLPattern     = regexp.MustCompile(`(?i)\bURL\s+"?([^\s")]+)`)
+	urlVersionPattern          = regexp.MustCompile(`v?(\d+(?:\.\d+)+)`)
+)
+
+// resolveFetchContent reports the sources CMake FetchContent populated in the
+// project's build directories (<build>/_deps/<name>-src, or the
+// FETCHCONTENT_BASE_DIR and FETCHCONTENT_SOURCE_DIR_<NAME> cache entries).
+// Names and versions (GIT_TAG, or a version in the URL) come from the
+// FetchContent_Declare calls in the project's CMake files. FetchContent keeps
+// no dependency graph, so every populated source is a direct dependency.
+func resolveFetchContent(_ context.Context, targetDir, root string) (*ResolveResult, error) {
+	result := &ResolveResult{
+		RootModule:     root,
+		Graph:          make(map[string][]string),
+		VersionedGraph: make(map[string][]Ref),
+	}
+
+	decls := readFetchContentDecls(targetDir)
+	sources := fetchContentSourceDirs(targetDir)
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rootRef := Ref{Module: root}
+	for _, name := range names {
+		decl, ok := decls[name]
+		if !ok {
+			decl = fetchContentDecl{Name: name}
+		}
+		result.Dependencies = append(result.Dependencies, Dependency{
+			Module:  decl.Name,
+			Version: decl.Version,
+			Dir:     sources[name],
+		})
+		addCEdge(result, rootRef, Ref{Module: decl.Name, Version: decl.Version})
+	}
+	return result, nil
+}
+
+// fetchContentBaseDirs returns the FetchContent base directories of the
+// project's CMake build trees: <build>/_deps for each top-level directory
+// (and out/build/* preset directory) that has one, plus any
+// FETCHCONTENT_BASE_DIR recorded in their CMakeCache.txt.
+func fetchContentBaseDirs(targetDir string) []string {
+	buildDirs := cmakeBuildDirs(targetDir)
+	seen := make(map[string]bool)
+	var bases []string
+	add := func(dir string) {
+		if dir != "" && !seen[dir] && isDir(dir) {
+			seen[dir] = true
+			bases = append(bases, dir)
+		}
+	}
+	for _, buildDir := range buildDirs {
+		add(filepath.Join(buildDir, fetchContentDepsDir))
+		if base := readCMakeCache(buildDir)["FETCHCONTENT_BASE_DIR"]; base != "" {
+			add(base)
+		}
+	}
+	return bases
+}
+
+// cmakeBuildDirs lists candidate build directories: top-level directories
+// and out/build/<preset> directories holding a CMakeCache.txt or _deps.
+func cmakeBuildDirs(targetDir string) []string {
+	var candidates []string
+	for _, parent := range []string{targetDir, filepath.Join(targetDir, "out", "build")} {
+		entries, err := os.ReadDir(parent)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			dir := filepath.Join(parent, entry.Name())
+			if fileExists(filepath.Join(dir, cmakeCacheFile)) || isDir(filepath.Join(dir, fetchContentDepsDir)) {
+				candidates = append(candidates, dir)
+			}
+		}
+	}
+	return candidates
+}
+
+// fetchContentSourceDirs maps each lowercased dependency name to its
+// populated source directory. FETCHCONTENT_SOURCE_DIR_<NAME> overrides win
+// over the <name>-src directories.
+func fetchContentSourceDirs(targetDir string) map[string]string {
+	sources := make(map[string]string)
+	for _, base := range fetchContentBaseDirs(targetDir) {
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutSuffix(entry.Name(), fetchContentSrcSuffix)
+			if !entry.IsDir() || !ok || name == "" {
+				continue
+			}
+			if _, exists := sources[name]; !exists {
+				sources[name] = filepath.Join(base, entry.Name())
+			}
+		}
+	}
+
+	for _, buildDir := range cmakeBuildDirs(targetDir) {
+		for key, value := range readCMakeCache(buildDir) {
+			name, ok := strings.CutPrefix(key, "FETCHCONTENT_SOURCE_DIR_")
+			if ok && value != "" && isDir(value) {
+				sources[strings.ToLower(name)] = value
+			}
+		}
+	}
+	return sources
+}
+
+// readCMakeCache returns the entries of <buildDir>/CMakeCache.txt, keyed by
+// variable name without the ":TYPE" suffix.
+func readCMakeCache(buildDir string) map[string]string {
+	entries := make(map[string]string)
+	file, err := os.Open(filepath.Join(buildDir, cmakeCacheFile))
+	if err != nil {
+		return entries
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, _, _ = strings.Cut(key, ":")
+		entries[key] = value
+	}
+	return entries
+}
+
+// readFetchContentDecls scans CMakeLists.txt and *.cmake files outside build
+// directories for FetchContent_Declare calls, keyed by lowercased name as
+// FetchContent names its directories.
+func readFetchContentDecls(targetDir string) map[string]fetchContentDecl {
+	skip := make(map[string]bool)
+	for _, buildDir := range cmakeBuildDirs(targetDir) {
+		skip[buildDir] = true
+	}
+
+	decls := make(map[string]fetchContentDecl)
+	_ = filepath.WalkDir(targetDir, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if filePath != targetDir && (skip[filePath] || strings.HasPrefix(name, ".") ||
+				name == fetchContentDepsDir || name == vcpkgInstalledDirName) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "CMakeLists.txt" && !strings.HasSuffix(entry.Name(), ".cmake") {
+			return nil
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil
+		}
+		for _, match := range fetchContentDeclarePattern.FindAllSubmatch(data, -1) {
+			decl := fetchContentDecl{Name: string(match[1]), Version: fetchContentVersion(string(match[2]))}
+			key := strings.ToLower(decl.Name)
+			if _, exists := decls[key]; !exists {
+				decls[key] = decl
+			}
+		}
+		return nil
+	})
+	return decls
+}
+
+// fetchContentVersion returns the GIT_TAG of a declaration, or the version
+// embedded in the file name of its URL.
+func fetchContentVersion(args string) string {
+	if match := fetchContentGitTagPattern.FindStringSubmatch(args); match != nil {
+		return match[1]
+	}
+	if match := fetchContentURLPattern.FindStringSubmatch(args); match != nil {
+		if version := urlVersionPattern.FindStringSubmatch(path.Base(match[1])); version != nil {
+			return version[1]
+		}
+	}
+	return ""
+}
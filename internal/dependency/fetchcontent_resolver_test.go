@@ -0,0 +1,64 @@
+package dependency
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveFetchContent(t *testing.T) {
+	target := t.TempDir()
+	override := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"CMakeLists.txt": `cmake_minimum_required(VERSION 3.24)
+project(firmware C)
+include(FetchContent)
+FetchContent_Declare(
+  MbedTLS
+  GIT_REPOSITORY https://github.com/Mbed-TLS/mbedtls.git
+  GIT_TAG        v3.5.0
+)
+FetchContent_MakeAvailable(MbedTLS)
+`,
+		"cmake/deps.cmake": `FetchContent_Declare(wolfssl URL https://github.com/wolfSSL/wolfssl/archive/refs/tags/v5.6.6-stable.tar.gz URL_HASH SHA256=00)
+FetchContent_Declare(libsodium GIT_REPOSITORY https://github.com/jedisct1/libsodium.git)
+`,
+		"build/CMakeCache.txt":                          "# comment\nFETCHCONTENT_SOURCE_DIR_LIBSODIUM:PATH=" + override + "\nCMAKE_BUILD_TYPE:STRING=Release\n",
+		"build/_deps/mbedtls-src/library/aes.c":         "",
+		"build/_deps/mbedtls-build/CMakeCache.txt":      "",
+		"build/_deps/mbedtls-subbuild/CMakeLists.txt":   "FetchContent_Declare(ignored GIT_TAG v0)\n",
+		"out/build/debug/_deps/wolfssl-src/wolfcrypt.c": "",
+	})
+
+	result, err := resolveFetchContent(context.Background(), target, "firmware")
+	if err != nil {
+		t.Fatalf("resolveFetchContent: %v", err)
+	}
+
+	want := []Dependency{
+		{Module: "libsodium", Dir: override},
+		{Module: "MbedTLS", Version: "v3.5.0", Dir: filepath.Join(target, "build", "_deps", "mbedtls-src")},
+		{Module: "wolfssl", Version: "5.6.6", Dir: filepath.Join(target, "out", "build", "debug", "_deps", "wolfssl-src")},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+	if got := result.Graph["firmware"]; !reflect.DeepEqual(got, []string{"libsodium", "MbedTLS", "wolfssl"}) {
+		t.Fatalf("Graph[firmware] = %v", got)
+	}
+}
+
+func TestFetchContentBaseDirs_CacheBaseDir(t *testing.T) {
+	target := t.TempDir()
+	base := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"cmake-build-release/CMakeCache.txt": "FETCHCONTENT_BASE_DIR:PATH=" + base + "\n",
+	})
+	if got := fetchContentBaseDirs(target); !reflect.DeepEqual(got, []string{base}) {
+		t.Fatalf("fetchContentBaseDirs = %v, want [%s]", got, base)
+	}
+	if got := fetchContentBaseDirs(t.TempDir()); len(got) != 0 {
+		t.Fatalf("fetchContentBaseDirs without build dirs = %v, want none", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/rs/zerolog/log"
@@ -33,21 +34,42 @@ func (r *GoResolver) Ecosystem() string {
 }
 
 // Resolve uses `go list -m -json all` to resolve all transitive dependencies
-// for the Go project at targetDir.
+// for the Go project at targetDir. Projects with a vendor/modules.txt are
+// resolved from the vendor directory without invoking the go command. The
+// modules of a go.work workspace are reported as workspace members. Modules
+// only the project's tests import from have test scope; modules neither the
+// project's packages nor its tests import from have no scope.
 func (r *GoResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	if modulesFile := goVendorModulesFile(targetDir); modulesFile != "" {
+		return r.resolveVendor(targetDir, modulesFile)
+	}
+
 	modules, err := r.goListModules(ctx, targetDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list Go modules in %s: %w", targetDir, err)
 	}
 
+	production, err := r.goImportedModules(ctx, targetDir, false)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to list the project's imported packages, Go dependency scopes are unknown")
+	}
+	var tested map[string]bool
+	if production != nil {
+		tested, err = r.goImportedModules(ctx, targetDir, true)
+		if err != nil {
+			log.Debug().Err(err).Msg("Failed to list the packages the project's tests import, Go test scopes are unknown")
+		}
+	}
+
 	result := &ResolveResult{
 		Dependencies: make([]Dependency, 0, len(modules)),
 		Graph:        make(map[string][]string),
 	}
 
+	mains := make([]goModule, 0, 1)
 	for _, m := range modules {
 		if m.Main {
-			result.RootModule = m.Path
+			mains = append(mains, m)
 			continue
 		}
 
@@ -61,9 +83,12 @@ func (r *GoResolver) Resolve(ctx context.Context, targetDir string) (*ResolveRes
 			Module:  m.Path,
 			Version: m.Version,
 			Dir:     m.Dir,
+			Scope:   goModuleScope(m.Path, production, tested),
 		})
 	}
 
+	result.RootModule, result.WorkspaceMembers = goRootAndMembers(targetDir, mains)
+
 	// Build dependency graph using `go mod graph`
 	graph, err := r.goModGraph(ctx, targetDir)
 	if err != nil {
@@ -80,6 +105,28 @@ func (r *GoResolver) Resolve(ctx context.Context, targetDir string) (*ResolveRes
 	return result, nil
 }
 
+// goRootAndMembers picks the root module among the main modules. A go.work
+// workspace has several main modules; they become workspace members and the
+// root is the one at targetDir, or the first one listed.
+func goRootAndMembers(targetDir string, mains []goModule) (string, []WorkspaceMember) {
+	if len(mains) == 0 {
+		return "", nil
+	}
+	if len(mains) == 1 {
+		return mains[0].Path, nil
+	}
+
+	root := mains[0].Path
+	members := make([]WorkspaceMember, 0, len(mains))
+	for _, m := range mains {
+		members = append(members, WorkspaceMember{Name: m.Path, Dir: m.Dir})
+		if m.Dir != "" && filepath.Clean(m.Dir) == filepath.Clean(targetDir) {
+			root = m.Path
+		}
+	}
+	return root, members
+}
+
 // goListModules runs `go list -m -json all` and parses the streamed JSON output.
 // The output is a stream of JSON objects (not a JSON array), so we decode them one by one.
 func (r *GoResolver) goListModules(ctx context.Context, dir string) ([]goModule, error) {
@@ -107,6 +154,55 @@ func (r *GoResolver) goListModules(ctx context.Context, dir string) ([]goModule,
 	return modules, nil
 }
 
+// goImportedModules runs `go list -deps ./...`, with -test when withTests is
+// set, and returns the modules that provide a package to the project's
+// packages, or to its packages and their tests.
+func (r *GoResolver) goImportedModules(ctx context.Context, dir string, withTests bool) (map[string]bool, error) {
+	args := []string{"list", "-e", "-deps"}
+	name := "go list -deps ./..."
+	if withTests {
+		args = append(args, "-test")
+		name = "go list -deps -test ./..."
+	}
+	args = append(args, "-f", "{{with .Module}}{{.Path}}{{end}}", "./...")
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w\nstderr: %s", name, err, stderr.String())
+	}
+
+	modules := make(map[string]bool)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if path := strings.TrimSpace(line); path != "" {
+			modules[path] = true
+		}
+	}
+	return modules, nil
+}
+
+// goModuleScope returns compile for a module the project's non-test
+// packages import from and test for one only the project's tests import
+// from. The rest of the module graph, which the project's code never loads
+// and only its dependencies' own tests may need, has no scope, as does
+// every module when the imported packages could not be listed.
+func goModuleScope(module string, production, tested map[string]bool) string {
+	switch {
+	case production == nil:
+		return ""
+	case production[module]:
+		return ScopeCompile
+	case tested[module]:
+		return ScopeTest
+	default:
+		return ""
+	}
+}
+
 // goModGraph runs `go mod graph` and parses the output into an adjacency list.
 // Each line of output is: "module@version module@version" (parent -> dependency).
 func (r *GoResolver) goModGraph(ctx context.Context, dir string) (map[string][]string, error) {
@@ -25,11 +25,25 @@ func prependPath(t *testing.T, dir string) {
 func TestGoResolver_Resolve(t *testing.T) {
 	tmpBin := t.TempDir()
 	writeExecutable(t, tmpBin, "go", `#!/bin/sh
+if [ "$1" = "list" ] && [ "$3" = "-deps" ] && [ "$4" = "-test" ]; then
+  echo "example.com/app"
+  echo "example.com/imported"
+  echo "example.com/testonly"
+  exit 0
+fi
+if [ "$1" = "list" ] && [ "$3" = "-deps" ]; then
+  echo "example.com/app"
+  echo "example.com/imported"
+  echo "example.com/imported"
+  exit 0
+fi
 if [ "$1" = "list" ]; then
   cat <<'JSON'
 {"Path":"example.com/app","Main":true}
 {"Path":"example.com/dep","Version":"v1.0.0","Dir":"/deps/dep"}
 {"Path":"example.com/no-dir","Version":"v1.2.0"}
+{"Path":"example.com/imported","Version":"v0.3.0","Dir":"/deps/imported"}
+{"Path":"example.com/testonly","Version":"v0.1.0","Dir":"/deps/testonly"}
 JSON
   exit 0
 fi
@@ -53,12 +67,19 @@ exit 1
 	if result.RootModule != "example.com/app" {
 		t.Fatalf("RootModule = %q, want example.com/app", result.RootModule)
 	}
-	if len(result.Dependencies) != 1 {
-		t.Fatalf("Dependencies len = %d, want 1", len(result.Dependencies))
+	if len(result.Dependencies) != 3 {
+		t.Fatalf("Dependencies len = %d, want 3", len(result.Dependencies))
 	}
 	if result.Dependencies[0].Module != "example.com/dep" {
 		t.Fatalf("unexpected dependency module: %s", result.Dependencies[0].Module)
 	}
+	// example.com/imported provides a package to the project's own code,
+	// example.com/testonly only to its tests, and nothing imports
+	// example.com/dep.
+	deps := result.Dependencies
+	if deps[0].Scope != "" || deps[1].Scope != ScopeCompile || deps[2].Scope != ScopeTest {
+		t.Fatalf("scopes = %q, %q, %q, want \"\", compile, test", deps[0].Scope, deps[1].Scope, deps[2].Scope)
+	}
 	children := result.Graph["example.com/app"]
 	if len(children) != 2 {
 		t.Fatalf("graph children len = %d, want 2", len(children))
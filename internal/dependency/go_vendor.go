@@ -0,0 +1,410 @@
+package dependency
+
+import (
+	"bufio"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	goVendorDirName     = "vendor"
+	goVendorModulesName = "modules.txt"
+	goWorkFileName      = "go.work"
+	goModFileName       = "go.mod"
+)
+
+// goVendorModule is one module entry of vendor/modules.txt.
+type goVendorModule struct {
+	Path    string
+	Version string
+	// ReplacementVersion is the version of a module replacement
+	// ("# old v1 => new v2"); empty for directory replacements.
+	ReplacementVersion string
+	// Explicit marks modules required by a go.mod of the main module(s).
+	Explicit bool
+	// Packages are the import paths copied into vendor/.
+	Packages []string
+}
+
+// goModFile is the subset of a go.mod file the resolver needs offline.
+type goModFile struct {
+	Module string
+	// Direct lists requirements not marked "// indirect".
+	Direct []string
+}
+
+// goVendorModulesFile returns the vendor/modules.txt the go command would
+// build from at targetDir, or "" when the build uses the module cache.
+// Like the go command, vendoring is honoured whenever the file exists
+// unless GOFLAGS selects -mod=mod or -mod=readonly.
+func goVendorModulesFile(targetDir string) string {
+	switch goFlagsModMode() {
+	case "mod", "readonly":
+		return ""
+	}
+
+	path := filepath.Join(targetDir, goVendorDirName, goVendorModulesName)
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// goFlagsModMode returns the -mod value set in GOFLAGS, if any.
+func goFlagsModMode() string {
+	mode := ""
+	for _, flag := range strings.Fields(os.Getenv("GOFLAGS")) {
+		flag = strings.TrimLeft(flag, "-")
+		if value, ok := strings.CutPrefix(flag, "mod="); ok {
+			mode = value
+		}
+	}
+	return mode
+}
+
+// resolveVendor builds the dependency set from vendor/modules.txt without
+// invoking the go command, so vendored projects resolve fully offline.
+// Dependencies point at their copies under vendor/. Module edges come from
+// the main modules' direct requirements and from the imports of the
+// vendored packages, since modules.txt does not record the module graph.
+// modules.txt does not say which packages only tests import either, so the
+// dependencies have no scope.
+func (r *GoResolver) resolveVendor(targetDir, modulesFile string) (*ResolveResult, error) {
+	modules, err := parseGoVendorModules(modulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", modulesFile, err)
+	}
+
+	result := &ResolveResult{
+		Dependencies: make([]Dependency, 0, len(modules)),
+		Graph:        make(map[string][]string),
+	}
+
+	mains, err := goMainModulesOffline(targetDir)
+	if err != nil {
+		return nil, err
+	}
+	result.RootModule, result.WorkspaceMembers = goRootAndMembers(targetDir, mains)
+
+	mainPaths := make(map[string]bool, len(mains))
+	for _, m := range mains {
+		mainPaths[m.Path] = true
+	}
+
+	vendorDir := filepath.Join(targetDir, goVendorDirName)
+	vendored := make([]goVendorModule, 0, len(modules))
+	for _, m := range modules {
+		if mainPaths[m.Path] || len(m.Packages) == 0 {
+			continue
+		}
+		dir := filepath.Join(vendorDir, filepath.FromSlash(m.Path))
+		if info, statErr := os.Stat(dir); statErr != nil || !info.IsDir() {
+			log.Debug().Str("module", m.Path).Str("version", m.Version).Msg("Skipping vendored module without local directory")
+			continue
+		}
+
+		version := m.Version
+		if m.ReplacementVersion != "" {
+			version = m.ReplacementVersion
+		}
+		result.Dependencies = append(result.Dependencies, Dependency{
+			Module:  m.Path,
+			Version: version,
+			Dir:     dir,
+		})
+		vendored = append(vendored, m)
+	}
+
+	populateGoVendorGraph(result.Graph, vendorDir, mains, modules, vendored)
+
+	log.Info().
+		Int("count", len(result.Dependencies)).
+		Str("root", result.RootModule).
+		Int("workspace_members", len(result.WorkspaceMembers)).
+		Msg("Resolved Go dependencies from vendor/modules.txt")
+
+	return result, nil
+}
+
+// goMainModulesOffline reads the main module(s) of targetDir from go.work
+// or go.mod. Workspace members that cannot be read are skipped with a warning.
+func goMainModulesOffline(targetDir string) ([]goModule, error) {
+	workFile := filepath.Join(targetDir, goWorkFileName)
+	if _, err := os.Stat(workFile); err == nil {
+		uses, parseErr := parseGoWorkUses(workFile)
+		if parseErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", workFile, parseErr)
+		}
+		mains := make([]goModule, 0, len(uses))
+		for _, use := range uses {
+			dir := use
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(targetDir, filepath.FromSlash(use))
+			}
+			mod, modErr := parseGoModFile(filepath.Join(dir, goModFileName))
+			if modErr != nil || mod.Module == "" {
+				log.Warn().Err(modErr).Str("dir", dir).Msg("Skipping go.work member without a readable go.mod")
+				continue
+			}
+			mains = append(mains, goModule{Path: mod.Module, Dir: dir, Main: true})
+		}
+		return mains, nil
+	}
+
+	mod, err := parseGoModFile(filepath.Join(targetDir, goModFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod in %s: %w", targetDir, err)
+	}
+	return []goModule{{Path: mod.Module, Dir: targetDir, Main: true}}, nil
+}
+
+// populateGoVendorGraph adds edges from each main module to its direct
+// requirements and from each vendored module to the modules its vendored
+// packages import.
+func populateGoVendorGraph(graph map[string][]string, vendorDir string, mains []goModule, modules, vendored []goVendorModule) {
+	known := make(map[string]bool, len(modules))
+	moduleByPackage := make(map[string]string)
+	explicit := make([]string, 0, len(modules))
+	for _, m := range modules {
+		known[m.Path] = true
+		for _, pkg := range m.Packages {
+			moduleByPackage[pkg] = m.Path
+		}
+		if m.Explicit {
+			explicit = append(explicit, m.Path)
+		}
+	}
+
+	for _, mainModule := range mains {
+		edges := make([]string, 0)
+		if mod, err := parseGoModFile(filepath.Join(mainModule.Dir, goModFileName)); err == nil {
+			for _, req := range mod.Direct {
+				if known[req] {
+					edges = append(edges, req)
+				}
+			}
+		} else {
+			// Without a readable go.mod, every explicit requirement is a
+			// possible direct dependency.
+			edges = append(edges, explicit...)
+		}
+		if len(edges) > 0 {
+			graph[mainModule.Path] = uniqueSortedStrings(edges)
+		}
+	}
+
+	for _, m := range vendored {
+		edges := make([]string, 0)
+		for _, pkg := range m.Packages {
+			for _, imp := range goPackageImports(filepath.Join(vendorDir, filepath.FromSlash(pkg))) {
+				if owner, ok := moduleByPackage[imp]; ok && owner != m.Path {
+					edges = append(edges, owner)
+				}
+			}
+		}
+		if len(edges) > 0 {
+			graph[m.Path] = uniqueSortedStrings(edges)
+		}
+	}
+}
+
+// goPackageImports returns the imports of the non-test Go files in dir.
+// Files that fail to parse are ignored.
+func goPackageImports(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	var imports []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, parseErr := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ImportsOnly)
+		if parseErr != nil {
+			continue
+		}
+		for _, spec := range file.Imports {
+			if path, unquoteErr := strconv.Unquote(spec.Path.Value); unquoteErr == nil {
+				imports = append(imports, path)
+			}
+		}
+	}
+	return imports
+}
+
+// parseGoVendorModules parses vendor/modules.txt. Module lines have the form
+// "# path version [=> replacement [version]]", followed by "## " annotation
+// lines and one line per vendored package.
+func parseGoVendorModules(path string) ([]goVendorModule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var modules []goVendorModule
+	var current *goVendorModule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "## "):
+			if current != nil && goVendorAnnotationHas(line, "explicit") {
+				current.Explicit = true
+			}
+		case strings.HasPrefix(line, "# "):
+			modules = append(modules, parseGoVendorModuleLine(strings.TrimPrefix(line, "# ")))
+			current = &modules[len(modules)-1]
+		case current != nil:
+			current.Packages = append(current.Packages, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+func parseGoVendorModuleLine(line string) goVendorModule {
+	original, replacement, _ := strings.Cut(line, "=>")
+	fields := strings.Fields(original)
+	var m goVendorModule
+	if len(fields) > 0 {
+		m.Path = fields[0]
+	}
+	if len(fields) > 1 {
+		m.Version = fields[1]
+	}
+	if replaced := strings.Fields(replacement); len(replaced) > 1 {
+		m.ReplacementVersion = replaced[1]
+	}
+	return m
+}
+
+func goVendorAnnotationHas(line, annotation string) bool {
+	for _, part := range strings.Split(strings.TrimPrefix(line, "## "), ";") {
+		if strings.TrimSpace(part) == annotation {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGoModFile reads the module path and direct requirements of a go.mod.
+func parseGoModFile(path string) (goModFile, error) {
+	var mod goModFile
+	lines, err := readGoDirectiveLines(path)
+	if err != nil {
+		return mod, err
+	}
+
+	for _, line := range lines {
+		switch line.verb {
+		case "module":
+			if len(line.args) > 0 {
+				mod.Module = line.args[0]
+			}
+		case "require":
+			if len(line.args) > 1 && !line.indirect {
+				mod.Direct = append(mod.Direct, line.args[0])
+			}
+		}
+	}
+	return mod, nil
+}
+
+// parseGoWorkUses returns the module directories listed by "use" directives.
+func parseGoWorkUses(path string) ([]string, error) {
+	lines, err := readGoDirectiveLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var uses []string
+	for _, line := range lines {
+		if line.verb == "use" && len(line.args) > 0 {
+			uses = append(uses, line.args[0])
+		}
+	}
+	return uses, nil
+}
+
+// goDirectiveLine is one directive of a go.mod or go.work file, with
+// factored blocks ("require ( ... )") expanded to one line per entry.
+type goDirectiveLine struct {
+	verb     string
+	args     []string
+	indirect bool
+}
+
+func readGoDirectiveLines(path string) ([]goDirectiveLine, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var lines []goDirectiveLine
+	block := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		text, comment, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case block != "" && fields[0] == ")":
+			block = ""
+			continue
+		case block == "" && len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		}
+
+		line := goDirectiveLine{indirect: strings.TrimSpace(comment) == "indirect"}
+		if block != "" {
+			line.verb = block
+			line.args = fields
+		} else {
+			line.verb = fields[0]
+			line.args = fields[1:]
+		}
+		for i, arg := range line.args {
+			if unquoted, unquoteErr := strconv.Unquote(arg); unquoteErr == nil {
+				line.args[i] = unquoted
+			}
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func uniqueSortedStrings(values []string) []string {
+	sort.Strings(values)
+	out := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			out = append(out, value)
+		}
+	}
+	return out
+}
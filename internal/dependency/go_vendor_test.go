@@ -0,0 +1,214 @@
+package dependency
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeGoTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// failGoCommand puts a `go` on PATH that fails, so tests prove the vendor
+// path never invokes the go command.
+func failGoCommand(t *testing.T) {
+	t.Helper()
+	tmpBin := t.TempDir()
+	writeExecutable(t, tmpBin, "go", "#!/bin/sh\necho \"go must not run: $*\" >&2\nexit 1\n")
+	prependPath(t, tmpBin)
+}
+
+func TestGoResolver_ResolveVendor(t *testing.T) {
+	failGoCommand(t)
+	t.Setenv("GOFLAGS", "")
+
+	target := t.TempDir()
+	writeGoTestFile(t, filepath.Join(target, "go.mod"), `module example.com/app
+
+go 1.22
+
+require (
+	golang.org/x/crypto v0.17.0
+	example.com/old v1.0.0
+	golang.org/x/sys v0.15.0 // indirect
+)
+`)
+	writeGoTestFile(t, filepath.Join(target, "vendor", "modules.txt"), `# golang.org/x/crypto v0.17.0
+## explicit; go 1.18
+golang.org/x/crypto/chacha20
+golang.org/x/crypto/internal/alias
+# golang.org/x/sys v0.15.0
+## explicit; go 1.18
+golang.org/x/sys/cpu
+# example.com/old v1.0.0 => example.com/new v1.1.0
+## explicit
+example.com/old/pkg
+# example.com/unused v0.1.0
+## explicit
+# example.com/local => ../local
+`)
+	writeGoTestFile(t, filepath.Join(target, "vendor", "golang.org", "x", "crypto", "chacha20", "chacha.go"), `package chacha20
+
+import (
+	"golang.org/x/crypto/internal/alias"
+	"golang.org/x/sys/cpu"
+	"crypto/cipher"
+)
+
+var _ = alias.AnyOverlap
+var _ = cpu.X86
+var _ cipher.Stream
+`)
+	writeGoTestFile(t, filepath.Join(target, "vendor", "golang.org", "x", "crypto", "chacha20", "chacha_test.go"), `package chacha20
+
+import "example.com/old/pkg"
+`)
+	writeGoTestFile(t, filepath.Join(target, "vendor", "golang.org", "x", "crypto", "internal", "alias", "alias.go"), "package alias\n")
+	writeGoTestFile(t, filepath.Join(target, "vendor", "golang.org", "x", "sys", "cpu", "cpu.go"), "package cpu\n")
+	writeGoTestFile(t, filepath.Join(target, "vendor", "example.com", "old", "pkg", "pkg.go"), "package pkg\n")
+
+	result, err := NewGoResolver().Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "example.com/app" {
+		t.Fatalf("RootModule = %q, want example.com/app", result.RootModule)
+	}
+	if len(result.WorkspaceMembers) != 0 {
+		t.Fatalf("WorkspaceMembers = %+v, want none", result.WorkspaceMembers)
+	}
+
+	want := []Dependency{
+		{Module: "golang.org/x/crypto", Version: "v0.17.0", Dir: filepath.Join(target, "vendor", "golang.org", "x", "crypto")},
+		{Module: "golang.org/x/sys", Version: "v0.15.0", Dir: filepath.Join(target, "vendor", "golang.org", "x", "sys")},
+		{Module: "example.com/old", Version: "v1.1.0", Dir: filepath.Join(target, "vendor", "example.com", "old")},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+
+	wantGraph := map[string][]string{
+		"example.com/app":     {"example.com/old", "golang.org/x/crypto"},
+		"golang.org/x/crypto": {"golang.org/x/sys"},
+	}
+	if !reflect.DeepEqual(result.Graph, wantGraph) {
+		t.Fatalf("Graph = %+v, want %+v", result.Graph, wantGraph)
+	}
+}
+
+func TestGoResolver_ResolveVendorWorkspace(t *testing.T) {
+	failGoCommand(t)
+	t.Setenv("GOFLAGS", "")
+
+	target := t.TempDir()
+	writeGoTestFile(t, filepath.Join(target, "go.work"), `go 1.22
+
+use ./svc // the service
+
+use (
+	"./lib"
+	./missing
+)
+`)
+	writeGoTestFile(t, filepath.Join(target, "svc", "go.mod"), "module example.com/svc\n\nrequire example.com/lib v0.0.0\nrequire golang.org/x/crypto v0.17.0\n")
+	writeGoTestFile(t, filepath.Join(target, "lib", "go.mod"), "module example.com/lib\n")
+	writeGoTestFile(t, filepath.Join(target, "vendor", "modules.txt"), `## workspace
+# golang.org/x/crypto v0.17.0
+## explicit; go 1.18
+golang.org/x/crypto/sha3
+`)
+	writeGoTestFile(t, filepath.Join(target, "vendor", "golang.org", "x", "crypto", "sha3", "sha3.go"), "package sha3\n")
+
+	result, err := NewGoResolver().Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	wantMembers := []WorkspaceMember{
+		{Name: "example.com/svc", Dir: filepath.Join(target, "svc")},
+		{Name: "example.com/lib", Dir: filepath.Join(target, "lib")},
+	}
+	if !reflect.DeepEqual(result.WorkspaceMembers, wantMembers) {
+		t.Fatalf("WorkspaceMembers = %+v, want %+v", result.WorkspaceMembers, wantMembers)
+	}
+	if result.RootModule != "example.com/svc" {
+		t.Fatalf("RootModule = %q, want example.com/svc", result.RootModule)
+	}
+	if len(result.Dependencies) != 1 || result.Dependencies[0].Module != "golang.org/x/crypto" {
+		t.Fatalf("Dependencies = %+v, want golang.org/x/crypto only", result.Dependencies)
+	}
+	if got := result.Graph["example.com/svc"]; !reflect.DeepEqual(got, []string{"golang.org/x/crypto"}) {
+		t.Fatalf("Graph[svc] = %v, want [golang.org/x/crypto]", got)
+	}
+	if got, ok := result.Graph["example.com/lib"]; ok {
+		t.Fatalf("Graph[lib] = %v, want no edges", got)
+	}
+}
+
+func TestGoVendorModulesFile_GOFLAGS(t *testing.T) {
+	target := t.TempDir()
+	writeGoTestFile(t, filepath.Join(target, "vendor", "modules.txt"), "")
+
+	t.Setenv("GOFLAGS", "-mod=vendor")
+	if got := goVendorModulesFile(target); got == "" {
+		t.Fatal("goVendorModulesFile with -mod=vendor = \"\", want modules.txt")
+	}
+
+	for _, flags := range []string{"-mod=mod", "-trimpath --mod=readonly"} {
+		t.Setenv("GOFLAGS", flags)
+		if got := goVendorModulesFile(target); got != "" {
+			t.Fatalf("goVendorModulesFile with GOFLAGS=%q = %q, want \"\"", flags, got)
+		}
+	}
+
+	t.Setenv("GOFLAGS", "")
+	if got := goVendorModulesFile(t.TempDir()); got != "" {
+		t.Fatalf("goVendorModulesFile without vendor = %q, want \"\"", got)
+	}
+}
+
+func TestGoResolver_ResolveWorkspaceMainModules(t *testing.T) {
+	target := t.TempDir()
+	tmpBin := t.TempDir()
+	writeExecutable(t, tmpBin, "go", `#!/bin/sh
+if [ "$1" = "list" ]; then
+  cat <<JSON
+{"Path":"example.com/lib","Main":true,"Dir":"`+filepath.Join(target, "lib")+`"}
+{"Path":"example.com/app","Main":true,"Dir":"`+target+`"}
+{"Path":"example.com/dep","Version":"v1.0.0","Dir":"/deps/dep"}
+JSON
+  exit 0
+fi
+exit 1
+`)
+	prependPath(t, tmpBin)
+	t.Setenv("GOFLAGS", "")
+
+	result, err := NewGoResolver().Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if result.RootModule != "example.com/app" {
+		t.Fatalf("RootModule = %q, want example.com/app", result.RootModule)
+	}
+	wantMembers := []WorkspaceMember{
+		{Name: "example.com/lib", Dir: filepath.Join(target, "lib")},
+		{Name: "example.com/app", Dir: target},
+	}
+	if !reflect.DeepEqual(result.WorkspaceMembers, wantMembers) {
+		t.Fatalf("WorkspaceMembers = %+v, want %+v", result.WorkspaceMembers, wantMembers)
+	}
+	if len(result.Dependencies) != 1 {
+		t.Fatalf("Dependencies len = %d, want 1", len(result.Dependencies))
+	}
+}
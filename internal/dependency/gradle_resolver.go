@@ -31,6 +31,7 @@ type gradleDependencyRecord struct {
 	Version           string `json:"version"`
 	BinaryPath        string `json:"binaryPath"`
 	SourceArchivePath string `json:"sourceArchivePath"`
+	Scope             string `json:"scope"`
 }
 
 type gradleWorkspaceMember struct {
@@ -49,6 +50,7 @@ type gradleResolveOutput struct {
 type GradleResolver struct {
 	javaRuntime javaruntime.Config
 	lookPath    func(string) (string, error)
+	scopes      []string
 }
 
 // NewGradleResolver creates a new Gradle dependency resolver.
@@ -61,6 +63,24 @@ func (r *GradleResolver) SetJavaRuntime(cfg javaruntime.Config) {
 	r.javaRuntime = cfg
 }
 
+// SetDependencyScopes adds the runtime and test classpaths to the exported
+// model when runtime or test dependencies are requested.
+func (r *GradleResolver) SetDependencyScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+// classpaths returns the configurations the export walks, compile first.
+func (r *GradleResolver) classpaths() []string {
+	classpaths := []string{"compileClasspath"}
+	if scopesRequested(r.scopes, ScopeRuntime) {
+		classpaths = append(classpaths, "runtimeClasspath")
+	}
+	if scopesRequested(r.scopes, ScopeTest) {
+		classpaths = append(classpaths, "testCompileClasspath", "testRuntimeClasspath")
+	}
+	return classpaths
+}
+
 // Ecosystem returns "java".
 func (r *GradleResolver) Ecosystem() string {
 	return ecosystemJava
@@ -109,6 +129,7 @@ func (r *GradleResolver) Resolve(ctx context.Context, targetDir string) (*Resolv
 			Version:              dep.Version,
 			CompiledArtifactPath: dep.BinaryPath,
 			SourceArchivePath:    dep.SourceArchivePath,
+			Scope:                dep.Scope,
 		}
 
 		if dep.SourceArchivePath != "" {
@@ -179,6 +200,7 @@ func (r *GradleResolver) exportDependencyModel(ctx context.Context, targetDir, c
 		"--no-parallel",
 		"--console=plain",
 		"-Dscanoss.crypto.finder.output=" + outPath,
+		"-Dscanoss.crypto.finder.configurations=" + strings.Join(r.classpaths(), ","),
 		gradleExportTaskName,
 	}
 
@@ -595,6 +617,7 @@ func canonicalGradleDependencies(deps []gradleDependencyRecord) []gradleDependen
 		if existing.SourceArchivePath == "" && dep.SourceArchivePath != "" {
 			existing.SourceArchivePath = dep.SourceArchivePath
 		}
+		existing.Scope = widerScope(existing.Scope, dep.Scope)
 		byKey[key] = existing
 	}
 
@@ -143,6 +143,21 @@ exit 0
 	if !strings.Contains(string(data), "--no-parallel\n") {
 		t.Fatalf("expected --no-parallel in Gradle args, got:\n%s", data)
 	}
+	if !strings.Contains(string(data), "-Dscanoss.crypto.finder.configurations=compileClasspath\n") {
+		t.Fatalf("expected only compileClasspath by default, got:\n%s", data)
+	}
+
+	resolver.SetDependencyScopes([]string{ScopeCompile, ScopeTest})
+	if _, err := resolver.Resolve(context.Background(), project); err != nil {
+		t.Fatalf("Resolve with test scope: %v", err)
+	}
+	data, err = os.ReadFile(argsCapture)
+	if err != nil {
+		t.Fatalf("read args capture: %v", err)
+	}
+	if !strings.Contains(string(data), "-Dscanoss.crypto.finder.configurations=compileClasspath,testCompileClasspath,testRuntimeClasspath\n") {
+		t.Fatalf("expected test classpaths with the test scope, got:\n%s", data)
+	}
 }
 
 func TestGradleResolver_Resolve_FallsBackToPathGradle(t *testing.T) {
@@ -11,8 +11,10 @@ import (
 )
 
 const (
-	javaBuildToolMaven  = "maven"
-	javaBuildToolGradle = "gradle"
+	javaBuildToolMaven     = "maven"
+	javaBuildToolGradle    = "gradle"
+	javaBuildToolSbt       = "sbt"
+	javaBuildToolLeiningen = "leiningen"
 )
 
 // JavaRuntimeConfigurer configures which Java runtime a resolver should use for
@@ -26,14 +28,19 @@ type JavaRuntimeConfigurer interface {
 type JavaResolver struct {
 	maven       *MavenResolver
 	gradle      *GradleResolver
+	sbt         *SbtResolver
+	lein        *LeinResolver
 	javaRuntime javaruntime.Config
 }
 
-// NewJavaResolver creates a Java resolver that auto-detects Maven vs Gradle.
+// NewJavaResolver creates a Java resolver that auto-detects Maven, Gradle,
+// sbt or Leiningen.
 func NewJavaResolver() *JavaResolver {
 	return &JavaResolver{
 		maven:  NewMavenResolver(),
 		gradle: NewGradleResolver(),
+		sbt:    NewSbtResolver(),
+		lein:   NewLeinResolver(),
 	}
 }
 
@@ -51,6 +58,29 @@ func (r *JavaResolver) SetJavaRuntime(cfg javaruntime.Config) {
 	if r.gradle != nil {
 		r.gradle.SetJavaRuntime(cfg)
 	}
+	if r.sbt != nil {
+		r.sbt.SetJavaRuntime(cfg)
+	}
+	if r.lein != nil {
+		r.lein.SetJavaRuntime(cfg)
+	}
+}
+
+// SetDependencyScopes configures which scopes the Java build-tool resolvers
+// resolve.
+func (r *JavaResolver) SetDependencyScopes(scopes []string) {
+	if r.maven != nil {
+		r.maven.SetDependencyScopes(scopes)
+	}
+	if r.gradle != nil {
+		r.gradle.SetDependencyScopes(scopes)
+	}
+	if r.sbt != nil {
+		r.sbt.SetDependencyScopes(scopes)
+	}
+	if r.lein != nil {
+		r.lein.SetDependencyScopes(scopes)
+	}
 }
 
 // Resolve delegates to the Java build-tool-specific resolver selected for targetDir.
@@ -65,6 +95,10 @@ func (r *JavaResolver) Resolve(ctx context.Context, targetDir string) (*ResolveR
 		return r.maven.Resolve(ctx, targetDir)
 	case javaBuildToolGradle:
 		return r.gradle.Resolve(ctx, targetDir)
+	case javaBuildToolSbt:
+		return r.sbt.Resolve(ctx, targetDir)
+	case javaBuildToolLeiningen:
+		return r.lein.Resolve(ctx, targetDir)
 	default:
 		return nil, failure.New(
 			failure.CodeDependencyBuildToolUnknown,
@@ -77,6 +111,8 @@ func (r *JavaResolver) Resolve(ctx context.Context, targetDir string) (*ResolveR
 
 // DetectJavaBuildTool inspects the repository root and chooses the Java build tool.
 // It fails clearly if both Maven and Gradle manifests are present at the root.
+// sbt (build.sbt) and Leiningen (project.clj) builds are only selected when
+// neither is present, since those projects often ship a generated pom.xml.
 func DetectJavaBuildTool(targetDir string) (string, error) {
 	hasPom := fileExists(filepath.Join(targetDir, "pom.xml"))
 	hasGradle := hasGradleManifest(targetDir)
@@ -93,6 +129,10 @@ func DetectJavaBuildTool(targetDir string) (string, error) {
 		return javaBuildToolMaven, nil
 	case hasGradle:
 		return javaBuildToolGradle, nil
+	case fileExists(filepath.Join(targetDir, "build.sbt")):
+		return javaBuildToolSbt, nil
+	case fileExists(filepath.Join(targetDir, leinProjectFile)):
+		return javaBuildToolLeiningen, nil
 	default:
 		return "", failure.New(
 			failure.CodeDependencyBuildToolUnknown,
@@ -105,7 +145,8 @@ func DetectJavaBuildTool(targetDir string) (string, error) {
 
 // HasJavaManifest reports whether the target root contains a supported Java build manifest.
 func HasJavaManifest(targetDir string) bool {
-	return fileExists(filepath.Join(targetDir, "pom.xml")) || hasGradleManifest(targetDir)
+	return fileExists(filepath.Join(targetDir, "pom.xml")) || hasGradleManifest(targetDir) ||
+		fileExists(filepath.Join(targetDir, "build.sbt")) || fileExists(filepath.Join(targetDir, leinProjectFile))
 }
 
 func hasGradleManifest(targetDir string) bool {
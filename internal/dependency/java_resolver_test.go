@@ -56,6 +56,41 @@ func TestDetectJavaBuildTool(t *testing.T) {
 		}
 	})
 
+	t.Run("sbt-and-leiningen", func(t *testing.T) {
+		for file, want := range map[string]string{"build.sbt": javaBuildToolSbt, "project.clj": javaBuildToolLeiningen} {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, file), []byte(""), 0o600); err != nil {
+				t.Fatalf("write %s: %v", file, err)
+			}
+			tool, err := DetectJavaBuildTool(dir)
+			if err != nil {
+				t.Fatalf("DetectJavaBuildTool(%s): %v", file, err)
+			}
+			if tool != want {
+				t.Fatalf("tool = %q, want %q", tool, want)
+			}
+			if !HasJavaManifest(dir) {
+				t.Fatalf("HasJavaManifest() = false for %s, want true", file)
+			}
+		}
+	})
+
+	t.Run("generated-pom-wins-over-sbt", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, file := range []string{"pom.xml", "build.sbt"} {
+			if err := os.WriteFile(filepath.Join(dir, file), []byte(""), 0o600); err != nil {
+				t.Fatalf("write %s: %v", file, err)
+			}
+		}
+		tool, err := DetectJavaBuildTool(dir)
+		if err != nil {
+			t.Fatalf("DetectJavaBuildTool: %v", err)
+		}
+		if tool != javaBuildToolMaven {
+			t.Fatalf("tool = %q, want %q", tool, javaBuildToolMaven)
+		}
+	})
+
 	t.Run("mixed-manifests-fail-clearly", func(t *testing.T) {
 		dir := t.TempDir()
 		if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project/>"), 0o600); err != nil {
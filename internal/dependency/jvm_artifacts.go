@@ -0,0 +1,225 @@
+package dependency
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxCoursierRepositoryDepth bounds how many path segments of a repository
+// URL (e.g. "maven2" in repo1.maven.org/maven2) are matched between the
+// host and the group directories of a coursier cache entry.
+const maxCoursierRepositoryDepth = 3
+
+// jvmArtifactLocator finds the binary and sources jars of resolved Maven
+// coordinates in the local caches sbt and Leiningen download into: the
+// coursier cache, the Ivy cache and the local Maven repository.
+type jvmArtifactLocator struct {
+	coursierDirs []string
+	ivyCache     string
+	m2Repo       string
+}
+
+func newJVMArtifactLocator() *jvmArtifactLocator {
+	locator := &jvmArtifactLocator{coursierDirs: coursierCacheDirs()}
+	if home, err := os.UserHomeDir(); err == nil {
+		locator.ivyCache = filepath.Join(home, ".ivy2", "cache")
+		locator.m2Repo = filepath.Join(home, ".m2", "repository")
+	}
+	return locator
+}
+
+// coursierCacheDirs returns the coursier v1 cache directories that exist:
+// $COURSIER_CACHE and the platform default.
+func coursierCacheDirs() []string {
+	var candidates []string
+	if dir := os.Getenv("COURSIER_CACHE"); dir != "" {
+		candidates = append(candidates, dir, filepath.Join(dir, "v1"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		switch runtime.GOOS {
+		case "darwin":
+			candidates = append(candidates, filepath.Join(home, "Library", "Caches", "Coursier", "v1"))
+		case "windows":
+			candidates = append(candidates, filepath.Join(os.Getenv("LOCALAPPDATA"), "Coursier", "Cache", "v1"))
+		default:
+			cacheHome := os.Getenv("XDG_CACHE_HOME")
+			if cacheHome == "" {
+				cacheHome = filepath.Join(home, ".cache")
+			}
+			candidates = append(candidates, filepath.Join(cacheHome, "coursier", "v1"))
+		}
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if isDir(filepath.Join(dir, "https")) || isDir(filepath.Join(dir, "http")) {
+			dirs = appendUniqueStrings(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// paths returns the binary and sources jars of ref, or "" for the ones not
+// found in any cache.
+func (l *jvmArtifactLocator) paths(ref Ref) (string, string) {
+	group, artifact, ok := strings.Cut(ref.Module, ":")
+	if !ok || ref.Version == "" {
+		return "", ""
+	}
+	jarName := artifact + "-" + ref.Version + ".jar"
+	sourcesName := artifact + "-" + ref.Version + "-sources.jar"
+	groupPath := filepath.Join(strings.Split(group, ".")...)
+
+	var jar, sources string
+	find := func(dir, jarFile, sourcesFile string) {
+		if jar == "" && fileExists(filepath.Join(dir, jarFile)) {
+			jar = filepath.Join(dir, jarFile)
+		}
+		if sources == "" && fileExists(filepath.Join(dir, sourcesFile)) {
+			sources = filepath.Join(dir, sourcesFile)
+		}
+	}
+
+	for _, cacheDir := range l.coursierDirs {
+		for depth := 0; depth <= maxCoursierRepositoryDepth; depth++ {
+			parts := []string{cacheDir, "*", "*"}
+			for i := 0; i < depth; i++ {
+				parts = append(parts, "*")
+			}
+			parts = append(parts, groupPath, artifact, ref.Version)
+			matches, err := filepath.Glob(filepath.Join(parts...))
+			if err != nil {
+				continue
+			}
+			for _, dir := range matches {
+				find(dir, jarName, sourcesName)
+			}
+		}
+	}
+	if l.ivyCache != "" {
+		dir := filepath.Join(l.ivyCache, group, artifact)
+		find(filepath.Join(dir, "jars"), jarName, "")
+		find(filepath.Join(dir, "srcs"), "", sourcesName)
+	}
+	if l.m2Repo != "" {
+		find(filepath.Join(l.m2Repo, groupPath, artifact, ref.Version), jarName, sourcesName)
+	}
+	return jar, sources
+}
+
+// dependency returns ref as a Dependency with its cached jars, extracting
+// the sources jar into cache.
+func (l *jvmArtifactLocator) dependency(ref Ref, cache *SourceCache) Dependency {
+	dep := Dependency{Module: ref.Module, Version: ref.Version}
+	dep.CompiledArtifactPath, dep.SourceArchivePath = l.paths(ref)
+	if cache == nil {
+		return dep
+	}
+	if dir := cache.CachedDir(dep.Module, dep.Version); dir != "" {
+		dep.Dir = dir
+		return dep
+	}
+	if dep.SourceArchivePath != "" {
+		dir, err := cache.ExtractZip(dep.SourceArchivePath, dep.Module, dep.Version, []string{".java"})
+		if err != nil {
+			log.Debug().Err(err).Str("module", dep.Module).Str("archive", dep.SourceArchivePath).Msg("Failed to extract source JAR")
+		}
+		dep.Dir = dir
+	}
+	return dep
+}
+
+// jvmTree accumulates the dependency trees printed by sbt and Leiningen:
+// each node's children, in first-seen order, the project's direct
+// dependencies, and the scope of the trees each node was seen in.
+type jvmTree struct {
+	nodes  []Ref
+	seen   map[Ref]bool
+	scopes map[Ref]string
+	scope  string
+	direct []Ref
+	edges  map[string][]Ref
+	stack  []Ref
+}
+
+func newJVMTree() *jvmTree {
+	return &jvmTree{seen: make(map[Ref]bool), scopes: make(map[Ref]string), edges: make(map[string][]Ref)}
+}
+
+// setScope sets the scope of the nodes added next: the scope of the
+// classpath or profile the tree being read was printed for.
+func (t *jvmTree) setScope(scope string) {
+	t.scope = scope
+}
+
+// add records node at depth (1 for a direct dependency) below the nodes
+// most recently added at shallower depths.
+func (t *jvmTree) add(node Ref, depth int) {
+	if depth < 1 {
+		return
+	}
+	if depth-1 < len(t.stack) {
+		t.stack = t.stack[:depth-1]
+	}
+	if len(t.stack) < depth-1 {
+		// A gap in the indentation; attach to the deepest known ancestor.
+		depth = len(t.stack) + 1
+	}
+	if depth == 1 {
+		if !containsRef(t.direct, node) {
+			t.direct = append(t.direct, node)
+		}
+	} else {
+		parent := t.stack[len(t.stack)-1]
+		if !containsRef(t.edges[parent.Key()], node) {
+			t.edges[parent.Key()] = append(t.edges[parent.Key()], node)
+		}
+	}
+	t.stack = append(t.stack, node)
+	t.scopes[node] = widerScope(t.scopes[node], t.scope)
+	if !t.seen[node] {
+		t.seen[node] = true
+		t.nodes = append(t.nodes, node)
+	}
+}
+
+// skip drops a node at depth that is not a dependency (another project of
+// the build); its children attach to its parent.
+func (t *jvmTree) skip(depth int) {
+	if depth-1 < len(t.stack) {
+		t.stack = t.stack[:max(depth-1, 0)]
+	}
+}
+
+// resetProject starts the tree of another project of the build.
+func (t *jvmTree) resetProject() {
+	t.stack = t.stack[:0]
+}
+
+// result converts the tree into a ResolveResult rooted at rootModule,
+// locating each node's jars with locator. A node seen in several trees has
+// the scope closest to production code.
+func (t *jvmTree) result(rootModule string, locator *jvmArtifactLocator, cache *SourceCache) *ResolveResult {
+	result := &ResolveResult{
+		RootModule:     rootModule,
+		Dependencies:   make([]Dependency, 0, len(t.nodes)),
+		VersionedGraph: make(map[string][]Ref, len(t.edges)+1),
+	}
+	for _, node := range t.nodes {
+		dep := locator.dependency(node, cache)
+		dep.Scope = t.scopes[node]
+		result.Dependencies = append(result.Dependencies, dep)
+	}
+	if len(t.direct) > 0 {
+		result.VersionedGraph[rootModule] = t.direct
+	}
+	for key, children := range t.edges {
+		result.VersionedGraph[key] = children
+	}
+	result.Graph = legacyGraphFromVersioned(result.VersionedGraph)
+	return result
+}
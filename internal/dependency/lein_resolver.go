@@ -0,0 +1,217 @@
+package dependency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/javaruntime"
+)
+
+const leinProjectFile = "project.clj"
+
+var (
+	leinTreeNodePattern   = regexp.MustCompile(`^( *)\[([^\s\[\]"]+) "([^"]+)"`)
+	leinDefprojectPattern = regexp.MustCompile(`\(defproject\s+([^\s"()]+)`)
+)
+
+// LeinResolver resolves Clojure/JVM dependencies of Leiningen projects from
+// the output of `lein deps :tree`. Leiningen downloads into the local Maven
+// repository; missing source jars are fetched with Maven when it is
+// available.
+type LeinResolver struct {
+	javaRuntime javaruntime.Config
+	lookPath    func(string) (string, error)
+	maven       *MavenResolver
+	scopes      []string
+}
+
+// leinProfileSet is a profile set `lein with-profile` prints the tree of and
+// the scope of the dependencies it adds to the production tree.
+type leinProfileSet struct {
+	profiles string
+	scope    string
+}
+
+// NewLeinResolver creates a new Leiningen dependency resolver.
+func NewLeinResolver() *LeinResolver {
+	return &LeinResolver{lookPath: exec.LookPath, maven: NewMavenResolver()}
+}
+
+// SetJavaRuntime configures which Java runtime Leiningen (and the Maven
+// source fallback) should run with.
+func (r *LeinResolver) SetJavaRuntime(cfg javaruntime.Config) {
+	r.javaRuntime = cfg
+	r.maven.SetJavaRuntime(cfg)
+}
+
+// SetDependencyScopes adds the trees of the :provided, :dev and :test
+// profiles when provided, dev or test dependencies are requested.
+func (r *LeinResolver) SetDependencyScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+// profileSets returns the profile sets to print the tree of, production
+// first. Each one costs a Leiningen run, so they are only added on request.
+func (r *LeinResolver) profileSets() []leinProfileSet {
+	sets := []leinProfileSet{{profiles: "production", scope: ScopeCompile}}
+	if scopesRequested(r.scopes, ScopeProvided) {
+		sets = append(sets, leinProfileSet{profiles: "production,provided", scope: ScopeProvided})
+	}
+	if scopesRequested(r.scopes, ScopeDev) {
+		sets = append(sets, leinProfileSet{profiles: "production,dev", scope: ScopeDev})
+	}
+	if scopesRequested(r.scopes, ScopeTest) {
+		sets = append(sets, leinProfileSet{profiles: "production,test", scope: ScopeTest})
+	}
+	return sets
+}
+
+// Ecosystem returns "java".
+func (r *LeinResolver) Ecosystem() string {
+	return ecosystemJava
+}
+
+// Resolve runs `lein with-profile production deps :tree` in targetDir, so
+// the :dev profile (REPL tooling, test libraries) is left out. The
+// dependencies the :provided, :dev and :test profiles add are resolved, with
+// those scopes, when requested.
+func (r *LeinResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	command, err := r.lookPath("lein")
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, failure.New(
+				failure.CodeDependencyResolutionFailed,
+				failure.StageDependency,
+				fmt.Sprintf("Leiningen dependency scanning requires lein in PATH for %s", targetDir),
+				failure.WithDetail("target_dir", targetDir),
+			)
+		}
+		return nil, failure.WrapUnknown(err, failure.CodeDependencyResolutionFailed, failure.StageDependency,
+			"locate lein executable", failure.WithDetail("target_dir", targetDir))
+	}
+
+	tree := newJVMTree()
+	for i, set := range r.profileSets() {
+		output, err := r.dependencyTree(ctx, command, targetDir, set.profiles)
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			log.Warn().Err(err).Str("profiles", set.profiles).Msg("Failed to resolve Leiningen profile dependencies, skipping them")
+			continue
+		}
+		tree.setScope(set.scope)
+		parseLeinDependencyTree(tree, output)
+	}
+
+	locator := newJVMArtifactLocator()
+	r.fetchMissingSources(ctx, tree.nodes, locator)
+
+	cache, err := NewSourceCache()
+	if err != nil {
+		log.Warn().Err(err).Msg("Source cache unavailable, resolving Leiningen dependencies without sources")
+		cache = nil
+	}
+	result := tree.result(leinRootModule(targetDir), locator, cache)
+
+	log.Info().
+		Int("total", len(result.Dependencies)).
+		Int("withSources", countDependenciesWithSources(result.Dependencies)).
+		Str("root", result.RootModule).
+		Msg("Resolved Leiningen dependencies")
+
+	return result, nil
+}
+
+// dependencyTree returns the output of `lein with-profile <profiles> deps
+// :tree` run in targetDir.
+func (r *LeinResolver) dependencyTree(ctx context.Context, command, targetDir, profiles string) (string, error) {
+	cmd := exec.CommandContext(ctx, command, "with-profile", profiles, "deps", ":tree")
+	cmd.Dir = targetDir
+	if err := configureJavaRuntimeCommand(cmd, r.javaRuntime); err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", failure.Wrap(
+			err,
+			failure.CodeDependencyResolutionFailed,
+			failure.StageDependency,
+			"lein deps :tree failed",
+			failure.WithDetail("target_dir", targetDir),
+			failure.WithDetail("profiles", profiles),
+			failure.WithDetail("output", truncateForFailure(strings.TrimSpace(stderr.String()), 4000)),
+		)
+	}
+	return stdout.String(), nil
+}
+
+// fetchMissingSources downloads the source jars Leiningen did not fetch into
+// the local Maven repository, using the isolated Maven source fallback.
+func (r *LeinResolver) fetchMissingSources(ctx context.Context, nodes []Ref, locator *jvmArtifactLocator) {
+	var missing []Dependency
+	for _, node := range nodes {
+		if _, sources := locator.paths(node); sources == "" {
+			missing = append(missing, Dependency{Module: node.Module, Version: node.Version})
+		}
+	}
+	if len(missing) == 0 || locator.m2Repo == "" {
+		return
+	}
+	if _, err := r.lookPath("mvn"); err != nil {
+		log.Warn().Int("missingSources", len(missing)).Msg("mvn not found in PATH, Leiningen dependencies without source jars are skipped")
+		return
+	}
+	workers := min(len(missing), sourceFallbackMaxWorkers)
+	downloaded := r.maven.runIsolatedSourceFallback(ctx, missing, locator.m2Repo, workers)
+	log.Info().Int("missingSources", len(missing)).Int("downloaded", downloaded).Msg("Fetched Leiningen dependency sources with Maven")
+}
+
+// parseLeinDependencyTree adds the nodes of `lein deps :tree` to tree. It
+// prints one [group/artifact "version" ...] vector per line, indented two
+// spaces per level below a one-space top level.
+func parseLeinDependencyTree(tree *jvmTree, output string) {
+	for _, line := range strings.Split(output, "\n") {
+		match := leinTreeNodePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil || strings.Contains(line, " -> ") {
+			continue
+		}
+		tree.add(Ref{Module: leinModule(match[2]), Version: match[3]}, len(match[1])/2+1)
+	}
+}
+
+// leinModule converts a Leiningen dependency symbol to Maven coordinates:
+// "group/artifact", or "artifact" whose group is the artifact itself.
+func leinModule(symbol string) string {
+	group, artifact, ok := strings.Cut(symbol, "/")
+	if !ok {
+		return symbol + ":" + symbol
+	}
+	return group + ":" + artifact
+}
+
+// leinRootModule returns the group of the defproject in project.clj, the
+// namespace prefix of the project's own code, falling back to the directory
+// name.
+func leinRootModule(targetDir string) string {
+	data, err := os.ReadFile(filepath.Join(targetDir, leinProjectFile))
+	if err == nil {
+		if match := leinDefprojectPattern.FindSubmatch(data); match != nil {
+			group, _, _ := strings.Cut(string(match[1]), "/")
+			return group
+		}
+	}
+	return filepath.Base(targetDir)
+}
@@ -0,0 +1,182 @@
+package dependency
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const leinTreeFixture = ` [buddy/buddy-core "1.11.423"]
+   [commons-codec "1.16.0"]
+   [org.bouncycastle/bcpkix-jdk18on "1.75"]
+     [org.bouncycastle/bcutil-jdk18on "1.75"]
+   [org.bouncycastle/bcprov-jdk18on "1.75"]
+ [org.clojure/clojure "1.11.1"]
+   [org.clojure/core.specs.alpha "0.2.62"]
+`
+
+func TestParseLeinDependencyTree(t *testing.T) {
+	t.Parallel()
+
+	tree := newJVMTree()
+	parseLeinDependencyTree(tree, leinTreeFixture+"[a \"1\"] -> [b \"2\"]\n")
+
+	wantDirect := []Ref{
+		{Module: "buddy:buddy-core", Version: "1.11.423"},
+		{Module: "org.clojure:clojure", Version: "1.11.1"},
+	}
+	if !reflect.DeepEqual(tree.direct, wantDirect) {
+		t.Fatalf("direct = %#v, want %#v", tree.direct, wantDirect)
+	}
+	wantEdges := map[string][]Ref{
+		"buddy:buddy-core@1.11.423": {
+			{Module: "commons-codec:commons-codec", Version: "1.16.0"},
+			{Module: "org.bouncycastle:bcpkix-jdk18on", Version: "1.75"},
+			{Module: "org.bouncycastle:bcprov-jdk18on", Version: "1.75"},
+		},
+		"org.bouncycastle:bcpkix-jdk18on@1.75": {{Module: "org.bouncycastle:bcutil-jdk18on", Version: "1.75"}},
+		"org.clojure:clojure@1.11.1":           {{Module: "org.clojure:core.specs.alpha", Version: "0.2.62"}},
+	}
+	if !reflect.DeepEqual(tree.edges, wantEdges) {
+		t.Fatalf("edges = %#v, want %#v", tree.edges, wantEdges)
+	}
+	if len(tree.nodes) != 7 {
+		t.Fatalf("nodes = %v, want 7", tree.nodes)
+	}
+}
+
+func TestLeinRootModule(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if got := leinRootModule(dir); got != filepath.Base(dir) {
+		t.Fatalf("leinRootModule() without project.clj = %q, want %q", got, filepath.Base(dir))
+	}
+	writeProjectFiles(t, dir, map[string]string{"project.clj": "(defproject com.example/wallet \"0.1.0\"\n  :dependencies [])\n"})
+	if got := leinRootModule(dir); got != "com.example" {
+		t.Fatalf("leinRootModule() = %q, want com.example", got)
+	}
+}
+
+func TestLeinResolver_Resolve(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("COURSIER_CACHE", "")
+
+	artifactDir := filepath.Join(home, ".m2", "repository", "org", "bouncycastle", "bcprov-jdk18on", "1.75")
+	if err := os.MkdirAll(artifactDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(artifactDir, "bcprov-jdk18on-1.75.jar"), []byte("jar"), 0o600); err != nil {
+		t.Fatalf("write jar: %v", err)
+	}
+	createZipArchive(t, filepath.Join(artifactDir, "bcprov-jdk18on-1.75-sources.jar"), map[string]string{
+		"org/bouncycastle/crypto/digests/SHA256Digest.java": "package org.bouncycastle.crypto.digests;\n",
+	})
+
+	tmpBin := t.TempDir()
+	argsFile := filepath.Join(t.TempDir(), "args")
+	writeExecutable(t, tmpBin, "lein", "#!/bin/sh\necho \"$@\" > "+argsFile+"\ncat <<'EOF'\n"+leinTreeFixture+"EOF\n")
+
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{"project.clj": "(defproject com.example/wallet \"0.1.0\")\n"})
+
+	resolver := NewLeinResolver()
+	// No mvn: the missing source jars are skipped, not fetched.
+	resolver.lookPath = func(name string) (string, error) {
+		if name == "lein" {
+			return filepath.Join(tmpBin, "lein"), nil
+		}
+		return "", exec.ErrNotFound
+	}
+	result, err := resolver.Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("read args: %v", err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "with-profile production deps :tree" {
+		t.Fatalf("lein args = %q", got)
+	}
+	if result.RootModule != "com.example" || len(result.VersionedGraph["com.example"]) != 2 {
+		t.Fatalf("RootModule = %q, direct = %v", result.RootModule, result.VersionedGraph["com.example"])
+	}
+	if len(result.Dependencies) != 7 {
+		t.Fatalf("Dependencies = %v, want 7", result.Dependencies)
+	}
+	for _, dep := range result.Dependencies {
+		hasSources := dep.Module == "org.bouncycastle:bcprov-jdk18on"
+		if (dep.Dir != "") != hasSources {
+			t.Fatalf("%s Dir = %q, want sources only for bcprov", dep.Module, dep.Dir)
+		}
+		if dep.Scope != ScopeCompile {
+			t.Fatalf("%s Scope = %q, want compile", dep.Module, dep.Scope)
+		}
+	}
+}
+
+func TestLeinResolver_ResolveProfileScopes(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("COURSIER_CACHE", "")
+
+	tmpBin := t.TempDir()
+	argsFile := filepath.Join(t.TempDir(), "args")
+	writeExecutable(t, tmpBin, "lein", `#!/bin/sh
+echo "$@" >> `+argsFile+`
+echo ' [org.clojure/clojure "1.11.1"]'
+case "$2" in
+  production,dev) echo ' [criterium "0.4.6"]' ;;
+  production,test) echo ' [org.clojure/clojure "1.11.1"]'; echo ' [lambdaisland/kaocha "1.87.1366"]' ;;
+esac
+`)
+
+	target := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{"project.clj": "(defproject com.example/wallet \"0.1.0\")\n"})
+
+	resolver := NewLeinResolver()
+	resolver.lookPath = func(name string) (string, error) {
+		if name == "lein" {
+			return filepath.Join(tmpBin, "lein"), nil
+		}
+		return "", exec.ErrNotFound
+	}
+	resolver.SetDependencyScopes([]string{ScopeCompile, ScopeDev, ScopeTest})
+	result, err := resolver.Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("read args: %v", err)
+	}
+	wantArgs := "with-profile production deps :tree\n" +
+		"with-profile production,dev deps :tree\n" +
+		"with-profile production,test deps :tree\n"
+	if string(args) != wantArgs {
+		t.Fatalf("lein runs = %q, want %q", args, wantArgs)
+	}
+
+	scopes := make(map[string]string)
+	for _, dep := range result.Dependencies {
+		scopes[dep.Module] = dep.Scope
+	}
+	wantScopes := map[string]string{
+		"org.clojure:clojure": ScopeCompile,
+		"criterium:criterium": ScopeDev,
+		"lambdaisland:kaocha": ScopeTest,
+	}
+	if !reflect.DeepEqual(scopes, wantScopes) {
+		t.Fatalf("scopes = %v, want %v", scopes, wantScopes)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -77,6 +78,7 @@ type mavenSourceSummary struct {
 // MavenResolver resolves Java/Maven dependencies using the `mvn` tool.
 type MavenResolver struct {
 	javaRuntime javaruntime.Config
+	scopes      []string
 }
 
 // NewMavenResolver creates a new Maven dependency resolver.
@@ -89,6 +91,22 @@ func (r *MavenResolver) SetJavaRuntime(cfg javaruntime.Config) {
 	r.javaRuntime = cfg
 }
 
+// SetDependencyScopes widens the dependency listing from the compile
+// classpath to every Maven scope when runtime or test dependencies are
+// requested.
+func (r *MavenResolver) SetDependencyScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+// includeScope returns the Maven scope the dependency goal lists. "compile"
+// covers the compile, provided and system scopes; "test" covers every scope.
+func (r *MavenResolver) includeScope() string {
+	if scopesRequested(r.scopes, ScopeRuntime, ScopeTest) {
+		return "test"
+	}
+	return "compile"
+}
+
 // Ecosystem returns "java".
 func (r *MavenResolver) Ecosystem() string {
 	return ecosystemJava
@@ -225,7 +243,7 @@ func (r *MavenResolver) listDependencyTree(ctx context.Context, targetDir string
 	// #nosec G702 -- exec.CommandContext is used without a shell and with fixed arguments.
 	cmd := exec.CommandContext(ctx, "mvn", "dependency:tree",
 		"-DoutputFile="+tmpPath,
-		"-Dscope=compile",
+		"-Dscope="+r.includeScope(),
 		"-DoutputType=text",
 		"--fail-never",
 	)
@@ -470,13 +488,13 @@ func (r *MavenResolver) listDependencies(ctx context.Context, targetDir string)
 
 	// includeScope=compile includes compile + provided + system scopes (Maven's
 	// scope hierarchy). This captures all dependencies the source code can call into,
-	// while excluding test-only dependencies.
+	// while excluding test-only dependencies unless runtime or test scopes are requested.
 	// --fail-never continues past module failures in multi-module builds.
 	// -DappendOutput=true appends each module's output instead of overwriting.
 	// #nosec G702 -- exec.CommandContext is used without a shell and with fixed arguments.
 	cmd := exec.CommandContext(ctx, "mvn", "dependency:list",
 		"-DoutputFile="+tmpPath,
-		"-DincludeScope=compile",
+		"-DincludeScope="+r.includeScope(),
 		"-DoutputAbsoluteArtifactFilename=false",
 		"-DappendOutput=true",
 		"--fail-never",
@@ -530,7 +548,7 @@ func (r *MavenResolver) listDependencies(ctx context.Context, targetDir string)
 func (r *MavenResolver) parseDependencyList(output string) []Dependency {
 	lines := strings.Split(output, "\n")
 	deps := make([]Dependency, 0, len(lines))
-	seen := make(map[string]bool)
+	seen := make(map[string]int)
 
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -548,23 +566,51 @@ func (r *MavenResolver) parseDependencyList(output string) []Dependency {
 		// parts[2] is type (jar, pom, etc.)
 		// scope is always last, so version is the penultimate token even when a classifier is present.
 		version := parts[len(parts)-2]
+		scope := mavenScope(parts[len(parts)-1])
 
 		module := groupID + ":" + artifactID
 		key := dependencyCoordinateKey(module, version)
-		if seen[key] {
+		if i, ok := seen[key]; ok {
+			// Modules of a reactor may use the same artifact in different scopes.
+			deps[i].Scope = widerScope(deps[i].Scope, scope)
 			continue
 		}
-		seen[key] = true
+		seen[key] = len(deps)
 
 		deps = append(deps, Dependency{
 			Module:  module,
 			Version: version,
+			Scope:   scope,
 		})
 	}
 
 	return deps
 }
 
+// mavenScope maps the scope token of a dependency:list line, which may be
+// followed by " (optional)" or " -- module <name>", to a dependency scope.
+func mavenScope(token string) string {
+	fields := strings.Fields(token)
+	if len(fields) == 0 {
+		return ""
+	}
+	if slices.Contains(fields, "(optional)") {
+		return ScopeOptional
+	}
+	switch fields[0] {
+	case "compile":
+		return ScopeCompile
+	case "runtime":
+		return ScopeRuntime
+	case "provided", "system":
+		return ScopeProvided
+	case "test":
+		return ScopeTest
+	default:
+		return ""
+	}
+}
+
 // downloadSources runs a reactor-wide source download first, then falls back to
 // isolated per-dependency source fetches for dependencies that are still missing
 // local source JARs.
@@ -905,7 +951,7 @@ func updateMavenTreeStack(graph map[string][]Ref, stack []Ref, node Ref, depth i
 // listDependenciesPerModule resolves dependencies for each module independently.
 // This is Tier 2: when the reactor build fails, individual modules may still resolve.
 func (r *MavenResolver) listDependenciesPerModule(ctx context.Context, targetDir string, modules []string) (*listDepsResult, error) {
-	seen := make(map[string]bool)
+	seen := make(map[string]int)
 	var allDeps []Dependency
 	anyPartial := false
 
@@ -919,10 +965,12 @@ func (r *MavenResolver) listDependenciesPerModule(ctx context.Context, targetDir
 		}
 		for _, dep := range deps {
 			key := dependencyCoordinateKey(dep.Module, dep.Version)
-			if !seen[key] {
-				seen[key] = true
-				allDeps = append(allDeps, dep)
+			if i, ok := seen[key]; ok {
+				allDeps[i].Scope = widerScope(allDeps[i].Scope, dep.Scope)
+				continue
 			}
+			seen[key] = len(allDeps)
+			allDeps = append(allDeps, dep)
 		}
 	}
 
@@ -951,7 +999,7 @@ func (r *MavenResolver) resolveModuleDependencies(ctx context.Context, targetDir
 	cmd := exec.CommandContext(ctx, "mvn", "dependency:list",
 		"-pl", module,
 		"-DoutputFile="+tmpPath,
-		"-DincludeScope=compile",
+		"-DincludeScope="+r.includeScope(),
 		"-DoutputAbsoluteArtifactFilename=false",
 	)
 	cmd.Dir = targetDir
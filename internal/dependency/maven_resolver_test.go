@@ -87,6 +87,9 @@ com.acme:tool:jar:2.0.0:runtime
 	if deps[1].Module == deps[0].Module && deps[1].Version == deps[0].Version {
 		t.Fatalf("expected distinct versioned coordinates to be preserved, got %#v", deps)
 	}
+	if deps[0].Scope != ScopeCompile || deps[3].Scope != ScopeRuntime {
+		t.Fatalf("scopes = %q, %q, want compile, runtime", deps[0].Scope, deps[3].Scope)
+	}
 
 	graph := r.parseTreeOutput(`
 com.acme:app:jar:1.0.0:compile
@@ -989,3 +992,41 @@ exit 0
 		t.Fatalf("unexpected first dep: %v", result.Dependencies[0])
 	}
 }
+
+func TestMavenScope(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"compile":                    ScopeCompile,
+		"runtime -- module com.acme": ScopeRuntime,
+		"provided":                   ScopeProvided,
+		"system":                     ScopeProvided,
+		"test":                       ScopeTest,
+		"compile (optional)":         ScopeOptional,
+		"import":                     "",
+		"":                           "",
+	}
+	for token, want := range tests {
+		if got := mavenScope(token); got != want {
+			t.Errorf("mavenScope(%q) = %q, want %q", token, got, want)
+		}
+	}
+
+	r := NewMavenResolver()
+	deps := r.parseDependencyList(`
+org.junit:junit:jar:4.13.2:test
+org.bouncycastle:bcprov-jdk18on:jar:1.77:test
+org.bouncycastle:bcprov-jdk18on:jar:1.77:compile
+`)
+	if len(deps) != 2 || deps[1].Scope != ScopeCompile {
+		t.Fatalf("an artifact used in several reactor modules should keep its widest scope, got %#v", deps)
+	}
+
+	if got := r.includeScope(); got != "compile" {
+		t.Fatalf("includeScope() = %q, want compile by default", got)
+	}
+	r.SetDependencyScopes([]string{ScopeCompile, ScopeTest})
+	if got := r.includeScope(); got != "test" {
+		t.Fatalf("includeScope() = %q, want test when test dependencies are requested", got)
+	}
+}
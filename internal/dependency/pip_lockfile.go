@@ -0,0 +1,694 @@
+package dependency
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// Python lockfile names, in the order they are preferred when a project has several.
+const (
+	uvLockFile      = "uv.lock"
+	poetryLockFile  = "poetry.lock"
+	pipfileLockFile = "Pipfile.lock"
+)
+
+// pythonLock is the dependency set read from a Python lockfile.
+type pythonLock struct {
+	// File is the lockfile the set was read from (for requirements, the first file).
+	File string
+	// Root is the project name recorded in the lockfile, if any.
+	Root string
+	// Packages are the locked third-party packages.
+	Packages []pythonLockedPackage
+	// Direct names the root's direct dependencies, when the lockfile or its
+	// manifest records them.
+	Direct []string
+	// Members are local workspace packages (uv workspaces).
+	Members []WorkspaceMember
+}
+
+// pythonLockedPackage is one pinned package and the packages it requires.
+type pythonLockedPackage struct {
+	Name     string
+	Version  string
+	Requires []string
+	// Local marks the project itself or a workspace member: its edges belong
+	// in the graph, but it has no release to fetch.
+	Local bool
+	// Scope is the dependency scope the lockfile gives the package, if any.
+	Scope string
+}
+
+// labelScope gives scope to the packages reachable from names through
+// Requires edges that have no scope yet. Labelling the runtime roots first
+// lets a package shared with a dev group keep compile scope.
+func (l *pythonLock) labelScope(names []string, scope string) {
+	index := make(map[string]int, len(l.Packages))
+	for i, pkg := range l.Packages {
+		if !pkg.Local {
+			index[normalizePackageName(pkg.Name)] = i
+		}
+	}
+	stack := append([]string(nil), names...)
+	for len(stack) > 0 {
+		last := len(stack) - 1
+		name := stack[last]
+		stack = stack[:last]
+		i, ok := index[normalizePackageName(name)]
+		if !ok || l.Packages[i].Scope != "" {
+			continue
+		}
+		l.Packages[i].Scope = scope
+		stack = append(stack, l.Packages[i].Requires...)
+	}
+}
+
+// useLockfile reports whether Resolve reads the lockfile instead of the
+// Python environment. In auto mode the lockfile is used only when the
+// project has one and no virtualenv, so the ambient interpreter's packages
+// never stand in for a locked project.
+func (r *PipResolver) useLockfile(targetDir string) bool {
+	switch r.options.Mode {
+	case PythonResolveLockfile:
+		return true
+	case PythonResolveEnvironment:
+		return false
+	default:
+		return !hasProjectEnvironment(targetDir) && findPythonLockfile(targetDir) != ""
+	}
+}
+
+// resolveLockfile builds the dependency graph from the project's lockfile
+// and extracts each package's sources from the configured distribution
+// directory or index. Packages without a distribution are still part of the
+// graph; they have no Dir and are not scanned.
+func (r *PipResolver) resolveLockfile(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	lock, err := readPythonLockfile(targetDir, scopesRequested(r.scopes, ScopeDev))
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *SourceCache
+	if r.options.DistDir != "" || r.options.IndexURL != "" {
+		cache, err = NewSourceCache()
+		if err != nil {
+			log.Warn().Err(err).Msg("Source cache unavailable, resolving Python lockfile without sources")
+		}
+	} else {
+		log.Warn().Msg("No Python distribution directory or index configured, resolving lockfile without sources")
+	}
+	fetcher := newPythonSourceFetcher(r.options, cache, r.httpClient)
+
+	result := &ResolveResult{
+		RootModule:       lock.Root,
+		WorkspaceMembers: lock.Members,
+		Dependencies:     make([]Dependency, 0, len(lock.Packages)),
+		Graph:            make(map[string][]string),
+		VersionedGraph:   make(map[string][]Ref),
+	}
+	if result.RootModule == "" {
+		result.RootModule = r.detectRootModule(targetDir)
+	}
+
+	// Edges name packages as their dependents spell them; map them to the
+	// locked names so graph keys match Dependency.Module.
+	locked := make(map[string]pythonLockedPackage, len(lock.Packages))
+	for _, pkg := range lock.Packages {
+		locked[normalizePackageName(pkg.Name)] = pkg
+	}
+	refsFor := func(names []string) ([]string, []Ref) {
+		var modules []string
+		var refs []Ref
+		for _, name := range names {
+			pkg, ok := locked[normalizePackageName(name)]
+			if !ok {
+				continue
+			}
+			modules = append(modules, pkg.Name)
+			refs = append(refs, Ref{Module: pkg.Name, Version: pkg.Version})
+		}
+		return modules, refs
+	}
+
+	withSource := 0
+	for _, pkg := range lock.Packages {
+		modules, refs := refsFor(pkg.Requires)
+		if len(modules) > 0 {
+			result.Graph[pkg.Name] = modules
+			result.VersionedGraph[Ref{Module: pkg.Name, Version: pkg.Version}.Key()] = refs
+		}
+		if pkg.Local {
+			continue
+		}
+
+		dir, archive := fetcher.fetch(ctx, pkg.Name, pkg.Version)
+		if dir != "" {
+			withSource++
+		}
+		result.Dependencies = append(result.Dependencies, Dependency{
+			Module:            pkg.Name,
+			Version:           pkg.Version,
+			Dir:               dir,
+			SourceArchivePath: archive,
+			Scope:             pkg.Scope,
+		})
+	}
+	if _, ok := result.Graph[result.RootModule]; !ok {
+		if modules, refs := refsFor(lock.Direct); len(modules) > 0 {
+			result.Graph[result.RootModule] = modules
+			result.VersionedGraph[result.RootModule] = refs
+		}
+	}
+
+	log.Info().
+		Str("lockfile", filepath.Base(lock.File)).
+		Int("resolved", len(result.Dependencies)).
+		Int("withSource", withSource).
+		Str("root", result.RootModule).
+		Msg("Resolved Python dependencies from lockfile")
+
+	return result, nil
+}
+
+// findPythonLockfile returns the lockfile readPythonLockfile would use for
+// targetDir, or "" when the project has none.
+func findPythonLockfile(targetDir string) string {
+	for _, name := range []string{uvLockFile, poetryLockFile, pipfileLockFile} {
+		path := filepath.Join(targetDir, name)
+		if isFile(path) {
+			return path
+		}
+	}
+	if files := requirementsFiles(targetDir); len(files) > 0 {
+		return files[0]
+	}
+	return ""
+}
+
+// readPythonLockfile reads the preferred lockfile of the project at targetDir:
+// uv.lock, poetry.lock, Pipfile.lock, then pinned requirements*.txt files.
+// Pipfile.lock develop packages are read only when develop is set.
+func readPythonLockfile(targetDir string, develop bool) (*pythonLock, error) {
+	path := findPythonLockfile(targetDir)
+	if path == "" {
+		return nil, fmt.Errorf("no Python lockfile (%s, %s, %s or requirements*.txt) found in %s",
+			uvLockFile, poetryLockFile, pipfileLockFile, targetDir)
+	}
+
+	var (
+		lock *pythonLock
+		err  error
+	)
+	switch filepath.Base(path) {
+	case uvLockFile:
+		lock, err = parseUVLock(path, targetDir)
+	case poetryLockFile:
+		lock, err = parsePoetryLock(path, targetDir)
+	case pipfileLockFile:
+		lock, err = parsePipfileLock(path, targetDir, develop)
+	default:
+		lock, err = parseRequirementsFiles(requirementsFiles(targetDir))
+	}
+	if err != nil {
+		return nil, err
+	}
+	lock.File = path
+	return lock, nil
+}
+
+// requirementsFiles lists requirements*.txt in targetDir, requirements.txt first.
+func requirementsFiles(targetDir string) []string {
+	matches, err := filepath.Glob(filepath.Join(targetDir, "requirements*.txt"))
+	if err != nil {
+		return nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		iMain := filepath.Base(matches[i]) == "requirements.txt"
+		jMain := filepath.Base(matches[j]) == "requirements.txt"
+		if iMain != jMain {
+			return iMain
+		}
+		return matches[i] < matches[j]
+	})
+	return matches
+}
+
+// uvLock is the subset of uv.lock the resolver reads.
+type uvLock struct {
+	Packages []struct {
+		Name                 string                    `toml:"name"`
+		Version              string                    `toml:"version"`
+		Source               map[string]string         `toml:"source"`
+		Dependencies         []uvDependency            `toml:"dependencies"`
+		OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+		DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+	} `toml:"package"`
+}
+
+type uvDependency struct {
+	Name string `toml:"name"`
+}
+
+// uvDependencyNames returns the package names of deps, across every group
+// of groups.
+func uvDependencyNames(deps []uvDependency, groups map[string][]uvDependency) []string {
+	names := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+	}
+	for _, group := range groups {
+		for _, dep := range group {
+			names = append(names, dep.Name)
+		}
+	}
+	return names
+}
+
+// parseUVLock reads uv.lock. Packages with an editable, virtual or directory
+// source are the project and its workspace members, not dependencies. What
+// the members depend on is compile scope; what only their extras or dev
+// groups pull in is optional or dev.
+func parseUVLock(path, targetDir string) (*pythonLock, error) {
+	var parsed uvLock
+	if err := readTOMLFile(path, &parsed); err != nil {
+		return nil, err
+	}
+
+	lock := &pythonLock{}
+	var optionalRoots, devRoots []string
+	for _, pkg := range parsed.Packages {
+		requires := uvDependencyNames(pkg.Dependencies, nil)
+		if local, ok := uvLocalSource(pkg.Source); ok {
+			optionalRoots = append(optionalRoots, uvDependencyNames(nil, pkg.OptionalDependencies)...)
+			devRoots = append(devRoots, uvDependencyNames(nil, pkg.DevDependencies)...)
+			if filepath.Clean(local) == "." {
+				lock.Root = pkg.Name
+				lock.Direct = requires
+			}
+			lock.Members = append(lock.Members, WorkspaceMember{Name: pkg.Name, Dir: filepath.Join(targetDir, local)})
+			// Members keep their edges so their dependencies stay reachable.
+			lock.Packages = append(lock.Packages, pythonLockedPackage{Name: pkg.Name, Requires: requires, Local: true})
+			continue
+		}
+		lock.Packages = append(lock.Packages, pythonLockedPackage{Name: pkg.Name, Version: pkg.Version, Requires: requires})
+	}
+	for _, pkg := range lock.Packages {
+		if pkg.Local {
+			lock.labelScope(pkg.Requires, ScopeCompile)
+		}
+	}
+	lock.labelScope(optionalRoots, ScopeOptional)
+	lock.labelScope(devRoots, ScopeDev)
+	if len(lock.Members) == 1 {
+		// A single-project lock has no workspace to speak of.
+		lock.Members = nil
+	}
+	return lock, nil
+}
+
+func uvLocalSource(source map[string]string) (string, bool) {
+	for _, key := range []string{"editable", "virtual", "directory"} {
+		if path, ok := source[key]; ok {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// poetryLock is the subset of poetry.lock the resolver reads.
+type poetryLock struct {
+	Packages []struct {
+		Name         string         `toml:"name"`
+		Version      string         `toml:"version"`
+		Optional     bool           `toml:"optional"`
+		Category     string         `toml:"category"`
+		Groups       []string       `toml:"groups"`
+		Dependencies map[string]any `toml:"dependencies"`
+	} `toml:"package"`
+}
+
+// parsePoetryLock reads poetry.lock. Direct dependencies come from the
+// pyproject.toml next to it. Packages are scoped by the groups (Poetry 2) or
+// category (Poetry 1.4 and earlier) the lockfile records; locks that record
+// neither are scoped from the dependency groups of pyproject.toml.
+func parsePoetryLock(path, targetDir string) (*pythonLock, error) {
+	var parsed poetryLock
+	if err := readTOMLFile(path, &parsed); err != nil {
+		return nil, err
+	}
+
+	lock := &pythonLock{}
+	for _, pkg := range parsed.Packages {
+		requires := make([]string, 0, len(pkg.Dependencies))
+		for name := range pkg.Dependencies {
+			requires = append(requires, name)
+		}
+		sort.Strings(requires)
+		scope := poetryScope(pkg.Groups, pkg.Category, pkg.Optional)
+		lock.Packages = append(lock.Packages, pythonLockedPackage{Name: pkg.Name, Version: pkg.Version, Requires: requires, Scope: scope})
+	}
+	pyproject := filepath.Join(targetDir, "pyproject.toml")
+	lock.Root, lock.Direct = pyprojectDirectDependencies(pyproject)
+	// Older lockfiles record neither groups nor a category: label from the
+	// manifest instead.
+	lock.labelScope(lock.Direct, ScopeCompile)
+	lock.labelScope(poetryGroupDependencies(pyproject), ScopeDev)
+	return lock, nil
+}
+
+// poetryScope maps the groups or category of a poetry.lock package to a
+// scope: the main group is compile (optional when only an extra needs it)
+// and every other group is dev.
+func poetryScope(groups []string, category string, optional bool) string {
+	main := slices.Contains(groups, "main")
+	if len(groups) == 0 {
+		if category == "" {
+			return ""
+		}
+		main = category == "main"
+	}
+	switch {
+	case main && optional:
+		return ScopeOptional
+	case main:
+		return ScopeCompile
+	default:
+		return ScopeDev
+	}
+}
+
+// poetryGroupDependencies returns the dependencies declared in the non-main
+// groups of pyproject.toml ([tool.poetry.group.<name>.dependencies] and the
+// legacy [tool.poetry.dev-dependencies]).
+func poetryGroupDependencies(path string) []string {
+	var parsed struct {
+		Tool struct {
+			Poetry struct {
+				Group map[string]struct {
+					Dependencies map[string]any `toml:"dependencies"`
+				} `toml:"group"`
+				DevDependencies map[string]any `toml:"dev-dependencies"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := readTOMLFile(path, &parsed); err != nil {
+		return nil
+	}
+	var names []string
+	for name := range parsed.Tool.Poetry.DevDependencies {
+		names = append(names, name)
+	}
+	for _, group := range parsed.Tool.Poetry.Group {
+		for name := range group.Dependencies {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pyprojectDirectDependencies returns the project name and direct runtime
+// dependencies declared in pyproject.toml ([project] or [tool.poetry]).
+func pyprojectDirectDependencies(path string) (string, []string) {
+	var parsed struct {
+		Project struct {
+			Name         string   `toml:"name"`
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Name         string         `toml:"name"`
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := readTOMLFile(path, &parsed); err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("Could not read pyproject.toml for direct dependencies")
+		return "", nil
+	}
+
+	name := parsed.Project.Name
+	if name == "" {
+		name = parsed.Tool.Poetry.Name
+	}
+	var direct []string
+	for _, req := range parsed.Project.Dependencies {
+		if dep := requirementName(req); dep != "" {
+			direct = append(direct, dep)
+		}
+	}
+	for dep := range parsed.Tool.Poetry.Dependencies {
+		if !strings.EqualFold(dep, "python") {
+			direct = append(direct, dep)
+		}
+	}
+	sort.Strings(direct)
+	return name, direct
+}
+
+// parsePipfileLock reads the default packages of Pipfile.lock, and its
+// develop packages with dev scope when develop is set. The lockfile records
+// no edges; direct dependencies come from the Pipfile.
+func parsePipfileLock(path, targetDir string, develop bool) (*pythonLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	type pipfileLockSection map[string]struct {
+		Version string `json:"version"`
+	}
+	var parsed struct {
+		Default pipfileLockSection `json:"default"`
+		Develop pipfileLockSection `json:"develop"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	lock := &pythonLock{}
+	seen := make(map[string]bool)
+	addSection := func(section pipfileLockSection, scope string) {
+		for name, pkg := range section {
+			version := strings.TrimPrefix(pkg.Version, "==")
+			if version == "" {
+				// VCS and path entries carry no pinned release to fetch.
+				log.Debug().Str("package", name).Msg("Pipfile.lock entry has no pinned version, skipping")
+				continue
+			}
+			if seen[normalizePackageName(name)] {
+				continue
+			}
+			seen[normalizePackageName(name)] = true
+			lock.Packages = append(lock.Packages, pythonLockedPackage{Name: name, Version: version, Scope: scope})
+		}
+	}
+	addSection(parsed.Default, ScopeCompile)
+	if develop {
+		addSection(parsed.Develop, ScopeDev)
+	}
+
+	var pipfile struct {
+		Packages    map[string]any `toml:"packages"`
+		DevPackages map[string]any `toml:"dev-packages"`
+	}
+	if err := readTOMLFile(filepath.Join(targetDir, "Pipfile"), &pipfile); err == nil {
+		for name := range pipfile.Packages {
+			lock.Direct = append(lock.Direct, name)
+		}
+		if develop {
+			for name := range pipfile.DevPackages {
+				lock.Direct = appendUniqueStrings(lock.Direct, name)
+			}
+		}
+		sort.Strings(lock.Direct)
+	}
+	return lock, nil
+}
+
+// parseRequirementsFiles reads pinned (name==version) requirements from files,
+// following -r includes. Every pinned requirement counts as direct; the
+// files record no edges.
+func parseRequirementsFiles(files []string) (*pythonLock, error) {
+	lock := &pythonLock{}
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+	for _, file := range files {
+		first := len(lock.Packages)
+		if err := readRequirementsFile(file, lock, seen, visited); err != nil {
+			return nil, err
+		}
+		scope := requirementsFileScope(file)
+		for i := first; i < len(lock.Packages); i++ {
+			lock.Packages[i].Scope = scope
+		}
+	}
+	for _, pkg := range lock.Packages {
+		lock.Direct = append(lock.Direct, pkg.Name)
+	}
+	return lock, nil
+}
+
+// requirementsFileScope scopes the pins of a requirements file by the
+// common naming convention: requirements-dev*.txt is dev,
+// requirements-test*.txt is test, and every other file is compile. Files a
+// file includes share its scope.
+func requirementsFileScope(path string) string {
+	suffix := strings.TrimPrefix(strings.TrimSuffix(filepath.Base(path), ".txt"), "requirements")
+	suffix = strings.TrimLeft(suffix, "-_.")
+	switch {
+	case strings.HasPrefix(suffix, "dev"):
+		return ScopeDev
+	case strings.HasPrefix(suffix, "test"):
+		return ScopeTest
+	default:
+		return ScopeCompile
+	}
+}
+
+func readRequirementsFile(path string, lock *pythonLock, seen, visited map[string]bool) error {
+	path = filepath.Clean(path)
+	if visited[path] {
+		return nil
+	}
+	visited[path] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			log.Debug().Err(closeErr).Str("path", path).Msg("Failed to close requirements file")
+		}
+	}()
+
+	for _, line := range requirementLines(f) {
+		if include, ok := requirementsInclude(line); ok {
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+			if err := readRequirementsFile(include, lock, seen, visited); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "-") {
+			// Other options (-e, --index-url, ...) carry no pin.
+			continue
+		}
+		name, version, ok := pinnedRequirement(line)
+		if !ok {
+			log.Debug().Str("file", path).Str("requirement", line).Msg("Requirement is not pinned with ==, skipping")
+			continue
+		}
+		if seen[normalizePackageName(name)] {
+			continue
+		}
+		seen[normalizePackageName(name)] = true
+		lock.Packages = append(lock.Packages, pythonLockedPackage{Name: name, Version: version})
+	}
+	return nil
+}
+
+// requirementLines returns the logical lines of a requirements file, with
+// continuations joined and comments removed.
+func requirementLines(f *os.File) []string {
+	var lines []string
+	var current strings.Builder
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			line = ""
+		}
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		if text := strings.TrimSpace(current.String()); text != "" {
+			lines = append(lines, text)
+		}
+		current.Reset()
+	}
+	if text := strings.TrimSpace(current.String()); text != "" {
+		lines = append(lines, text)
+	}
+	return lines
+}
+
+func requirementsInclude(line string) (string, bool) {
+	for _, prefix := range []string{"-r ", "--requirement ", "--requirement="} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix)), true
+		}
+	}
+	return "", false
+}
+
+// pinnedRequirement parses "name[extras]==version ; marker --hash=..." and
+// reports whether the requirement pins an exact version.
+func pinnedRequirement(line string) (string, string, bool) {
+	if i := strings.Index(line, ";"); i >= 0 {
+		line = line[:i]
+	}
+	if i := strings.Index(line, " --"); i >= 0 {
+		line = line[:i]
+	}
+	op := "==="
+	i := strings.Index(line, op)
+	if i < 0 {
+		op = "=="
+		i = strings.Index(line, op)
+	}
+	if i < 0 {
+		return "", "", false
+	}
+	name := requirementName(line[:i])
+	version := strings.TrimSpace(line[i+len(op):])
+	if name == "" || version == "" || strings.ContainsAny(version, "*,<>!~ ") {
+		return "", "", false
+	}
+	return name, version, true
+}
+
+// requirementName returns the distribution name at the start of a PEP 508
+// requirement string.
+func requirementName(req string) string {
+	req = strings.TrimSpace(req)
+	end := 0
+	for end < len(req) {
+		c := req[end]
+		if c == '-' || c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			end++
+			continue
+		}
+		break
+	}
+	return req[:end]
+}
+
+func readTOMLFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := toml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,446 @@
+package dependency
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeProjectFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func lockedVersions(lock *pythonLock) map[string]string {
+	versions := make(map[string]string)
+	for _, pkg := range lock.Packages {
+		if !pkg.Local {
+			versions[pkg.Name] = pkg.Version
+		}
+	}
+	return versions
+}
+
+func lockedScopes(lock *pythonLock) map[string]string {
+	scopes := make(map[string]string)
+	for _, pkg := range lock.Packages {
+		if !pkg.Local {
+			scopes[pkg.Name] = pkg.Scope
+		}
+	}
+	return scopes
+}
+
+const testUVLock = `version = 1
+requires-python = ">=3.11"
+
+[[package]]
+name = "app"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "cryptography" },
+]
+
+[[package]]
+name = "cffi"
+version = "1.17.1"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "pycparser" },
+]
+
+[[package]]
+name = "cryptography"
+version = "43.0.3"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "cffi", marker = "platform_python_implementation != 'PyPy'" },
+]
+
+[[package]]
+name = "pycparser"
+version = "2.22"
+source = { registry = "https://pypi.org/simple" }
+`
+
+const testPoetryLock = `[[package]]
+name = "cryptography"
+version = "43.0.3"
+description = "cryptographic recipes"
+optional = false
+python-versions = ">=3.7"
+files = []
+
+[package.dependencies]
+cffi = {version = ">=1.12", markers = "platform_python_implementation != \"PyPy\""}
+
+[[package]]
+name = "cffi"
+version = "1.17.1"
+description = "C Foreign Function Interface"
+optional = false
+python-versions = ">=3.8"
+files = []
+
+[package.dependencies]
+pycparser = "*"
+
+[[package]]
+name = "pycparser"
+version = "2.22"
+description = "C parser"
+optional = false
+python-versions = ">=3.8"
+files = []
+
+[metadata]
+lock-version = "2.0"
+`
+
+func TestReadPythonLockfile(t *testing.T) {
+	want := map[string]string{"cffi": "1.17.1", "cryptography": "43.0.3", "pycparser": "2.22"}
+
+	t.Run("uv.lock", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFiles(t, dir, map[string]string{"uv.lock": testUVLock, "requirements.txt": "requests==2.0.0\n"})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		if got := lockedVersions(lock); !reflect.DeepEqual(got, want) {
+			t.Errorf("versions = %v, want %v", got, want)
+		}
+		if lock.Root != "app" || !reflect.DeepEqual(lock.Direct, []string{"cryptography"}) {
+			t.Errorf("root = %q, direct = %v", lock.Root, lock.Direct)
+		}
+		if len(lock.Members) != 0 {
+			t.Errorf("single-project lock should have no workspace members, got %v", lock.Members)
+		}
+	})
+
+	t.Run("uv workspace", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFiles(t, dir, map[string]string{"uv.lock": testUVLock + `
+[[package]]
+name = "app-core"
+version = "0.1.0"
+source = { editable = "packages/core" }
+`})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		wantMembers := []WorkspaceMember{{Name: "app", Dir: dir}, {Name: "app-core", Dir: filepath.Join(dir, "packages", "core")}}
+		if !reflect.DeepEqual(lock.Members, wantMembers) {
+			t.Errorf("members = %v, want %v", lock.Members, wantMembers)
+		}
+	})
+
+	t.Run("uv dev group", func(t *testing.T) {
+		dir := t.TempDir()
+		lockfile := strings.Replace(testUVLock, `dependencies = [
+    { name = "cryptography" },
+]
+`, `dependencies = [
+    { name = "cryptography" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "pytest" },
+]
+`, 1) + `
+[[package]]
+name = "pytest"
+version = "8.0.0"
+source = { registry = "https://pypi.org/simple" }
+dependencies = [
+    { name = "cffi" },
+]
+`
+		writeProjectFiles(t, dir, map[string]string{"uv.lock": lockfile})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		wantScopes := map[string]string{"cffi": "compile", "cryptography": "compile", "pycparser": "compile", "pytest": "dev"}
+		if got := lockedScopes(lock); !reflect.DeepEqual(got, wantScopes) {
+			t.Errorf("scopes = %v, want %v", got, wantScopes)
+		}
+	})
+
+	t.Run("poetry.lock", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFiles(t, dir, map[string]string{
+			"poetry.lock":    testPoetryLock,
+			"pyproject.toml": "[tool.poetry]\nname = \"app\"\n\n[tool.poetry.dependencies]\npython = \"^3.11\"\ncryptography = \"^43\"\n",
+		})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		if got := lockedVersions(lock); !reflect.DeepEqual(got, want) {
+			t.Errorf("versions = %v, want %v", got, want)
+		}
+		if lock.Root != "app" || !reflect.DeepEqual(lock.Direct, []string{"cryptography"}) {
+			t.Errorf("root = %q, direct = %v", lock.Root, lock.Direct)
+		}
+	})
+
+	t.Run("poetry groups", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFiles(t, dir, map[string]string{
+			"poetry.lock": testPoetryLock + `
+[[package]]
+name = "pytest"
+version = "8.0.0"
+description = "testing"
+optional = false
+python-versions = ">=3.8"
+groups = ["dev"]
+files = []
+`,
+			"pyproject.toml": "[tool.poetry]\nname = \"app\"\n\n[tool.poetry.dependencies]\ncryptography = \"^43\"\n\n[tool.poetry.group.dev.dependencies]\npytest = \"^8\"\n",
+		})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		wantScopes := map[string]string{"cffi": "compile", "cryptography": "compile", "pycparser": "compile", "pytest": "dev"}
+		if got := lockedScopes(lock); !reflect.DeepEqual(got, wantScopes) {
+			t.Errorf("scopes = %v, want %v", got, wantScopes)
+		}
+	})
+
+	t.Run("Pipfile.lock", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFiles(t, dir, map[string]string{
+			"Pipfile.lock": `{"_meta": {}, "default": {
+				"cffi": {"version": "==1.17.1"},
+				"cryptography": {"hashes": [], "version": "==43.0.3"},
+				"pycparser": {"version": "==2.22"},
+				"local-lib": {"path": "./lib"}
+			}, "develop": {"pytest": {"version": "==8.0.0"}}}`,
+			"Pipfile": "[packages]\ncryptography = \"*\"\n\n[dev-packages]\npytest = \"*\"\n",
+		})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		if got := lockedVersions(lock); !reflect.DeepEqual(got, want) {
+			t.Errorf("versions = %v, want %v", got, want)
+		}
+		if !reflect.DeepEqual(lock.Direct, []string{"cryptography"}) {
+			t.Errorf("direct = %v", lock.Direct)
+		}
+
+		lock, err = readPythonLockfile(dir, true)
+		if err != nil {
+			t.Fatalf("readPythonLockfile(develop): %v", err)
+		}
+		wantScopes := map[string]string{"cffi": "compile", "cryptography": "compile", "pycparser": "compile", "pytest": "dev"}
+		if got := lockedScopes(lock); !reflect.DeepEqual(got, wantScopes) {
+			t.Errorf("scopes = %v, want %v", got, wantScopes)
+		}
+	})
+
+	t.Run("requirements", func(t *testing.T) {
+		dir := t.TempDir()
+		writeProjectFiles(t, dir, map[string]string{
+			"requirements.txt":      "# pinned\ncryptography==43.0.3 \\\n    --hash=sha256:abc\ncffi[extra]===1.17.1 ; python_version >= \"3.8\"\nrequests>=2\n-e ./local\n-r requirements-base.txt\n",
+			"requirements-base.txt": "pycparser==2.22  # via cffi\n",
+			"requirements-dev.txt":  "pytest==8.0.0\ncryptography==42.0.0\n",
+		})
+		lock, err := readPythonLockfile(dir, false)
+		if err != nil {
+			t.Fatalf("readPythonLockfile: %v", err)
+		}
+		wantAll := map[string]string{"cffi": "1.17.1", "cryptography": "43.0.3", "pycparser": "2.22", "pytest": "8.0.0"}
+		if got := lockedVersions(lock); !reflect.DeepEqual(got, wantAll) {
+			t.Errorf("versions = %v, want %v", got, wantAll)
+		}
+		if filepath.Base(lock.File) != "requirements.txt" {
+			t.Errorf("file = %s, want requirements.txt", lock.File)
+		}
+		wantScopes := map[string]string{"cffi": "compile", "cryptography": "compile", "pycparser": "compile", "pytest": "dev"}
+		if got := lockedScopes(lock); !reflect.DeepEqual(got, wantScopes) {
+			t.Errorf("scopes = %v, want %v", got, wantScopes)
+		}
+	})
+
+	t.Run("no lockfile", func(t *testing.T) {
+		if _, err := readPythonLockfile(t.TempDir(), false); err == nil {
+			t.Fatal("expected an error without a lockfile")
+		}
+	})
+}
+
+func TestPoetryScope(t *testing.T) {
+	tests := []struct {
+		groups   []string
+		category string
+		optional bool
+		want     string
+	}{
+		{groups: []string{"main"}, want: "compile"},
+		{groups: []string{"main", "dev"}, want: "compile"},
+		{groups: []string{"main"}, optional: true, want: "optional"},
+		{groups: []string{"docs"}, want: "dev"},
+		{category: "main", want: "compile"},
+		{category: "dev", want: "dev"},
+		{want: ""},
+	}
+	for _, tt := range tests {
+		if got := poetryScope(tt.groups, tt.category, tt.optional); got != tt.want {
+			t.Errorf("poetryScope(%v, %q, %v) = %q, want %q", tt.groups, tt.category, tt.optional, got, tt.want)
+		}
+	}
+}
+
+func TestPinnedRequirement(t *testing.T) {
+	tests := []struct {
+		line    string
+		name    string
+		version string
+		ok      bool
+	}{
+		{"cryptography==43.0.3", "cryptography", "43.0.3", true},
+		{"PyYAML == 6.0.1", "PyYAML", "6.0.1", true},
+		{"requests[socks]==2.32.3; python_version<'3.13'", "requests", "2.32.3", true},
+		{"requests>=2.0", "", "", false},
+		{"django==4.*", "", "", false},
+		{"flask", "", "", false},
+	}
+	for _, tt := range tests {
+		name, version, ok := pinnedRequirement(tt.line)
+		if name != tt.name || version != tt.version || ok != tt.ok {
+			t.Errorf("pinnedRequirement(%q) = %q, %q, %v; want %q, %q, %v", tt.line, name, version, ok, tt.name, tt.version, tt.ok)
+		}
+	}
+}
+
+func TestPipResolver_UseLockfile(t *testing.T) {
+	t.Setenv("VIRTUAL_ENV", "")
+	locked := t.TempDir()
+	writeProjectFiles(t, locked, map[string]string{"poetry.lock": testPoetryLock})
+	withVenv := t.TempDir()
+	writeProjectFiles(t, withVenv, map[string]string{"poetry.lock": testPoetryLock, ".venv/bin/python": ""})
+	unlocked := t.TempDir()
+
+	tests := []struct {
+		mode PythonResolveMode
+		dir  string
+		want bool
+	}{
+		{PythonResolveAuto, locked, true},
+		{PythonResolveAuto, withVenv, false},
+		{PythonResolveAuto, unlocked, false},
+		{PythonResolveEnvironment, locked, false},
+		{PythonResolveLockfile, unlocked, true},
+	}
+	for _, tt := range tests {
+		r := NewPipResolver()
+		r.SetPythonResolveOptions(PythonResolveOptions{Mode: tt.mode})
+		if got := r.useLockfile(tt.dir); got != tt.want {
+			t.Errorf("useLockfile(%s, %s) = %v, want %v", tt.mode, filepath.Base(tt.dir), got, tt.want)
+		}
+	}
+}
+
+func TestPipResolver_ResolveLockfile(t *testing.T) {
+	setTestHome(t, t.TempDir())
+	t.Setenv("VIRTUAL_ENV", "")
+
+	dists := t.TempDir()
+	createZipArchive(t, filepath.Join(dists, "cryptography-43.0.3-cp39-abi3-manylinux_2_28_x86_64.whl"), map[string]string{
+		"cryptography/__init__.py":       "",
+		"cryptography/_rust.abi3.so":     "binary",
+		"cryptography/hazmat/ciphers.py": "",
+	})
+	createTarGzArchive(t, filepath.Join(dists, "pycparser-2.22.tar.gz"), map[string]string{
+		"pycparser-2.22/pycparser/__init__.py": "",
+		"pycparser-2.22/setup.py":              "",
+	})
+
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{"uv.lock": testUVLock})
+
+	r := NewPipResolver()
+	r.SetPythonResolveOptions(PythonResolveOptions{DistDir: dists})
+	r.execCommand = nil // Lockfile resolution must not run Python.
+	result, err := r.Resolve(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "app" {
+		t.Errorf("RootModule = %q, want app", result.RootModule)
+	}
+	sources := make(map[string]string)
+	for _, dep := range result.Dependencies {
+		sources[dep.Module+"@"+dep.Version] = dep.Dir
+	}
+	if len(sources) != 3 {
+		t.Fatalf("dependencies = %v, want 3", result.Dependencies)
+	}
+	if dir := sources["cffi@1.17.1"]; dir != "" {
+		t.Errorf("cffi has no distribution but Dir = %q", dir)
+	}
+	for key, file := range map[string]string{
+		"cryptography@43.0.3": "cryptography/hazmat/ciphers.py",
+		"pycparser@2.22":      "pycparser-2.22/pycparser/__init__.py",
+	} {
+		if !isFile(filepath.Join(sources[key], file)) {
+			t.Errorf("%s: %s not extracted to %q", key, file, sources[key])
+		}
+	}
+	if isFile(filepath.Join(sources["cryptography@43.0.3"], "cryptography", "_rust.abi3.so")) {
+		t.Error("non-Python files should not be extracted")
+	}
+
+	wantGraph := map[string][]string{
+		"app":          {"cryptography"},
+		"cffi":         {"pycparser"},
+		"cryptography": {"cffi"},
+	}
+	if !reflect.DeepEqual(result.Graph, wantGraph) {
+		t.Errorf("Graph = %v, want %v", result.Graph, wantGraph)
+	}
+	if refs := result.VersionedGraph["cryptography@43.0.3"]; !reflect.DeepEqual(refs, []Ref{{Module: "cffi", Version: "1.17.1"}}) {
+		t.Errorf("VersionedGraph[cryptography@43.0.3] = %v", refs)
+	}
+}
+
+func TestPipResolver_ResolveLockfile_RequirementsRoot(t *testing.T) {
+	setTestHome(t, t.TempDir())
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{
+		"requirements.txt": "pycparser==2.22\ncffi==1.17.1\n",
+		"pyproject.toml":   "[project]\nname = \"svc\"\n",
+	})
+
+	r := NewPipResolver()
+	r.SetPythonResolveOptions(PythonResolveOptions{Mode: PythonResolveLockfile})
+	result, err := r.Resolve(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	direct := append([]string(nil), result.Graph["svc"]...)
+	sort.Strings(direct)
+	if result.RootModule != "svc" || !reflect.DeepEqual(direct, []string{"cffi", "pycparser"}) {
+		t.Errorf("root = %q, graph = %v", result.RootModule, result.Graph)
+	}
+}
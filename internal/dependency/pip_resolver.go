@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -33,10 +34,48 @@ type pipShowInfo struct {
 	Requires string
 }
 
-// PipResolver resolves Python dependencies using the `pip` CLI.
+// PythonResolveMode selects where PipResolver reads a project's dependencies from.
+type PythonResolveMode string
+
+const (
+	// PythonResolveAuto uses the project's virtualenv when one is active or
+	// present, and its lockfile otherwise.
+	PythonResolveAuto PythonResolveMode = "auto"
+	// PythonResolveEnvironment lists the packages installed in the project's
+	// Python environment with pip.
+	PythonResolveEnvironment PythonResolveMode = "env"
+	// PythonResolveLockfile reads uv.lock, poetry.lock, Pipfile.lock or pinned
+	// requirements*.txt without a Python environment.
+	PythonResolveLockfile PythonResolveMode = "lockfile"
+)
+
+// PythonResolveModes lists the accepted PythonResolveMode values.
+var PythonResolveModes = []PythonResolveMode{PythonResolveAuto, PythonResolveEnvironment, PythonResolveLockfile}
+
+// PythonResolveOptions configures lockfile resolution and where its sources
+// are fetched from.
+type PythonResolveOptions struct {
+	Mode PythonResolveMode
+	// DistDir is a local directory of wheels and sdists to extract sources from.
+	DistDir string
+	// IndexURL is a PEP 503 simple index (e.g., a PyPI mirror) to download
+	// wheels and sdists from when DistDir does not have them.
+	IndexURL string
+}
+
+// PythonResolveConfigurer configures how a resolver resolves Python dependencies.
+type PythonResolveConfigurer interface {
+	SetPythonResolveOptions(opts PythonResolveOptions)
+}
+
+// PipResolver resolves Python dependencies using the `pip` CLI, or from the
+// project's lockfile when there is no environment to ask.
 type PipResolver struct {
 	lookPath    func(string) (string, error)
 	execCommand func(context.Context, string, ...string) *exec.Cmd
+	options     PythonResolveOptions
+	httpClient  *http.Client
+	scopes      []string
 }
 
 // NewPipResolver creates a new Python/pip dependency resolver.
@@ -44,7 +83,23 @@ func NewPipResolver() *PipResolver {
 	return &PipResolver{
 		lookPath:    exec.LookPath,
 		execCommand: exec.CommandContext,
+		options:     PythonResolveOptions{Mode: PythonResolveAuto},
+		httpClient:  &http.Client{Timeout: defaultPythonIndexTimeout},
+	}
+}
+
+// SetPythonResolveOptions configures the resolution mode and source locations.
+func (r *PipResolver) SetPythonResolveOptions(opts PythonResolveOptions) {
+	if opts.Mode == "" {
+		opts.Mode = PythonResolveAuto
 	}
+	r.options = opts
+}
+
+// SetDependencyScopes makes lockfile resolution read the Pipfile.lock
+// develop packages when dev dependencies are requested.
+func (r *PipResolver) SetDependencyScopes(scopes []string) {
+	r.scopes = scopes
 }
 
 // Ecosystem returns "python".
@@ -57,6 +112,10 @@ func (r *PipResolver) Ecosystem() string {
 //
 //nolint:gocognit,gocyclo // This workflow intentionally keeps fallback resolution logic together.
 func (r *PipResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	if r.useLockfile(targetDir) {
+		return r.resolveLockfile(ctx, targetDir)
+	}
+
 	// Step 1: Detect root module name
 	rootModule := r.detectRootModule(targetDir)
 	pythonExec, err := r.resolvePythonExecutable(targetDir)
@@ -205,6 +264,20 @@ func (r *PipResolver) resolvePythonExecutable(projectDir string) (string, error)
 	return "", fmt.Errorf("neither python3 nor python is available in PATH")
 }
 
+// hasProjectEnvironment reports whether an activated or project-local
+// virtualenv is available for the project at projectDir.
+func hasProjectEnvironment(projectDir string) bool {
+	if virtualEnv := strings.TrimSpace(os.Getenv("VIRTUAL_ENV")); virtualEnv != "" && venvPythonCandidate(virtualEnv) != "" {
+		return true
+	}
+	for _, venvDir := range []string{".venv", "venv"} {
+		if venvPythonCandidate(filepath.Join(filepath.Clean(projectDir), venvDir)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // venvPythonCandidate returns the first existing Python executable found inside
 // the given virtual-environment directory, or "" when none is found.
 // Checks the standard platform-specific locations (bin/ on POSIX, Scripts/ on Windows).
@@ -0,0 +1,315 @@
+package dependency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultPythonIndexTimeout = 2 * time.Minute
+	maxPythonIndexPageSize    = 16 << 20  // 16 MiB
+	maxPythonArchiveSize      = 512 << 20 // 512 MiB
+)
+
+var (
+	pep503Separators = regexp.MustCompile(`[-_.]+`)
+	simpleIndexLink  = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*["']([^"']+)["']`)
+)
+
+// pythonDistribution is a wheel or sdist file, local or on an index.
+type pythonDistribution struct {
+	Filename string
+	// Location is a local path or an absolute URL.
+	Location string
+	// SHA256 is the expected digest from the index link, if given.
+	SHA256 string
+}
+
+// pythonSourceFetcher locates the wheel or sdist of a locked package in a
+// local distribution directory or a PEP 503 simple index and extracts its
+// Python sources through the SourceCache.
+type pythonSourceFetcher struct {
+	distDir    string
+	indexURL   string
+	httpClient *http.Client
+	cache      *SourceCache
+
+	localFiles []pythonDistribution
+	listed     bool
+}
+
+func newPythonSourceFetcher(opts PythonResolveOptions, cache *SourceCache, client *http.Client) *pythonSourceFetcher {
+	return &pythonSourceFetcher{
+		distDir:    opts.DistDir,
+		indexURL:   strings.TrimRight(opts.IndexURL, "/"),
+		httpClient: client,
+		cache:      cache,
+	}
+}
+
+// enabled reports whether the fetcher has anywhere to look for sources.
+func (f *pythonSourceFetcher) enabled() bool {
+	return f.cache != nil && (f.distDir != "" || f.indexURL != "")
+}
+
+// fetch returns the extracted source directory of name==version and the
+// local archive it came from, or "" when no distribution is available.
+// Archives downloaded from the index are removed after extraction.
+func (f *pythonSourceFetcher) fetch(ctx context.Context, name, version string) (string, string) {
+	if !f.enabled() {
+		return "", ""
+	}
+	key := "pypi:" + pep503Name(name)
+
+	if f.distDir != "" {
+		if dist, ok := selectPythonDistribution(f.listLocal(), name, version); ok {
+			dir, err := f.extract(dist.Location, key, version)
+			if err != nil {
+				log.Debug().Err(err).Str("archive", dist.Location).Msg("Failed to extract Python distribution")
+				return "", ""
+			}
+			return dir, dist.Location
+		}
+	}
+
+	if dir := f.cache.CachedDir(key, version); dir != "" {
+		return dir, ""
+	}
+	if f.indexURL == "" {
+		return "", ""
+	}
+
+	dir, err := f.fetchFromIndex(ctx, name, version, key)
+	if err != nil {
+		log.Debug().Err(err).Str("package", name).Str("version", version).Msg("Failed to fetch Python distribution from index")
+		return "", ""
+	}
+	return dir, ""
+}
+
+func (f *pythonSourceFetcher) listLocal() []pythonDistribution {
+	if f.listed {
+		return f.localFiles
+	}
+	f.listed = true
+	entries, err := os.ReadDir(f.distDir)
+	if err != nil {
+		log.Warn().Err(err).Str("dir", f.distDir).Msg("Cannot read Python distribution directory")
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		f.localFiles = append(f.localFiles, pythonDistribution{
+			Filename: entry.Name(),
+			Location: filepath.Join(f.distDir, entry.Name()),
+		})
+	}
+	return f.localFiles
+}
+
+func (f *pythonSourceFetcher) fetchFromIndex(ctx context.Context, name, version, key string) (string, error) {
+	dists, err := f.indexDistributions(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	dist, ok := selectPythonDistribution(dists, name, version)
+	if !ok {
+		return "", fmt.Errorf("no wheel or sdist for %s==%s on %s", name, version, f.indexURL)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "crypto-finder-pypi-*")
+	if err != nil {
+		return "", fmt.Errorf("create download dir: %w", err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(tmpDir); removeErr != nil {
+			log.Debug().Err(removeErr).Str("dir", tmpDir).Msg("Failed to remove Python download dir")
+		}
+	}()
+
+	archive := filepath.Join(tmpDir, filepath.Base(dist.Filename))
+	if err := f.download(ctx, dist, archive); err != nil {
+		return "", err
+	}
+	return f.extract(archive, key, version)
+}
+
+// indexDistributions lists the files of a project page on a PEP 503 simple index.
+func (f *pythonSourceFetcher) indexDistributions(ctx context.Context, name string) ([]pythonDistribution, error) {
+	pageURL := f.indexURL + "/" + pep503Name(name) + "/"
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index URL %s: %w", f.indexURL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", pageURL, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Debug().Err(closeErr).Str("url", pageURL).Msg("Failed to close index response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: unexpected status %s", pageURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPythonIndexPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", pageURL, err)
+	}
+	return parseSimpleIndexPage(base, string(body)), nil
+}
+
+// parseSimpleIndexPage extracts the distribution links of a simple index page.
+func parseSimpleIndexPage(base *url.URL, page string) []pythonDistribution {
+	var dists []pythonDistribution
+	for _, m := range simpleIndexLink.FindAllStringSubmatch(page, -1) {
+		link, err := base.Parse(html.UnescapeString(m[1]))
+		if err != nil {
+			continue
+		}
+		dist := pythonDistribution{}
+		if digest, ok := strings.CutPrefix(link.Fragment, "sha256="); ok {
+			dist.SHA256 = strings.ToLower(digest)
+		}
+		link.Fragment = ""
+		dist.Location = link.String()
+		dist.Filename = path.Base(link.Path)
+		dists = append(dists, dist)
+	}
+	return dists
+}
+
+func (f *pythonSourceFetcher) download(ctx context.Context, dist pythonDistribution, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dist.Location, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", dist.Location, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Debug().Err(closeErr).Str("url", dist.Location).Msg("Failed to close download response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: unexpected status %s", dist.Location, resp.Status)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dest, err)
+	}
+	hash := sha256.New()
+	written, copyErr := io.Copy(io.MultiWriter(out, hash), io.LimitReader(resp.Body, maxPythonArchiveSize+1))
+	if closeErr := out.Close(); copyErr == nil {
+		copyErr = closeErr
+	}
+	if copyErr != nil {
+		return fmt.Errorf("download %s: %w", dist.Location, copyErr)
+	}
+	if written > maxPythonArchiveSize {
+		return fmt.Errorf("download %s: archive exceeds size limit", dist.Location)
+	}
+	if dist.SHA256 != "" {
+		if got := hex.EncodeToString(hash.Sum(nil)); got != dist.SHA256 {
+			return fmt.Errorf("download %s: sha256 mismatch: got %s, want %s", dist.Location, got, dist.SHA256)
+		}
+	}
+	return nil
+}
+
+func (f *pythonSourceFetcher) extract(archive, key, version string) (string, error) {
+	lower := strings.ToLower(archive)
+	switch {
+	case strings.HasSuffix(lower, ".whl"), strings.HasSuffix(lower, ".zip"):
+		return f.cache.ExtractZip(archive, key, version, []string{".py"})
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return f.cache.ExtractTarGz(archive, key, version, []string{".py"})
+	default:
+		return "", fmt.Errorf("unsupported distribution format: %s", filepath.Base(archive))
+	}
+}
+
+// selectPythonDistribution picks the distribution of name==version to
+// extract: a pure-Python wheel, else an sdist, else any other wheel.
+func selectPythonDistribution(dists []pythonDistribution, name, version string) (pythonDistribution, bool) {
+	best, bestRank := pythonDistribution{}, -1
+	for _, dist := range dists {
+		rank := pythonDistributionRank(dist.Filename, name, version)
+		if rank > bestRank {
+			best, bestRank = dist, rank
+		}
+	}
+	return best, bestRank >= 0
+}
+
+// pythonDistributionRank scores a filename for name==version; -1 means it
+// is not a distribution of that release.
+func pythonDistributionRank(filename, name, version string) int {
+	lower := strings.ToLower(filename)
+	var distName, distVersion string
+	rank := -1
+	switch {
+	case strings.HasSuffix(lower, ".whl"):
+		// {name}-{version}(-{build})?-{python}-{abi}-{platform}.whl
+		parts := strings.Split(strings.TrimSuffix(filename, filepath.Ext(filename)), "-")
+		if len(parts) < 5 {
+			return -1
+		}
+		distName, distVersion = parts[0], parts[1]
+		rank = 0
+		if strings.HasSuffix(lower, "-none-any.whl") {
+			rank = 2
+		}
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"), strings.HasSuffix(lower, ".zip"):
+		stem := filename
+		for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+			if strings.HasSuffix(lower, ext) {
+				stem = filename[:len(filename)-len(ext)]
+				break
+			}
+		}
+		i := strings.LastIndex(stem, "-")
+		if i <= 0 {
+			return -1
+		}
+		distName, distVersion = stem[:i], stem[i+1:]
+		rank = 1
+	default:
+		return -1
+	}
+	if pep503Name(distName) != pep503Name(name) || !strings.EqualFold(distVersion, version) {
+		return -1
+	}
+	return rank
+}
+
+// pep503Name normalizes a project name the way simple index URLs spell it.
+func pep503Name(name string) string {
+	return strings.ToLower(pep503Separators.ReplaceAllString(name, "-"))
+}
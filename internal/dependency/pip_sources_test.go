@@ -0,0 +1,128 @@
+package dependency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPythonDistributionRank(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     int
+	}{
+		{"cryptography-43.0.3-py3-none-any.whl", 2},
+		{"cryptography-43.0.3.tar.gz", 1},
+		{"cryptography-43.0.3-cp39-abi3-manylinux_2_28_x86_64.whl", 0},
+		{"Cryptography-43.0.3.zip", 1},
+		{"cryptography-43.0.2.tar.gz", -1},
+		{"cryptography_vectors-43.0.3.tar.gz", -1},
+		{"cryptography-43.0.3.exe", -1},
+	}
+	for _, tt := range tests {
+		if got := pythonDistributionRank(tt.filename, "cryptography", "43.0.3"); got != tt.want {
+			t.Errorf("pythonDistributionRank(%q) = %d, want %d", tt.filename, got, tt.want)
+		}
+	}
+
+	// Names compare in their PEP 503 form.
+	if got := pythonDistributionRank("zope_interface-7.1.tar.gz", "zope.interface", "7.1"); got != 1 {
+		t.Errorf("pythonDistributionRank(zope_interface) = %d, want 1", got)
+	}
+}
+
+func TestParseSimpleIndexPage(t *testing.T) {
+	base, err := url.Parse("https://mirror.example/simple/cffi/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := `<html><body>
+<a href="../../packages/cffi-1.17.1.tar.gz#sha256=ABCDEF">cffi-1.17.1.tar.gz</a>
+<a data-requires-python="&gt;=3.8" href="https://files.example/cffi-1.17.1-cp312-cp312-linux_x86_64.whl?x=1&amp;y=2">wheel</a>
+</body></html>`
+
+	got := parseSimpleIndexPage(base, page)
+	want := []pythonDistribution{
+		{Filename: "cffi-1.17.1.tar.gz", Location: "https://mirror.example/packages/cffi-1.17.1.tar.gz", SHA256: "abcdef"},
+		{Filename: "cffi-1.17.1-cp312-cp312-linux_x86_64.whl", Location: "https://files.example/cffi-1.17.1-cp312-cp312-linux_x86_64.whl?x=1&y=2"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseSimpleIndexPage() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("link %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPythonSourceFetcher_Index(t *testing.T) {
+	setTestHome(t, t.TempDir())
+	cache, err := NewSourceCache()
+	if err != nil {
+		t.Fatalf("NewSourceCache: %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "pycparser-2.22-py3-none-any.whl")
+	createZipArchive(t, archive, map[string]string{"pycparser/c_parser.py": "class CParser: pass\n"})
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+
+	downloads := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/simple/pycparser/", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `<a href="/files/pycparser-2.22-py3-none-any.whl#sha256=%s">whl</a>`, hex.EncodeToString(sum[:]))
+		fmt.Fprint(w, `<a href="/files/pycparser-2.22-py3-none-any.whl#sha256=00">bad</a>`)
+	})
+	mux.HandleFunc("/simple/cffi/", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `<a href="/files/cffi-1.17.1.tar.gz#sha256=00">cffi</a>`)
+	})
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		_, _ = w.Write(data)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher := newPythonSourceFetcher(PythonResolveOptions{IndexURL: server.URL + "/simple/"}, cache, server.Client())
+
+	dir, localArchive := fetcher.fetch(context.Background(), "PyCParser", "2.22")
+	if dir == "" || localArchive != "" {
+		t.Fatalf("fetch() = %q, %q; want an extracted dir and no local archive", dir, localArchive)
+	}
+	if !isFile(filepath.Join(dir, "pycparser", "c_parser.py")) {
+		t.Errorf("c_parser.py not extracted to %s", dir)
+	}
+
+	// The second fetch is served from the source cache.
+	if again, _ := fetcher.fetch(context.Background(), "pycparser", "2.22"); again != dir || downloads != 1 {
+		t.Errorf("second fetch = %q after %d downloads, want %q after 1", again, downloads, dir)
+	}
+
+	// A digest mismatch leaves the package without sources.
+	if dir, _ := fetcher.fetch(context.Background(), "cffi", "1.17.1"); dir != "" {
+		t.Errorf("fetch() with a digest mismatch = %q, want no source", dir)
+	}
+
+	// Unknown projects are not an error, just unavailable.
+	if dir, _ := fetcher.fetch(context.Background(), "missing", "1.0"); dir != "" {
+		t.Errorf("fetch() for a missing project = %q, want no source", dir)
+	}
+}
+
+func TestPythonSourceFetcher_Disabled(t *testing.T) {
+	fetcher := newPythonSourceFetcher(PythonResolveOptions{}, nil, http.DefaultClient)
+	if dir, archive := fetcher.fetch(context.Background(), "cffi", "1.17.1"); dir != "" || archive != "" {
+		t.Errorf("fetch() without sources = %q, %q", dir, archive)
+	}
+}
@@ -2,9 +2,41 @@
 // project dependencies to their source code locations on disk.
 package dependency
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
 
-const ecosystemJava = "java"
+const (
+	ecosystemGo     = "go"
+	ecosystemJava   = "java"
+	ecosystemPython = "python"
+)
+
+// Dependency scopes. A resolver labels a dependency with the scope its build
+// tool gives it; an empty scope means the tool does not record one.
+const (
+	// ScopeCompile marks a dependency of the production code.
+	ScopeCompile = "compile"
+	// ScopeRuntime marks a dependency needed only at run time (Maven runtime
+	// scope, Gradle runtimeOnly).
+	ScopeRuntime = "runtime"
+	// ScopeProvided marks a dependency the runtime environment supplies
+	// (Maven provided and system scopes).
+	ScopeProvided = "provided"
+	// ScopeTest marks a dependency used only to build or run tests.
+	ScopeTest = "test"
+	// ScopeDev marks a development-only dependency (Cargo dev-dependencies,
+	// Poetry and uv non-main groups, Pipfile develop packages).
+	ScopeDev = "dev"
+	// ScopeOptional marks a dependency the project declares optional.
+	ScopeOptional = "optional"
+)
+
+// Scopes lists the dependency scopes in the order they are documented.
+var Scopes = []string{ScopeCompile, ScopeRuntime, ScopeProvided, ScopeTest, ScopeDev, ScopeOptional}
 
 // Dependency represents a single resolved dependency with its source location.
 type Dependency struct {
@@ -20,6 +52,9 @@ type Dependency struct {
 	// SourceArchivePath is the absolute path to the downloaded source archive when
 	// available. The extracted source directory in Dir remains the preferred scan input.
 	SourceArchivePath string
+	// Scope is one of the Scope* constants, or empty when the build tool
+	// records no scope for the dependency.
+	Scope string
 }
 
 // Ref identifies a dependency edge target without requiring a source directory.
@@ -66,6 +101,79 @@ type ResolveResult struct {
 	VersionedGraph map[string][]Ref
 }
 
+// DependencyScopeConfigurer is implemented by resolvers whose build tool
+// leaves test or runtime-only dependencies out unless asked for them.
+type DependencyScopeConfigurer interface {
+	// SetDependencyScopes names the scopes the scan keeps. Resolvers widen
+	// resolution to cover them; they do not filter by them.
+	SetDependencyScopes(scopes []string)
+}
+
+// ParseScopes validates a list of scope names, as given to --dep-scopes, and
+// returns them lower-cased and deduplicated.
+func ParseScopes(raw []string) ([]string, error) {
+	var scopes []string
+	for _, value := range raw {
+		scope := strings.ToLower(strings.TrimSpace(value))
+		if scope == "" {
+			continue
+		}
+		if !slices.Contains(Scopes, scope) {
+			return nil, fmt.Errorf("unknown dependency scope %q (supported: %s)", value, strings.Join(Scopes, ", "))
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// ScopeSelected reports whether a dependency of scope is kept when the scan
+// is restricted to scopes. Every dependency is kept when scopes is empty, and
+// dependencies without a recorded scope are always kept.
+func ScopeSelected(scope string, scopes []string) bool {
+	return len(scopes) == 0 || scope == "" || slices.Contains(scopes, scope)
+}
+
+// scopeRank orders scopes from the one that reaches production code most
+// directly; widerScope keeps the lower rank.
+var scopeRank = map[string]int{
+	ScopeCompile:  1,
+	ScopeRuntime:  2,
+	ScopeProvided: 3,
+	ScopeOptional: 4,
+	ScopeTest:     5,
+	ScopeDev:      6,
+}
+
+// widerScope returns the scope to record for a dependency reached in both
+// scope a and scope b: the one closer to production code. A recorded scope
+// wins over an unknown one.
+func widerScope(a, b string) string {
+	rankA, okA := scopeRank[a]
+	rankB, okB := scopeRank[b]
+	switch {
+	case !okA:
+		return b
+	case !okB:
+		return a
+	case rankB < rankA:
+		return b
+	default:
+		return a
+	}
+}
+
+// scopesRequested reports whether any of wanted is among scopes.
+func scopesRequested(scopes []string, wanted ...string) bool {
+	for _, scope := range wanted {
+		if slices.Contains(scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
 // Resolver resolves a project's dependencies to filesystem paths.
 type Resolver interface {
 	// Resolve returns all dependencies for the project at targetDir.
@@ -1,6 +1,9 @@
 package dependency
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestDependencyRefKey(t *testing.T) {
 	t.Parallel()
@@ -15,3 +18,50 @@ func TestDependencyRefKey(t *testing.T) {
 		t.Fatalf("Key() = %q, want org.example:lib@1.2.3", got)
 	}
 }
+
+func TestParseScopes(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseScopes([]string{"Runtime", " compile", "runtime", ""})
+	if err != nil {
+		t.Fatalf("ParseScopes: %v", err)
+	}
+	if want := []string{ScopeRuntime, ScopeCompile}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseScopes() = %v, want %v", got, want)
+	}
+	if _, err := ParseScopes([]string{"production"}); err == nil {
+		t.Fatal("ParseScopes(production) should fail")
+	}
+}
+
+func TestScopeSelected(t *testing.T) {
+	t.Parallel()
+
+	scopes := []string{ScopeCompile, ScopeRuntime}
+	if !ScopeSelected(ScopeTest, nil) {
+		t.Error("every scope is selected without a restriction")
+	}
+	if !ScopeSelected("", scopes) {
+		t.Error("a dependency without a recorded scope is always selected")
+	}
+	if !ScopeSelected(ScopeRuntime, scopes) || ScopeSelected(ScopeTest, scopes) {
+		t.Error("only the listed scopes are selected")
+	}
+}
+
+func TestWiderScope(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct{ a, b, want string }{
+		{ScopeTest, ScopeCompile, ScopeCompile},
+		{ScopeRuntime, ScopeDev, ScopeRuntime},
+		{"", ScopeDev, ScopeDev},
+		{ScopeOptional, "", ScopeOptional},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := widerScope(tt.a, tt.b); got != tt.want {
+			t.Errorf("widerScope(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package dependency
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// sbomDocument is the component list and dependency graph of a CycloneDX or
+// SPDX SBOM, keyed by the document's own element identifiers (bom-ref or
+// SPDXID).
+type sbomDocument struct {
+	// Format names the SBOM format, for logs.
+	Format string
+	// Root identifies the component the SBOM describes, if recorded.
+	Root string
+	// Components lists the identifiers of package components in document order.
+	Components []string
+	// Purls maps a component to its package URL.
+	Purls map[string]string
+	// Names maps a component to its name, used when the root has no purl.
+	Names map[string]string
+	// Edges maps a component to the components it depends on.
+	Edges map[string][]string
+	// Scopes maps a component to the dependency scope the SBOM records for
+	// it, when it records one.
+	Scopes map[string]string
+}
+
+func newSBOMDocument(format string) *sbomDocument {
+	return &sbomDocument{
+		Format: format,
+		Purls:  make(map[string]string),
+		Names:  make(map[string]string),
+		Edges:  make(map[string][]string),
+		Scopes: make(map[string]string),
+	}
+}
+
+func (d *sbomDocument) addComponent(id, name, purl string) {
+	if id == "" {
+		return
+	}
+	if _, ok := d.Names[id]; !ok {
+		d.Components = append(d.Components, id)
+	}
+	d.Names[id] = name
+	if purl != "" {
+		d.Purls[id] = purl
+	}
+}
+
+func (d *sbomDocument) addEdge(from, to string) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	d.Edges[from] = appendUniqueStrings(d.Edges[from], to)
+}
+
+// addScope records that component id is used in scope. A component used in
+// several scopes keeps the one closest to production code.
+func (d *sbomDocument) addScope(id, scope string) {
+	if id == "" || scope == "" {
+		return
+	}
+	d.Scopes[id] = widerScope(d.Scopes[id], scope)
+}
+
+// readSBOM reads a CycloneDX (JSON or XML) or SPDX (2.x JSON or 3.0 JSON-LD)
+// document.
+func readSBOM(path string) (*sbomDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("<")) {
+		return parseCycloneDXSBOM(trimmed, cdx.BOMFileFormatXML)
+	}
+
+	var probe struct {
+		BOMFormat   string          `json:"bomFormat"`
+		SPDXVersion string          `json:"spdxVersion"`
+		Graph       json.RawMessage `json:"@graph"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return nil, fmt.Errorf("parse SBOM %s: %w", path, err)
+	}
+	switch {
+	case probe.BOMFormat == "CycloneDX":
+		return parseCycloneDXSBOM(trimmed, cdx.BOMFileFormatJSON)
+	case strings.HasPrefix(probe.SPDXVersion, "SPDX-2"):
+		return parseSPDX2SBOM(trimmed)
+	case len(probe.Graph) > 0:
+		return parseSPDX3SBOM(probe.Graph)
+	default:
+		return nil, fmt.Errorf("%s is not a CycloneDX or SPDX document", path)
+	}
+}
+
+func parseCycloneDXSBOM(data []byte, format cdx.BOMFileFormat) (*sbomDocument, error) {
+	var bom cdx.BOM
+	if err := cdx.NewBOMDecoder(bytes.NewReader(data), format).Decode(&bom); err != nil {
+		return nil, fmt.Errorf("parse CycloneDX: %w", err)
+	}
+	doc := newSBOMDocument("CycloneDX")
+
+	var addComponents func(components *[]cdx.Component)
+	addComponents = func(components *[]cdx.Component) {
+		if components == nil {
+			return
+		}
+		for _, component := range *components {
+			id := component.BOMRef
+			if id == "" {
+				id = component.PackageURL
+			}
+			name := component.Name
+			if component.Group != "" {
+				name = component.Group + ":" + component.Name
+			}
+			doc.addComponent(id, name, component.PackageURL)
+			doc.addScope(id, cycloneDXScope(component.Scope))
+			addComponents(component.Components)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		root := bom.Metadata.Component
+		doc.Root = root.BOMRef
+		if doc.Root == "" {
+			doc.Root = root.PackageURL
+		}
+		name := root.Name
+		if root.Group != "" {
+			name = root.Group + ":" + root.Name
+		}
+		doc.addComponent(doc.Root, name, root.PackageURL)
+	}
+	addComponents(bom.Components)
+
+	if bom.Dependencies != nil {
+		for _, dependency := range *bom.Dependencies {
+			if dependency.Dependencies == nil {
+				continue
+			}
+			for _, child := range *dependency.Dependencies {
+				doc.addEdge(dependency.Ref, child)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// cycloneDXScope maps a CycloneDX component scope to a dependency scope.
+// Excluded components are, per the specification, used for tests and other
+// non-runtime purposes.
+func cycloneDXScope(scope cdx.Scope) string {
+	switch scope {
+	case cdx.ScopeRequired:
+		return ScopeCompile
+	case cdx.ScopeOptional:
+		return ScopeOptional
+	case cdx.ScopeExcluded:
+		return ScopeTest
+	default:
+		return ""
+	}
+}
+
+// spdx2DependencyScopes maps the SPDX 2 "X_DEPENDENCY_OF" relationship types
+// to the scope of the dependency they name. DEPENDENCY_OF records no scope.
+var spdx2DependencyScopes = map[string]string{
+	"DEPENDENCY_OF":          "",
+	"RUNTIME_DEPENDENCY_OF":  ScopeRuntime,
+	"OPTIONAL_DEPENDENCY_OF": ScopeOptional,
+	"PROVIDED_DEPENDENCY_OF": ScopeProvided,
+	"DEV_DEPENDENCY_OF":      ScopeDev,
+	"TEST_DEPENDENCY_OF":     ScopeTest,
+	"BUILD_DEPENDENCY_OF":    ScopeDev,
+}
+
+// spdx3LifecycleScopes maps the scope of an SPDX 3 LifecycleScopedRelationship
+// to a dependency scope.
+var spdx3LifecycleScopes = map[string]string{
+	"runtime":     ScopeRuntime,
+	"development": ScopeDev,
+	"build":       ScopeDev,
+	"test":        ScopeTest,
+}
+
+// spdx2Document is the subset of an SPDX 2.x JSON document that names
+// packages and their relationships.
+type spdx2Document struct {
+	DocumentDescribes []string `json:"documentDescribes"`
+	Packages          []struct {
+		SPDXID       string `json:"SPDXID"`
+		Name         string `json:"name"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+	Relationships []struct {
+		Element          string `json:"spdxElementId"`
+		RelationshipType string `json:"relationshipType"`
+		Related          string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+}
+
+func parseSPDX2SBOM(data []byte) (*sbomDocument, error) {
+	var spdx spdx2Document
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		return nil, fmt.Errorf("parse SPDX: %w", err)
+	}
+	doc := newSBOMDocument("SPDX 2")
+	for _, pkg := range spdx.Packages {
+		purl := ""
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				purl = ref.ReferenceLocator
+				break
+			}
+		}
+		doc.addComponent(pkg.SPDXID, pkg.Name, purl)
+	}
+	if len(spdx.DocumentDescribes) > 0 {
+		doc.Root = spdx.DocumentDescribes[0]
+	}
+	for _, rel := range spdx.Relationships {
+		switch rel.RelationshipType {
+		case "DESCRIBES":
+			if doc.Root == "" && rel.Element == "SPDXRef-DOCUMENT" {
+				doc.Root = rel.Related
+			}
+		case "DEPENDS_ON":
+			doc.addEdge(rel.Element, rel.Related)
+		default:
+			if scope, ok := spdx2DependencyScopes[rel.RelationshipType]; ok {
+				doc.addEdge(rel.Related, rel.Element)
+				doc.addScope(rel.Element, scope)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// spdx3Element is the subset of an SPDX 3.0 JSON-LD element used to read
+// packages, dependsOn relationships and the described root.
+type spdx3Element struct {
+	Type                string `json:"type"`
+	SpdxID              string `json:"spdxId"`
+	Name                string `json:"name"`
+	PackageURL          string `json:"software_packageUrl"`
+	ExternalIdentifiers []struct {
+		Type       string `json:"externalIdentifierType"`
+		Identifier string `json:"identifier"`
+	} `json:"externalIdentifier"`
+	RelationshipType string   `json:"relationshipType"`
+	From             string   `json:"from"`
+	To               []string `json:"to"`
+	Scope            string   `json:"scope"`
+	RootElements     []string `json:"rootElement"`
+}
+
+func parseSPDX3SBOM(graph json.RawMessage) (*sbomDocument, error) {
+	var elements []spdx3Element
+	if err := json.Unmarshal(graph, &elements); err != nil {
+		return nil, fmt.Errorf("parse SPDX: %w", err)
+	}
+	doc := newSBOMDocument("SPDX 3")
+	var roots []string
+	for _, element := range elements {
+		switch element.Type {
+		case "software_Package":
+			purl := element.PackageURL
+			for _, identifier := range element.ExternalIdentifiers {
+				if purl == "" && identifier.Type == "packageUrl" {
+					purl = identifier.Identifier
+				}
+			}
+			doc.addComponent(element.SpdxID, element.Name, purl)
+		case "Relationship", "LifecycleScopedRelationship":
+			if element.RelationshipType == "dependsOn" {
+				for _, to := range element.To {
+					doc.addEdge(element.From, to)
+					doc.addScope(to, spdx3LifecycleScopes[element.Scope])
+				}
+			}
+		case "software_Sbom", "SpdxDocument":
+			roots = append(roots, element.RootElements...)
+		}
+	}
+	for _, root := range roots {
+		if _, ok := doc.Names[root]; ok {
+			doc.Root = root
+			break
+		}
+	}
+	return doc, nil
+}
@@ -0,0 +1,342 @@
+package dependency
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/pkg/purl"
+)
+
+// sbomEcosystems are the ecosystems an SBOM can supply dependencies for, in
+// the order SBOMEcosystem prefers them on a tie.
+var sbomEcosystems = []string{ecosystemGo, ecosystemJava, ecosystemPython, "rust", "c"}
+
+// SBOMResolver resolves dependencies from a CycloneDX or SPDX SBOM produced
+// by the build instead of invoking build tools. Components are selected by
+// the type of their package URL, and their sources are located in the
+// ecosystem's local caches: the Go module cache, the coursier/Ivy/Maven
+// caches, the Python distribution directory or index, the Cargo registry and
+// the Conan cache. Components not found in a cache are still part of the
+// graph; they have no Dir and are not scanned.
+type SBOMResolver struct {
+	ecosystem  string
+	path       string
+	python     PythonResolveOptions
+	httpClient *http.Client
+}
+
+// NewSBOMResolver creates a resolver that reads the dependencies of
+// ecosystem from the SBOM at path.
+func NewSBOMResolver(ecosystem, path string) *SBOMResolver {
+	return &SBOMResolver{
+		ecosystem:  ecosystem,
+		path:       path,
+		httpClient: &http.Client{Timeout: defaultPythonIndexTimeout},
+	}
+}
+
+// SetPythonResolveOptions configures where Python sources are fetched from.
+func (r *SBOMResolver) SetPythonResolveOptions(opts PythonResolveOptions) {
+	r.python = opts
+}
+
+// Ecosystem returns the ecosystem whose dependencies the resolver reports.
+func (r *SBOMResolver) Ecosystem() string {
+	return r.ecosystem
+}
+
+// SBOMEcosystem returns the supported ecosystem most components of the SBOM
+// at path belong to, or "" when the SBOM cannot be read or names none.
+func SBOMEcosystem(path string) string {
+	doc, err := readSBOM(path)
+	if err != nil {
+		return ""
+	}
+	best, bestCount := "", 0
+	for _, ecosystem := range sbomEcosystems {
+		count := 0
+		for _, id := range doc.Components {
+			if _, _, ok := purl.Module(ecosystem, doc.Purls[id]); ok {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = ecosystem, count
+		}
+	}
+	return best
+}
+
+// Resolve reads the SBOM and returns the components of the resolver's
+// ecosystem. Edges come from the SBOM's dependency graph; when it records no
+// dependencies for the root, components no other component depends on are
+// the root's direct dependencies.
+func (r *SBOMResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	doc, err := readSBOM(r.path)
+	if err != nil {
+		return nil, failure.Wrap(
+			err,
+			failure.CodeDependencyResolutionFailed,
+			failure.StageDependency,
+			"failed to read dependency SBOM",
+			failure.WithDetail("sbom", r.path),
+		)
+	}
+
+	sources := r.newSourceLocator()
+	result := &ResolveResult{
+		RootModule:     r.rootModule(doc, targetDir),
+		Graph:          make(map[string][]string),
+		VersionedGraph: make(map[string][]Ref),
+	}
+
+	refs := make(map[string]Ref)
+	seen := make(map[Ref]int)
+	skipped := 0
+	for _, id := range doc.Components {
+		if id == doc.Root {
+			continue
+		}
+		module, version, ok := purl.Module(r.ecosystem, doc.Purls[id])
+		if !ok {
+			skipped++
+			continue
+		}
+		if r.ecosystem == ecosystemGo && module == "stdlib" {
+			continue
+		}
+		dep := sources.locate(ctx, module, version)
+		dep.Scope = doc.Scopes[id]
+		ref := Ref{Module: dep.Module, Version: dep.Version}
+		refs[id] = ref
+		if i, ok := seen[ref]; ok {
+			result.Dependencies[i].Scope = widerScope(result.Dependencies[i].Scope, dep.Scope)
+			continue
+		}
+		seen[ref] = len(result.Dependencies)
+		result.Dependencies = append(result.Dependencies, dep)
+	}
+
+	hasParent := make(map[string]bool)
+	for _, id := range doc.Components {
+		parent, ok := refs[id]
+		if !ok {
+			continue
+		}
+		for _, childID := range doc.Edges[id] {
+			if child, ok := refs[childID]; ok {
+				addCEdge(result, parent, child)
+				hasParent[childID] = true
+			}
+		}
+	}
+	rootRef := Ref{Module: result.RootModule}
+	for _, childID := range doc.Edges[doc.Root] {
+		if child, ok := refs[childID]; ok {
+			addCEdge(result, rootRef, child)
+		}
+	}
+	if len(result.VersionedGraph[rootRef.Key()]) == 0 {
+		for _, id := range doc.Components {
+			if ref, ok := refs[id]; ok && !hasParent[id] {
+				addCEdge(result, rootRef, ref)
+			}
+		}
+	}
+
+	log.Info().
+		Str("sbom", r.path).
+		Str("format", doc.Format).
+		Int("resolved", len(result.Dependencies)).
+		Int("withSources", countDependenciesWithSources(result.Dependencies)).
+		Int("otherComponents", skipped).
+		Str("root", result.RootModule).
+		Msg("Resolved dependencies from SBOM")
+
+	return result, nil
+}
+
+// rootModule returns the root module of the component the SBOM describes,
+// in the form the ecosystem's own resolver reports it: the groupId for Java
+// and the go.mod module path for Go (package URLs lower-case Go paths).
+func (r *SBOMResolver) rootModule(doc *sbomDocument, targetDir string) string {
+	if r.ecosystem == ecosystemGo {
+		if mod, err := parseGoModFile(filepath.Join(targetDir, "go.mod")); err == nil && mod.Module != "" {
+			return mod.Module
+		}
+	}
+	if module, _, ok := purl.Module(r.ecosystem, doc.Purls[doc.Root]); ok {
+		if r.ecosystem == ecosystemJava {
+			group, _, _ := strings.Cut(module, ":")
+			return group
+		}
+		return module
+	}
+	if name := doc.Names[doc.Root]; name != "" {
+		if r.ecosystem == ecosystemJava {
+			group, _, _ := strings.Cut(name, ":")
+			return group
+		}
+		return name
+	}
+	return filepath.Base(targetDir)
+}
+
+// sbomSourceLocator finds the sources of SBOM components in the local
+// caches of one ecosystem.
+type sbomSourceLocator struct {
+	ecosystem string
+	cache     *SourceCache
+	jvm       *jvmArtifactLocator
+	python    *pythonSourceFetcher
+}
+
+func (r *SBOMResolver) newSourceLocator() *sbomSourceLocator {
+	locator := &sbomSourceLocator{ecosystem: r.ecosystem}
+	if r.ecosystem != ecosystemJava && r.ecosystem != ecosystemPython {
+		return locator
+	}
+	cache, err := NewSourceCache()
+	if err != nil {
+		log.Warn().Err(err).Msg("Source cache unavailable, resolving SBOM dependencies without sources")
+		cache = nil
+	}
+	locator.cache = cache
+	if r.ecosystem == ecosystemJava {
+		locator.jvm = newJVMArtifactLocator()
+		return locator
+	}
+	locator.python = newPythonSourceFetcher(r.python, cache, r.httpClient)
+	if !locator.python.enabled() {
+		log.Warn().Msg("No Python distribution directory or index configured, resolving SBOM without Python sources")
+	}
+	return locator
+}
+
+// locate returns module@version as a Dependency with its cached sources.
+func (l *sbomSourceLocator) locate(ctx context.Context, module, version string) Dependency {
+	dep := Dependency{Module: module, Version: version}
+	switch l.ecosystem {
+	case ecosystemGo:
+		dep.Dir, dep.Module, dep.Version = goModuleCacheDir(module, version)
+	case ecosystemJava:
+		dep = l.jvm.dependency(Ref{Module: module, Version: version}, l.cache)
+	case ecosystemPython:
+		dep.Dir, dep.SourceArchivePath = l.python.fetch(ctx, module, version)
+	case "rust":
+		dep.Dir = cargoRegistrySourceDir(module, version)
+	default:
+		dep.Dir = conanSourceDir(ctx, conanRef{Name: module, Version: version})
+	}
+	return dep
+}
+
+// goModuleCacheDir returns the extracted module directory of module@version
+// in the Go module cache ($GOMODCACHE, or $GOPATH/pkg/mod), together with
+// the module path and version as spelled in the cache. Package URLs lower
+// case module paths, so the cache is matched case-insensitively.
+func goModuleCacheDir(module, version string) (string, string, string) {
+	if version != "" && !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	cacheDir := os.Getenv("GOMODCACHE")
+	if cacheDir == "" {
+		gopath := filepath.SplitList(os.Getenv("GOPATH"))
+		if len(gopath) > 0 && gopath[0] != "" {
+			cacheDir = filepath.Join(gopath[0], "pkg", "mod")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			cacheDir = filepath.Join(home, "go", "pkg", "mod")
+		}
+	}
+	if cacheDir == "" || version == "" {
+		return "", module, version
+	}
+
+	segments := strings.Split(module, "/")
+	segments[len(segments)-1] += "@" + version
+	dir := cacheDir
+	for i, segment := range segments {
+		exact := filepath.Join(dir, escapeGoModulePath(segment))
+		if isDir(exact) {
+			dir = exact
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return "", module, version
+		}
+		found := false
+		for _, entry := range entries {
+			if entry.IsDir() && strings.EqualFold(unescapeGoModulePath(entry.Name()), segment) {
+				dir = filepath.Join(dir, entry.Name())
+				segments[i] = unescapeGoModulePath(entry.Name())
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", module, version
+		}
+	}
+	last := segments[len(segments)-1]
+	segments[len(segments)-1] = last[:strings.LastIndex(last, "@")]
+	return dir, strings.Join(segments, "/"), version
+}
+
+// escapeGoModulePath applies the module cache's case encoding: each upper
+// case letter becomes "!" followed by its lower case form.
+func escapeGoModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func unescapeGoModulePath(path string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range path {
+		switch {
+		case r == '!':
+			upper = true
+			continue
+		case upper:
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// cargoRegistrySourceDir returns the unpacked crate name-version in the
+// Cargo registry sources ($CARGO_HOME/registry/src/<index>/).
+func cargoRegistrySourceDir(name, version string) string {
+	cargoHome := os.Getenv("CARGO_HOME")
+	if cargoHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		cargoHome = filepath.Join(home, ".cargo")
+	}
+	matches, _ := filepath.Glob(filepath.Join(cargoHome, "registry", "src", "*", name+"-"+version))
+	for _, dir := range matches {
+		if isDir(dir) {
+			return dir
+		}
+	}
+	return ""
+}
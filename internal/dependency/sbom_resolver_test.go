@@ -0,0 +1,265 @@
+package dependency
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+func TestSBOMResolver_ResolveCycloneDXJava(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("COURSIER_CACHE", "")
+
+	artifactDir := filepath.Join(home, ".m2", "repository", "org", "bouncycastle", "bcprov-jdk18on", "1.77")
+	if err := os.MkdirAll(artifactDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(artifactDir, "bcprov-jdk18on-1.77.jar"), []byte("jar"), 0o600); err != nil {
+		t.Fatalf("write jar: %v", err)
+	}
+	createZipArchive(t, filepath.Join(artifactDir, "bcprov-jdk18on-1.77-sources.jar"), map[string]string{
+		"org/bouncycastle/crypto/engines/AESEngine.java": "package org.bouncycastle.crypto.engines;\n",
+	})
+
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{"bom.cdx.json": `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "group": "com.example", "name": "wallet", "purl": "pkg:maven/com.example/wallet@1.0.0"}},
+  "components": [
+    {"bom-ref": "pkix", "type": "library", "name": "bcpkix-jdk18on", "purl": "pkg:maven/org.bouncycastle/bcpkix-jdk18on@1.77?type=jar"},
+    {"bom-ref": "prov", "type": "library", "name": "bcprov-jdk18on", "purl": "pkg:maven/org.bouncycastle/bcprov-jdk18on@1.77?type=jar"},
+    {"bom-ref": "npm", "type": "library", "name": "left-pad", "purl": "pkg:npm/left-pad@1.3.0"}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["pkix"]},
+    {"ref": "pkix", "dependsOn": ["prov", "npm"]}
+  ]
+}`})
+
+	result, err := NewSBOMResolver("java", filepath.Join(dir, "bom.cdx.json")).Resolve(context.Background(), t.TempDir())
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "com.example" {
+		t.Fatalf("RootModule = %q, want com.example", result.RootModule)
+	}
+	pkix := Ref{Module: "org.bouncycastle:bcpkix-jdk18on", Version: "1.77"}
+	prov := Ref{Module: "org.bouncycastle:bcprov-jdk18on", Version: "1.77"}
+	wantGraph := map[string][]Ref{
+		"com.example": {pkix},
+		pkix.Key():    {prov},
+	}
+	if !reflect.DeepEqual(result.VersionedGraph, wantGraph) {
+		t.Fatalf("VersionedGraph = %#v, want %#v", result.VersionedGraph, wantGraph)
+	}
+	if len(result.Dependencies) != 2 {
+		t.Fatalf("Dependencies = %v, want the two Maven components", result.Dependencies)
+	}
+	dep := result.Dependencies[1]
+	if dep.CompiledArtifactPath != filepath.Join(artifactDir, "bcprov-jdk18on-1.77.jar") {
+		t.Fatalf("CompiledArtifactPath = %q", dep.CompiledArtifactPath)
+	}
+	if dep.Dir == "" || !fileExists(filepath.Join(dep.Dir, "org", "bouncycastle", "crypto", "engines", "AESEngine.java")) {
+		t.Fatalf("sources not extracted into %q", dep.Dir)
+	}
+	if result.Dependencies[0].Dir != "" {
+		t.Fatalf("bcpkix Dir = %q, want none without a sources jar", result.Dependencies[0].Dir)
+	}
+}
+
+func TestSBOMResolver_ResolveSPDX2Go(t *testing.T) {
+	modCache := t.TempDir()
+	t.Setenv("GOMODCACHE", modCache)
+	moduleDir := filepath.Join(modCache, "github.com", "!azure", "go-ansiterm@v0.0.0-20250102033503-faa5f7b0171c")
+	writeGoTestFile(t, filepath.Join(moduleDir, "parser.go"), "package ansiterm\n")
+	cryptoDir := filepath.Join(modCache, "golang.org", "x", "crypto@v0.17.0")
+	writeGoTestFile(t, filepath.Join(cryptoDir, "go.mod"), "module golang.org/x/crypto\n")
+
+	target := t.TempDir()
+	writeGoTestFile(t, filepath.Join(target, "go.mod"), "module github.com/Example/App\n\ngo 1.22\n")
+	writeProjectFiles(t, target, map[string]string{"sbom.spdx.json": `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "documentDescribes": ["SPDXRef-app"],
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "github.com/Example/App",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/example/app"}]},
+    {"SPDXID": "SPDXRef-ansiterm", "name": "github.com/Azure/go-ansiterm",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/Azure/go-ansiterm@v0.0.0-20250102033503-faa5f7b0171c"}]},
+    {"SPDXID": "SPDXRef-crypto", "name": "golang.org/x/crypto",
+     "externalRefs": [{"referenceCategory": "PACKAGE_MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/golang.org/x/crypto@v0.17.0"}]},
+    {"SPDXID": "SPDXRef-stdlib", "name": "stdlib",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/stdlib@1.22.0"}]}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-ansiterm"},
+    {"spdxElementId": "SPDXRef-crypto", "relationshipType": "DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-ansiterm"}
+  ]
+}`})
+
+	result, err := NewSBOMResolver("go", filepath.Join(target, "sbom.spdx.json")).Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "github.com/Example/App" {
+		t.Fatalf("RootModule = %q, want the go.mod module path", result.RootModule)
+	}
+	want := []Dependency{
+		{Module: "github.com/Azure/go-ansiterm", Version: "v0.0.0-20250102033503-faa5f7b0171c", Dir: moduleDir},
+		{Module: "golang.org/x/crypto", Version: "v0.17.0", Dir: cryptoDir},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %#v, want %#v", result.Dependencies, want)
+	}
+	wantGraph := map[string][]string{
+		"github.com/Example/App":       {"github.com/Azure/go-ansiterm"},
+		"github.com/Azure/go-ansiterm": {"golang.org/x/crypto"},
+	}
+	if !reflect.DeepEqual(result.Graph, wantGraph) {
+		t.Fatalf("Graph = %#v, want %#v", result.Graph, wantGraph)
+	}
+}
+
+func TestSBOMResolver_ResolveSPDX3RustWithoutEdges(t *testing.T) {
+	cargoHome := t.TempDir()
+	t.Setenv("CARGO_HOME", cargoHome)
+	ringDir := filepath.Join(cargoHome, "registry", "src", "index.crates.io-6f17d22bba15001f", "ring-0.17.8")
+	writeProjectFiles(t, ringDir, map[string]string{"src/lib.rs": "pub mod aead;\n"})
+
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{"sbom.spdx3.json": `{
+  "@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+  "@graph": [
+    {"type": "CreationInfo", "@id": "_:creationinfo", "specVersion": "3.0.1"},
+    {"type": "software_Sbom", "spdxId": "urn:sbom", "rootElement": ["urn:app"]},
+    {"type": "software_Package", "spdxId": "urn:app", "name": "vault", "software_packageUrl": "pkg:cargo/vault@0.3.0"},
+    {"type": "software_Package", "spdxId": "urn:ring", "name": "ring", "software_packageUrl": "pkg:cargo/ring@0.17.8"},
+    {"type": "software_Package", "spdxId": "urn:sha2", "name": "sha2",
+     "externalIdentifier": [{"type": "ExternalIdentifier", "externalIdentifierType": "packageUrl", "identifier": "pkg:cargo/sha2@0.10.8"}]}
+  ]
+}`})
+
+	result, err := NewSBOMResolver("rust", filepath.Join(dir, "sbom.spdx3.json")).Resolve(context.Background(), t.TempDir())
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	if result.RootModule != "vault" {
+		t.Fatalf("RootModule = %q, want vault", result.RootModule)
+	}
+	wantDirect := []Ref{{Module: "ring", Version: "0.17.8"}, {Module: "sha2", Version: "0.10.8"}}
+	if !reflect.DeepEqual(result.VersionedGraph["vault"], wantDirect) {
+		t.Fatalf("direct = %#v, want every component without a parent: %#v", result.VersionedGraph["vault"], wantDirect)
+	}
+	if result.Dependencies[0].Dir != ringDir || result.Dependencies[1].Dir != "" {
+		t.Fatalf("Dependencies = %#v, want ring from the registry sources", result.Dependencies)
+	}
+}
+
+func TestReadSBOMScopes(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{
+		"bom.cdx.json": `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"bom-ref": "prov", "type": "library", "name": "bcprov", "scope": "required"},
+    {"bom-ref": "junit", "type": "library", "name": "junit", "scope": "excluded"},
+    {"bom-ref": "slf4j", "type": "library", "name": "slf4j", "scope": "optional"},
+    {"bom-ref": "guava", "type": "library", "name": "guava"}
+  ]
+}`,
+		"sbom.spdx.json": `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "documentDescribes": ["SPDXRef-app"],
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app"},
+    {"SPDXID": "SPDXRef-crypto", "name": "crypto"},
+    {"SPDXID": "SPDXRef-testify", "name": "testify"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-crypto", "relationshipType": "RUNTIME_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-testify", "relationshipType": "TEST_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-crypto", "relationshipType": "DEV_DEPENDENCY_OF", "relatedSpdxElement": "SPDXRef-testify"}
+  ]
+}`,
+		"sbom.spdx3.json": `{
+  "@graph": [
+    {"type": "software_Package", "spdxId": "urn:app", "name": "vault"},
+    {"type": "software_Package", "spdxId": "urn:ring", "name": "ring"},
+    {"type": "software_Package", "spdxId": "urn:criterion", "name": "criterion"},
+    {"type": "LifecycleScopedRelationship", "relationshipType": "dependsOn", "from": "urn:app", "to": ["urn:ring"], "scope": "runtime"},
+    {"type": "LifecycleScopedRelationship", "relationshipType": "dependsOn", "from": "urn:app", "to": ["urn:criterion"], "scope": "development"}
+  ]
+}`,
+	})
+
+	tests := []struct {
+		file string
+		want map[string]string
+	}{
+		{file: "bom.cdx.json", want: map[string]string{"prov": "compile", "junit": "test", "slf4j": "optional"}},
+		{file: "sbom.spdx.json", want: map[string]string{"SPDXRef-crypto": "runtime", "SPDXRef-testify": "test"}},
+		{file: "sbom.spdx3.json", want: map[string]string{"urn:ring": "runtime", "urn:criterion": "dev"}},
+	}
+	for _, tt := range tests {
+		doc, err := readSBOM(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatalf("readSBOM(%s): %v", tt.file, err)
+		}
+		if !reflect.DeepEqual(doc.Scopes, tt.want) {
+			t.Errorf("%s scopes = %#v, want %#v", tt.file, doc.Scopes, tt.want)
+		}
+	}
+}
+
+func TestSBOMEcosystem(t *testing.T) {
+	dir := t.TempDir()
+	writeProjectFiles(t, dir, map[string]string{
+		"bom.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1">
+  <components>
+    <component type="library" bom-ref="a"><name>cryptography</name><purl>pkg:pypi/cryptography@42.0.5</purl></component>
+    <component type="library" bom-ref="b"><name>cffi</name><purl>pkg:pypi/cffi@1.16.0</purl></component>
+    <component type="library" bom-ref="c"><name>lib</name><purl>pkg:maven/org.example/lib@1.0</purl></component>
+  </components>
+</bom>`,
+		"notes.json": `{"name": "not an SBOM"}`,
+	})
+
+	if got := SBOMEcosystem(filepath.Join(dir, "bom.xml")); got != "python" {
+		t.Fatalf("SBOMEcosystem(bom.xml) = %q, want python", got)
+	}
+	if got := SBOMEcosystem(filepath.Join(dir, "notes.json")); got != "" {
+		t.Fatalf("SBOMEcosystem(notes.json) = %q, want empty", got)
+	}
+
+	_, err := NewSBOMResolver("python", filepath.Join(dir, "notes.json")).Resolve(context.Background(), dir)
+	structured, ok := failure.As(err)
+	if !ok || structured.Code != failure.CodeDependencyResolutionFailed || !strings.Contains(err.Error(), "SBOM") {
+		t.Fatalf("expected SBOM read failure, got %v", err)
+	}
+}
+
+func TestGoModulePathEscaping(t *testing.T) {
+	t.Parallel()
+
+	if got := escapeGoModulePath("github.com/BurntSushi/toml"); got != "github.com/!burnt!sushi/toml" {
+		t.Fatalf("escapeGoModulePath() = %q", got)
+	}
+	if got := unescapeGoModulePath("github.com/!burnt!sushi/toml"); got != "github.com/BurntSushi/toml" {
+		t.Fatalf("unescapeGoModulePath() = %q", got)
+	}
+}
@@ -0,0 +1,288 @@
+package dependency
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/javaruntime"
+)
+
+// sbtDependencyTreePlugin enables the dependencyTree task bundled with sbt
+// 1.4+ for builds that do not enable it themselves.
+const sbtDependencyTreePlugin = "addDependencyTreePlugin\n"
+
+var (
+	sbtLogPrefixPattern = regexp.MustCompile(`^\[(?:info|success)\] ?`)
+	sbtTreeRootPattern  = regexp.MustCompile(`^([\w.\-]+):([\w.\-]+):([^\s:]+)(?: \[S\])?$`)
+	sbtTreeNodePattern  = regexp.MustCompile(`^([ |]*)\+-([\w.\-]+):([\w.\-]+):([^\s:]+)(?: \[S\])?(.*)$`)
+)
+
+// sbtTreeTask is a dependencyTree task of one sbt configuration and the
+// scope of the dependencies it prints.
+type sbtTreeTask struct {
+	task  string
+	scope string
+}
+
+// SbtResolver resolves Scala/JVM dependencies of sbt builds from the output
+// of `sbt dependencyTree`, locating jars in the coursier (or Ivy) cache.
+type SbtResolver struct {
+	javaRuntime javaruntime.Config
+	lookPath    func(string) (string, error)
+	scopes      []string
+}
+
+// NewSbtResolver creates a new sbt dependency resolver.
+func NewSbtResolver() *SbtResolver {
+	return &SbtResolver{lookPath: exec.LookPath}
+}
+
+// SetJavaRuntime configures which Java runtime sbt should run with.
+func (r *SbtResolver) SetJavaRuntime(cfg javaruntime.Config) {
+	r.javaRuntime = cfg
+}
+
+// SetDependencyScopes adds the Runtime and Test configuration trees when
+// runtime or test dependencies are requested.
+func (r *SbtResolver) SetDependencyScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+// treeTasks returns the dependencyTree tasks to run, Compile first. The
+// Compile tree leaves out the Provided configuration, so its tree is always
+// printed too.
+func (r *SbtResolver) treeTasks() []sbtTreeTask {
+	tasks := []sbtTreeTask{
+		{task: "dependencyTree", scope: ScopeCompile},
+		{task: "Provided/dependencyTree", scope: ScopeProvided},
+	}
+	if scopesRequested(r.scopes, ScopeRuntime) {
+		tasks = append(tasks, sbtTreeTask{task: "Runtime/dependencyTree", scope: ScopeRuntime})
+	}
+	if scopesRequested(r.scopes, ScopeTest) {
+		tasks = append(tasks, sbtTreeTask{task: "Test/dependencyTree", scope: ScopeTest})
+	}
+	return tasks
+}
+
+// Ecosystem returns "java".
+func (r *SbtResolver) Ecosystem() string {
+	return ecosystemJava
+}
+
+// Resolve runs `sbt dependencyTree updateClassifiers` in targetDir. The
+// trees give the dependencies and their edges of the Compile and Provided
+// configurations, plus Runtime and Test when requested, for every project
+// of the build; updateClassifiers downloads the source jars into the
+// coursier cache. Projects of the build are user code, so their
+// organization is the root module and they are left out of the
+// dependencies.
+func (r *SbtResolver) Resolve(ctx context.Context, targetDir string) (*ResolveResult, error) {
+	command, err := r.lookPath("sbt")
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, failure.New(
+				failure.CodeDependencyResolutionFailed,
+				failure.StageDependency,
+				fmt.Sprintf("sbt dependency scanning requires sbt in PATH for %s", targetDir),
+				failure.WithDetail("target_dir", targetDir),
+			)
+		}
+		return nil, failure.WrapUnknown(err, failure.CodeDependencyResolutionFailed, failure.StageDependency,
+			"locate sbt executable", failure.WithDetail("target_dir", targetDir))
+	}
+
+	args := []string{"-batch", "-Dsbt.log.noformat=true"}
+	if !sbtHasDependencyTreePlugin(targetDir) {
+		pluginFile, err := writeTempFile("crypto-finder-sbt-plugins-*.sbt", sbtDependencyTreePlugin)
+		if err != nil {
+			return nil, failure.WrapUnknown(err, failure.CodeDependencyResolutionFailed, failure.StageDependency,
+				"failed to prepare sbt plugin file")
+		}
+		defer func() { _ = os.Remove(pluginFile) }()
+		args = append(args, "--addPluginSbtFile="+pluginFile)
+	}
+	tasks := r.treeTasks()
+	scopes := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		args = append(args, task.task)
+		scopes = append(scopes, task.scope)
+	}
+	args = append(args, "updateClassifiers")
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = targetDir
+	if err := configureJavaRuntimeCommand(cmd, r.javaRuntime); err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	rootModule, tree := parseSbtDependencyTree(stdout.String(), scopes)
+	if rootModule == "" {
+		output := strings.TrimSpace(stdout.String() + "\n" + stderr.String())
+		if runErr == nil {
+			runErr = errors.New("no dependency tree in sbt output")
+		}
+		return nil, failure.Wrap(
+			runErr,
+			failure.CodeDependencyResolutionFailed,
+			failure.StageDependency,
+			"sbt dependencyTree failed",
+			failure.WithDetail("target_dir", targetDir),
+			failure.WithDetail("output", truncateForFailure(output, 4000)),
+		)
+	}
+	if runErr != nil {
+		// The tree printed before a later command (usually updateClassifiers
+		// for artifacts without sources) failed.
+		log.Warn().Err(runErr).Msg("sbt exited with an error after printing the dependency tree; some sources may be missing")
+	}
+
+	cache, err := NewSourceCache()
+	if err != nil {
+		log.Warn().Err(err).Msg("Source cache unavailable, resolving sbt dependencies without sources")
+		cache = nil
+	}
+	result := tree.result(rootModule, newJVMArtifactLocator(), cache)
+
+	log.Info().
+		Int("total", len(result.Dependencies)).
+		Int("withSources", countDependenciesWithSources(result.Dependencies)).
+		Str("root", result.RootModule).
+		Msg("Resolved sbt dependencies")
+
+	return result, nil
+}
+
+// parseSbtDependencyTree parses the `dependencyTree` output of every project
+// of the build. It returns the organization of the first project (the root
+// module) and the merged tree. Each command ends with a "[success]" line;
+// the nodes printed by the i-th command have scopes[i], and the output of
+// later commands is ignored. Evicted versions are skipped, and so are the
+// build's own projects when they appear as dependencies of each other.
+func parseSbtDependencyTree(output string, scopes []string) (string, *jvmTree) {
+	lines := strings.Split(output, "\n")
+	commandEnds := make([]bool, len(lines))
+	for i, line := range lines {
+		commandEnds[i] = strings.HasPrefix(line, "[success]")
+		lines[i] = strings.TrimRight(sbtLogPrefixPattern.ReplaceAllString(line, ""), " \r")
+	}
+
+	rootModule := ""
+	projects := make(map[string]bool)
+	for _, line := range lines {
+		if match := sbtTreeRootPattern.FindStringSubmatch(line); match != nil {
+			projects[match[1]+":"+match[2]] = true
+			if rootModule == "" {
+				rootModule = match[1]
+			}
+		}
+	}
+
+	tree := newJVMTree()
+	inTree := false
+	command := 0
+	for i, line := range lines {
+		if commandEnds[i] {
+			command++
+			inTree = false
+			continue
+		}
+		if command >= len(scopes) {
+			break
+		}
+		tree.setScope(scopes[command])
+		if sbtTreeRootPattern.MatchString(line) {
+			tree.resetProject()
+			inTree = true
+			continue
+		}
+		match := sbtTreeNodePattern.FindStringSubmatch(line)
+		if match == nil {
+			if strings.Trim(line, " |") != "" {
+				inTree = false
+			}
+			continue
+		}
+		if !inTree {
+			continue
+		}
+		depth := len(match[1]) / 2
+		module := match[2] + ":" + match[3]
+		if projects[module] || strings.Contains(match[5], "evicted by") {
+			tree.skip(depth)
+			continue
+		}
+		tree.add(Ref{Module: module, Version: match[4]}, depth)
+	}
+	return rootModule, tree
+}
+
+// sbtHasDependencyTreePlugin reports whether the build already enables the
+// dependency tree plugin (bundled or the older sbt-dependency-graph).
+func sbtHasDependencyTreePlugin(targetDir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(targetDir, "project", "*.sbt"))
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if bytes.Contains(data, []byte("addDependencyTreePlugin")) || bytes.Contains(data, []byte("sbt-dependency-graph")) {
+			return true
+		}
+	}
+	return false
+}
+
+// configureJavaRuntimeCommand points cmd at the explicitly selected JDK, if
+// any.
+func configureJavaRuntimeCommand(cmd *exec.Cmd, cfg javaruntime.Config) error {
+	selection, err := javaruntime.ResolveExplicitSelection(cfg)
+	if err != nil {
+		return err
+	}
+	if selection != nil {
+		cmd.Env = javaruntime.EnvWithJavaHome(os.Environ(), selection.JavaHome)
+	}
+	return nil
+}
+
+func writeTempFile(pattern, content string) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+func countDependenciesWithSources(deps []Dependency) int {
+	count := 0
+	for _, dep := range deps {
+		if dep.Dir != "" {
+			count++
+		}
+	}
+	return count
+}
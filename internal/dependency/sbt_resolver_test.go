@@ -0,0 +1,211 @@
+package dependency
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+)
+
+const sbtTreeFixture = `[info] welcome to sbt 1.9.7 (Eclipse Adoptium Java 17.0.9)
+[info] loading project definition from /work/project
+[info] com.example:core_2.13:0.1.0 [S]
+[info]   +-com.google.crypto.tink:tink:1.12.0
+[info]   | +-com.google.code.gson:gson:2.10.1
+[info]   | +-com.google.protobuf:protobuf-java:3.25.1
+[info]   |
+[info]   +-org.bouncycastle:bcprov-jdk18on:1.77
+[info]   +-org.scala-lang:scala-library:2.13.12 [S]
+[info]
+[info] com.example:api_2.13:0.1.0 [S]
+[info]   +-com.example:core_2.13:0.1.0 [S]
+[info]   | +-org.bouncycastle:bcprov-jdk18on:1.77
+[info]   |
+[info]   +-com.google.code.gson:gson:2.9.0 (evicted by: 2.10.1)
+[info]   +-org.bouncycastle:bcpkix-jdk18on:1.77
+[info]     +-org.bouncycastle:bcutil-jdk18on:1.77
+[info]       +-org.bouncycastle:bcprov-jdk18on:1.77
+[info]
+[success] Total time: 3 s, completed Jan 10, 2024, 10:00:00 AM
+`
+
+func TestParseSbtDependencyTree(t *testing.T) {
+	t.Parallel()
+
+	rootModule, tree := parseSbtDependencyTree(sbtTreeFixture, []string{ScopeCompile})
+	if rootModule != "com.example" {
+		t.Fatalf("rootModule = %q, want com.example", rootModule)
+	}
+
+	wantDirect := []Ref{
+		{Module: "com.google.crypto.tink:tink", Version: "1.12.0"},
+		{Module: "org.bouncycastle:bcprov-jdk18on", Version: "1.77"},
+		{Module: "org.scala-lang:scala-library", Version: "2.13.12"},
+		{Module: "org.bouncycastle:bcpkix-jdk18on", Version: "1.77"},
+	}
+	if !reflect.DeepEqual(tree.direct, wantDirect) {
+		t.Fatalf("direct = %#v, want %#v", tree.direct, wantDirect)
+	}
+
+	wantEdges := map[string][]Ref{
+		"com.google.crypto.tink:tink@1.12.0": {
+			{Module: "com.google.code.gson:gson", Version: "2.10.1"},
+			{Module: "com.google.protobuf:protobuf-java", Version: "3.25.1"},
+		},
+		"org.bouncycastle:bcpkix-jdk18on@1.77": {{Module: "org.bouncycastle:bcutil-jdk18on", Version: "1.77"}},
+		"org.bouncycastle:bcutil-jdk18on@1.77": {{Module: "org.bouncycastle:bcprov-jdk18on", Version: "1.77"}},
+	}
+	if !reflect.DeepEqual(tree.edges, wantEdges) {
+		t.Fatalf("edges = %#v, want %#v", tree.edges, wantEdges)
+	}
+
+	for _, node := range tree.nodes {
+		if node.Module == "com.example:core_2.13" || node.Version == "2.9.0" {
+			t.Fatalf("unexpected node %v: build projects and evicted versions must be skipped", node)
+		}
+	}
+	if len(tree.nodes) != 7 {
+		t.Fatalf("nodes = %v, want 7 unique dependencies", tree.nodes)
+	}
+}
+
+func TestParseSbtDependencyTree_Scopes(t *testing.T) {
+	t.Parallel()
+
+	output := `[info] com.example:core_2.13:0.1.0 [S]
+[info]   +-org.bouncycastle:bcprov-jdk18on:1.77
+[success] Total time: 1 s, completed Jan 10, 2024, 10:00:00 AM
+[info] com.example:core_2.13:0.1.0 [S]
+[info]   +-javax.servlet:javax.servlet-api:4.0.1
+[success] Total time: 0 s, completed Jan 10, 2024, 10:00:01 AM
+[info] com.example:core_2.13:0.1.0 [S]
+[info]   +-org.bouncycastle:bcprov-jdk18on:1.77
+[info]   +-org.scalatest:scalatest_2.13:3.2.17
+[info]     +-org.scalactic:scalactic_2.13:3.2.17
+[success] Total time: 1 s, completed Jan 10, 2024, 10:00:02 AM
+[info] com.example:core_2.13:0.1.0 [S]
+[info]   +-com.example.ignored:after-last-tree:1.0
+[success] Total time: 4 s, completed Jan 10, 2024, 10:00:06 AM
+`
+	_, tree := parseSbtDependencyTree(output, []string{ScopeCompile, ScopeProvided, ScopeTest})
+
+	want := map[Ref]string{
+		{Module: "org.bouncycastle:bcprov-jdk18on", Version: "1.77"}:  ScopeCompile,
+		{Module: "javax.servlet:javax.servlet-api", Version: "4.0.1"}: ScopeProvided,
+		{Module: "org.scalatest:scalatest_2.13", Version: "3.2.17"}:   ScopeTest,
+		{Module: "org.scalactic:scalactic_2.13", Version: "3.2.17"}:   ScopeTest,
+	}
+	if !reflect.DeepEqual(tree.scopes, want) {
+		t.Fatalf("scopes = %v, want %v", tree.scopes, want)
+	}
+}
+
+func TestSbtResolver_Resolve(t *testing.T) {
+	home := t.TempDir()
+	setTestHome(t, home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+
+	coursier := filepath.Join(t.TempDir(), "coursier")
+	t.Setenv("COURSIER_CACHE", coursier)
+	artifactDir := filepath.Join(coursier, "v1", "https", "repo1.maven.org", "maven2",
+		"org", "bouncycastle", "bcprov-jdk18on", "1.77")
+	if err := os.MkdirAll(artifactDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(artifactDir, "bcprov-jdk18on-1.77.jar"), []byte("jar"), 0o600); err != nil {
+		t.Fatalf("write jar: %v", err)
+	}
+	createZipArchive(t, filepath.Join(artifactDir, "bcprov-jdk18on-1.77-sources.jar"), map[string]string{
+		"org/bouncycastle/crypto/engines/AESEngine.java": "package org.bouncycastle.crypto.engines;\n",
+	})
+
+	tmpBin := t.TempDir()
+	argsFile := filepath.Join(t.TempDir(), "args")
+	writeExecutable(t, tmpBin, "sbt", "#!/bin/sh\necho \"$@\" > "+argsFile+"\ncat <<'EOF'\n"+sbtTreeFixture+"EOF\n")
+	prependPath(t, tmpBin)
+
+	target := t.TempDir()
+	if err := os.WriteFile(filepath.Join(target, "build.sbt"), []byte(`organization := "com.example"`), 0o600); err != nil {
+		t.Fatalf("write build.sbt: %v", err)
+	}
+
+	result, err := NewSbtResolver().Resolve(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("read args: %v", err)
+	}
+	for _, want := range []string{"-batch", "--addPluginSbtFile=", "dependencyTree Provided/dependencyTree updateClassifiers"} {
+		if !strings.Contains(string(args), want) {
+			t.Fatalf("sbt args %q missing %q", args, want)
+		}
+	}
+
+	if result.RootModule != "com.example" {
+		t.Fatalf("RootModule = %q, want com.example", result.RootModule)
+	}
+	if len(result.VersionedGraph["com.example"]) != 4 {
+		t.Fatalf("direct dependencies = %v, want 4", result.VersionedGraph["com.example"])
+	}
+
+	var bcprov *Dependency
+	for i := range result.Dependencies {
+		if result.Dependencies[i].Module == "org.bouncycastle:bcprov-jdk18on" {
+			bcprov = &result.Dependencies[i]
+		}
+	}
+	if bcprov == nil {
+		t.Fatalf("bcprov missing from %v", result.Dependencies)
+	}
+	if bcprov.Scope != ScopeCompile {
+		t.Fatalf("bcprov Scope = %q, want compile", bcprov.Scope)
+	}
+	if bcprov.CompiledArtifactPath != filepath.Join(artifactDir, "bcprov-jdk18on-1.77.jar") {
+		t.Fatalf("CompiledArtifactPath = %q", bcprov.CompiledArtifactPath)
+	}
+	if bcprov.Dir == "" || !fileExists(filepath.Join(bcprov.Dir, "org", "bouncycastle", "crypto", "engines", "AESEngine.java")) {
+		t.Fatalf("sources not extracted into %q", bcprov.Dir)
+	}
+}
+
+func TestSbtResolver_ResolveFailures(t *testing.T) {
+	t.Run("missing sbt", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		_, err := NewSbtResolver().Resolve(context.Background(), t.TempDir())
+		structured, ok := failure.As(err)
+		if !ok || structured.Code != failure.CodeDependencyResolutionFailed {
+			t.Fatalf("expected dependency resolution failure, got %v", err)
+		}
+	})
+
+	t.Run("no tree", func(t *testing.T) {
+		tmpBin := t.TempDir()
+		writeExecutable(t, tmpBin, "sbt", "#!/bin/sh\necho '[error] Not a valid command: dependencyTree'\nexit 1\n")
+		prependPath(t, tmpBin)
+
+		_, err := NewSbtResolver().Resolve(context.Background(), t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "sbt dependencyTree failed") {
+			t.Fatalf("expected sbt dependencyTree failure, got %v", err)
+		}
+	})
+}
+
+func TestSbtHasDependencyTreePlugin(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if sbtHasDependencyTreePlugin(dir) {
+		t.Fatal("sbtHasDependencyTreePlugin() = true without project/plugins.sbt")
+	}
+	writeProjectFiles(t, dir, map[string]string{"project/plugins.sbt": "addDependencyTreePlugin\n"})
+	if !sbtHasDependencyTreePlugin(dir) {
+		t.Fatal("sbtHasDependencyTreePlugin() = false with addDependencyTreePlugin")
+	}
+}
@@ -1,13 +1,16 @@
 package dependency
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
@@ -36,15 +39,30 @@ func NewSourceCache() (*SourceCache, error) {
 	return &SourceCache{baseDir: baseDir}, nil
 }
 
+// Dir returns the source cache directory.
+func (c *SourceCache) Dir() string {
+	return c.baseDir
+}
+
 // CachedDir returns the cached directory for a dependency, or "" if not cached.
+// A hit refreshes the directory's modification time, which `cache prune`
+// treats as its last use.
 func (c *SourceCache) CachedDir(key, version string) string {
 	dir := filepath.Join(c.baseDir, sanitizeSourceCacheKey(key), version)
 	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		touchSourceCacheDir(dir)
 		return dir
 	}
 	return ""
 }
 
+func touchSourceCacheDir(dir string) {
+	now := time.Now()
+	if err := os.Chtimes(dir, now, now); err != nil {
+		log.Debug().Err(err).Str("dir", dir).Msg("Failed to record source cache use")
+	}
+}
+
 // ExtractZip extracts a ZIP/JAR archive to a cache directory, keeping only files
 // matching the given extensions (e.g., []string{".java"}). If extensions is empty,
 // all files are extracted.
@@ -54,6 +72,7 @@ func (c *SourceCache) ExtractZip(archivePath, key, version string, extensions []
 
 	// If already extracted, return immediately
 	if info, err := os.Stat(destDir); err == nil && info.IsDir() {
+		touchSourceCacheDir(destDir)
 		return destDir, nil
 	}
 
@@ -119,30 +138,102 @@ func extractZipFile(f *zip.File, destPath string) error {
 		return err
 	}
 
-	out, err := os.Create(destPath)
+	writeErr := writeExtractedFile(rc, destPath, f.Name)
+	closeReaderErr := rc.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return closeReaderErr
+}
+
+// ExtractTarGz extracts a gzip-compressed tar archive (e.g., a Python sdist)
+// to the cache directory, the same way ExtractZip does for zip archives.
+// Only regular files are extracted; links and special files are skipped.
+func (c *SourceCache) ExtractTarGz(archivePath, key, version string, extensions []string) (string, error) {
+	destDir := filepath.Join(c.baseDir, sanitizeSourceCacheKey(key), version)
+
+	// If already extracted, return immediately
+	if info, err := os.Stat(destDir); err == nil && info.IsDir() {
+		touchSourceCacheDir(destDir)
+		return destDir, nil
+	}
+
+	f, err := os.Open(archivePath)
 	if err != nil {
-		if closeErr := rc.Close(); closeErr != nil {
-			return fmt.Errorf("open zip file failed and close reader failed: %w", closeErr)
+		return "", fmt.Errorf("open archive %s: %w", archivePath, err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			log.Debug().Err(closeErr).Str("archive", archivePath).Msg("Failed to close archive file")
 		}
+	}()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("open archive %s: %w", archivePath, err)
+	}
+	defer func() {
+		if closeErr := gz.Close(); closeErr != nil {
+			log.Debug().Err(closeErr).Str("archive", archivePath).Msg("Failed to close gzip reader")
+		}
+	}()
+
+	if err := os.MkdirAll(destDir, 0o750); err != nil {
+		return "", fmt.Errorf("create extraction dir: %w", err)
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// A truncated archive keeps what was extracted so far, like a
+			// zip entry that fails to extract.
+			log.Debug().Err(err).Str("archive", archivePath).Msg("Failed to read tar entry")
+			break
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if len(extensions) > 0 && !hasMatchingExtension(hdr.Name, extensions) {
+			continue
+		}
+
+		// #nosec G305 -- validated against path traversal with a canonical prefix check below.
+		destPath := filepath.Join(destDir, hdr.Name)
+		if !strings.HasPrefix(filepath.Clean(destPath), filepath.Clean(destDir)+string(os.PathSeparator)) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(destPath), 0o750); err != nil {
+			continue
+		}
+		if err := writeExtractedFile(tr, destPath, hdr.Name); err != nil {
+			// Non-fatal — skip individual files that fail
+			continue
+		}
+	}
+
+	return destDir, nil
+}
+
+// writeExtractedFile copies one archive entry to destPath, enforcing the
+// per-file size limit.
+func writeExtractedFile(src io.Reader, destPath, name string) error {
+	out, err := os.Create(destPath)
+	if err != nil {
 		return err
 	}
 
 	limit := int64(maxExtractedFileSize) + 1
-	written, copyErr := io.CopyN(out, rc, limit)
+	written, copyErr := io.CopyN(out, src, limit)
 	closeOutErr := out.Close()
-	closeReaderErr := rc.Close()
 	if copyErr != nil && !errors.Is(copyErr, io.EOF) {
 		return copyErr
 	}
 	if written > int64(maxExtractedFileSize) {
-		return fmt.Errorf("extracted file exceeds size limit: %s (%d bytes)", f.Name, written)
+		return fmt.Errorf("extracted file exceeds size limit: %s (%d bytes)", name, written)
 	}
-	if closeOutErr != nil {
-		return closeOutErr
-	}
-	if closeReaderErr != nil {
-		return closeReaderErr
-	}
-
-	return nil
+	return closeOutErr
 }
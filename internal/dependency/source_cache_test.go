@@ -1,12 +1,15 @@
 package dependency
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 func createZipArchive(t *testing.T, path string, files map[string]string) {
@@ -33,6 +36,33 @@ func createZipArchive(t *testing.T, path string, files map[string]string) {
 	}
 }
 
+func createTarGzArchive(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create tar.gz: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("tar header %s: %v", name, err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("tar write %s: %v", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+}
+
 func setTestHome(t *testing.T, home string) {
 	t.Helper()
 	t.Setenv("HOME", home)
@@ -59,9 +89,24 @@ func TestNewSourceCacheAndCachedDir(t *testing.T) {
 		t.Fatalf("mkdir existing cache dir: %v", err)
 	}
 
+	old := time.Now().Add(-72 * time.Hour)
+	if err := os.Chtimes(existing, old, old); err != nil {
+		t.Fatalf("chtimes existing cache dir: %v", err)
+	}
+
 	if got := cache.CachedDir("org.example:lib", "1.0.0"); got != existing {
 		t.Fatalf("CachedDir() = %q, want %q", got, existing)
 	}
+	info, err := os.Stat(existing)
+	if err != nil {
+		t.Fatalf("stat existing cache dir: %v", err)
+	}
+	if !info.ModTime().After(old) {
+		t.Fatalf("cache dir mtime = %v, want refreshed by CachedDir()", info.ModTime())
+	}
+	if cache.Dir() != filepath.Dir(filepath.Dir(existing)) {
+		t.Fatalf("Dir() = %q, want %q", cache.Dir(), filepath.Dir(filepath.Dir(existing)))
+	}
 }
 
 func TestNewSourceCache_ErrorWhenHomeIsNotUsable(t *testing.T) {
@@ -205,6 +250,41 @@ func TestExtractZipFile_CreateDestinationError(t *testing.T) {
 	}
 }
 
+func TestSourceCache_ExtractTarGz(t *testing.T) {
+	cache := &SourceCache{baseDir: t.TempDir()}
+	archive := filepath.Join(t.TempDir(), "lib-1.0.tar.gz")
+	createTarGzArchive(t, archive, map[string]string{
+		"lib-1.0/lib/__init__.py": "x = 1\n",
+		"lib-1.0/README.md":       "readme",
+		"../escape.py":            "nope",
+	})
+
+	dir, err := cache.ExtractTarGz(archive, "pypi:lib", "1.0", []string{".py"})
+	if err != nil {
+		t.Fatalf("ExtractTarGz: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "lib-1.0", "lib", "__init__.py")); err != nil || string(data) != "x = 1\n" {
+		t.Fatalf("extracted __init__.py = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "lib-1.0", "README.md")); !os.IsNotExist(err) {
+		t.Error("expected README.md to be filtered out")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escape.py")); !os.IsNotExist(err) {
+		t.Error("expected path traversal entry to be skipped")
+	}
+
+	invalid := filepath.Join(t.TempDir(), "broken.tar.gz")
+	if err := os.WriteFile(invalid, []byte("not gzip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.ExtractTarGz(invalid, "pypi:broken", "1.0", nil); err == nil {
+		t.Fatal("expected an error for an invalid archive")
+	}
+	if _, err := os.Stat(filepath.Join(cache.baseDir, "pypi-broken")); !os.IsNotExist(err) {
+		t.Error("expected no cache entry for an invalid archive")
+	}
+}
+
 func TestSanitizeSourceCacheKey(t *testing.T) {
 	if got := sanitizeSourceCacheKey("org.example:lib:extra"); got != "org.example-lib-extra" {
 		t.Fatalf("sanitizeSourceCacheKey() = %q", got)
@@ -0,0 +1,281 @@
+package dependency
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	vcpkgManifestFile     = "vcpkg.json"
+	vcpkgInstalledDirName = "vcpkg_installed"
+)
+
+// vcpkgManifest is the subset of vcpkg.json the resolver reads. Entries of
+// "dependencies" are either a port name or an object with a "name".
+type vcpkgManifest struct {
+	Name         string            `json:"name"`
+	Dependencies []json.RawMessage `json:"dependencies"`
+}
+
+// vcpkgPackage is one installed port from vcpkg_installed/vcpkg/status.
+type vcpkgPackage struct {
+	Name         string
+	Version      string
+	Architecture string
+	Depends      []string
+}
+
+// resolveVcpkg reads vcpkg.json and the manifest-mode installed tree. Each
+// installed port's sources come from the vcpkg buildtrees, falling back to
+// its installed headers; ports that are declared but not installed stay in
+// the graph without sources. vcpkg-* helper ports are build tooling and
+// are left out.
+func resolveVcpkg(_ context.Context, targetDir, root string) (*ResolveResult, error) {
+	manifestPath := filepath.Join(targetDir, vcpkgManifestFile)
+	direct, err := readVcpkgManifestDependencies(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", manifestPath, err)
+	}
+
+	result := &ResolveResult{
+		RootModule:     root,
+		Graph:          make(map[string][]string),
+		VersionedGraph: make(map[string][]Ref),
+	}
+
+	installedDir := vcpkgInstalledDir(targetDir)
+	installed, err := readVcpkgStatus(filepath.Join(installedDir, "vcpkg", "status"))
+	if err != nil {
+		log.Debug().Err(err).Str("dir", installedDir).Msg("No vcpkg installed tree, using vcpkg.json only")
+	}
+
+	versions := make(map[string]string, len(installed))
+	for _, pkg := range installed {
+		versions[pkg.Name] = pkg.Version
+	}
+	buildtrees := vcpkgBuildtreesDir(targetDir)
+	for _, pkg := range installed {
+		result.Dependencies = append(result.Dependencies, Dependency{
+			Module:  pkg.Name,
+			Version: pkg.Version,
+			Dir:     vcpkgSourceDir(buildtrees, installedDir, pkg),
+		})
+		for _, dep := range pkg.Depends {
+			if _, ok := versions[dep]; ok {
+				addCEdge(result, Ref{Module: pkg.Name, Version: pkg.Version}, Ref{Module: dep, Version: versions[dep]})
+			}
+		}
+	}
+
+	rootRef := Ref{Module: root}
+	for _, name := range direct {
+		version, ok := versions[name]
+		if !ok {
+			result.Dependencies = append(result.Dependencies, Dependency{Module: name})
+		}
+		addCEdge(result, rootRef, Ref{Module: name, Version: version})
+	}
+	return result, nil
+}
+
+func readVcpkgManifestDependencies(manifestPath string) ([]string, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	var manifest vcpkgManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(manifest.Dependencies))
+	for _, raw := range manifest.Dependencies {
+		var name string
+		if json.Unmarshal(raw, &name) != nil {
+			var entry struct {
+				Name string `json:"name"`
+				Host bool   `json:"host"`
+			}
+			if json.Unmarshal(raw, &entry) != nil || entry.Host {
+				continue
+			}
+			name = entry.Name
+		}
+		if name != "" && !isVcpkgHelperPort(name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// vcpkgInstalledDir returns $VCPKG_INSTALLED_DIR or the manifest-mode
+// default <project>/vcpkg_installed.
+func vcpkgInstalledDir(targetDir string) string {
+	if dir := os.Getenv("VCPKG_INSTALLED_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(targetDir, vcpkgInstalledDirName)
+}
+
+// vcpkgBuildtreesDir returns the buildtrees of $VCPKG_ROOT, or of a vcpkg
+// checkout inside the project (a common submodule layout).
+func vcpkgBuildtreesDir(targetDir string) string {
+	for _, root := range []string{os.Getenv("VCPKG_ROOT"), filepath.Join(targetDir, "vcpkg")} {
+		if root == "" {
+			continue
+		}
+		if dir := filepath.Join(root, "buildtrees"); isDir(dir) {
+			return dir
+		}
+	}
+	return ""
+}
+
+// readVcpkgStatus parses the dpkg-style status database of an installed
+// tree and returns the installed ports, one per name, sorted by name.
+// Feature paragraphs and ports that are not installed are skipped.
+func readVcpkgStatus(statusPath string) ([]vcpkgPackage, error) {
+	file, err := os.Open(statusPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	byName := make(map[string]vcpkgPackage)
+	fields := make(map[string]string)
+	flush := func() {
+		defer clear(fields)
+		name := fields["Package"]
+		if name == "" || fields["Feature"] != "" || isVcpkgHelperPort(name) ||
+			!strings.HasSuffix(fields["Status"], "install ok installed") {
+			return
+		}
+		if _, ok := byName[name]; ok {
+			return
+		}
+		byName[name] = vcpkgPackage{
+			Name:         name,
+			Version:      fields["Version"],
+			Architecture: fields["Architecture"],
+			Depends:      parseVcpkgDepends(fields["Depends"]),
+		}
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok && !strings.HasPrefix(line, " ") {
+			fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	packages := make([]vcpkgPackage, 0, len(byName))
+	for _, pkg := range byName {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Name < packages[j].Name })
+	return packages, nil
+}
+
+// parseVcpkgDepends parses "zlib, openssl[core], vcpkg-cmake:x64-linux".
+func parseVcpkgDepends(value string) []string {
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(part), ":")
+		name, _, _ = strings.Cut(name, "[")
+		if name != "" && !isVcpkgHelperPort(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isVcpkgHelperPort(name string) bool {
+	return strings.HasPrefix(name, "vcpkg-")
+}
+
+// vcpkgSourceDir returns the extracted sources of pkg under buildtrees
+// (<port>/src/<ref>), preferring the directory named after the installed
+// version. Without buildtrees (e.g. after --clean-buildtrees-after-build) it
+// falls back to the port's own include directory in the installed tree.
+func vcpkgSourceDir(buildtrees, installedDir string, pkg vcpkgPackage) string {
+	if buildtrees != "" {
+		srcRoot := filepath.Join(buildtrees, pkg.Name, "src")
+		if entries, err := os.ReadDir(srcRoot); err == nil {
+			var candidates []string
+			for _, entry := range entries {
+				if entry.IsDir() {
+					candidates = append(candidates, entry.Name())
+				}
+			}
+			sort.Strings(candidates)
+			for _, name := range candidates {
+				if pkg.Version != "" && strings.Contains(name, pkg.Version) {
+					return filepath.Join(srcRoot, name)
+				}
+			}
+			if len(candidates) > 0 {
+				return filepath.Join(srcRoot, candidates[len(candidates)-1])
+			}
+		}
+	}
+	return vcpkgInstalledHeaderDir(installedDir, pkg)
+}
+
+// vcpkgInstalledHeaderDir returns the deepest directory below
+// <triplet>/include that holds every header the port installed, using the
+// file list in vcpkg/info. Ports that install headers directly into include/
+// share it with other ports, so they get no directory.
+func vcpkgInstalledHeaderDir(installedDir string, pkg vcpkgPackage) string {
+	listPath := filepath.Join(installedDir, "vcpkg", "info",
+		fmt.Sprintf("%s_%s_%s.list", pkg.Name, pkg.Version, pkg.Architecture))
+	file, err := os.Open(listPath)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	includePrefix := pkg.Architecture + "/include/"
+	common := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, includePrefix) || strings.HasSuffix(line, "/") {
+			continue
+		}
+		dir := path.Dir(line)
+		if common == "" {
+			common = dir
+			continue
+		}
+		for common != dir && !strings.HasPrefix(dir, common+"/") {
+			common = path.Dir(common)
+		}
+	}
+
+	if common == "" || !strings.HasPrefix(common, includePrefix) {
+		return ""
+	}
+	dir := filepath.Join(installedDir, filepath.FromSlash(common))
+	if !isDir(dir) {
+		return ""
+	}
+	return dir
+}
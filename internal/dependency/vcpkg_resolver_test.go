@@ -0,0 +1,103 @@
+package dependency
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testVcpkgStatus = `Package: vcpkg-cmake
+Version: 2023-05-04
+Architecture: x64-linux
+Status: install ok installed
+
+Package: mbedtls
+Version: 3.5.0
+Depends: vcpkg-cmake:x64-linux, zlib
+Architecture: x64-linux
+Status: install ok installed
+
+Package: mbedtls
+Feature: pthreads
+Architecture: x64-linux
+Status: install ok installed
+
+Package: zlib
+Version: 1.3
+Architecture: x64-linux
+Status: install ok installed
+
+Package: libsodium
+Version: 1.0.19
+Architecture: x64-linux
+Status: purge ok not-installed
+`
+
+func TestResolveVcpkg(t *testing.T) {
+	target := t.TempDir()
+	vcpkgRoot := t.TempDir()
+	writeProjectFiles(t, target, map[string]string{
+		"vcpkg.json": `{
+  "name": "firmware",
+  "dependencies": ["mbedtls", {"name": "vcpkg-cmake", "host": true}, {"name": "libsodium"}]
+}`,
+		"vcpkg_installed/vcpkg/status":                       testVcpkgStatus,
+		"vcpkg_installed/vcpkg/info/zlib_1.3_x64-linux.list": "x64-linux/\nx64-linux/include/\nx64-linux/include/zlib.h\nx64-linux/lib/libz.a\n",
+		"vcpkg_installed/x64-linux/include/zlib.h":           "",
+	})
+	writeProjectFiles(t, vcpkgRoot, map[string]string{
+		"buildtrees/mbedtls/src/v3.4.0-1a2b3c.clean/library/aes.c": "",
+		"buildtrees/mbedtls/src/v3.5.0-4d5e6f.clean/library/aes.c": "",
+	})
+	t.Setenv("VCPKG_ROOT", vcpkgRoot)
+	t.Setenv("VCPKG_INSTALLED_DIR", "")
+
+	result, err := resolveVcpkg(context.Background(), target, "firmware")
+	if err != nil {
+		t.Fatalf("resolveVcpkg: %v", err)
+	}
+
+	want := []Dependency{
+		{Module: "mbedtls", Version: "3.5.0", Dir: filepath.Join(vcpkgRoot, "buildtrees", "mbedtls", "src", "v3.5.0-4d5e6f.clean")},
+		// zlib installs its header directly into include/, shared with other ports.
+		{Module: "zlib", Version: "1.3"},
+		{Module: "libsodium"},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Fatalf("Dependencies = %+v, want %+v", result.Dependencies, want)
+	}
+	wantGraph := map[string][]string{
+		"firmware": {"mbedtls", "libsodium"},
+		"mbedtls":  {"zlib"},
+	}
+	if !reflect.DeepEqual(result.Graph, wantGraph) {
+		t.Fatalf("Graph = %v, want %v", result.Graph, wantGraph)
+	}
+	if got := result.VersionedGraph["mbedtls@3.5.0"]; !reflect.DeepEqual(got, []Ref{{Module: "zlib", Version: "1.3"}}) {
+		t.Fatalf("VersionedGraph[mbedtls@3.5.0] = %v", got)
+	}
+}
+
+func TestVcpkgInstalledHeaderDir(t *testing.T) {
+	installed := t.TempDir()
+	writeProjectFiles(t, installed, map[string]string{
+		"vcpkg/info/mbedtls_3.5.0_arm-none-eabi.list": "arm-none-eabi/include/mbedtls/aes.h\n" +
+			"arm-none-eabi/include/mbedtls/private/gcm.h\narm-none-eabi/lib/libmbedcrypto.a\n",
+		"arm-none-eabi/include/mbedtls/aes.h":         "",
+		"arm-none-eabi/include/mbedtls/private/gcm.h": "",
+	})
+	pkg := vcpkgPackage{Name: "mbedtls", Version: "3.5.0", Architecture: "arm-none-eabi"}
+
+	got := vcpkgSourceDir("", installed, pkg)
+	if want := filepath.Join(installed, "arm-none-eabi", "include", "mbedtls"); got != want {
+		t.Fatalf("vcpkgSourceDir without buildtrees = %q, want %q", got, want)
+	}
+}
+
+func TestParseVcpkgDepends(t *testing.T) {
+	got := parseVcpkgDepends("zlib, openssl[core]:x64-linux, vcpkg-cmake-config:x64-linux")
+	if want := []string{"zlib", "openssl"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseVcpkgDepends = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/scanoss/crypto-finder/internal/dependency"
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/pkg/purl"
+)
+
+// buildDependencyGraph converts the resolver output into the report's
+// dependency section. Every resolved dependency version becomes one component,
+// scoped "direct" when the root module or a workspace member depends on it and
+// "transitive" otherwise, and carrying the build-tool scope the resolver
+// recorded for it and, from skipped, the reason its sources were not scanned.
+// Edges to project modules or to versions the resolver did not report are
+// dropped so the graph only references listed components.
+func buildDependencyGraph(resolved *dependency.ResolveResult, ecosystem string, skipped map[string]string) *entities.DependencyGraph {
+	graph := &entities.DependencyGraph{
+		Ecosystem:  ecosystem,
+		Components: []entities.DependencyComponent{},
+	}
+	if resolved == nil {
+		return graph
+	}
+	graph.RootModule = resolved.RootModule
+
+	projectModules := map[string]bool{resolved.RootModule: true}
+	for _, member := range resolved.WorkspaceMembers {
+		projectModules[member.Name] = true
+	}
+
+	refs := make(map[string]dependency.Ref)
+	scopes := make(map[string]string)
+	addRef := func(ref dependency.Ref) {
+		if ref.Module == "" || projectModules[ref.Module] {
+			return
+		}
+		refs[ref.Key()] = ref
+	}
+	for _, dep := range resolved.Dependencies {
+		ref := dependency.Ref{Module: dep.Module, Version: dep.Version}
+		addRef(ref)
+		if dep.Scope != "" {
+			scopes[ref.Key()] = dep.Scope
+		}
+	}
+	for _, children := range resolved.VersionedGraph {
+		for _, child := range children {
+			addRef(child)
+		}
+	}
+
+	direct := make(map[string]bool)
+	for module := range projectModules {
+		for _, ref := range directDependencyRefs(resolved, module) {
+			addRef(ref)
+			direct[ref.Key()] = true
+		}
+	}
+
+	for key, ref := range refs {
+		component := entities.DependencyComponent{
+			Module:          ref.Module,
+			Version:         ref.Version,
+			PURL:            purl.Dependency(ecosystem, ref.Module, ref.Version),
+			Scope:           entities.DependencyScopeTransitive,
+			DependencyScope: scopes[key],
+			SkipReason:      skipped[key],
+		}
+		if direct[key] {
+			component.Scope = entities.DependencyScopeDirect
+		}
+		seen := make(map[string]bool)
+		for _, child := range componentDependencyRefs(resolved, ref) {
+			childKey := child.Key()
+			if _, ok := refs[childKey]; !ok || childKey == key || seen[childKey] {
+				continue
+			}
+			seen[childKey] = true
+			component.DependsOn = append(component.DependsOn, childKey)
+		}
+		sort.Strings(component.DependsOn)
+		graph.Components = append(graph.Components, component)
+	}
+
+	sort.Slice(graph.Components, func(i, j int) bool {
+		a, b := graph.Components[i], graph.Components[j]
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		return a.Version < b.Version
+	})
+	return graph
+}
+
+// componentDependencyRefs returns the direct dependencies of one resolved
+// dependency, preferring the versioned graph and falling back to the
+// module-level adjacency list for resolvers that do not preserve versions.
+func componentDependencyRefs(resolved *dependency.ResolveResult, ref dependency.Ref) []dependency.Ref {
+	if children, ok := resolved.VersionedGraph[ref.Key()]; ok {
+		return children
+	}
+	if children, ok := resolved.VersionedGraph[ref.Module]; ok {
+		return children
+	}
+	return graphDirectDependencyRefs(resolved, ref.Module)
+}
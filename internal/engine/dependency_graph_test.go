@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/dependency"
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	t.Parallel()
+
+	resolved := &dependency.ResolveResult{
+		RootModule: "example.com/app",
+		WorkspaceMembers: []dependency.WorkspaceMember{
+			{Name: "example.com/app/tools", Dir: "/src/tools"},
+		},
+		Dependencies: []dependency.Dependency{
+			{Module: "golang.org/x/crypto", Version: "v0.17.0", Dir: "/mod/crypto", Scope: dependency.ScopeCompile},
+			{Module: "golang.org/x/sys", Version: "v0.15.0", Dir: "/mod/sys"},
+			{Module: "github.com/spf13/cobra", Version: "v1.8.0", Dir: "/mod/cobra", Scope: dependency.ScopeTest},
+		},
+		VersionedGraph: map[string][]dependency.Ref{
+			"example.com/app": {
+				{Module: "golang.org/x/crypto", Version: "v0.17.0"},
+				{Module: "example.com/app/tools"},
+			},
+			"example.com/app/tools": {{Module: "github.com/spf13/cobra", Version: "v1.8.0"}},
+			"golang.org/x/crypto@v0.17.0": {
+				{Module: "golang.org/x/sys", Version: "v0.15.0"},
+				{Module: "golang.org/x/sys", Version: "v0.15.0"},
+			},
+			"github.com/spf13/cobra@v1.8.0": {{Module: "github.com/inconshreveable/mousetrap", Version: "v1.1.0"}},
+		},
+	}
+
+	graph := buildDependencyGraph(resolved, "go", map[string]string{"github.com/spf13/cobra@v1.8.0": entities.DependencySkipScope})
+
+	want := &entities.DependencyGraph{
+		Ecosystem:  "go",
+		RootModule: "example.com/app",
+		Components: []entities.DependencyComponent{
+			{
+				Module:  "github.com/inconshreveable/mousetrap",
+				Version: "v1.1.0",
+				PURL:    "pkg:golang/github.com/inconshreveable/mousetrap@v1.1.0",
+				Scope:   entities.DependencyScopeTransitive,
+			},
+			{
+				Module:          "github.com/spf13/cobra",
+				Version:         "v1.8.0",
+				PURL:            "pkg:golang/github.com/spf13/cobra@v1.8.0",
+				Scope:           entities.DependencyScopeDirect,
+				DependencyScope: dependency.ScopeTest,
+				SkipReason:      entities.DependencySkipScope,
+				DependsOn:       []string{"github.com/inconshreveable/mousetrap@v1.1.0"},
+			},
+			{
+				Module:          "golang.org/x/crypto",
+				Version:         "v0.17.0",
+				PURL:            "pkg:golang/golang.org/x/crypto@v0.17.0",
+				Scope:           entities.DependencyScopeDirect,
+				DependencyScope: dependency.ScopeCompile,
+				DependsOn:       []string{"golang.org/x/sys@v0.15.0"},
+			},
+			{
+				Module:  "golang.org/x/sys",
+				Version: "v0.15.0",
+				PURL:    "pkg:golang/golang.org/x/sys@v0.15.0",
+				Scope:   entities.DependencyScopeTransitive,
+			},
+		},
+	}
+	if !reflect.DeepEqual(graph, want) {
+		t.Errorf("buildDependencyGraph() =\n%+v\nwant\n%+v", graph, want)
+	}
+}
+
+func TestBuildDependencyGraph_ModuleGraphFallback(t *testing.T) {
+	t.Parallel()
+
+	resolved := &dependency.ResolveResult{
+		RootModule: "app",
+		Dependencies: []dependency.Dependency{
+			{Module: "requests", Version: "2.31.0"},
+			{Module: "urllib3", Version: "2.1.0"},
+		},
+		Graph: map[string][]string{
+			"app":      {"requests"},
+			"requests": {"urllib3"},
+		},
+	}
+
+	graph := buildDependencyGraph(resolved, "python", nil)
+
+	if len(graph.Components) != 2 {
+		t.Fatalf("components = %+v, want 2", graph.Components)
+	}
+	requests, urllib3 := graph.Components[0], graph.Components[1]
+	if requests.Scope != entities.DependencyScopeDirect || !reflect.DeepEqual(requests.DependsOn, []string{"urllib3@2.1.0"}) {
+		t.Errorf("requests = %+v, want direct dependency on urllib3@2.1.0", requests)
+	}
+	if urllib3.Scope != entities.DependencyScopeTransitive || len(urllib3.DependsOn) != 0 {
+		t.Errorf("urllib3 = %+v, want transitive leaf", urllib3)
+	}
+}
+
+func TestBuildDependencyGraph_NilResult(t *testing.T) {
+	t.Parallel()
+
+	graph := buildDependencyGraph(nil, "go", nil)
+	if graph.Ecosystem != "go" || graph.Components == nil || len(graph.Components) != 0 {
+		t.Errorf("buildDependencyGraph(nil) = %+v, want empty go graph", graph)
+	}
+}
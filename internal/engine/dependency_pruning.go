@@ -0,0 +1,285 @@
+package engine
+
+import (
+	"archive/zip"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/callgraph"
+	"github.com/scanoss/crypto-finder/internal/dependency"
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// pruneUnimportedDependencies returns resolved without the dependencies the
+// project cannot reach through imports, recording each one in skipped.
+//
+// The pre-pass reads only import tables (callgraph.FileAnalysis.Imports and
+// the wildcard imports): those of the project's code first, then those of
+// every dependency an import resolves to, until no new dependency is reached.
+// A reached dependency whose sources cannot be read keeps the dependencies
+// the resolver graph gives it. Nothing is pruned when the ecosystem's parser
+// cannot read imports on their own, when the project's code yields no
+// imports, or when none of them resolve to a dependency, since any of these
+// means the imports cannot be trusted to describe the project.
+func (ds *DependencyScanner) pruneUnimportedDependencies(
+	target string,
+	resolved *dependency.ResolveResult,
+	skipped map[string]string,
+) *dependency.ResolveResult {
+	if len(resolved.Dependencies) == 0 {
+		return resolved
+	}
+	deps := resolved.Dependencies
+	index := newDependencyNamespaceIndex(ds.resolver.Ecosystem(), ds.cgBuilder.PackageSeparator(), deps)
+
+	reached := make([]bool, len(deps))
+	var queue []int
+	reach := func(i int) {
+		if !reached[i] {
+			reached[i] = true
+			queue = append(queue, i)
+		}
+	}
+	reachImports := func(analyses []*callgraph.FileAnalysis) {
+		for _, importPath := range importedPaths(analyses) {
+			for _, i := range index.match(importPath) {
+				reach(i)
+			}
+		}
+	}
+
+	userAnalyses := 0
+	for _, pkg := range userPackageDirs(target, resolved) {
+		analyses, ok := ds.cgBuilder.ParseImports(pkg)
+		if !ok {
+			log.Info().Str("ecosystem", ds.resolver.Ecosystem()).Msg("Import pruning is not supported for this ecosystem, scanning every dependency")
+			return resolved
+		}
+		userAnalyses += len(analyses)
+		reachImports(analyses)
+	}
+	if userAnalyses == 0 || len(queue) == 0 {
+		log.Warn().
+			Int("files", userAnalyses).
+			Msg("Project imports resolve to no dependency, scanning every dependency")
+		return resolved
+	}
+
+	byModule := make(map[string][]int, len(deps))
+	for i := range deps {
+		byModule[deps[i].Module] = append(byModule[deps[i].Module], i)
+	}
+	for len(queue) > 0 {
+		dep := deps[queue[0]]
+		queue = queue[1:]
+		if dep.Dir != "" {
+			analyses, _ := ds.cgBuilder.ParseImports(callgraph.PackageDir{
+				Dir:        dep.Dir,
+				ImportPath: dep.Module,
+				Version:    dep.Version,
+			})
+			if len(analyses) > 0 {
+				reachImports(analyses)
+				continue
+			}
+		}
+		// Without readable sources the dependency's imports are unknown:
+		// assume it uses everything the resolver says it depends on.
+		for _, child := range componentDependencyRefs(resolved, dependency.Ref{Module: dep.Module, Version: dep.Version}) {
+			for _, i := range byModule[child.Module] {
+				reach(i)
+			}
+		}
+	}
+
+	pruned := *resolved
+	pruned.Dependencies = make([]dependency.Dependency, 0, len(deps))
+	var unimported []string
+	for i, dep := range deps {
+		if reached[i] {
+			pruned.Dependencies = append(pruned.Dependencies, dep)
+			continue
+		}
+		key := dependency.Ref{Module: dep.Module, Version: dep.Version}.Key()
+		skipped[key] = entities.DependencySkipNotImported
+		unimported = append(unimported, key)
+	}
+	sort.Strings(unimported)
+	log.Info().
+		Int("kept", len(pruned.Dependencies)).
+		Int("notImported", len(unimported)).
+		Msg("Pruned dependencies the project does not import")
+	log.Debug().Strs("dependencies", unimported).Msg("Dependencies skipped as not imported")
+	return &pruned
+}
+
+// userPackageDirs returns the package roots of the project's own code, as
+// collectPackageSets adds them to the call graph.
+func userPackageDirs(target string, resolved *dependency.ResolveResult) []callgraph.PackageDir {
+	if len(resolved.WorkspaceMembers) == 0 {
+		return []callgraph.PackageDir{{Dir: target, ImportPath: resolved.RootModule}}
+	}
+	dirs := make([]callgraph.PackageDir, 0, len(resolved.WorkspaceMembers))
+	for _, member := range resolved.WorkspaceMembers {
+		dirs = append(dirs, callgraph.PackageDir{Dir: member.Dir, ImportPath: member.Name})
+	}
+	return dirs
+}
+
+// importedPaths returns the distinct import paths and wildcard prefixes of
+// analyses.
+func importedPaths(analyses []*callgraph.FileAnalysis) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	add := func(importPath string) {
+		if importPath != "" && !seen[importPath] {
+			seen[importPath] = true
+			paths = append(paths, importPath)
+		}
+	}
+	for _, analysis := range analyses {
+		for _, importPath := range analysis.Imports {
+			add(importPath)
+		}
+		for _, prefix := range analysis.WildcardImports {
+			add(prefix)
+		}
+		for _, owner := range analysis.StaticWildcardImports {
+			add(owner)
+		}
+	}
+	return paths
+}
+
+// dependencyNamespaceIndex maps the namespaces a dependency provides (Go
+// module path, Java package, Python top-level package) to the dependencies
+// providing them.
+type dependencyNamespaceIndex struct {
+	separator string
+	owners    map[string][]int
+}
+
+func newDependencyNamespaceIndex(ecosystem, separator string, deps []dependency.Dependency) *dependencyNamespaceIndex {
+	index := &dependencyNamespaceIndex{separator: separator, owners: make(map[string][]int)}
+	for i := range deps {
+		for _, namespace := range dependencyNamespaces(ecosystem, deps[i]) {
+			owners := index.owners[namespace]
+			if len(owners) == 0 || owners[len(owners)-1] != i {
+				index.owners[namespace] = append(owners, i)
+			}
+		}
+	}
+	return index
+}
+
+// match returns the dependencies providing the longest namespace that
+// importPath equals or lies under.
+func (idx *dependencyNamespaceIndex) match(importPath string) []int {
+	for candidate := importPath; candidate != ""; {
+		if owners, ok := idx.owners[candidate]; ok {
+			return owners
+		}
+		cut := strings.LastIndex(candidate, idx.separator)
+		if cut <= 0 {
+			return nil
+		}
+		candidate = candidate[:cut]
+	}
+	return nil
+}
+
+// dependencyNamespaces returns the namespaces imports use to refer to dep.
+func dependencyNamespaces(ecosystem string, dep dependency.Dependency) []string {
+	switch ecosystem {
+	case "go":
+		return []string{dep.Module}
+	case languageJava:
+		return javaDependencyPackages(dep)
+	case ecosystemPython:
+		return pythonDependencyPackages(dep)
+	default:
+		return nil
+	}
+}
+
+// javaDependencyPackages returns the packages of a Java dependency: the
+// directories holding .java files in its extracted sources, and those
+// holding .class files in its compiled artifact.
+func javaDependencyPackages(dep dependency.Dependency) []string {
+	packages := make(map[string]bool)
+	if dep.Dir != "" {
+		_ = filepath.WalkDir(dep.Dir, func(p string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || !strings.HasSuffix(entry.Name(), ".java") {
+				return nil
+			}
+			if rel, err := filepath.Rel(dep.Dir, filepath.Dir(p)); err == nil && rel != "." {
+				packages[strings.ReplaceAll(filepath.ToSlash(rel), "/", ".")] = true
+			}
+			return nil
+		})
+	}
+	if dep.CompiledArtifactPath != "" {
+		if archive, err := zip.OpenReader(dep.CompiledArtifactPath); err == nil {
+			for _, file := range archive.File {
+				dir := path.Dir(file.Name)
+				if strings.HasSuffix(file.Name, ".class") && dir != "." && !strings.HasPrefix(dir, "META-INF") {
+					packages[strings.ReplaceAll(dir, "/", ".")] = true
+				}
+			}
+			_ = archive.Close()
+		}
+	}
+	return sortedKeys(packages)
+}
+
+// pythonDependencyPackages returns the top-level modules of a Python
+// dependency. Environment resolution points Dir at the import package
+// itself; lockfile resolution points it at the extracted distribution, whose
+// packages and modules sit at its root or under src/. The normalized
+// distribution name is always included, as most distributions use it.
+func pythonDependencyPackages(dep dependency.Dependency) []string {
+	packages := map[string]bool{strings.ToLower(strings.ReplaceAll(dep.Module, "-", "_")): true}
+	if dep.Dir == "" {
+		return sortedKeys(packages)
+	}
+	if fileExists(filepath.Join(dep.Dir, "__init__.py")) {
+		packages[filepath.Base(dep.Dir)] = true
+		return sortedKeys(packages)
+	}
+	for _, root := range []string{dep.Dir, filepath.Join(dep.Dir, "src")} {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			switch {
+			case entry.IsDir() && fileExists(filepath.Join(root, name, "__init__.py")):
+				packages[name] = true
+			case !entry.IsDir() && strings.HasSuffix(name, ".py") && name != "setup.py":
+				packages[strings.TrimSuffix(name, ".py")] = true
+			}
+		}
+	}
+	return sortedKeys(packages)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,209 @@
+package engine
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/callgraph"
+	"github.com/scanoss/crypto-finder/internal/dependency"
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func writePruningFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func dependencyModules(deps []dependency.Dependency) []string {
+	modules := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		modules = append(modules, dep.Module)
+	}
+	return modules
+}
+
+func TestPruneUnimportedDependencies_Go(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	target := filepath.Join(root, "app")
+	writePruningFiles(t, target, map[string]string{
+		"main.go":      "package main\n\nimport (\n\t\"fmt\"\n\t\"golang.org/x/crypto/chacha20poly1305\"\n\t\"example.com/nosrc/api\"\n)\n",
+		"main_test.go": "package main\n\nimport \"github.com/stretchr/testify/assert\"\n",
+	})
+	cryptoDir := filepath.Join(root, "crypto")
+	writePruningFiles(t, cryptoDir, map[string]string{
+		"chacha20poly1305/aead.go": "package chacha20poly1305\n\nimport \"golang.org/x/sys/cpu\"\n",
+	})
+	sysDir := filepath.Join(root, "sys")
+	writePruningFiles(t, sysDir, map[string]string{"cpu/cpu.go": "package cpu\n"})
+	viaGraphDir := filepath.Join(root, "viagraph")
+	writePruningFiles(t, viaGraphDir, map[string]string{"lib.go": "package viagraph\n"})
+	testifyDir := filepath.Join(root, "testify")
+	writePruningFiles(t, testifyDir, map[string]string{"assert/assert.go": "package assert\n"})
+
+	resolved := &dependency.ResolveResult{
+		RootModule: "example.com/app",
+		Dependencies: []dependency.Dependency{
+			{Module: "golang.org/x/crypto", Version: "v0.17.0", Dir: cryptoDir},
+			{Module: "golang.org/x/sys", Version: "v0.15.0", Dir: sysDir},
+			{Module: "example.com/nosrc", Version: "v1.0.0"},
+			{Module: "example.com/viagraph", Version: "v1.0.0", Dir: viaGraphDir},
+			{Module: "github.com/stretchr/testify", Version: "v1.9.0", Dir: testifyDir},
+		},
+		VersionedGraph: map[string][]dependency.Ref{
+			"example.com/nosrc@v1.0.0": {{Module: "example.com/viagraph", Version: "v1.0.0"}},
+		},
+	}
+	ds := &DependencyScanner{
+		resolver:  &fakeResolver{ecosystem: "go"},
+		cgBuilder: callgraph.NewBuilderForEcosystem("go", callgraph.NewGoParser()),
+	}
+
+	skipped := make(map[string]string)
+	got := ds.pruneUnimportedDependencies(target, resolved, skipped)
+
+	wantKept := []string{"golang.org/x/crypto", "golang.org/x/sys", "example.com/nosrc", "example.com/viagraph"}
+	if modules := dependencyModules(got.Dependencies); !reflect.DeepEqual(modules, wantKept) {
+		t.Fatalf("kept = %v, want %v", modules, wantKept)
+	}
+	wantSkipped := map[string]string{"github.com/stretchr/testify@v1.9.0": entities.DependencySkipNotImported}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Fatalf("skipped = %v, want %v", skipped, wantSkipped)
+	}
+	if len(resolved.Dependencies) != 5 {
+		t.Fatalf("pruning must not modify the resolve result")
+	}
+}
+
+func TestPruneUnimportedDependencies_JavaPackagesFromSourcesAndJar(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	target := filepath.Join(root, "app")
+	writePruningFiles(t, target, map[string]string{
+		"src/main/java/com/example/App.java": "package com.example;\n\nimport org.bouncycastle.crypto.engines.AESEngine;\nimport static com.google.common.base.Preconditions.checkArgument;\n\nclass App {}\n",
+	})
+	bcDir := filepath.Join(root, "bcprov")
+	writePruningFiles(t, bcDir, map[string]string{
+		"org/bouncycastle/crypto/engines/AESEngine.java": "package org.bouncycastle.crypto.engines;\n\nimport org.bouncycastle.util.*;\n\npublic class AESEngine {}\n",
+	})
+	bcUtilDir := filepath.Join(root, "bcutil")
+	writePruningFiles(t, bcUtilDir, map[string]string{
+		"org/bouncycastle/util/Arrays.java": "package org.bouncycastle.util;\n\npublic class Arrays {}\n",
+	})
+	jar := filepath.Join(root, "guava.jar")
+	writePruningJar(t, jar, "com/google/common/base/Preconditions.class", "META-INF/MANIFEST.MF")
+	jacksonDir := filepath.Join(root, "jackson")
+	writePruningFiles(t, jacksonDir, map[string]string{
+		"com/fasterxml/jackson/databind/ObjectMapper.java": "package com.fasterxml.jackson.databind;\n\npublic class ObjectMapper {}\n",
+	})
+
+	resolved := &dependency.ResolveResult{
+		RootModule: "com.example",
+		Dependencies: []dependency.Dependency{
+			{Module: "org.bouncycastle:bcprov-jdk18on", Version: "1.77", Dir: bcDir},
+			{Module: "org.bouncycastle:bcutil-jdk18on", Version: "1.77", Dir: bcUtilDir},
+			{Module: "com.google.guava:guava", Version: "33.0.0", CompiledArtifactPath: jar},
+			{Module: "com.fasterxml.jackson.core:jackson-databind", Version: "2.17.0", Dir: jacksonDir},
+		},
+	}
+	ds := &DependencyScanner{
+		resolver:  &fakeResolver{ecosystem: "java"},
+		cgBuilder: callgraph.NewBuilderForEcosystem("java", callgraph.NewJavaParser()),
+	}
+
+	skipped := make(map[string]string)
+	got := ds.pruneUnimportedDependencies(target, resolved, skipped)
+
+	wantKept := []string{"org.bouncycastle:bcprov-jdk18on", "org.bouncycastle:bcutil-jdk18on", "com.google.guava:guava"}
+	if modules := dependencyModules(got.Dependencies); !reflect.DeepEqual(modules, wantKept) {
+		t.Fatalf("kept = %v, want %v", modules, wantKept)
+	}
+	if skipped["com.fasterxml.jackson.core:jackson-databind@2.17.0"] != entities.DependencySkipNotImported || len(skipped) != 1 {
+		t.Fatalf("skipped = %v, want jackson-databind not imported", skipped)
+	}
+}
+
+func TestPruneUnimportedDependencies_KeepsEverythingWithoutEvidence(t *testing.T) {
+	t.Parallel()
+
+	resolved := &dependency.ResolveResult{
+		RootModule:   "example.com/app",
+		Dependencies: []dependency.Dependency{{Module: "golang.org/x/crypto", Version: "v0.17.0"}},
+	}
+
+	unsupported := &DependencyScanner{
+		resolver:  &fakeResolver{ecosystem: "rust"},
+		cgBuilder: callgraph.NewBuilder(noopCallgraphParser{}),
+	}
+	skipped := make(map[string]string)
+	if got := unsupported.pruneUnimportedDependencies(t.TempDir(), resolved, skipped); got != resolved || len(skipped) != 0 {
+		t.Fatalf("a parser without ImportParser must not prune, skipped = %v", skipped)
+	}
+
+	// Project code whose imports match no dependency says nothing about
+	// which dependencies are used.
+	target := t.TempDir()
+	writePruningFiles(t, target, map[string]string{"main.go": "package main\n\nimport \"fmt\"\n"})
+	goScanner := &DependencyScanner{
+		resolver:  &fakeResolver{ecosystem: "go"},
+		cgBuilder: callgraph.NewBuilderForEcosystem("go", callgraph.NewGoParser()),
+	}
+	if got := goScanner.pruneUnimportedDependencies(target, resolved, skipped); got != resolved || len(skipped) != 0 {
+		t.Fatalf("unmatched imports must not prune, skipped = %v", skipped)
+	}
+}
+
+func TestPythonDependencyPackages(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	envDir := filepath.Join(root, "site-packages", "jwt")
+	writePruningFiles(t, envDir, map[string]string{"__init__.py": "", "algorithms.py": ""})
+	wheelDir := filepath.Join(root, "wheel")
+	writePruningFiles(t, wheelDir, map[string]string{
+		"nacl/__init__.py":                "",
+		"six.py":                          "",
+		"PyNaCl-1.5.0.dist-info/METADATA": "",
+		"src/extra/__init__.py":           "",
+	})
+
+	if got, want := pythonDependencyPackages(dependency.Dependency{Module: "PyJWT", Dir: envDir}), []string{"jwt", "pyjwt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("environment packages = %v, want %v", got, want)
+	}
+	if got, want := pythonDependencyPackages(dependency.Dependency{Module: "PyNaCl", Dir: wheelDir}), []string{"extra", "nacl", "pynacl", "six"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("distribution packages = %v, want %v", got, want)
+	}
+}
+
+func writePruningJar(t *testing.T, path string, entries ...string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create jar: %v", err)
+	}
+	writer := zip.NewWriter(file)
+	for _, entry := range entries {
+		if _, err := writer.Create(entry); err != nil {
+			t.Fatalf("add %s: %v", entry, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close jar: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("close jar file: %v", err)
+	}
+}
@@ -39,6 +39,17 @@ type DepScanOptions struct {
 	ScanOptions ScanOptions
 	// Workers is the number of concurrent dependency scans (0 = default to NumCPU/2, capped at 8).
 	Workers int
+	// Scopes restricts scanning to dependencies of these scopes (see
+	// dependency.Scopes). Dependencies without a recorded scope are always
+	// scanned. Empty scans every resolved dependency.
+	Scopes []string
+	// PruneUnimported skips the dependencies no import of the project, or of
+	// a dependency it imports, refers to (see pruneUnimportedDependencies).
+	PruneUnimported bool
+	// Spool, when non-nil, hands dependency scans to worker processes through
+	// a directory-based queue (see DependencySpool). Workers then sizes the
+	// scans this process runs itself, unless the spool is coordinate-only.
+	Spool *DependencySpool
 }
 
 // DependencyScanner coordinates dependency resolution, scanning, call graph
@@ -141,8 +152,17 @@ func (ds *DependencyScanner) ScanWithDependencies(
 		ecosystem = ds.resolver.Ecosystem()
 	}
 	enrichDirectFindingPURLs(userReport, opts.ScanOptions.Target, resolved, ecosystem)
+	// The report's dependency section lists every resolved dependency; only
+	// the ones in the selected scopes, and imported when pruning, are
+	// scanned. skipped records why the others were not.
+	inventory := resolved
+	skipped := make(map[string]string)
+	resolved = restrictDependencyScopes(resolved, opts.Scopes, skipped)
+	if opts.PruneUnimported {
+		resolved = ds.pruneUnimportedDependencies(opts.ScanOptions.Target, resolved, skipped)
+	}
 	if len(resolved.Dependencies) == 0 {
-		return ds.emptyDependencyScanResult(userReport, resolved, opts), nil
+		return ds.emptyDependencyScanResult(userReport, inventory, skipped, opts), nil
 	}
 
 	depResults, err := ds.scanDependenciesParallel(ctx, resolved.Dependencies, filteredRulePaths, rulesHash, opts)
@@ -175,6 +195,7 @@ func (ds *DependencyScanner) ScanWithDependencies(
 	userPackages := ds.buildUserPackages(resolved)
 	ds.attributeDependencyResults(depResults, opts.ScanOptions.Target, tracer, userPackages)
 	result := ds.mergeReports(userReport, depResults)
+	result.Dependencies = buildDependencyGraph(inventory, ecosystem, skipped)
 
 	pipelineDuration := time.Since(pipelineStart)
 	log.Info().
@@ -229,6 +250,35 @@ func (ds *DependencyScanner) prepareDependencyScan(
 	return resolved, filteredRulePaths, ds.computeRulesHash(filteredRulePaths), cleanupRulePaths, nil
 }
 
+// restrictDependencyScopes returns resolved with only the dependencies whose
+// scope is selected by scopes, recording the others in skipped. The graph is
+// kept whole so call chains can still pass through dependencies that are not
+// scanned.
+func restrictDependencyScopes(resolved *dependency.ResolveResult, scopes []string, skipped map[string]string) *dependency.ResolveResult {
+	if len(scopes) == 0 {
+		return resolved
+	}
+	restricted := *resolved
+	restricted.Dependencies = make([]dependency.Dependency, 0, len(resolved.Dependencies))
+	skippedByScope := make(map[string]int)
+	for _, dep := range resolved.Dependencies {
+		if dependency.ScopeSelected(dep.Scope, scopes) {
+			restricted.Dependencies = append(restricted.Dependencies, dep)
+			continue
+		}
+		skipped[dependency.Ref{Module: dep.Module, Version: dep.Version}.Key()] = entities.DependencySkipScope
+		skippedByScope[dep.Scope]++
+	}
+	if len(skippedByScope) > 0 {
+		log.Info().
+			Strs("scopes", scopes).
+			Int("kept", len(restricted.Dependencies)).
+			Interface("skippedByScope", skippedByScope).
+			Msg("Skipping dependencies outside the selected scopes")
+	}
+	return &restricted
+}
+
 func (ds *DependencyScanner) computeRulesHash(rulePaths []string) string {
 	if ds.findingsCache == nil {
 		return ""
@@ -244,9 +294,11 @@ func (ds *DependencyScanner) computeRulesHash(rulePaths []string) string {
 func (ds *DependencyScanner) emptyDependencyScanResult(
 	userReport *entities.InterimReport,
 	resolved *dependency.ResolveResult,
+	skipped map[string]string,
 	opts DepScanOptions,
 ) *DepScanResult {
-	log.Info().Msg("No dependencies found, skipping dependency scan")
+	log.Info().Msg("No dependencies to scan, skipping dependency scan")
+	userReport.Dependencies = buildDependencyGraph(resolved, ds.resolver.Ecosystem(), skipped)
 	return &DepScanResult{
 		Report:      userReport,
 		RootModule:  resolved.RootModule,
@@ -366,6 +418,14 @@ func dependencyScanWorkers(configured int, ecosystem string) int {
 	return min(max(runtime.NumCPU()/2, 1), limit)
 }
 
+// dependencyWork is a dependency whose sources are to be scanned, at index
+// in the canonical dependency order.
+type dependencyWork struct {
+	index int
+	key   string
+	dep   dependency.Dependency
+}
+
 // scanDependenciesParallel scans all dependencies concurrently using a worker pool.
 func (ds *DependencyScanner) scanDependenciesParallel(
 	ctx context.Context,
@@ -378,13 +438,8 @@ func (ds *DependencyScanner) scanDependenciesParallel(
 
 	orderedDeps := canonicalDependencies(deps)
 
-	type depWork struct {
-		index int
-		key   string
-		dep   dependency.Dependency
-	}
 	outcomes := make([]depScanResult, len(orderedDeps))
-	work := make([]depWork, 0, len(orderedDeps))
+	work := make([]dependencyWork, 0, len(orderedDeps))
 	for i, dep := range orderedDeps {
 		key := dependencyKey(dep)
 		if dep.Dir == "" {
@@ -401,7 +456,7 @@ func (ds *DependencyScanner) scanDependenciesParallel(
 			continue
 		}
 
-		work = append(work, depWork{index: i, key: key, dep: dep})
+		work = append(work, dependencyWork{index: i, key: key, dep: dep})
 	}
 
 	log.Info().
@@ -428,8 +483,12 @@ func (ds *DependencyScanner) scanDependenciesParallel(
 	depCtx, depCancel := detachDeadlineKeepCancel(ctx)
 	defer depCancel()
 
+	if opts.Spool != nil {
+		return outcomes, ds.scanDependenciesSpooled(depCtx, work, outcomes, rulePaths, rulesHash, opts)
+	}
+
 	// Worker pool
-	workCh := make(chan depWork, len(work))
+	workCh := make(chan dependencyWork, len(work))
 	resultCh := make(chan depScanResult, len(work))
 
 	var wg sync.WaitGroup
@@ -486,22 +545,9 @@ func (ds *DependencyScanner) scanSingleDep(
 	rulesHash string,
 	opts DepScanOptions,
 ) depScanResult {
-	cacheKey := key + ":" + rulesHash
-	if opts.ScanOptions.JavaRuntimeCacheToken != "" {
-		cacheKey += ":" + opts.ScanOptions.JavaRuntimeCacheToken
-	}
-
-	// Check cache
-	if ds.findingsCache != nil && rulesHash != "" {
-		if report, ok, err := ds.findingsCache.Get(ctx, cacheKey); err == nil && ok {
-			log.Info().
-				Str("module", dep.Module).
-				Str("version", dep.Version).
-				Msg("Cache hit for dependency scan")
-			return depScanResult{key: key, dep: dep, report: dependencyReportWithFindings(report), status: depScanStatusScanned}
-		} else if err != nil {
-			log.Warn().Err(err).Str("module", dep.Module).Msg("Cache read error, scanning normally")
-		}
+	cacheKey := dependencyCacheKey(key, rulesHash, opts)
+	if report, ok := ds.cachedDependencyScan(ctx, dep, cacheKey, rulesHash); ok {
+		return depScanResult{key: key, dep: dep, report: dependencyReportWithFindings(report), status: depScanStatusScanned}
 	}
 
 	log.Info().Str("module", dep.Module).Str("version", dep.Version).Msg("Scanning dependency")
@@ -513,11 +559,8 @@ func (ds *DependencyScanner) scanSingleDep(
 		Str("version", dep.Version).
 		Msg("Scanned dependency")
 
-	// Store in cache on success
-	if err == nil && ds.findingsCache != nil && rulesHash != "" {
-		if putErr := ds.findingsCache.Put(ctx, cacheKey, report); putErr != nil {
-			log.Warn().Err(putErr).Str("module", dep.Module).Msg("Failed to cache scan result")
-		}
+	if err == nil {
+		ds.cacheDependencyScan(ctx, dep, cacheKey, rulesHash, report)
 	}
 
 	return depScanResult{
@@ -529,6 +572,57 @@ func (ds *DependencyScanner) scanSingleDep(
 	}
 }
 
+// dependencyCacheKey returns the findings cache key of a dependency scan.
+func dependencyCacheKey(key, rulesHash string, opts DepScanOptions) string {
+	cacheKey := key + ":" + rulesHash
+	if opts.ScanOptions.JavaRuntimeCacheToken != "" {
+		cacheKey += ":" + opts.ScanOptions.JavaRuntimeCacheToken
+	}
+	return cacheKey
+}
+
+// cachedDependencyScan returns the cached report of a dependency scan, if a
+// findings cache is configured and holds one.
+func (ds *DependencyScanner) cachedDependencyScan(
+	ctx context.Context,
+	dep dependency.Dependency,
+	cacheKey string,
+	rulesHash string,
+) (*entities.InterimReport, bool) {
+	if ds.findingsCache == nil || rulesHash == "" {
+		return nil, false
+	}
+	report, ok, err := ds.findingsCache.Get(ctx, cacheKey)
+	if err != nil {
+		log.Warn().Err(err).Str("module", dep.Module).Msg("Cache read error, scanning normally")
+		return nil, false
+	}
+	if ok {
+		log.Info().
+			Str("module", dep.Module).
+			Str("version", dep.Version).
+			Msg("Cache hit for dependency scan")
+	}
+	return report, ok
+}
+
+// cacheDependencyScan stores the report of a successful dependency scan, if
+// a findings cache is configured.
+func (ds *DependencyScanner) cacheDependencyScan(
+	ctx context.Context,
+	dep dependency.Dependency,
+	cacheKey string,
+	rulesHash string,
+	report *entities.InterimReport,
+) {
+	if ds.findingsCache == nil || rulesHash == "" {
+		return
+	}
+	if err := ds.findingsCache.Put(ctx, cacheKey, report); err != nil {
+		log.Warn().Err(err).Str("module", dep.Module).Msg("Failed to cache scan result")
+	}
+}
+
 func dependencyReportWithFindings(report *entities.InterimReport) *entities.InterimReport {
 	if !hasFindings(report) {
 		return nil
@@ -553,6 +647,7 @@ func (ds *DependencyScanner) buildDepScanOptions(dep *dependency.Dependency, rul
 	depOpts.LanguageHint = ecosystemToLanguages(ds.resolver.Ecosystem())
 	depOpts.Progress = nil
 	depOpts.ProgressDetectionStarted = false
+	depOpts.RulesProfile = nil
 	// Preserve only built-in test exclusions for dependency scans. Other user/project
 	// skip patterns should not hide dependency source files.
 	depOpts.ScannerConfig.SkipPatterns = skip.OnlyDefaultTestPatterns(depOpts.ScannerConfig.SkipPatterns)
@@ -566,16 +661,17 @@ type packageSets struct {
 	// still be parsed here because they can be bridge nodes in a call chain
 	// (for example A -> B(no crypto) -> C(crypto)).
 	graphPackages []callgraph.PackageDir
-	// typeOnlyPackages are used only for bytecode type indexing (no source parsing).
+	// typeOnlyPackages are used for bytecode type indexing (no source parsing).
 	// This preserves type resolution accuracy for dependencies whose source is
 	// unavailable or whose scan failed, while avoiding duplicate source parsing for
-	// dependencies already listed in graphPackages.
+	// dependencies already listed in graphPackages. Their compiled artifacts also
+	// supply bytecode call graph nodes, keeping chains through them connected.
 	typeOnlyPackages []callgraph.PackageDir
 }
 
 // collectPackageSets builds two lists of PackageDirs for the two-phase callgraph build.
 // graphPackages: user code + successfully scanned deps with source, regardless of findings.
-// typeOnlyPackages: Java deps not source-parsed, used for bytecode type resolution only.
+// typeOnlyPackages: Java deps not source-parsed, used for bytecode type resolution and call graph nodes.
 func (ds *DependencyScanner) collectPackageSets(
 	userTarget string,
 	resolved *dependency.ResolveResult,
@@ -789,9 +885,10 @@ func (ds *DependencyScanner) attributeFindings(
 			asset.Source = findingSourceDependency
 			asset.PURL = ""
 			asset.DependencyInfo = &entities.DependencyInfo{
-				Module:  dep.Module,
-				Version: dep.Version,
-				PURL:    purl.Dependency(ecosystem, dep.Module, dep.Version),
+				Module:          dep.Module,
+				Version:         dep.Version,
+				PURL:            purl.Dependency(ecosystem, dep.Module, dep.Version),
+				DependencyScope: dep.Scope,
 			}
 		}
 	}
@@ -1037,6 +1134,9 @@ func ecosystemToLanguages(ecosystem string) []string {
 		return []string{"rust"}
 	case "c":
 		return []string{"c"}
+	case "cpp":
+		// C++ projects commonly depend on C libraries (OpenSSL, Mbed TLS).
+		return []string{"c", "cpp"}
 	default:
 		return nil
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -122,6 +123,9 @@ func TestDependencyScanner_HelperFunctions(t *testing.T) {
 	if langs := ecosystemToLanguages("go"); len(langs) != 1 || langs[0] != "go" {
 		t.Fatalf("unexpected go languages: %#v", langs)
 	}
+	if langs := ecosystemToLanguages("cpp"); len(langs) != 2 || langs[0] != "c" || langs[1] != "cpp" {
+		t.Fatalf("unexpected cpp languages: %#v", langs)
+	}
 	if langs := ecosystemToLanguages("unknown"); langs != nil {
 		t.Fatalf("expected nil for unknown ecosystem, got %#v", langs)
 	}
@@ -299,7 +303,7 @@ func TestDependencyScanner_AttributeAndEnrich(t *testing.T) {
 	tracer := callgraph.NewTracer(graph, "/")
 
 	ds := &DependencyScanner{resolver: &fakeResolver{ecosystem: "go"}}
-	dep := &dependency.Dependency{Module: "dep/mod", Version: "v1.0.0", Dir: depDir}
+	dep := &dependency.Dependency{Module: "dep/mod", Version: "v1.0.0", Dir: depDir, Scope: dependency.ScopeTest}
 	depReport := &entities.InterimReport{Findings: []entities.Finding{{
 		FilePath:            "lib.go",
 		CryptographicAssets: []entities.CryptographicAsset{{StartLine: 10, PURL: "pkg:golang/dep/mod"}},
@@ -316,6 +320,9 @@ func TestDependencyScanner_AttributeAndEnrich(t *testing.T) {
 	if asset.DependencyInfo.PURL != "pkg:golang/dep/mod@v1.0.0" {
 		t.Errorf("dependency purl = %q, want pkg:golang/dep/mod@v1.0.0", asset.DependencyInfo.PURL)
 	}
+	if asset.DependencyInfo.DependencyScope != dependency.ScopeTest {
+		t.Errorf("dependency scope = %q, want test", asset.DependencyInfo.DependencyScope)
+	}
 	if asset.PURL != "" {
 		t.Errorf("top-level dependency PURL = %q, want empty", asset.PURL)
 	}
@@ -324,6 +331,42 @@ func TestDependencyScanner_AttributeAndEnrich(t *testing.T) {
 	}
 }
 
+func TestRestrictDependencyScopes(t *testing.T) {
+	t.Parallel()
+
+	resolved := &dependency.ResolveResult{
+		RootModule: "example.com/app",
+		Dependencies: []dependency.Dependency{
+			{Module: "golang.org/x/crypto", Version: "v0.17.0", Scope: dependency.ScopeCompile},
+			{Module: "github.com/stretchr/testify", Version: "v1.9.0", Scope: dependency.ScopeTest},
+			{Module: "example.com/unscoped", Version: "v1.0.0"},
+		},
+		VersionedGraph: map[string][]dependency.Ref{
+			"example.com/app": {{Module: "github.com/stretchr/testify", Version: "v1.9.0"}},
+		},
+	}
+
+	skipped := make(map[string]string)
+	if got := restrictDependencyScopes(resolved, nil, skipped); got != resolved || len(skipped) != 0 {
+		t.Fatalf("restrictDependencyScopes(nil) should return the resolve result unchanged")
+	}
+
+	got := restrictDependencyScopes(resolved, []string{dependency.ScopeCompile, dependency.ScopeRuntime}, skipped)
+	var modules []string
+	for _, dep := range got.Dependencies {
+		modules = append(modules, dep.Module)
+	}
+	if want := []string{"golang.org/x/crypto", "example.com/unscoped"}; !reflect.DeepEqual(modules, want) {
+		t.Fatalf("scanned = %v, want %v", modules, want)
+	}
+	if want := map[string]string{"github.com/stretchr/testify@v1.9.0": entities.DependencySkipScope}; !reflect.DeepEqual(skipped, want) {
+		t.Fatalf("skipped = %v, want %v", skipped, want)
+	}
+	if len(resolved.Dependencies) != 3 || !reflect.DeepEqual(got.VersionedGraph, resolved.VersionedGraph) {
+		t.Fatalf("restricting must not change the resolve result or drop graph edges")
+	}
+}
+
 func TestEnrichDirectFindingPURLs(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,581 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+)
+
+// spoolFormatVersion is the version of the job and result files. Workers
+// fail jobs of another version instead of guessing at their meaning.
+const spoolFormatVersion = 1
+
+const (
+	defaultSpoolLease        = time.Minute
+	defaultSpoolMaxAttempts  = 3
+	defaultSpoolPollInterval = 500 * time.Millisecond
+	// defaultSpoolClaimLeases is how many leases queued jobs may wait
+	// without any worker activity before the coordinator gives up on them.
+	defaultSpoolClaimLeases = 5
+)
+
+const (
+	spoolRunPrefix     = "run-"
+	spoolRulesDir      = "rules"
+	spoolJobsDir       = "jobs"
+	spoolClaimedDir    = "claimed"
+	spoolResultsDir    = "results"
+	spoolFileExtension = ".json"
+)
+
+// DependencySpool is a directory-based job queue that spreads dependency
+// scans over worker processes ("crypto-finder dep-worker"), on this machine
+// or on any machine that mounts the directory.
+//
+// Each coordinating scan creates a run directory holding a copy of its
+// filtered rules and one job file per dependency:
+//
+//	<dir>/run-*/rules/    rules the jobs reference, relative to the run
+//	<dir>/run-*/jobs/     jobs waiting for a worker
+//	<dir>/run-*/claimed/  jobs a worker is scanning
+//	<dir>/run-*/results/  reports and errors written back by workers
+//
+// A worker claims a job by renaming it from jobs/ to claimed/, which only one
+// worker can do, and keeps the claim alive by touching the file. Every file is
+// written under a temporary name and renamed into place, so readers never see
+// a partial file. Dependency sources are read from the path the coordinator
+// resolved, so workers on other machines must see them at the same path.
+type DependencySpool struct {
+	dir string
+	// Lease is how long a claimed job may go without a heartbeat before the
+	// coordinator assumes its worker died and queues the job again.
+	Lease time.Duration
+	// MaxAttempts bounds how many times a job is handed out before its
+	// dependency is recorded as failed.
+	MaxAttempts int
+	// PollInterval is how often the coordinator and workers look for files.
+	PollInterval time.Duration
+	// ClaimTimeout is how long the coordinator waits for any worker to
+	// claim, renew or answer one of its jobs while jobs are queued. When it
+	// passes, no worker is serving the run and the queued jobs' dependencies
+	// are recorded as failed instead of waiting forever. Zero means five
+	// leases.
+	ClaimTimeout time.Duration
+	// CoordinateOnly leaves every job to worker processes instead of also
+	// scanning in the coordinating process.
+	CoordinateOnly bool
+}
+
+// NewDependencySpool returns a spool in dir with the default lease, attempts
+// and poll interval.
+func NewDependencySpool(dir string) *DependencySpool {
+	return &DependencySpool{
+		dir:          dir,
+		Lease:        defaultSpoolLease,
+		MaxAttempts:  defaultSpoolMaxAttempts,
+		PollInterval: defaultSpoolPollInterval,
+	}
+}
+
+// Dir returns the spool directory.
+func (s *DependencySpool) Dir() string {
+	return s.dir
+}
+
+// claimTimeout returns ClaimTimeout, or five leases when it is not set.
+func (s *DependencySpool) claimTimeout() time.Duration {
+	if s.ClaimTimeout > 0 {
+		return s.ClaimTimeout
+	}
+	return defaultSpoolClaimLeases * s.Lease
+}
+
+// heartbeat is how often workers touch their claims: often enough that a
+// slow file system or a missed tick does not cost the lease.
+func (s *DependencySpool) heartbeat() time.Duration {
+	return max(s.Lease/4, time.Millisecond)
+}
+
+// spoolJob is a dependency scan waiting in, or claimed from, a run's jobs/.
+// RulePaths are relative to the run directory. A job names its scanner but
+// never how to run it: anyone who can write to the spool can queue jobs, so
+// the executable, its arguments and its environment are the worker's own.
+type spoolJob struct {
+	Version      int           `json:"version"`
+	ID           string        `json:"id"`
+	Attempt      int           `json:"attempt"`
+	Module       string        `json:"module"`
+	ModVersion   string        `json:"module_version"`
+	Dir          string        `json:"dir"`
+	ScannerName  string        `json:"scanner"`
+	Languages    []string      `json:"languages,omitempty"`
+	RulePaths    []string      `json:"rule_paths"`
+	Timeout      time.Duration `json:"timeout,omitempty"`
+	SkipPatterns []string      `json:"skip_patterns,omitempty"`
+	DisableDedup bool          `json:"disable_dedup,omitempty"`
+	Interfile    bool          `json:"interfile,omitempty"`
+	Heartbeat    time.Duration `json:"heartbeat"`
+}
+
+// scanOptions returns the options a worker scans the job with, running the
+// scanner as the worker's config says.
+func (j *spoolJob) scanOptions(runDir string, config scanner.Config) ScanOptions {
+	rulePaths := make([]string, 0, len(j.RulePaths))
+	for _, rulePath := range j.RulePaths {
+		rulePaths = append(rulePaths, filepath.Join(runDir, filepath.FromSlash(rulePath)))
+	}
+	return ScanOptions{
+		Target:       j.Dir,
+		ScannerName:  j.ScannerName,
+		LanguageHint: j.Languages,
+		RulePaths:    rulePaths,
+		ScannerConfig: scanner.Config{
+			ExecutablePath: config.ExecutablePath,
+			Timeout:        j.Timeout,
+			WorkDir:        config.WorkDir,
+			Env:            config.Env,
+			ExtraArgs:      config.ExtraArgs,
+			SkipPatterns:   j.SkipPatterns,
+			DisableDedup:   j.DisableDedup,
+			Interfile:      j.Interfile,
+		},
+	}
+}
+
+// spoolResult is what a worker writes back for a job attempt: the report of
+// a successful scan or the error of a failed one.
+type spoolResult struct {
+	Version int                     `json:"version"`
+	ID      string                  `json:"id"`
+	Attempt int                     `json:"attempt"`
+	Worker  string                  `json:"worker"`
+	Report  *entities.InterimReport `json:"report,omitempty"`
+	Error   string                  `json:"error,omitempty"`
+}
+
+// spoolRun is one coordinating scan's directory in the spool.
+type spoolRun struct {
+	dir string
+}
+
+func (r *spoolRun) jobPath(id string) string {
+	return filepath.Join(r.dir, spoolJobsDir, id+spoolFileExtension)
+}
+
+func (r *spoolRun) claimPath(id string) string {
+	return filepath.Join(r.dir, spoolClaimedDir, id+spoolFileExtension)
+}
+
+// createRun creates a run directory and copies rulePaths, files or
+// directories, into it. It returns the run and the copies' paths relative to
+// the run directory.
+func (s *DependencySpool) createRun(rulePaths []string) (*spoolRun, []string, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return nil, nil, fmt.Errorf("create spool directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(s.dir, spoolRunPrefix)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create spool run: %w", err)
+	}
+	run := &spoolRun{dir: dir}
+	for _, sub := range []string{spoolRulesDir, spoolJobsDir, spoolClaimedDir, spoolResultsDir} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o750); err != nil {
+			run.remove()
+			return nil, nil, fmt.Errorf("create spool run: %w", err)
+		}
+	}
+
+	copied := make([]string, 0, len(rulePaths))
+	for i, rulePath := range rulePaths {
+		rel := filepath.Join(spoolRulesDir, strconv.Itoa(i), filepath.Base(rulePath))
+		if err := copyTree(rulePath, filepath.Join(dir, rel)); err != nil {
+			run.remove()
+			return nil, nil, fmt.Errorf("copy rules into spool: %w", err)
+		}
+		copied = append(copied, filepath.ToSlash(rel))
+	}
+	return run, copied, nil
+}
+
+func (r *spoolRun) remove() {
+	if err := os.RemoveAll(r.dir); err != nil {
+		log.Warn().Err(err).Str("dir", r.dir).Msg("Failed to remove dependency spool run")
+	}
+}
+
+// enqueue writes job to jobs/, where workers can claim it, and drops any
+// earlier claim of it.
+func (r *spoolRun) enqueue(job *spoolJob) error {
+	if err := writeSpoolFile(r.jobPath(job.ID), job); err != nil {
+		return err
+	}
+	removeSpoolFile(r.claimPath(job.ID))
+	return nil
+}
+
+// retire removes every trace of a job that needs no further scan.
+func (r *spoolRun) retire(id string) {
+	removeSpoolFile(r.jobPath(id))
+	removeSpoolFile(r.claimPath(id))
+}
+
+// takeResults reads and removes the results workers have written back.
+// Unreadable results are dropped: their job is retried once its claim
+// expires.
+func (r *spoolRun) takeResults() []spoolResult {
+	paths, _ := filepath.Glob(filepath.Join(r.dir, spoolResultsDir, "*"+spoolFileExtension))
+	sort.Strings(paths)
+	results := make([]spoolResult, 0, len(paths))
+	for _, path := range paths {
+		var result spoolResult
+		err := readSpoolFile(path, &result)
+		removeSpoolFile(path)
+		if err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("Ignoring unreadable dependency spool result")
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// spoolEntry is the coordinator's view of a job it still waits for.
+type spoolEntry struct {
+	work dependencyWork
+	job  spoolJob
+	// alive is the last time the job was seen queued or its claim was
+	// touched; a job neither queued nor alive within the lease is lost.
+	alive time.Time
+}
+
+// scanDependenciesSpooled hands work to the spool's workers, recording one
+// outcome per item. Cached scans are answered without a job, and successful
+// worker scans are stored in the findings cache like local ones. A job whose
+// worker fails it or stops renewing its claim is queued again until
+// Spool.MaxAttempts attempts have been made. Queued jobs fail when no worker
+// shows any activity for Spool.ClaimTimeout.
+//
+//nolint:gocognit // The coordination loop reads results and claims in one pass.
+func (ds *DependencyScanner) scanDependenciesSpooled(
+	ctx context.Context,
+	work []dependencyWork,
+	outcomes []depScanResult,
+	rulePaths []string,
+	rulesHash string,
+	opts DepScanOptions,
+) error {
+	spool := opts.Spool
+	run, spooledRules, err := spool.createRun(rulePaths)
+	if err != nil {
+		return failure.WrapUnknown(err, failure.CodeScannerExecutionFailed, failure.StageScan, "failed to prepare dependency spool")
+	}
+	defer run.remove()
+
+	pending := make(map[string]*spoolEntry, len(work))
+	for _, item := range work {
+		cacheKey := dependencyCacheKey(item.key, rulesHash, opts)
+		if report, ok := ds.cachedDependencyScan(ctx, item.dep, cacheKey, rulesHash); ok {
+			outcomes[item.index] = depScanResult{
+				index:  item.index,
+				key:    item.key,
+				dep:    item.dep,
+				report: dependencyReportWithFindings(report),
+				status: depScanStatusScanned,
+			}
+			continue
+		}
+		entry := &spoolEntry{work: item, job: ds.newSpoolJob(item, spooledRules, opts), alive: time.Now()}
+		if err := run.enqueue(&entry.job); err != nil {
+			return failure.WrapUnknown(err, failure.CodeScannerExecutionFailed, failure.StageScan, "failed to queue dependency scan")
+		}
+		pending[entry.job.ID] = entry
+	}
+
+	log.Info().
+		Str("spool", run.dir).
+		Int("jobs", len(pending)).
+		Int("cached", len(work)-len(pending)).
+		Msg("Queued dependency scans for spool workers")
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if !spool.CoordinateOnly {
+		localCtx, stopLocal := context.WithCancel(ctx)
+		var local sync.WaitGroup
+		local.Add(1)
+		go func() {
+			defer local.Done()
+			worker := NewDependencySpoolWorker(spool, ds.orchestrator)
+			worker.run = run.dir
+			worker.scannerConfig = opts.ScanOptions.ScannerConfig
+			_ = worker.Run(localCtx, dependencyScanWorkers(opts.Workers, ds.resolver.Ecosystem()), 0)
+		}()
+		defer func() {
+			stopLocal()
+			local.Wait()
+		}()
+	}
+
+	finish := func(entry *spoolEntry, report *entities.InterimReport, scanErr error) {
+		item := entry.work
+		if scanErr != nil {
+			log.Warn().Err(scanErr).Str("module", item.dep.Module).Msg("Failed to scan dependency source")
+		}
+		outcomes[item.index] = depScanResult{
+			index:  item.index,
+			key:    item.key,
+			dep:    item.dep,
+			report: dependencyReportWithFindings(report),
+			status: scanStatusForError(scanErr),
+			err:    scanErr,
+		}
+		delete(pending, entry.job.ID)
+		run.retire(entry.job.ID)
+	}
+	retry := func(entry *spoolEntry, cause error) error {
+		entry.job.Attempt++
+		if entry.job.Attempt >= max(spool.MaxAttempts, 1) {
+			finish(entry, nil, cause)
+			return nil
+		}
+		log.Warn().
+			Err(cause).
+			Str("module", entry.work.dep.Module).
+			Int("attempt", entry.job.Attempt+1).
+			Msg("Retrying dependency scan")
+		entry.alive = time.Now()
+		return run.enqueue(&entry.job)
+	}
+
+	// lastActivity is when a worker last claimed, renewed or answered one
+	// of the run's jobs.
+	lastActivity := time.Now()
+	ticker := time.NewTicker(spool.PollInterval)
+	defer ticker.Stop()
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return failure.WrapUnknown(ctx.Err(), failure.CodeScannerCancelled, failure.StageScan, "dependency scan canceled")
+		case <-ticker.C:
+		}
+
+		for _, result := range run.takeResults() {
+			entry := pending[result.ID]
+			if entry == nil {
+				continue
+			}
+			lastActivity = time.Now()
+			if result.Error == "" {
+				log.Info().
+					Str("module", entry.work.dep.Module).
+					Str("version", entry.work.dep.Version).
+					Str("worker", result.Worker).
+					Msg("Scanned dependency")
+				ds.cacheDependencyScan(ctx, entry.work.dep, dependencyCacheKey(entry.work.key, rulesHash, opts), rulesHash, result.Report)
+				finish(entry, result.Report, nil)
+				continue
+			}
+			// A failure of an attempt already given up on must not start
+			// yet another one.
+			if result.Attempt != entry.job.Attempt {
+				continue
+			}
+			if err := retry(entry, fmt.Errorf("worker %s: %s", result.Worker, result.Error)); err != nil {
+				return failure.WrapUnknown(err, failure.CodeScannerExecutionFailed, failure.StageScan, "failed to queue dependency scan")
+			}
+		}
+
+		now := time.Now()
+		var queued []*spoolEntry
+		for _, id := range sortedPendingIDs(pending) {
+			entry := pending[id]
+			if fileExists(run.jobPath(id)) {
+				entry.alive = now
+				queued = append(queued, entry)
+				continue
+			}
+			if info, err := os.Stat(run.claimPath(id)); err == nil && info.ModTime().After(entry.alive) {
+				entry.alive = info.ModTime()
+			}
+			if now.Sub(entry.alive) <= spool.Lease {
+				if entry.alive.After(lastActivity) {
+					lastActivity = entry.alive
+				}
+				continue
+			}
+			if err := retry(entry, fmt.Errorf("worker stopped renewing its claim for %s", spool.Lease)); err != nil {
+				return failure.WrapUnknown(err, failure.CodeScannerExecutionFailed, failure.StageScan, "failed to queue dependency scan")
+			}
+		}
+
+		if len(queued) > 0 && now.Sub(lastActivity) > spool.claimTimeout() {
+			log.Warn().
+				Str("spool", run.dir).
+				Int("jobs", len(queued)).
+				Dur("timeout", spool.claimTimeout()).
+				Msg("No dependency spool worker is taking jobs; giving up on the queued ones")
+			for _, entry := range queued {
+				finish(entry, nil, fmt.Errorf("no spool worker claimed the job within %s", spool.claimTimeout()))
+			}
+		}
+	}
+	return nil
+}
+
+// newSpoolJob returns the job for item, carrying the scan settings its local
+// scan would use.
+func (ds *DependencyScanner) newSpoolJob(item dependencyWork, spooledRules []string, opts DepScanOptions) spoolJob {
+	depOpts := ds.buildDepScanOptions(&item.dep, spooledRules, opts)
+	return spoolJob{
+		Version:      spoolFormatVersion,
+		ID:           fmt.Sprintf("%06d", item.index),
+		Module:       item.dep.Module,
+		ModVersion:   item.dep.Version,
+		Dir:          depOpts.Target,
+		ScannerName:  depOpts.ScannerName,
+		Languages:    depOpts.LanguageHint,
+		RulePaths:    depOpts.RulePaths,
+		Timeout:      depOpts.ScannerConfig.Timeout,
+		SkipPatterns: depOpts.ScannerConfig.SkipPatterns,
+		DisableDedup: depOpts.ScannerConfig.DisableDedup,
+		Interfile:    depOpts.ScannerConfig.Interfile,
+		Heartbeat:    opts.Spool.heartbeat(),
+	}
+}
+
+func sortedPendingIDs(pending map[string]*spoolEntry) []string {
+	ids := make([]string, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// writeSpoolFile writes value as JSON to path through a temporary file in
+// the same directory, so the file appears whole or not at all.
+func writeSpoolFile(path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", filepath.Base(path), err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Base(path), err)
+	}
+	tmpPath := tmpFile.Name()
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// #nosec G703 -- tmpPath is created by os.CreateTemp in this function.
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		removeSpoolFile(tmpPath)
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func readSpoolFile(path string, value any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
+
+// readSpoolJob reads a job, refusing fields the job format does not have:
+// a job that tries to set, say, the scanner executable is not scanned with
+// the field silently dropped.
+func readSpoolJob(path string, job *spoolJob) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(job)
+}
+
+func removeSpoolFile(path string) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Debug().Err(err).Str("path", path).Msg("Failed to remove dependency spool file")
+	}
+}
+
+// copyTree copies the file or directory src to dst.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0o750)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+			return err
+		}
+		return copyFile(path, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src) // #nosec G304 -- src is a rule path the scan already reads.
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) // #nosec G304 -- dst is inside the spool run.
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// spoolRunDirs returns the run directories of the spool in dir.
+func spoolRunDirs(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	runs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), spoolRunPrefix) {
+			runs = append(runs, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return runs
+}
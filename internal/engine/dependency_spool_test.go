@@ -0,0 +1,379 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/dependency"
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/rules"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/skip"
+)
+
+// spoolWorkerProcessEnv names the spool a re-executed test binary serves
+// as a worker process (see TestDependencySpoolWorkerProcess).
+const spoolWorkerProcessEnv = "CRYPTO_FINDER_TEST_SPOOL_WORKER"
+
+func testSpool(dir string) *DependencySpool {
+	spool := NewDependencySpool(dir)
+	spool.Lease = 400 * time.Millisecond
+	spool.PollInterval = 10 * time.Millisecond
+	return spool
+}
+
+func writeSpoolTestRule(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "go.yaml")
+	if err := os.WriteFile(path, []byte("rules: []\n"), 0o600); err != nil {
+		t.Fatalf("write rule: %v", err)
+	}
+	return path
+}
+
+// spoolTestOrchestrator scans with a test scanner that reports one finding
+// per dependency, or fails the dependencies whose directory is named "bad".
+func spoolTestOrchestrator(scanned func(target string)) *Orchestrator {
+	registry := scanner.NewRegistry()
+	registry.Register("test-scanner", &mockScanner{
+		scanFunc: func(_ context.Context, target string, _ []string, _ entities.ToolInfo) (*entities.InterimReport, error) {
+			if scanned != nil {
+				scanned(target)
+			}
+			if filepath.Base(target) == "bad" {
+				return nil, errors.New("scan failed")
+			}
+			return &entities.InterimReport{Findings: []entities.Finding{{
+				FilePath:            "crypto.go",
+				CryptographicAssets: []entities.CryptographicAsset{{StartLine: 1}},
+			}}}, nil
+		},
+	})
+	return NewOrchestrator(&mockDetector{}, rules.NewManager(&mockRuleSource{}), registry)
+}
+
+func TestDependencySpool_LocalWorkersRetryFailuresAndUseCache(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	scans := make(map[string]int)
+	orch := spoolTestOrchestrator(func(target string) {
+		mu.Lock()
+		defer mu.Unlock()
+		scans[filepath.Base(target)]++
+	})
+	root := t.TempDir()
+	deps := []dependency.Dependency{
+		{Module: "a", Version: "1", Dir: filepath.Join(root, "good")},
+		{Module: "b", Version: "1", Dir: filepath.Join(root, "bad")},
+		{Module: "c", Version: "1", Dir: filepath.Join(root, "cached")},
+	}
+	cache := &fakeFindingsCache{getMap: map[string]*entities.InterimReport{
+		"c@1:rules": {Findings: []entities.Finding{{CryptographicAssets: []entities.CryptographicAsset{{}}}}},
+	}}
+	ds := &DependencyScanner{orchestrator: orch, resolver: &fakeResolver{ecosystem: "go"}, findingsCache: cache}
+	spoolDir := t.TempDir()
+	spool := testSpool(spoolDir)
+	spool.MaxAttempts = 2
+
+	outcomes, err := ds.scanDependenciesParallel(context.Background(), deps, []string{writeSpoolTestRule(t)}, "rules", DepScanOptions{
+		Workers:     2,
+		ScanOptions: ScanOptions{ScannerName: "test-scanner"},
+		Spool:       spool,
+	})
+	if err != nil {
+		t.Fatalf("scanDependenciesParallel: %v", err)
+	}
+
+	if outcomes[0].status != depScanStatusScanned || outcomes[0].report == nil {
+		t.Fatalf("a = %#v, want scanned with findings", outcomes[0])
+	}
+	if outcomes[1].status != depScanStatusFailed || outcomes[1].err == nil || !strings.Contains(outcomes[1].err.Error(), "scan failed") {
+		t.Fatalf("b = %#v, want failed with the worker's error", outcomes[1])
+	}
+	if outcomes[2].status != depScanStatusScanned || outcomes[2].report == nil {
+		t.Fatalf("c = %#v, want the cached report", outcomes[2])
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if scans["good"] != 1 || scans["bad"] != 2 || scans["cached"] != 0 {
+		t.Fatalf("scans = %v, want good once, bad twice, cached never", scans)
+	}
+	if cache.putCalls != 1 || cache.putLastKey != "a@1:rules" {
+		t.Fatalf("cache puts = %d (last %q), want the successful worker scan stored", cache.putCalls, cache.putLastKey)
+	}
+	if runs := spoolRunDirs(spoolDir); len(runs) != 0 {
+		t.Fatalf("spool runs left behind: %v", runs)
+	}
+}
+
+func TestDependencySpoolWorker_ReleasesJobOnCancellation(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	registry := scanner.NewRegistry()
+	registry.Register("test-scanner", &mockScanner{
+		scanFunc: func(ctx context.Context, _ string, _ []string, _ entities.ToolInfo) (*entities.InterimReport, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, failure.New(failure.CodeScannerCancelled, failure.StageScan, "scan canceled")
+		},
+	})
+	orch := NewOrchestrator(&mockDetector{}, rules.NewManager(&mockRuleSource{}), registry)
+
+	spool := testSpool(t.TempDir())
+	run, rulePaths, err := spool.createRun([]string{writeSpoolTestRule(t)})
+	if err != nil {
+		t.Fatalf("createRun: %v", err)
+	}
+	job := spoolJob{Version: spoolFormatVersion, ID: "000000", Dir: t.TempDir(), ScannerName: "test-scanner", RulePaths: rulePaths}
+	if err := run.enqueue(&job); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewDependencySpoolWorker(spool, orch).Run(ctx, 1, 0) }()
+	<-started
+	if !fileExists(run.claimPath(job.ID)) {
+		t.Fatal("a job being scanned must be claimed")
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() = %v, want context.Canceled", err)
+	}
+	if !fileExists(run.jobPath(job.ID)) || fileExists(run.claimPath(job.ID)) {
+		t.Fatal("a canceled scan must put its job back in the queue")
+	}
+	if results := run.takeResults(); len(results) != 0 {
+		t.Fatalf("a canceled scan must not report a result, got %v", results)
+	}
+}
+
+// TestDependencySpool_WorkerProcesses coordinates a scan over two worker
+// processes, one of which dies while scanning: its job must be taken over
+// once its claim expires.
+func TestDependencySpool_WorkerProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts worker processes")
+	}
+	t.Parallel()
+
+	spoolDir := t.TempDir()
+	workers := make([]*exec.Cmd, 2)
+	for i := range workers {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDependencySpoolWorkerProcess$") // #nosec G204 -- re-executes the test binary.
+		cmd.Env = append(os.Environ(), spoolWorkerProcessEnv+"="+spoolDir)
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("start worker: %v", err)
+		}
+		t.Cleanup(func() { _ = cmd.Process.Kill() })
+		workers[i] = cmd
+	}
+
+	root := t.TempDir()
+	deps := []dependency.Dependency{
+		{Module: "a", Version: "1", Dir: filepath.Join(root, "a")},
+		{Module: "b", Version: "1", Dir: filepath.Join(root, "crash")},
+		{Module: "c", Version: "1", Dir: filepath.Join(root, "c")},
+		{Module: "d", Version: "1", Dir: filepath.Join(root, "d")},
+	}
+	ds := &DependencyScanner{orchestrator: spoolTestOrchestrator(nil), resolver: &fakeResolver{ecosystem: "go"}}
+	spool := testSpool(spoolDir)
+	spool.CoordinateOnly = true
+
+	outcomes, err := ds.scanDependenciesParallel(context.Background(), deps, []string{writeSpoolTestRule(t)}, "", DepScanOptions{
+		ScanOptions: ScanOptions{ScannerName: "test-scanner"},
+		Spool:       spool,
+	})
+	if err != nil {
+		t.Fatalf("scanDependenciesParallel: %v", err)
+	}
+	for _, outcome := range outcomes {
+		if outcome.status != depScanStatusScanned || outcome.report == nil {
+			t.Fatalf("%s = %#v, want scanned with findings", outcome.dep.Module, outcome)
+		}
+	}
+
+	crashed := 0
+	for _, cmd := range workers {
+		err := cmd.Wait()
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == 3:
+			crashed++
+		default:
+			t.Fatalf("worker process: %v", err)
+		}
+	}
+	if crashed != 1 {
+		t.Fatalf("crashed workers = %d, want 1", crashed)
+	}
+}
+
+// TestDependencySpoolWorkerProcess is the worker process of
+// TestDependencySpool_WorkerProcesses. The first scan of a dependency
+// directory named "crash" kills the process.
+func TestDependencySpoolWorkerProcess(t *testing.T) {
+	spoolDir := os.Getenv(spoolWorkerProcessEnv)
+	if spoolDir == "" {
+		t.Skip("runs only as a worker process")
+	}
+	orch := spoolTestOrchestrator(func(target string) {
+		if filepath.Base(target) != "crash" {
+			return
+		}
+		marker := target + ".crashed"
+		if _, err := os.Stat(marker); err == nil {
+			return
+		}
+		_ = os.WriteFile(marker, nil, 0o600)
+		os.Exit(3)
+	})
+	_ = NewDependencySpoolWorker(testSpool(spoolDir), orch).Run(context.Background(), 1, 3*time.Second)
+}
+
+// TestDependencySpool_NoWorkerFailsQueuedJobs coordinates a scan that no
+// worker serves: it must finish, with the queued dependencies failed.
+func TestDependencySpool_NoWorkerFailsQueuedJobs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	deps := []dependency.Dependency{
+		{Module: "a", Version: "1", Dir: filepath.Join(root, "a")},
+		{Module: "b", Version: "1", Dir: filepath.Join(root, "b")},
+	}
+	ds := &DependencyScanner{orchestrator: spoolTestOrchestrator(nil), resolver: &fakeResolver{ecosystem: "go"}}
+	spoolDir := t.TempDir()
+	spool := testSpool(spoolDir)
+	spool.CoordinateOnly = true
+	spool.ClaimTimeout = 200 * time.Millisecond
+
+	done := make(chan struct{})
+	var outcomes []depScanResult
+	var err error
+	go func() {
+		defer close(done)
+		outcomes, err = ds.scanDependenciesParallel(context.Background(), deps, []string{writeSpoolTestRule(t)}, "", DepScanOptions{
+			ScanOptions: ScanOptions{ScannerName: "test-scanner"},
+			Spool:       spool,
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("a scan no worker serves did not finish")
+	}
+	if err != nil {
+		t.Fatalf("scanDependenciesParallel: %v", err)
+	}
+	for _, outcome := range outcomes {
+		if outcome.status != depScanStatusFailed || outcome.err == nil || !strings.Contains(outcome.err.Error(), "no spool worker claimed") {
+			t.Fatalf("%s = %#v, want failed for want of a worker", outcome.dep.Module, outcome)
+		}
+	}
+	if runs := spoolRunDirs(spoolDir); len(runs) != 0 {
+		t.Fatalf("spool runs left behind: %v", runs)
+	}
+}
+
+// TestSpoolJob_ScanOptions checks that a worker scans a job with the scan
+// settings the coordinator's local scan would use, but runs the scanner as
+// its own config says: the coordinator's executable, arguments and
+// environment never reach the spool.
+func TestSpoolJob_ScanOptions(t *testing.T) {
+	t.Parallel()
+
+	ds := &DependencyScanner{orchestrator: spoolTestOrchestrator(nil), resolver: &fakeResolver{ecosystem: "go"}}
+	opts := DepScanOptions{
+		ScanOptions: ScanOptions{
+			ScannerName: "test-scanner",
+			ScannerConfig: scanner.Config{
+				ExecutablePath: "/opt/opengrep/bin/opengrep",
+				Timeout:        5 * time.Minute,
+				WorkDir:        "/tmp/work",
+				Env:            map[string]string{"SCANNER_TOKEN": "secret"},
+				ExtraArgs:      []string{"--max-memory=4096"},
+				SkipPatterns:   skip.WithDefaultTestPatterns(nil),
+				DisableDedup:   true,
+				Interfile:      true,
+			},
+		},
+		Spool: testSpool(t.TempDir()),
+	}
+	item := dependencyWork{index: 3, key: "a@1", dep: dependency.Dependency{Module: "a", Version: "1", Dir: "/deps/a"}}
+	local := ds.buildDepScanOptions(&item.dep, []string{"rules/0/go.yaml"}, opts)
+
+	job := ds.newSpoolJob(item, []string{"rules/0/go.yaml"}, opts)
+	path := filepath.Join(t.TempDir(), job.ID+spoolFileExtension)
+	if err := writeSpoolFile(path, &job); err != nil {
+		t.Fatalf("writeSpoolFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read job: %v", err)
+	}
+	for _, leaked := range []string{"/opt/opengrep", "/tmp/work", "secret", "--max-memory"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("job file carries the coordinator's scanner setting %q: %s", leaked, data)
+		}
+	}
+	var read spoolJob
+	if err := readSpoolJob(path, &read); err != nil {
+		t.Fatalf("readSpoolJob: %v", err)
+	}
+
+	workerConfig := scanner.Config{ExecutablePath: "/usr/local/bin/opengrep", ExtraArgs: []string{"--jobs=2"}}
+	got := read.scanOptions("/spool/run-1", workerConfig)
+	want := local.ScannerConfig
+	want.ExecutablePath, want.WorkDir, want.Env, want.ExtraArgs = workerConfig.ExecutablePath, "", nil, workerConfig.ExtraArgs
+	if !reflect.DeepEqual(got.ScannerConfig, want) {
+		t.Errorf("worker scanner config = %+v, want %+v", got.ScannerConfig, want)
+	}
+	if got.Target != "/deps/a" || got.ScannerName != "test-scanner" || !reflect.DeepEqual(got.LanguageHint, local.LanguageHint) {
+		t.Errorf("worker scan options = %+v, want the dependency scanned as locally", got)
+	}
+	if want := []string{filepath.Join("/spool/run-1", "rules", "0", "go.yaml")}; !reflect.DeepEqual(got.RulePaths, want) {
+		t.Errorf("worker rule paths = %v, want %v", got.RulePaths, want)
+	}
+}
+
+// TestDependencySpoolWorker_RefusesJobWithExecutablePath checks that a job
+// written to the spool with a scanner executable is not scanned.
+func TestDependencySpoolWorker_RefusesJobWithExecutablePath(t *testing.T) {
+	t.Parallel()
+
+	scanned := false
+	orch := spoolTestOrchestrator(func(string) { scanned = true })
+	spool := testSpool(t.TempDir())
+	run, rulePaths, err := spool.createRun([]string{writeSpoolTestRule(t)})
+	if err != nil {
+		t.Fatalf("createRun: %v", err)
+	}
+	job := fmt.Sprintf(`{"version":%d,"id":"000000","dir":%q,"scanner":"test-scanner","rule_paths":[%q],`+
+		`"executable_path":"/tmp/evil","heartbeat":100000000}`, spoolFormatVersion, t.TempDir(), rulePaths[0])
+	if err := os.WriteFile(run.jobPath("000000"), []byte(job), 0o600); err != nil {
+		t.Fatalf("write job: %v", err)
+	}
+
+	if err := NewDependencySpoolWorker(spool, orch).Run(context.Background(), 1, 50*time.Millisecond); err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+	if scanned {
+		t.Fatal("a job naming a scanner executable must not be scanned")
+	}
+	if results := run.takeResults(); len(results) != 0 {
+		t.Fatalf("a refused job must not report a result, got %v", results)
+	}
+}
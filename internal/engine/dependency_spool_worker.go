@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+)
+
+// DependencySpoolWorker scans the jobs coordinating scans queue in a
+// DependencySpool and writes their reports back.
+type DependencySpoolWorker struct {
+	spool        *DependencySpool
+	orchestrator *Orchestrator
+	name         string
+	// run, when set, restricts the worker to one run directory; the
+	// coordinator's own workers only take its jobs.
+	run string
+	// scannerConfig says how to run the scanner a job names; jobs never
+	// carry it. The orchestrator's registry is the list of scanners a job
+	// may name.
+	scannerConfig scanner.Config
+}
+
+// NewDependencySpoolWorker creates a worker that takes jobs from every run in
+// spool and scans them with orchestrator.
+func NewDependencySpoolWorker(spool *DependencySpool, orchestrator *Orchestrator) *DependencySpoolWorker {
+	return &DependencySpoolWorker{
+		spool:        spool,
+		orchestrator: orchestrator,
+		name:         spoolWorkerName(),
+	}
+}
+
+// spoolWorkerName identifies a worker process in results and logs.
+func spoolWorkerName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// spoolClaim is a job this worker holds in a run's claimed/.
+type spoolClaim struct {
+	run  *spoolRun
+	job  spoolJob
+	path string
+}
+
+// Run scans jobs, up to concurrency at a time (0 = half of CPU cores, capped
+// at 8), until ctx is canceled or, when idleExit is positive, no job has been
+// found for that long. A scan interrupted by cancellation is put back in the
+// queue for another worker.
+func (w *DependencySpoolWorker) Run(ctx context.Context, concurrency int, idleExit time.Duration) error {
+	concurrency = dependencyScanWorkers(concurrency, "")
+	log.Info().
+		Str("spool", w.spool.Dir()).
+		Str("worker", w.name).
+		Int("concurrency", concurrency).
+		Msg("Dependency spool worker started")
+
+	var wg sync.WaitGroup
+	for slot := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.runSlot(ctx, fmt.Sprintf("%s/%d", w.name, slot), idleExit)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (w *DependencySpoolWorker) runSlot(ctx context.Context, name string, idleExit time.Duration) {
+	idleSince := time.Now()
+	for ctx.Err() == nil {
+		claim, ok := w.claim()
+		if ok {
+			w.process(ctx, name, claim)
+			idleSince = time.Now()
+			continue
+		}
+		if idleExit > 0 && time.Since(idleSince) >= idleExit {
+			return
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(w.spool.PollInterval):
+		}
+	}
+}
+
+// claim takes the first queued job it can rename into claimed/. Runs are
+// visited oldest first, so earlier scans finish first.
+func (w *DependencySpoolWorker) claim() (*spoolClaim, bool) {
+	runs := []string{w.run}
+	if w.run == "" {
+		runs = spoolRunDirs(w.spool.Dir())
+		sort.Slice(runs, func(i, j int) bool { return modTime(runs[i]).Before(modTime(runs[j])) })
+	}
+	for _, runDir := range runs {
+		run := &spoolRun{dir: runDir}
+		jobs, _ := filepath.Glob(filepath.Join(runDir, spoolJobsDir, "*"+spoolFileExtension))
+		sort.Strings(jobs)
+		for _, jobPath := range jobs {
+			claimPath := filepath.Join(runDir, spoolClaimedDir, filepath.Base(jobPath))
+			// Another worker, or the coordinator retiring the job, got
+			// there first.
+			if err := os.Rename(jobPath, claimPath); err != nil {
+				continue
+			}
+			now := time.Now()
+			_ = os.Chtimes(claimPath, now, now)
+			claim := &spoolClaim{run: run, path: claimPath}
+			if err := readSpoolJob(claimPath, &claim.job); err != nil {
+				// Leave it to expire: the coordinator retries or fails it.
+				log.Warn().Err(err).Str("path", claimPath).Msg("Refusing unreadable dependency spool job")
+				continue
+			}
+			return claim, true
+		}
+	}
+	return nil, false
+}
+
+// process scans a claimed job, renewing the claim while the scan runs, and
+// writes the result back to the job's run.
+func (w *DependencySpoolWorker) process(ctx context.Context, name string, claim *spoolClaim) {
+	job := &claim.job
+	result := spoolResult{Version: spoolFormatVersion, ID: job.ID, Attempt: job.Attempt, Worker: name}
+	if job.Version != spoolFormatVersion {
+		result.Error = fmt.Sprintf("unsupported spool job version %d (worker reads %d)", job.Version, spoolFormatVersion)
+		w.writeResult(claim, &result)
+		return
+	}
+
+	stopHeartbeat := renewClaim(claim.path, job.Heartbeat)
+	log.Info().
+		Str("module", job.Module).
+		Str("version", job.ModVersion).
+		Int("attempt", job.Attempt+1).
+		Msg("Scanning spooled dependency")
+	report, err := w.orchestrator.Scan(ctx, job.scanOptions(claim.run.dir, w.scannerConfig))
+	stopHeartbeat()
+
+	if err != nil && (ctx.Err() != nil || isScanCancellation(err)) {
+		// Stopping is not the dependency's fault: hand the job back.
+		if renameErr := os.Rename(claim.path, claim.run.jobPath(job.ID)); renameErr != nil {
+			log.Debug().Err(renameErr).Str("job", job.ID).Msg("Failed to release dependency spool job")
+		}
+		return
+	}
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Report = report
+	}
+	w.writeResult(claim, &result)
+}
+
+func (w *DependencySpoolWorker) writeResult(claim *spoolClaim, result *spoolResult) {
+	path := filepath.Join(claim.run.dir, spoolResultsDir, fmt.Sprintf("%s-%d-%s%s",
+		result.ID, result.Attempt, safeSpoolFilename(result.Worker), spoolFileExtension))
+	if err := writeSpoolFile(path, result); err != nil {
+		// The run is gone when its coordinator finished or gave up.
+		log.Warn().Err(err).Str("job", result.ID).Msg("Failed to write dependency spool result")
+	}
+}
+
+// renewClaim touches path every interval until the returned function is
+// called.
+func renewClaim(path string, interval time.Duration) func() {
+	if interval <= 0 {
+		interval = defaultSpoolLease / 4
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				if err := os.Chtimes(path, now, now); err != nil && !errors.Is(err, os.ErrNotExist) {
+					log.Debug().Err(err).Str("path", path).Msg("Failed to renew dependency spool claim")
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+func isScanCancellation(err error) bool {
+	structured, ok := failure.As(err)
+	return ok && structured.Code == failure.CodeScannerCancelled
+}
+
+func safeSpoolFilename(name string) string {
+	return findingsCacheFilenameUnsafeChars.ReplaceAllString(name, "_")
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -24,6 +24,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"time"
+
+	"github.com/rs/zerolog/log"
 
 	"github.com/scanoss/crypto-finder/internal/config"
 	"github.com/scanoss/crypto-finder/internal/entities"
@@ -76,8 +79,15 @@ func NewDiskFindingsCacheWithDir(dir string) (*DiskFindingsCache, error) {
 	return &DiskFindingsCache{dir: dir}, nil
 }
 
+// Dir returns the findings cache directory.
+func (c *DiskFindingsCache) Dir() string {
+	return c.dir
+}
+
 // Get retrieves a cached report by key.
-// Corrupted cache files are treated as cache misses and are removed.
+// Corrupted cache files are treated as cache misses and are removed. A hit
+// refreshes the file's modification time, which `cache prune` treats as its
+// last use.
 func (c *DiskFindingsCache) Get(_ context.Context, key string) (*entities.InterimReport, bool, error) {
 	path := filepath.Join(c.dir, cacheKeyToFilename(key))
 
@@ -98,6 +108,10 @@ func (c *DiskFindingsCache) Get(_ context.Context, key string) (*entities.Interi
 		return nil, false, nil
 	}
 
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("Failed to record findings cache use")
+	}
 	return envelope.Report, true, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/scanoss/crypto-finder/internal/entities"
 )
@@ -397,3 +398,36 @@ func TestCacheKeyToFilename(t *testing.T) {
 		}
 	}
 }
+
+func TestDiskFindingsCache_Get_RecordsUse(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskFindingsCacheWithDir(dir)
+	if err != nil {
+		t.Fatalf("NewDiskFindingsCacheWithDir: %v", err)
+	}
+	if cache.Dir() != dir {
+		t.Fatalf("Dir() = %q, want %q", cache.Dir(), dir)
+	}
+
+	ctx := context.Background()
+	key := "org.bouncycastle:bcprov-jdk18on@1.78:abcd1234"
+	if err := cache.Put(ctx, key, &entities.InterimReport{Version: "1.2"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	path := filepath.Join(dir, cacheKeyToFilename(key))
+	old := time.Now().Add(-72 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	if _, ok, err := cache.Get(ctx, key); err != nil || !ok {
+		t.Fatalf("Get: ok=%v err=%v, want hit", ok, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if !info.ModTime().After(old) {
+		t.Errorf("mtime = %v, want refreshed after hit", info.ModTime())
+	}
+}
@@ -21,6 +21,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -87,6 +88,11 @@ type ScanOptions struct {
 	// ProgressDetectionStarted reports that the caller already opened detection
 	// before invoking Scan, for example while it pre-detects languages for export.
 	ProgressDetectionStarted bool
+
+	// RulesProfile, when non-nil, is filled with per-rule match counts and
+	// scanner times. Dependency scans clear it so only the primary scan is
+	// profiled.
+	RulesProfile *RulesProfile
 }
 
 // ProgressReporter receives a lifecycle transition for a scan phase.
@@ -201,7 +207,18 @@ func (o *Orchestrator) Scan(ctx context.Context, opts ScanOptions) (result *enti
 		Name:    version.ToolName,
 		Version: version.Version,
 	}
-	report, scanErr := scannerInstance.Scan(ctx, opts.Target, rulePaths, toolInfo)
+	scanStart := time.Now()
+	var report *entities.InterimReport
+	var ruleTimes map[string]time.Duration
+	var scanErr error
+	if profiler, ok := scannerInstance.(scanner.RuleProfiler); ok && opts.RulesProfile != nil {
+		report, ruleTimes, scanErr = profiler.ScanWithRuleTimes(ctx, opts.Target, rulePaths, toolInfo)
+	} else {
+		if opts.RulesProfile != nil {
+			log.Warn().Str("scanner", opts.ScannerName).Msg("Scanner does not report rule times, profiling match counts only")
+		}
+		report, scanErr = scannerInstance.Scan(ctx, opts.Target, rulePaths, toolInfo)
+	}
 	if scanErr != nil {
 		return nil, failure.WrapUnknown(
 			scanErr,
@@ -212,11 +229,13 @@ func (o *Orchestrator) Scan(ctx context.Context, opts ScanOptions) (result *enti
 		)
 	}
 
-	// Step 5b: Stamp the ruleset that produced these findings. Best-effort:
-	// remote sources lift it from .cache-meta.json, local sources compute a
-	// content fingerprint, MultiSource picks the first non-empty. An empty
+	// Step 5b: Stamp the rulesets that produced these findings, and which of
+	// them each rule came from. Best-effort: remote sources lift it from
+	// .cache-meta.json, local sources compute a content fingerprint. An empty
 	// RulesInfo is acceptable (ad-hoc local files with no metadata).
 	report.Rules = o.rulesManager.Info()
+	o.rulesManager.AttributeRules(report)
+	opts.RulesProfile.recordScan(opts.ScannerName, report, ruleTimes, o.rulesManager.RuleOrigins(), time.Since(scanStart))
 
 	// Step 6: Process and enrich results
 	enrichedReport, processErr := o.processor.Process(report, languages, opts.Target)
@@ -253,6 +272,7 @@ func (o *Orchestrator) loadRules(opts ScanOptions, languages []string, rulePaths
 			return failure.WrapUnknown(loadErr, failure.CodeRulesLoadFailed, failure.StageRules, "failed to prepare pre-loaded rules for scanner")
 		}
 		*rulePaths, *cleanupRulePaths = preparedRulePaths, cleanup
+		opts.RulesProfile.recordRules(opts.RulePaths, opts.RulePaths, languages)
 		log.Debug().Int("count", len(*rulePaths)).Msg("Using pre-loaded rule paths")
 	} else {
 		loadedRulePaths, loadErr := o.rulesManager.Load()
@@ -262,7 +282,12 @@ func (o *Orchestrator) loadRules(opts ScanOptions, languages []string, rulePaths
 		*rulePaths = loadedRulePaths
 		log.Info().Int("count", len(*rulePaths)).Msg("Loaded rules")
 		*rawRulePaths = *rulePaths
-		preparedRulePaths, cleanup, prepareErr := prepareRulePathsForScanner(*rulePaths, languages)
+		candidateRules := *rulePaths
+		if len(languages) > 0 {
+			candidateRules = filterRulesByLanguages(*rulePaths, languages)
+		}
+		opts.RulesProfile.recordRules(*rulePaths, candidateRules, languages)
+		preparedRulePaths, cleanup, prepareErr := optimizeRulePathsForScanner(candidateRules)
 		if prepareErr != nil {
 			return failure.WrapUnknown(prepareErr, failure.CodeRulesLoadFailed, failure.StageRules, "failed to prepare filtered rules for scanner")
 		}
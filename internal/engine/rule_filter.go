@@ -17,9 +17,10 @@ import (
 )
 
 // ruleFile is a minimal representation of a semgrep rule file, used only to
-// extract the languages field for filtering.
+// extract the languages field for filtering and the rule IDs for profiling.
 type ruleFile struct {
 	Rules []struct {
+		ID        string   `yaml:"id"`
 		Languages []string `yaml:"languages"`
 	} `yaml:"rules"`
 }
@@ -0,0 +1,216 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// RulesProfile reports, for one scan, how often each rule matched, how long
+// the scanner spent on it and which rules the language filter left out.
+// Set ScanOptions.RulesProfile to have Orchestrator.Scan fill it in.
+type RulesProfile struct {
+	// Scanner is the scanner that ran the rules.
+	Scanner string `json:"scanner"`
+	// Languages are the languages rules were filtered by.
+	Languages []string `json:"languages,omitempty"`
+	// TimingAvailable is false when the scanner did not report per-rule
+	// times; TimeSeconds is then zero for every rule.
+	TimingAvailable bool `json:"timing_available"`
+	// ScanSeconds is the wall-clock duration of the scanner run.
+	ScanSeconds float64 `json:"scan_seconds"`
+	// Rules lists every rule passed to the scanner, slowest first.
+	Rules []RuleProfile `json:"rules"`
+	// NeverMatched lists the IDs of scanned rules without a single match.
+	NeverMatched []string `json:"never_matched"`
+	// FilteredOut lists the rules that were not scanned because none of
+	// their languages was detected in the target.
+	FilteredOut []FilteredRule `json:"filtered_out"`
+
+	scanned []ruleDefinition
+}
+
+// RuleProfile is the match count and scanner time of one rule.
+type RuleProfile struct {
+	ID          string  `json:"id"`
+	Ruleset     string  `json:"ruleset,omitempty"`
+	Path        string  `json:"path,omitempty"`
+	Matches     int     `json:"matches"`
+	TimeSeconds float64 `json:"time_seconds"`
+}
+
+// FilteredRule is a rule left out by language filtering.
+type FilteredRule struct {
+	ID        string   `json:"id"`
+	Ruleset   string   `json:"ruleset,omitempty"`
+	Path      string   `json:"path"`
+	Languages []string `json:"languages"`
+}
+
+// ruleDefinition is one rule of a rule file.
+type ruleDefinition struct {
+	id        string
+	path      string
+	languages []string
+}
+
+// recordRules records the rules that were loaded and the subset of them
+// that is passed to the scanner. Both may contain directories.
+func (p *RulesProfile) recordRules(loaded, scanned, languages []string) {
+	if p == nil {
+		return
+	}
+	p.Languages = languages
+	p.scanned = nil
+	p.FilteredOut = []FilteredRule{}
+
+	scannedFiles := make(map[string]bool)
+	for _, path := range expandRulePaths(scanned) {
+		scannedFiles[path] = true
+		p.scanned = append(p.scanned, ruleDefinitions(path)...)
+	}
+	for _, path := range expandRulePaths(loaded) {
+		if scannedFiles[path] {
+			continue
+		}
+		for _, rule := range ruleDefinitions(path) {
+			p.FilteredOut = append(p.FilteredOut, FilteredRule{
+				ID:        rule.id,
+				Path:      rule.path,
+				Languages: rule.languages,
+			})
+		}
+	}
+}
+
+// recordScan fills in match counts from the scanner's report (before
+// processing merges findings), the per-rule times and the ruleset each
+// rule came from. times is nil when the scanner reported no timing.
+func (p *RulesProfile) recordScan(scannerName string, report *entities.InterimReport, times map[string]time.Duration, origins map[string]string, elapsed time.Duration) {
+	if p == nil {
+		return
+	}
+	p.Scanner = scannerName
+	p.TimingAvailable = times != nil
+	p.ScanSeconds = elapsed.Seconds()
+
+	matches := make(map[string]int)
+	if report != nil {
+		for i := range report.Findings {
+			for _, asset := range report.Findings[i].CryptographicAssets {
+				for _, rule := range asset.Rules {
+					matches[rule.ID]++
+				}
+			}
+		}
+	}
+
+	p.Rules = make([]RuleProfile, 0, len(p.scanned))
+	seen := make(map[string]bool, len(p.scanned))
+	for _, rule := range p.scanned {
+		if seen[rule.id] {
+			continue
+		}
+		seen[rule.id] = true
+		p.Rules = append(p.Rules, RuleProfile{ID: rule.id, Path: rule.path})
+	}
+	// Matches the scanner attributed to an ID no rule file defines (e.g.
+	// when its rule ID prefix could not be stripped) are kept visible.
+	for id := range matches {
+		if !seen[id] {
+			seen[id] = true
+			p.Rules = append(p.Rules, RuleProfile{ID: id})
+		}
+	}
+
+	p.NeverMatched = []string{}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		rule.Ruleset = origins[rule.ID]
+		rule.Matches = matches[rule.ID]
+		rule.TimeSeconds = times[rule.ID].Seconds()
+		if rule.Matches == 0 {
+			p.NeverMatched = append(p.NeverMatched, rule.ID)
+		}
+	}
+	for i := range p.FilteredOut {
+		p.FilteredOut[i].Ruleset = origins[p.FilteredOut[i].ID]
+	}
+
+	sort.SliceStable(p.Rules, func(i, j int) bool {
+		if p.Rules[i].TimeSeconds != p.Rules[j].TimeSeconds {
+			return p.Rules[i].TimeSeconds > p.Rules[j].TimeSeconds
+		}
+		return p.Rules[i].ID < p.Rules[j].ID
+	})
+	sort.Strings(p.NeverMatched)
+	sort.SliceStable(p.FilteredOut, func(i, j int) bool {
+		return p.FilteredOut[i].ID < p.FilteredOut[j].ID
+	})
+}
+
+// Write writes the profile to path as indented JSON.
+func (p *RulesProfile) Write(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rules profile: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write rules profile: %w", err)
+	}
+	return nil
+}
+
+// ruleDefinitions parses a rule file and returns its rules. Unreadable or
+// malformed files yield no rules: rule loading reports them.
+func ruleDefinitions(path string) []ruleDefinition {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("Failed to read rule file for profiling")
+		return nil
+	}
+	var rf ruleFile
+	if err := yaml.Unmarshal(data, &rf); err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("Failed to parse rule file for profiling")
+		return nil
+	}
+
+	displayPath := filepath.ToSlash(path)
+	definitions := make([]ruleDefinition, 0, len(rf.Rules))
+	for _, r := range rf.Rules {
+		if r.ID == "" {
+			continue
+		}
+		languages := make([]string, 0, len(r.Languages))
+		for _, l := range r.Languages {
+			languages = append(languages, strings.ToLower(l))
+		}
+		definitions = append(definitions, ruleDefinition{id: r.ID, path: displayPath, languages: languages})
+	}
+	return definitions
+}
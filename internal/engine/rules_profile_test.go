@@ -0,0 +1,223 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/rules"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+)
+
+// profilingScanner is a mockScanner that also reports rule times.
+type profilingScanner struct {
+	mockScanner
+	times map[string]time.Duration
+}
+
+func (p *profilingScanner) ScanWithRuleTimes(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, map[string]time.Duration, error) {
+	report, err := p.Scan(ctx, target, rulePaths, toolInfo)
+	return report, p.times, err
+}
+
+func profileTestReport(toolInfo entities.ToolInfo, ruleIDs ...string) *entities.InterimReport {
+	report := &entities.InterimReport{Version: "1.0", Tool: toolInfo}
+	for i, id := range ruleIDs {
+		report.Findings = append(report.Findings, entities.Finding{
+			FilePath: "main.go",
+			Language: "go",
+			CryptographicAssets: []entities.CryptographicAsset{{
+				StartLine: i + 1,
+				EndLine:   i + 1,
+				Match:     id,
+				Rules:     []entities.RuleInfo{{ID: id}},
+				Status:    "pending",
+			}},
+		})
+	}
+	return report
+}
+
+func profileTestOrchestrator(t *testing.T, scannerInstance scanner.Scanner) (*Orchestrator, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "go.yaml", `rules:
+  - id: go-aes
+    languages: [go]
+  - id: go-rsa
+    languages: [go]
+  - id: go-unused
+    languages: [Go]
+`)
+	writeRuleFile(t, dir, "python.yaml", `rules:
+  - id: py-aes
+    languages: [python]
+`)
+	ruleSource := &mockRuleSource{
+		loadFunc: func() ([]string, error) { return []string{dir}, nil },
+	}
+
+	registry := scanner.NewRegistry()
+	registry.Register("test-scanner", scannerInstance)
+	return NewOrchestrator(&mockDetector{}, rules.NewManager(ruleSource), registry), dir
+}
+
+func TestOrchestrator_Scan_RulesProfile(t *testing.T) {
+	t.Parallel()
+
+	profiling := &profilingScanner{
+		times: map[string]time.Duration{
+			"go-aes":    100 * time.Millisecond,
+			"go-rsa":    2 * time.Second,
+			"go-unused": 0,
+		},
+	}
+	profiling.scanFunc = func(_ context.Context, _ string, _ []string, toolInfo entities.ToolInfo) (*entities.InterimReport, error) {
+		return profileTestReport(toolInfo, "go-aes", "go-aes", "go-rsa"), nil
+	}
+	orchestrator, dir := profileTestOrchestrator(t, profiling)
+
+	profile := &RulesProfile{}
+	_, err := orchestrator.Scan(context.Background(), ScanOptions{
+		Target:       "/path/to/code",
+		ScannerName:  "test-scanner",
+		LanguageHint: []string{"go"},
+		RulesProfile: profile,
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	goRules := filepath.ToSlash(filepath.Join(dir, "go.yaml"))
+	wantRules := []RuleProfile{
+		{ID: "go-rsa", Path: goRules, Matches: 1, TimeSeconds: 2},
+		{ID: "go-aes", Path: goRules, Matches: 2, TimeSeconds: 0.1},
+		{ID: "go-unused", Path: goRules},
+	}
+	if !profile.TimingAvailable {
+		t.Error("TimingAvailable = false, want true")
+	}
+	if profile.Scanner != "test-scanner" {
+		t.Errorf("Scanner = %q, want test-scanner", profile.Scanner)
+	}
+	if !reflect.DeepEqual(profile.Rules, wantRules) {
+		t.Errorf("Rules = %+v, want %+v", profile.Rules, wantRules)
+	}
+	if want := []string{"go-unused"}; !reflect.DeepEqual(profile.NeverMatched, want) {
+		t.Errorf("NeverMatched = %v, want %v", profile.NeverMatched, want)
+	}
+	wantFiltered := []FilteredRule{{
+		ID:        "py-aes",
+		Path:      filepath.ToSlash(filepath.Join(dir, "python.yaml")),
+		Languages: []string{"python"},
+	}}
+	if !reflect.DeepEqual(profile.FilteredOut, wantFiltered) {
+		t.Errorf("FilteredOut = %+v, want %+v", profile.FilteredOut, wantFiltered)
+	}
+}
+
+func TestOrchestrator_Scan_RulesProfileWithoutTiming(t *testing.T) {
+	t.Parallel()
+
+	plain := &mockScanner{
+		scanFunc: func(_ context.Context, _ string, _ []string, toolInfo entities.ToolInfo) (*entities.InterimReport, error) {
+			return profileTestReport(toolInfo, "unknown-rule"), nil
+		},
+	}
+	orchestrator, _ := profileTestOrchestrator(t, plain)
+
+	profile := &RulesProfile{}
+	_, err := orchestrator.Scan(context.Background(), ScanOptions{
+		Target:       "/path/to/code",
+		ScannerName:  "test-scanner",
+		LanguageHint: []string{"go", "python"},
+		RulesProfile: profile,
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if profile.TimingAvailable {
+		t.Error("TimingAvailable = true, want false")
+	}
+	if len(profile.FilteredOut) != 0 {
+		t.Errorf("FilteredOut = %+v, want none", profile.FilteredOut)
+	}
+	// Rules are sorted by ID when no times are known; a matched ID that no
+	// rule file defines is still listed.
+	var ids []string
+	for _, rule := range profile.Rules {
+		ids = append(ids, rule.ID)
+	}
+	wantIDs := []string{"go-aes", "go-rsa", "go-unused", "py-aes", "unknown-rule"}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("rule IDs = %v, want %v", ids, wantIDs)
+	}
+	wantNeverMatched := []string{"go-aes", "go-rsa", "go-unused", "py-aes"}
+	if !reflect.DeepEqual(profile.NeverMatched, wantNeverMatched) {
+		t.Errorf("NeverMatched = %v, want %v", profile.NeverMatched, wantNeverMatched)
+	}
+}
+
+func TestRulesProfile_Write(t *testing.T) {
+	t.Parallel()
+
+	profile := &RulesProfile{
+		Scanner:      "opengrep",
+		Rules:        []RuleProfile{{ID: "go-aes", Matches: 1}},
+		NeverMatched: []string{},
+		FilteredOut:  []FilteredRule{},
+	}
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := profile.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read profile: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("profile is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"scanner", "timing_available", "scan_seconds", "rules", "never_matched", "filtered_out"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("profile JSON is missing %q", key)
+		}
+	}
+
+	if err := profile.Write(filepath.Join(t.TempDir(), "missing", "profile.json")); err == nil {
+		t.Error("Write() to a missing directory succeeded, want error")
+	}
+}
+
+func TestRulesProfile_NilIsNoop(t *testing.T) {
+	t.Parallel()
+
+	var profile *RulesProfile
+	profile.recordRules([]string{"rules"}, nil, []string{"go"})
+	profile.recordScan("opengrep", &entities.InterimReport{}, nil, nil, time.Second)
+}
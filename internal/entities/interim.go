@@ -22,6 +22,18 @@ import "github.com/scanoss/crypto-finder/pkg/schema"
 // InterimFormatVersion is the current version of the interim report schema.
 const InterimFormatVersion = schema.InterimFormatVersion
 
+// Dependency scope values for DependencyComponent.Scope.
+const (
+	DependencyScopeDirect     = schema.DependencyScopeDirect
+	DependencyScopeTransitive = schema.DependencyScopeTransitive
+)
+
+// Skip reasons for DependencyComponent.SkipReason.
+const (
+	DependencySkipScope       = schema.DependencySkipScope
+	DependencySkipNotImported = schema.DependencySkipNotImported
+)
+
 type (
 	// InterimReport is the standardized output format for all scanners.
 	InterimReport = schema.InterimReport
@@ -29,12 +41,18 @@ type (
 	ToolInfo = schema.ToolInfo
 	// RulesInfo describes the ruleset that fed a scan.
 	RulesInfo = schema.RulesInfo
+	// RulesSourceInfo describes one rule source that fed a scan.
+	RulesSourceInfo = schema.RulesSourceInfo
 	// Finding represents all cryptographic assets discovered in a single file.
 	Finding = schema.Finding
 	// CryptographicAsset represents a single detected cryptographic element.
 	CryptographicAsset = schema.CryptographicAsset
 	// DependencyInfo contains attribution metadata for dependency findings.
 	DependencyInfo = schema.DependencyInfo
+	// DependencyGraph describes the dependencies resolved for a scanned project.
+	DependencyGraph = schema.DependencyGraph
+	// DependencyComponent is a single resolved dependency version and its edges.
+	DependencyComponent = schema.DependencyComponent
 	// RuleInfo contains information about the detection rule that identified an asset.
 	RuleInfo = schema.RuleInfo
 )
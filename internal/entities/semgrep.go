@@ -16,10 +16,13 @@
 
 package entities
 
+import "encoding/json"
+
 // SemgrepOutput represents the JSON output structure from Semgrep.
 type SemgrepOutput struct {
 	Results []SemgrepResult `json:"results"`
 	Errors  []SemgrepError  `json:"errors"`
+	Time    *SemgrepTiming  `json:"time,omitempty"` // Present only when run with --time
 }
 
 // SemgrepResult represents a single finding from Semgrep.
@@ -111,3 +114,37 @@ type SemgrepErrorSpan struct {
 	Start SemgrepLocation `json:"start"`
 	End   SemgrepLocation `json:"end"`
 }
+
+// SemgrepTiming is the profile Semgrep and OpenGrep emit with --time.
+type SemgrepTiming struct {
+	Rules   []SemgrepRuleID       `json:"rules"`   // Rules in the order MatchTimes uses
+	Targets []SemgrepTargetTiming `json:"targets"` // One entry per scanned file
+}
+
+// SemgrepTargetTiming is the time spent on one scanned file.
+type SemgrepTargetTiming struct {
+	Path       string    `json:"path"`
+	MatchTimes []float64 `json:"match_times"` // Seconds per rule, indexed like SemgrepTiming.Rules
+	RunTime    float64   `json:"run_time"`    // Seconds
+}
+
+// SemgrepRuleID is a rule ID in the timing profile. Current versions emit
+// a plain string; older ones an object with an "id" field.
+type SemgrepRuleID string
+
+// UnmarshalJSON accepts both rule ID encodings.
+func (r *SemgrepRuleID) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*r = SemgrepRuleID(id)
+		return nil
+	}
+	var object struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*r = SemgrepRuleID(object.ID)
+	return nil
+}
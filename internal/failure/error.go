@@ -43,6 +43,10 @@ const (
 	CodeOutputWriterUnavailable     = publicfailure.CodeOutputWriterUnavailable
 	CodeOutputWriteFailed           = publicfailure.CodeOutputWriteFailed
 	CodeFindingsDetected            = publicfailure.CodeFindingsDetected
+	CodeRuleTestsFailed             = publicfailure.CodeRuleTestsFailed
+	CodeRuleLintFailed              = publicfailure.CodeRuleLintFailed
+	CodeRulesetSignatureRejected    = publicfailure.CodeRulesetSignatureRejected
+	CodeRulesetBundleInvalid        = publicfailure.CodeRulesetBundleInvalid
 
 	StageUnknown    = publicfailure.StageUnknown
 	StageInput      = publicfailure.StageInput
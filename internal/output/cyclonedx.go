@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/rs/zerolog/log"
 
 	"github.com/scanoss/crypto-finder/internal/converter"
@@ -31,7 +32,8 @@ import (
 )
 
 // CycloneDXWriter implements the Writer interface for CycloneDX CBOM format.
-// It converts interim format to CycloneDX 1.6 CBOM and validates the output.
+// It converts interim format to a CycloneDX CBOM (1.6 unless another supported
+// version is selected) and validates the output.
 type CycloneDXWriter struct {
 	// PrettyPrint enables indented formatting. Default: true
 	PrettyPrint bool
@@ -45,19 +47,25 @@ type CycloneDXWriter struct {
 
 // NewCycloneDXWriter creates a new CycloneDX writer with default settings.
 func NewCycloneDXWriter() *CycloneDXWriter {
+	return NewCycloneDXWriterForSpecVersion(converter.DefaultSpecVersion)
+}
+
+// NewCycloneDXWriterForSpecVersion creates a CycloneDX writer emitting the
+// given spec version (1.5, 1.6 or 1.7).
+func NewCycloneDXWriterForSpecVersion(specVersion cdx.SpecVersion) *CycloneDXWriter {
 	return &CycloneDXWriter{
 		PrettyPrint: true,
 		Indent:      "  ", // 2 spaces
-		converter:   converter.NewConverter(),
+		converter:   converter.NewConverterForSpecVersion(specVersion),
 	}
 }
 
 // Write converts the interim report to CycloneDX CBOM format and writes it.
 //
 // The conversion process:
-// 1. Transforms interim format to CycloneDX 1.6 BOM
+// 1. Transforms interim format to a CycloneDX BOM of the selected version
 // 2. Applies strict mapping (skips incomplete assets)
-// 3. Validates against CycloneDX 1.6 schema
+// 3. Validates against the schema of that version
 // 4. Writes to destination (stdout or file)
 //
 // Destination handling:
@@ -85,8 +93,21 @@ func (w *CycloneDXWriter) Write(report *entities.InterimReport, destination stri
 		Int("components", componentCount(bom)).
 		Msg("CycloneDX BOM generated successfully")
 
+	return w.WriteBOM(bom, destination)
+}
+
+// WriteBOM writes an already built CycloneDX BOM, e.g. an SBOM enriched by
+// converter.MergeCBOM, with the same destination handling as Write.
+func (w *CycloneDXWriter) WriteBOM(bom *cdx.BOM, destination string) error {
+	if bom == nil {
+		return fmt.Errorf("BOM cannot be nil")
+	}
+
 	// Marshal to JSON
-	var data []byte
+	var (
+		data []byte
+		err  error
+	)
 	if w.PrettyPrint {
 		data, err = json.MarshalIndent(bom, "", w.Indent)
 	} else {
@@ -20,6 +20,10 @@ package output
 import (
 	"fmt"
 	"sort"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/scanoss/crypto-finder/internal/converter"
 )
 
 // WriterFactory provides a registry of output format writers.
@@ -37,16 +41,38 @@ type WriterFactory struct {
 	writers map[string]Writer
 }
 
+// WriterFactoryOption configures a WriterFactory.
+type WriterFactoryOption func(*writerFactoryConfig)
+
+type writerFactoryConfig struct {
+	cycloneDXSpecVersion cdx.SpecVersion
+}
+
+// WithCycloneDXSpecVersion selects the CycloneDX version written by the
+// cyclonedx writer. Use converter.ParseSpecVersion to validate user input.
+func WithCycloneDXSpecVersion(specVersion cdx.SpecVersion) WriterFactoryOption {
+	return func(cfg *writerFactoryConfig) {
+		cfg.cycloneDXSpecVersion = specVersion
+	}
+}
+
 // NewWriterFactory creates a factory with all supported output format writers registered.
 //
 // Currently supported formats:
 //   - json: Standard JSON output (pretty-printed by default)
-//   - cyclonedx: CycloneDX 1.6 CBOM format
-func NewWriterFactory() *WriterFactory {
+//   - cyclonedx: CycloneDX CBOM format (1.6 unless WithCycloneDXSpecVersion is given)
+//   - spdx: SPDX 3.0 JSON-LD
+func NewWriterFactory(opts ...WriterFactoryOption) *WriterFactory {
+	cfg := writerFactoryConfig{cycloneDXSpecVersion: converter.DefaultSpecVersion}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	return &WriterFactory{
 		writers: map[string]Writer{
 			"json":      NewJSONWriter(),
-			"cyclonedx": NewCycloneDXWriter(),
+			"cyclonedx": NewCycloneDXWriterForSpecVersion(cfg.cycloneDXSpecVersion),
+			"spdx":      NewSPDXWriter(),
 		},
 	}
 }
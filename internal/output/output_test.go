@@ -22,6 +22,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
 	"github.com/scanoss/crypto-finder/internal/entities"
 )
 
@@ -340,6 +342,62 @@ func TestWriterFactory_GetCycloneDXWriter(t *testing.T) {
 	}
 }
 
+func TestWriterFactory_CycloneDXSpecVersion(t *testing.T) {
+	t.Parallel()
+
+	factory := NewWriterFactory(WithCycloneDXSpecVersion(cdx.SpecVersion1_7))
+	writer, err := factory.GetWriter("cyclonedx")
+	if err != nil {
+		t.Fatalf("GetWriter(\"cyclonedx\") failed: %v", err)
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "output.cdx.json")
+	if err := writer.Write(createTestReport(), outputFile); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	var cdxBom map[string]interface{}
+	if err := json.Unmarshal(data, &cdxBom); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if cdxBom["specVersion"] != "1.7" {
+		t.Errorf("specVersion = %v, want 1.7", cdxBom["specVersion"])
+	}
+}
+
+func TestSPDXWriter_WriteToFile(t *testing.T) {
+	t.Parallel()
+
+	writer, err := NewWriterFactory().GetWriter("spdx")
+	if err != nil {
+		t.Fatalf("GetWriter(\"spdx\") failed: %v", err)
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "output.spdx.json")
+	if err := writer.Write(createTestReport(), outputFile); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if document["@context"] != "https://spdx.org/rdf/3.0.1/spdx-context.jsonld" {
+		t.Errorf("@context = %v", document["@context"])
+	}
+	if graph, ok := document["@graph"].([]interface{}); !ok || len(graph) == 0 {
+		t.Error("Missing @graph")
+	}
+}
+
 func TestWriterFactory_GetUnsupportedFormat(t *testing.T) {
 	t.Parallel()
 
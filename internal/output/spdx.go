@@ -0,0 +1,99 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/spdx"
+	"github.com/scanoss/crypto-finder/internal/utils"
+)
+
+// SPDXWriter implements the Writer interface for SPDX 3.0 JSON-LD.
+type SPDXWriter struct {
+	// PrettyPrint enables indented formatting. Default: true
+	PrettyPrint bool
+
+	// Indent specifies the indentation string. Default: "  " (2 spaces)
+	Indent string
+
+	// Converter transforms interim to SPDX format
+	converter *spdx.Converter
+}
+
+// NewSPDXWriter creates a new SPDX writer with default settings.
+func NewSPDXWriter() *SPDXWriter {
+	return &SPDXWriter{
+		PrettyPrint: true,
+		Indent:      "  ", // 2 spaces
+		converter:   spdx.NewConverter(),
+	}
+}
+
+// Write converts the interim report to an SPDX 3.0 document and writes it.
+//
+// Destination handling:
+//   - "" (empty) or "-": Write to stdout
+//   - file path: Write atomically with permissions 0600 (rw-------)
+func (w *SPDXWriter) Write(report *entities.InterimReport, destination string) error {
+	if report == nil {
+		return fmt.Errorf("report cannot be nil")
+	}
+
+	document, err := w.converter.Convert(report)
+	if err != nil {
+		return fmt.Errorf("conversion to SPDX failed: %w", err)
+	}
+
+	//nolint:nestif // Separate stdout and file paths are inherently nested
+	if destination == "" || destination == "-" {
+		if err := w.encode(document, os.Stdout); err != nil {
+			return fmt.Errorf("failed to write SPDX to stdout: %w", err)
+		}
+	} else {
+		absPath, err := filepath.Abs(destination)
+		if err != nil {
+			return fmt.Errorf("failed to resolve destination path: %w", err)
+		}
+
+		if err := utils.WriteFileAtomic(absPath, 0o600, func(file *os.File) error {
+			return w.encode(document, file)
+		}); err != nil {
+			return fmt.Errorf("failed to write SPDX file: %w", err)
+		}
+
+		log.Info().Str("file", absPath).Msg("SPDX document written successfully")
+	}
+
+	return nil
+}
+
+func (w *SPDXWriter) encode(document *spdx.Document, dst io.Writer) error {
+	enc := json.NewEncoder(dst)
+	enc.SetEscapeHTML(false)
+	if w.PrettyPrint {
+		enc.SetIndent("", w.Indent)
+	}
+	return enc.Encode(document)
+}
@@ -25,6 +25,8 @@ import (
 //
 // Implementations exist for:
 //   - JSON (default format)
+//   - CycloneDX CBOM
+//   - SPDX 3.0
 type Writer interface {
 	// Write formats and writes the report to the specified destination.
 	//
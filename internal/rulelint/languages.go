@@ -0,0 +1,118 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rulelint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// languageAliases maps every language name semgrep and opengrep accept to
+// its canonical name.
+var languageAliases = map[string]string{
+	"apex": "apex", "bash": "bash", "sh": "bash", "c": "c", "cairo": "cairo",
+	"clojure": "clojure", "cpp": "cpp", "c++": "cpp", "csharp": "csharp", "c#": "csharp",
+	"dart": "dart", "dockerfile": "dockerfile", "docker": "dockerfile", "elixir": "elixir", "ex": "elixir",
+	"generic": "generic", "go": "go", "golang": "go", "hack": "hack", "html": "html",
+	"java": "java", "javascript": "javascript", "js": "javascript", "json": "json", "jsonnet": "jsonnet",
+	"julia": "julia", "kotlin": "kotlin", "kt": "kotlin", "lisp": "lisp", "lua": "lua",
+	"move_on_aptos": "move_on_aptos", "none": "none", "ocaml": "ocaml", "php": "php", "promql": "promql",
+	"proto": "proto", "protobuf": "proto", "python": "python", "py": "python", "python2": "python",
+	"python3": "python", "ql": "ql", "r": "r", "regex": "regex", "ruby": "ruby", "rust": "rust",
+	"scala": "scala", "scheme": "scheme", "solidity": "solidity", "sol": "solidity", "swift": "swift",
+	"terraform": "terraform", "hcl": "terraform", "typescript": "typescript", "ts": "typescript",
+	"vue": "vue", "xml": "xml", "yaml": "yaml",
+}
+
+// syntaxFamilies groups languages one code pattern can be written for.
+var syntaxFamilies = map[string]string{
+	"cpp":        "c",
+	"typescript": "javascript",
+	"vue":        "javascript",
+}
+
+// languageAgnostic languages accept any pattern.
+var languageAgnostic = map[string]bool{"generic": true, "regex": true, "none": true}
+
+// codePatternKeys are the pattern keys parsed in the rule's languages, as
+// opposed to pattern-regex.
+var codePatternKeys = map[string]bool{
+	"pattern": true, "patterns": true, "pattern-either": true, "match": true,
+	"pattern-sources": true, "pattern-sinks": true,
+}
+
+func (l *linter) lintLanguages(file string, line int, r *rule, keys map[string]bool) {
+	if len(r.Languages) == 0 {
+		l.report(SeverityError, file, line, r.ID, CheckLanguages, "rule has no languages")
+		return
+	}
+
+	families := make(map[string]bool)
+	canonical := make(map[string]bool)
+	for _, language := range r.Languages {
+		name, ok := languageAliases[strings.ToLower(strings.TrimSpace(language))]
+		if !ok {
+			l.report(SeverityError, file, line, r.ID, CheckLanguages, fmt.Sprintf("unknown language '%s'", language))
+			continue
+		}
+		canonical[name] = true
+		if !languageAgnostic[name] {
+			families[syntaxFamily(name)] = true
+		}
+	}
+
+	if canonical["regex"] && !keys["pattern-regex"] {
+		l.report(SeverityError, file, line, r.ID, CheckLanguages, "languages [regex] requires pattern-regex")
+	}
+	if len(families) > 1 && usesCodePatterns(keys) {
+		l.report(SeverityWarning, file, line, r.ID, CheckLanguages,
+			fmt.Sprintf("code patterns cannot parse in every listed language (%s)", strings.Join(sortedKeys(families), ", ")))
+	}
+
+	// Rule IDs start with the language they target, e.g. "java.crypto.aes".
+	prefix, _, _ := strings.Cut(r.ID, ".")
+	if name, ok := languageAliases[strings.ToLower(prefix)]; ok && !languageAgnostic[name] && len(families) > 0 && !families[syntaxFamily(name)] {
+		l.report(SeverityWarning, file, line, r.ID, CheckLanguages,
+			fmt.Sprintf("rule ID targets '%s' but languages are [%s]", prefix, strings.Join(r.Languages, ", ")))
+	}
+}
+
+func syntaxFamily(language string) string {
+	if family, ok := syntaxFamilies[language]; ok {
+		return family
+	}
+	return language
+}
+
+func usesCodePatterns(keys map[string]bool) bool {
+	for key := range keys {
+		if codePatternKeys[key] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+// Package rulelint checks detection rule YAML for problems that would
+// otherwise only surface at scan or CBOM conversion time, for
+// `crypto-finder rules lint`.
+package rulelint
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/scanoss/crypto-finder/internal/converter"
+	"github.com/scanoss/crypto-finder/pkg/paramcondition"
+)
+
+// Severity is the severity of a lint issue.
+type Severity string
+
+const (
+	// SeverityError marks a rule that breaks scanning or conversion.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a rule that works but is likely wrong.
+	SeverityWarning Severity = "warning"
+)
+
+// Checks reported besides the converter.RuleMetadataCheck* ones.
+const (
+	CheckParse              = "parse"
+	CheckID                 = "id"
+	CheckDuplicateID        = "duplicate-id"
+	CheckCryptoBlock        = "crypto-block"
+	CheckLanguages          = "languages"
+	CheckPattern            = "pattern"
+	CheckParameterCondition = "parameter-condition"
+)
+
+// Issue is one problem found in a rule.
+type Issue struct {
+	Severity Severity
+	File     string
+	Line     int
+	RuleID   string
+	Check    string
+	Message  string
+}
+
+// Result is the outcome of linting a set of rule sources.
+type Result struct {
+	Files  int
+	Rules  int
+	Issues []Issue
+}
+
+// Errors counts the error-severity issues.
+func (r *Result) Errors() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Warnings counts the warning-severity issues.
+func (r *Result) Warnings() int {
+	return len(r.Issues) - r.Errors()
+}
+
+// patternKeys are the top-level rule keys that define what a rule matches.
+var patternKeys = []string{
+	"pattern", "patterns", "pattern-either", "pattern-regex", "match",
+	"pattern-sources", "pattern-sinks",
+}
+
+// rule is the subset of a semgrep rule the linter inspects.
+type rule struct {
+	ID        string   `yaml:"id"`
+	Languages []string `yaml:"languages"`
+	Metadata  struct {
+		Crypto map[string]any `yaml:"crypto"`
+	} `yaml:"metadata"`
+}
+
+type definition struct {
+	file string
+	line int
+}
+
+type linter struct {
+	result  Result
+	defined map[string]definition
+}
+
+// Lint checks every rule in the given rule files or directories. Each path
+// is a rule source; rule IDs must be unique across all of them.
+func Lint(rulePaths []string) *Result {
+	l := &linter{defined: make(map[string]definition)}
+	for _, path := range rulePaths {
+		for _, file := range expandRuleFiles(path) {
+			l.lintFile(file)
+		}
+	}
+	sort.SliceStable(l.result.Issues, func(i, j int) bool {
+		a, b := l.result.Issues[i], l.result.Issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return &l.result
+}
+
+func (l *linter) report(severity Severity, file string, line int, ruleID, check, message string) {
+	l.result.Issues = append(l.result.Issues, Issue{
+		Severity: severity,
+		File:     file,
+		Line:     line,
+		RuleID:   ruleID,
+		Check:    check,
+		Message:  message,
+	})
+}
+
+func (l *linter) lintFile(file string) {
+	l.result.Files++
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		l.report(SeverityError, file, 0, "", CheckParse, err.Error())
+		return
+	}
+	var parsed struct {
+		Rules []yaml.Node `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		l.report(SeverityError, file, 0, "", CheckParse, err.Error())
+		return
+	}
+
+	for i := range parsed.Rules {
+		l.result.Rules++
+		l.lintRule(file, &parsed.Rules[i])
+	}
+}
+
+func (l *linter) lintRule(file string, node *yaml.Node) {
+	var r rule
+	if err := node.Decode(&r); err != nil {
+		l.report(SeverityError, file, node.Line, "", CheckParse, err.Error())
+		return
+	}
+	line := node.Line
+
+	if r.ID == "" {
+		l.report(SeverityError, file, line, "", CheckID, "rule has no id")
+	} else if first, ok := l.defined[r.ID]; ok {
+		l.report(SeverityError, file, line, r.ID, CheckDuplicateID,
+			fmt.Sprintf("rule ID already defined at %s:%d", first.file, first.line))
+	} else {
+		l.defined[r.ID] = definition{file: file, line: line}
+	}
+
+	keys := mappingKeys(node)
+	l.lintLanguages(file, line, &r, keys)
+	if !hasPatternKey(keys) {
+		l.report(SeverityError, file, line, r.ID, CheckPattern,
+			"rule has no pattern (want one of "+strings.Join(patternKeys, ", ")+")")
+	}
+
+	if r.Metadata.Crypto == nil {
+		l.report(SeverityError, file, line, r.ID, CheckCryptoBlock, "rule has no metadata.crypto block")
+		return
+	}
+	metadata := stringifyCryptoBlock(r.Metadata.Crypto)
+	for _, issue := range converter.CheckRuleMetadata(metadata) {
+		severity := SeverityError
+		if issue.Warning {
+			severity = SeverityWarning
+		}
+		l.report(severity, file, line, r.ID, issue.Check, issue.Message)
+	}
+	if raw := strings.TrimSpace(metadata["parameterCondition"]); raw != "" {
+		if _, err := paramcondition.ParseAll(raw); err != nil {
+			l.report(SeverityError, file, line, r.ID, CheckParameterCondition, err.Error())
+		}
+	}
+}
+
+func mappingKeys(node *yaml.Node) map[string]bool {
+	keys := make(map[string]bool)
+	if node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys[node.Content[i].Value] = true
+	}
+	return keys
+}
+
+func hasPatternKey(keys map[string]bool) bool {
+	for _, key := range patternKeys {
+		if keys[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// stringifyCryptoBlock renders metadata.crypto values the way the scanner
+// transformer does before they reach the converter.
+func stringifyCryptoBlock(crypto map[string]any) map[string]string {
+	metadata := make(map[string]string, len(crypto))
+	for key, value := range crypto {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v != "" {
+				metadata[key] = v
+			}
+		default:
+			metadata[key] = fmt.Sprint(v)
+		}
+	}
+	return metadata
+}
+
+func expandRuleFiles(path string) []string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return []string{path}
+	}
+	var files []string
+	_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(p))
+		if ext == ".yaml" || ext == ".yml" {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rulelint
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/converter"
+)
+
+func TestLint(t *testing.T) {
+	result := Lint([]string{"testdata/rules", "testdata/extra"})
+
+	if result.Files != 2 || result.Rules != 7 {
+		t.Errorf("Files, Rules = %d, %d, want 2, 7", result.Files, result.Rules)
+	}
+
+	type issueKey struct {
+		Severity Severity
+		RuleID   string
+		Check    string
+	}
+	var got []issueKey
+	for _, issue := range result.Issues {
+		got = append(got, issueKey{issue.Severity, issue.RuleID, issue.Check})
+	}
+	want := []issueKey{
+		{SeverityError, "java.crypto.aes", CheckDuplicateID},
+		{SeverityError, "java.crypto.typo-primitive", converter.RuleMetadataCheckPrimitive},
+		{SeverityWarning, "python.crypto.misplaced", CheckLanguages},
+		{SeverityWarning, "python.crypto.misplaced", converter.RuleMetadataCheckOID},
+		{SeverityError, "python.crypto.misplaced", CheckParameterCondition},
+		{SeverityError, "java.crypto.no-pattern", CheckLanguages},
+		{SeverityError, "java.crypto.no-pattern", CheckPattern},
+		{SeverityError, "java.crypto.no-pattern", converter.RuleMetadataCheckRequired},
+		{SeverityError, "java.crypto.no-metadata", CheckLanguages},
+		{SeverityError, "java.crypto.no-metadata", CheckCryptoBlock},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("issues =\n%+v\nwant\n%+v", result.Issues, want)
+	}
+
+	duplicate := result.Issues[0]
+	if duplicate.File != filepath.Join("testdata", "extra", "dup.yaml") || duplicate.Line != 2 ||
+		!strings.Contains(duplicate.Message, filepath.Join("testdata", "rules", "java", "crypto.yaml")+":2") {
+		t.Errorf("duplicate issue = %+v, want it on dup.yaml pointing at the first definition", duplicate)
+	}
+	if result.Errors() != 8 || result.Warnings() != 2 {
+		t.Errorf("Errors, Warnings = %d, %d, want 8, 2", result.Errors(), result.Warnings())
+	}
+}
+
+func TestLint_LanguageChecks(t *testing.T) {
+	result := Lint([]string{"testdata/rules/java/crypto.yaml"})
+	var messages []string
+	for _, issue := range result.Issues {
+		if issue.Check == CheckLanguages {
+			messages = append(messages, issue.Message)
+		}
+	}
+	want := []string{
+		"code patterns cannot parse in every listed language (java, python)",
+		"unknown language 'jav'",
+		"languages [regex] requires pattern-regex",
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("language issues = %q, want %q", messages, want)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteText(&out, Lint([]string{"testdata/extra"})); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if got := out.String(); got != "1 rules in 1 files: 0 errors, 0 warnings\n" {
+		t.Errorf("WriteText() = %q", got)
+	}
+
+	out.Reset()
+	if err := WriteText(&out, Lint([]string{"testdata/rules"})); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "ERROR    testdata/rules/java/crypto.yaml:14  java.crypto.typo-primitive") {
+		t.Errorf("WriteText() output:\n%s", out.String())
+	}
+}
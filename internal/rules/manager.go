@@ -25,6 +25,8 @@ import "github.com/scanoss/crypto-finder/internal/entities"
 // (local files, remote URLs, etc.) and will handle caching and validation in the future.
 type Manager struct {
 	sources []RuleSource
+	// merged aggregates sources and remembers what each of them loaded.
+	merged *MultiSource
 	// Future: Add cache directory and HTTP client for remote rules
 }
 
@@ -46,6 +48,7 @@ type Manager struct {
 func NewManager(sources ...RuleSource) *Manager {
 	return &Manager{
 		sources: sources,
+		merged:  NewMultiSource(sources...),
 	}
 }
 
@@ -56,19 +59,41 @@ func NewManager(sources ...RuleSource) *Manager {
 //   - []string: Deduplicated absolute paths to all rule files
 //   - error: If any source fails to load
 func (m *Manager) Load() ([]string, error) {
-	// Use MultiSource to aggregate all sources
-	multiSource := NewMultiSource(m.sources...)
-	return multiSource.Load()
+	return m.merged.Load()
 }
 
-// Info returns the first non-empty RulesInfo across the configured sources,
-// matching MultiSource.Info semantics. Call after a successful Load() —
-// before that, returns the zero RulesInfo.
+// Info describes the rulesets the configured sources loaded, matching
+// MultiSource.Info semantics. Call after a successful Load() — before
+// that, returns the zero RulesInfo.
 func (m *Manager) Info() entities.RulesInfo {
-	for _, s := range m.sources {
-		if info := s.Info(); info.Source != "" {
-			return info
+	return m.merged.Info()
+}
+
+// RuleOrigins maps each loaded rule ID to the ID of the Info().Sources
+// entry that defines it, matching MultiSource.RuleOrigins semantics.
+func (m *Manager) RuleOrigins() map[string]string {
+	return m.merged.RuleOrigins()
+}
+
+// AttributeRules sets RuleInfo.Ruleset on every rule of the report's
+// findings to the source that defines the rule. Rules no source defines,
+// such as synthesized entry points, are left as they are.
+func (m *Manager) AttributeRules(report *entities.InterimReport) {
+	if report == nil {
+		return
+	}
+	origins := m.RuleOrigins()
+	if len(origins) == 0 {
+		return
+	}
+	for i := range report.Findings {
+		assets := report.Findings[i].CryptographicAssets
+		for j := range assets {
+			for k := range assets[j].Rules {
+				if ruleset, ok := origins[assets[j].Rules[k].ID]; ok {
+					assets[j].Rules[k].Ruleset = ruleset
+				}
+			}
 		}
 	}
-	return entities.RulesInfo{}
 }
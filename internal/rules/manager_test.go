@@ -18,6 +18,7 @@ package rules
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/scanoss/crypto-finder/internal/entities"
@@ -119,6 +120,40 @@ func TestManager_Load_ErrorPropagation(t *testing.T) {
 	}
 }
 
+func TestManager_AttributeRules(t *testing.T) {
+	t.Parallel()
+
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRuleIDsFile(t, rulesFile, "go.crypto.aes")
+	source := &mockRuleSource{
+		loadFunc: func() ([]string, error) { return []string{rulesFile}, nil },
+		infoFunc: func() entities.RulesInfo { return entities.RulesInfo{Source: "local", ChecksumSHA256: "abc"} },
+	}
+	manager := NewManager(source)
+	if _, err := manager.Load(); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	report := &entities.InterimReport{Findings: []entities.Finding{{
+		FilePath: "main.go",
+		CryptographicAssets: []entities.CryptographicAsset{{
+			Rules: []entities.RuleInfo{{ID: "go.crypto.aes"}, {ID: "crypto.entrypoint.synthetic", Ruleset: "kept"}},
+		}},
+	}}}
+	manager.AttributeRules(report)
+
+	rules := report.Findings[0].CryptographicAssets[0].Rules
+	if rules[0].Ruleset != "local" {
+		t.Errorf("Ruleset of loaded rule = %q, want %q", rules[0].Ruleset, "local")
+	}
+	if rules[1].Ruleset != "kept" {
+		t.Errorf("Ruleset of unknown rule = %q, want it left unchanged", rules[1].Ruleset)
+	}
+	if info := manager.Info(); len(info.Sources) != 1 || info.Sources[0].ID != "local" {
+		t.Errorf("Info().Sources = %+v, want the local source", info.Sources)
+	}
+}
+
 // mockRuleSource is a test helper.
 type mockRuleSource struct {
 	loadFunc func() ([]string, error)
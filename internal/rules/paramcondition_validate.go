@@ -58,7 +58,7 @@ type paramConditionRuleFile struct {
 func ValidateParameterConditions(rulePaths []string) error {
 	var errs []error
 
-	for _, ruleFile := range expandParamConditionRulePaths(rulePaths) {
+	for _, ruleFile := range expandRuleFilePaths(rulePaths) {
 		if err := validateParameterConditionsInFile(ruleFile); err != nil {
 			errs = append(errs, err)
 		}
@@ -93,9 +93,9 @@ func validateParameterConditionsInFile(path string) error {
 	return errors.Join(errs...)
 }
 
-// expandParamConditionRulePaths resolves rulePaths (a mix of individual rule
+// expandRuleFilePaths resolves rulePaths (a mix of individual rule
 // files and directories) to the flat list of .yaml/.yml files they contain.
-func expandParamConditionRulePaths(rulePaths []string) []string {
+func expandRuleFilePaths(rulePaths []string) []string {
 	var files []string
 	for _, path := range rulePaths {
 		info, err := os.Stat(path)
@@ -0,0 +1,57 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rules
+
+import (
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"go.yaml.in/yaml/v3"
+)
+
+// ruleIDFile is a narrow representation of a semgrep rule file, used only
+// to list the rule IDs it defines.
+type ruleIDFile struct {
+	Rules []struct {
+		ID string `yaml:"id"`
+	} `yaml:"rules"`
+}
+
+// ruleIDsIn returns the IDs of the rules defined in rulePaths (files or
+// directories). Unreadable or malformed files are skipped: rule loading
+// reports them, this is only used for attribution.
+func ruleIDsIn(rulePaths []string) []string {
+	var ids []string
+	for _, path := range expandRuleFilePaths(rulePaths) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Debug().Err(err).Str("rule_path", path).Msg("Skipping unreadable rule file")
+			continue
+		}
+		var parsed ruleIDFile
+		if err := yaml.Unmarshal(data, &parsed); err != nil {
+			log.Debug().Err(err).Str("rule_path", path).Msg("Skipping malformed rule file")
+			continue
+		}
+		for _, rule := range parsed.Rules {
+			if rule.ID != "" {
+				ids = append(ids, rule.ID)
+			}
+		}
+	}
+	return ids
+}
@@ -18,6 +18,9 @@ package rules
 
 import (
 	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
 
 	"github.com/scanoss/crypto-finder/internal/entities"
 	"github.com/scanoss/crypto-finder/internal/utils"
@@ -48,6 +51,15 @@ type RuleSource interface {
 // It loads rules from all sources and merges them, removing duplicates.
 type MultiSource struct {
 	sources []RuleSource
+
+	mu sync.Mutex
+	// loaded holds each source's rule paths from the most recent successful
+	// Load(), indexed like sources.
+	loaded [][]string
+	// infos and origins cache Info() and RuleOrigins() until the next
+	// Load(); local sources hash their rule files for Info().
+	infos   []entities.RulesInfo
+	origins map[string]string
 }
 
 // NewMultiSource creates a new MultiSource that aggregates rules from multiple sources.
@@ -73,6 +85,7 @@ func NewMultiSource(sources ...RuleSource) *MultiSource {
 //   - error: First error encountered while loading sources, if any
 func (m *MultiSource) Load() ([]string, error) {
 	allRules := make([]string, 0)
+	loaded := make([][]string, 0, len(m.sources))
 
 	// Load from each source
 	for _, source := range m.sources {
@@ -81,8 +94,13 @@ func (m *MultiSource) Load() ([]string, error) {
 			return nil, fmt.Errorf("failed to load rules from %s: %w", source.Name(), err)
 		}
 		allRules = append(allRules, rulePaths...)
+		loaded = append(loaded, rulePaths)
 	}
 
+	m.mu.Lock()
+	m.loaded, m.infos, m.origins = loaded, nil, nil
+	m.mu.Unlock()
+
 	// Deduplicate paths
 	return utils.DeduplicateSliceOfStrings(allRules), nil
 }
@@ -98,17 +116,111 @@ func (m *MultiSource) Name() string {
 	return fmt.Sprintf("MultiSource(%d sources)", len(m.sources))
 }
 
-// Info returns the first non-empty RulesInfo across the configured sources.
-// We prefer the first non-empty over a "merged" representation because
-// downstream stamps a single (rules_version, checksum) on each result row;
-// a mixed-source scan is a niche operator decision and the chosen source is
-// the most informative single label. If you operate in mixed mode and want
-// reproducibility, prefer one source per scan.
+// Info describes the rulesets the sources loaded. Its top-level fields are
+// the first non-empty RulesInfo across the sources, so single-label
+// consumers keep seeing the primary ruleset; Sources lists every source
+// with a non-empty RulesInfo, in order, under a unique ID.
 func (m *MultiSource) Info() entities.RulesInfo {
-	for _, s := range m.sources {
-		if info := s.Info(); info.Source != "" {
-			return info
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	infos := m.sourceInfos()
+	sources, indexes := rulesSources(infos)
+	if len(sources) == 0 {
+		return entities.RulesInfo{}
+	}
+	info := infos[indexes[0]]
+	info.Sources = sources
+	return info
+}
+
+// RuleOrigins maps the ID of every rule loaded by the most recent Load() to
+// the ID of the Info().Sources entry it was loaded from. A rule ID defined
+// by several sources is attributed to the last of them. Rules of sources
+// with an empty RulesInfo are not mapped.
+func (m *MultiSource) RuleOrigins() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.origins != nil {
+		return m.origins
+	}
+
+	sources, indexes := rulesSources(m.sourceInfos())
+	origins := make(map[string]string)
+	for i, source := range sources {
+		if indexes[i] >= len(m.loaded) {
+			continue
+		}
+		for _, ruleID := range ruleIDsIn(m.loaded[indexes[i]]) {
+			if previous, ok := origins[ruleID]; ok && previous != source.ID {
+				log.Debug().Str("rule_id", ruleID).Str("previous", previous).Str("source", source.ID).
+					Msg("Rule defined by several rule sources, attributing it to the last")
+			}
+			origins[ruleID] = source.ID
 		}
 	}
-	return entities.RulesInfo{}
+	if m.loaded != nil {
+		m.origins = origins
+	}
+	return origins
+}
+
+// sourceInfos returns the RulesInfo of every source, cached once the
+// sources are loaded. The caller must hold m.mu.
+func (m *MultiSource) sourceInfos() []entities.RulesInfo {
+	if m.infos != nil {
+		return m.infos
+	}
+	infos := make([]entities.RulesInfo, len(m.sources))
+	for i, source := range m.sources {
+		infos[i] = source.Info()
+	}
+	if m.loaded != nil {
+		m.infos = infos
+	}
+	return infos
+}
+
+// rulesSources converts the non-empty infos to RulesSourceInfo entries with
+// unique IDs, along with the index of the info each entry came from.
+func rulesSources(infos []entities.RulesInfo) ([]entities.RulesSourceInfo, []int) {
+	var sources []entities.RulesSourceInfo
+	var indexes []int
+	seen := make(map[string]int)
+	for i, info := range infos {
+		if info.Source == "" {
+			continue
+		}
+		id := rulesSourceID(info)
+		// Sources that only differ in what RulesInfo does not record (e.g.
+		// two subdirectories of one git repository) are numbered.
+		if seen[id]++; seen[id] > 1 {
+			id = fmt.Sprintf("%s#%d", id, seen[id])
+		}
+		sources = append(sources, entities.RulesSourceInfo{
+			ID:             id,
+			Source:         info.Source,
+			Name:           info.Name,
+			Version:        info.Version,
+			Revision:       info.Revision,
+			ChecksumSHA256: info.ChecksumSHA256,
+			Signature:      info.Signature,
+			SignatureKeyID: info.SignatureKeyID,
+		})
+		indexes = append(indexes, i)
+	}
+	return sources, indexes
+}
+
+// rulesSourceID identifies a rule source in a report as
+// <source>[:<name>][@<version>], e.g. "remote:dca@latest" or "local".
+func rulesSourceID(info entities.RulesInfo) string {
+	id := info.Source
+	if info.Name != "" {
+		id += ":" + info.Name
+	}
+	if info.Version != "" {
+		id += "@" + info.Version
+	}
+	return id
 }
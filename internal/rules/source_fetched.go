@@ -0,0 +1,216 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rules
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// fetchedSourceMetadataFileName is written into every cached git checkout
+// and OCI artifact. It is hidden, so rule collection never picks it up.
+const fetchedSourceMetadataFileName = ".crypto-finder-source.json"
+
+// fetchedSourceMetadata describes one cached revision of a git or OCI rule
+// source.
+type fetchedSourceMetadata struct {
+	Location  string    `json:"location"`
+	Revision  string    `json:"revision"`
+	FetchedAt time.Time `json:"fetched_at"`
+	// Refs maps each tag or branch that resolved to this revision to when
+	// it last did, so an unreachable source can fall back to the revision
+	// a ref resolved to most recently.
+	Refs map[string]time.Time `json:"refs,omitempty"`
+}
+
+// fetchedSourceCacheKey returns the directory name, below a source type's
+// cache directory, of the revisions cached for one repository or artifact.
+func fetchedSourceCacheKey(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	return hex.EncodeToString(sum[:8])
+}
+
+func loadFetchedSourceMetadata(dir string) (*fetchedSourceMetadata, error) {
+	// #nosec G304 -- dir is a revision directory inside the rule source cache.
+	data, err := os.ReadFile(filepath.Join(dir, fetchedSourceMetadataFileName))
+	if err != nil {
+		return nil, err
+	}
+	var metadata fetchedSourceMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fetchedSourceMetadataFileName, err)
+	}
+	return &metadata, nil
+}
+
+func (m *fetchedSourceMetadata) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, fetchedSourceMetadataFileName), data, 0o600)
+}
+
+// cachedRevision returns the cached directory of revision below repoDir,
+// recording that ref resolved to it, or "" when the revision is not cached.
+func cachedRevision(repoDir, revision, ref string) string {
+	dir := filepath.Join(repoDir, revisionDirName(revision))
+	metadata, err := loadFetchedSourceMetadata(dir)
+	if err != nil {
+		return ""
+	}
+	recordRevisionUse(dir, metadata, ref)
+	return dir
+}
+
+// recordRevisionUse records that ref resolved to the revision cached in dir
+// and refreshes the directory's modification time, which `cache prune`
+// treats as its last use. Failures only cost the offline fallback.
+func recordRevisionUse(dir string, metadata *fetchedSourceMetadata, ref string) {
+	now := time.Now().UTC()
+	if ref != "" && ref != metadata.Revision {
+		if metadata.Refs == nil {
+			metadata.Refs = make(map[string]time.Time)
+		}
+		metadata.Refs[ref] = now
+		if err := metadata.save(dir); err != nil {
+			log.Debug().Err(err).Str("dir", dir).Msg("Failed to record rule source ref")
+		}
+	}
+	if err := os.Chtimes(dir, now, now); err != nil {
+		log.Debug().Err(err).Str("dir", dir).Msg("Failed to record rule source use")
+	}
+}
+
+// lastResolvedRevision returns the cached revision directory that ref
+// resolved to most recently, for use when the source is unreachable.
+func lastResolvedRevision(repoDir, ref string) (string, *fetchedSourceMetadata, bool) {
+	entries, err := os.ReadDir(repoDir)
+	if err != nil {
+		return "", nil, false
+	}
+	var bestDir string
+	var best *fetchedSourceMetadata
+	var bestAt time.Time
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dir := filepath.Join(repoDir, entry.Name())
+		metadata, err := loadFetchedSourceMetadata(dir)
+		if err != nil {
+			continue
+		}
+		if at, ok := metadata.Refs[ref]; ok && at.After(bestAt) {
+			bestDir, best, bestAt = dir, metadata, at
+		}
+	}
+	return bestDir, best, best != nil
+}
+
+// installRevision populates a staging directory below repoDir with populate,
+// writes its metadata, and renames it into place as the cached revision.
+// When a concurrent scan installed the revision first, its copy is kept.
+func installRevision(repoDir string, metadata *fetchedSourceMetadata, populate func(dir string) (string, error)) (string, error) {
+	if err := os.MkdirAll(repoDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create rule source cache directory: %w", err)
+	}
+	stagingDir, err := os.MkdirTemp(repoDir, ".fetch-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(stagingDir); err != nil {
+			log.Debug().Err(err).Str("path", stagingDir).Msg("Failed to clean up rule source staging directory")
+		}
+	}()
+
+	revision, err := populate(stagingDir)
+	if err != nil {
+		return "", err
+	}
+	metadata.Revision = revision
+	metadata.FetchedAt = time.Now().UTC()
+	if err := metadata.save(stagingDir); err != nil {
+		return "", fmt.Errorf("failed to write rule source metadata: %w", err)
+	}
+
+	targetDir := filepath.Join(repoDir, revisionDirName(revision))
+	if err := os.Rename(stagingDir, targetDir); err != nil {
+		if _, statErr := os.Stat(filepath.Join(targetDir, fetchedSourceMetadataFileName)); statErr == nil {
+			return targetDir, nil
+		}
+		return "", fmt.Errorf("failed to install rule source revision: %w", err)
+	}
+	return targetDir, nil
+}
+
+// revisionDirName returns the cache directory name of a revision: a commit
+// SHA as is, and a digest without its algorithm prefix.
+func revisionDirName(revision string) string {
+	if _, hexDigest, ok := strings.Cut(revision, ":"); ok {
+		return hexDigest
+	}
+	return revision
+}
+
+// collectRuleFiles returns the rule files below root. Hidden files and
+// directories (.git, .github, the source metadata) are skipped, so CI
+// configuration in a rules repository is not mistaken for rules.
+func collectRuleFiles(root string) ([]string, error) {
+	var ruleFiles []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && isRuleFileName(path) {
+			ruleFiles = append(ruleFiles, path)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("rules path does not exist")
+		}
+		return nil, err
+	}
+	if len(ruleFiles) == 0 {
+		return nil, fmt.Errorf("no rule files found (expected .yaml or .yml files)")
+	}
+	return ruleFiles, nil
+}
+
+func isRuleFileName(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,274 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rules
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// commitSHAPattern matches full SHA-1 and SHA-256 commit object names.
+var commitSHAPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// GitRuleSource loads rules from a git repository checked out at a pinned
+// ref. Checkouts are cached per commit, so a tag or commit is only fetched
+// once; the ref is resolved again by every new source so moved branches are
+// picked up, but only once per source, so everything that loads one source
+// (a --locked check, then the scan) sees the same commit. The git executable does the fetching, which makes the user's
+// SSH keys and credential helpers available for private repositories.
+type GitRuleSource struct {
+	ctx      context.Context
+	repoURL  string
+	ref      string
+	subdir   string
+	cacheDir string
+
+	// commit and loadedPaths record the first successful Load(), which later
+	// loads return.
+	commit      string
+	loadedPaths []string
+}
+
+// ParseGitRulesRef splits a --rules-git value of the form
+// <repository>@<ref>[#<subdir>] into its parts. The ref is required; an @
+// followed by a host (as in git@github.com:org/rules) does not count as one.
+func ParseGitRulesRef(value string) (repoURL, ref, subdir string, err error) {
+	spec, subdir, _ := strings.Cut(value, "#")
+	at := strings.LastIndex(spec, "@")
+	if at <= 0 || at == len(spec)-1 || strings.Contains(spec[at+1:], ":") {
+		return "", "", "", fmt.Errorf("invalid git rules reference %q (want <repository>@<ref>, e.g. https://github.com/org/rules@v1.4.0)", value)
+	}
+	repoURL, ref = spec[:at], spec[at+1:]
+	subdir = filepath.Clean(filepath.FromSlash(subdir))
+	if subdir == "." {
+		subdir = ""
+	}
+	if filepath.IsAbs(subdir) || subdir == ".." || strings.HasPrefix(subdir, ".."+string(filepath.Separator)) {
+		return "", "", "", fmt.Errorf("invalid git rules reference %q: subdirectory must be inside the repository", value)
+	}
+	return repoURL, ref, subdir, nil
+}
+
+// NewGitRuleSource creates a rule source for repoURL at ref, reading rules
+// from subdir ("" for the repository root). Checkouts are cached below
+// cacheDir.
+func NewGitRuleSource(ctx context.Context, repoURL, ref, subdir, cacheDir string) *GitRuleSource {
+	return &GitRuleSource{
+		ctx:      ctx,
+		repoURL:  repoURL,
+		ref:      ref,
+		subdir:   subdir,
+		cacheDir: cacheDir,
+	}
+}
+
+// Load resolves the ref to a commit, checks the commit out into the cache
+// unless it is already there, and returns the rule files of the checkout.
+// When the repository is unreachable, the commit the ref resolved to most
+// recently is used from the cache, with a warning. Once a load succeeds,
+// later loads return its rule files without resolving the ref again.
+func (g *GitRuleSource) Load() ([]string, error) {
+	if g.loadedPaths != nil {
+		return g.loadedPaths, nil
+	}
+	repoDir := filepath.Join(g.cacheDir, fetchedSourceCacheKey(g.repoURL))
+
+	checkoutDir, err := g.checkout(repoDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git rules %s: %w", g.Name(), err)
+	}
+
+	ruleFiles, err := collectRuleFiles(filepath.Join(checkoutDir, g.subdir))
+	if err != nil {
+		return nil, fmt.Errorf("invalid git rules %s: %w", g.Name(), err)
+	}
+	g.loadedPaths = ruleFiles
+	return ruleFiles, nil
+}
+
+func (g *GitRuleSource) checkout(repoDir string) (string, error) {
+	commit := g.ref
+	if !commitSHAPattern.MatchString(g.ref) {
+		resolved, err := g.resolveRef()
+		if err != nil {
+			dir, metadata, ok := lastResolvedRevision(repoDir, g.ref)
+			if !ok {
+				return "", err
+			}
+			log.Warn().
+				Err(err).
+				Str("repository", redactURL(g.repoURL)).
+				Str("ref", g.ref).
+				Str("commit", metadata.Revision).
+				Msg("Git repository unreachable, using the cached commit the ref last resolved to")
+			g.commit = metadata.Revision
+			return dir, nil
+		}
+		commit = resolved
+	}
+
+	if dir := cachedRevision(repoDir, commit, g.ref); dir != "" {
+		g.commit = commit
+		return dir, nil
+	}
+
+	metadata := &fetchedSourceMetadata{Location: redactURL(g.repoURL)}
+	dir, err := installRevision(repoDir, metadata, func(stagingDir string) (string, error) {
+		return g.fetch(stagingDir, commit)
+	})
+	if err != nil {
+		return "", err
+	}
+	recordRevisionUse(dir, metadata, g.ref)
+	g.commit = metadata.Revision
+	log.Info().
+		Str("repository", redactURL(g.repoURL)).
+		Str("ref", g.ref).
+		Str("commit", metadata.Revision).
+		Msg("Fetched git rules")
+	return dir, nil
+}
+
+// resolveRef resolves the ref with git ls-remote, preferring the commit an
+// annotated tag points to, then tags, then branches, then any other ref
+// with that name (e.g. refs/heads/main or HEAD).
+func (g *GitRuleSource) resolveRef() (string, error) {
+	output, err := g.git("", "ls-remote", "--", g.repoURL, g.ref)
+	if err != nil {
+		return "", err
+	}
+
+	candidates := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		sha, name, ok := strings.Cut(scanner.Text(), "\t")
+		if ok {
+			candidates[name] = sha
+		}
+	}
+	for _, name := range []string{
+		"refs/tags/" + g.ref + "^{}",
+		"refs/tags/" + g.ref,
+		"refs/heads/" + g.ref,
+		g.ref,
+	} {
+		if sha, ok := candidates[name]; ok {
+			return sha, nil
+		}
+	}
+	return "", fmt.Errorf("ref %s not found in %s", g.ref, redactURL(g.repoURL))
+}
+
+// fetch checks commit out into dir without git metadata and returns the
+// commit that was checked out. Named refs are fetched by name, which every
+// server allows, and the fetched commit is what gets cached.
+func (g *GitRuleSource) fetch(dir, commit string) (string, error) {
+	fetchRef := commit
+	if !commitSHAPattern.MatchString(g.ref) {
+		fetchRef = g.ref
+	}
+	steps := [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth", "1", "--", g.repoURL, fetchRef},
+		{"-c", "advice.detachedHead=false", "checkout", "--quiet", "FETCH_HEAD"},
+	}
+	for _, args := range steps {
+		if _, err := g.git(dir, args...); err != nil {
+			return "", err
+		}
+	}
+	output, err := g.git(dir, "rev-parse", "FETCH_HEAD^{commit}")
+	if err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		return "", fmt.Errorf("failed to remove git metadata: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// git runs a git command in dir without prompting for credentials, so an
+// unattended scan fails instead of hanging.
+func (g *GitRuleSource) git(dir string, args ...string) ([]byte, error) {
+	// #nosec G204 -- git is run without a shell; repository and ref are passed after "--" or as fetch arguments.
+	cmd := exec.CommandContext(g.ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(redactURLsIn(stderr.String(), g.repoURL)))
+	}
+	return output, nil
+}
+
+// Name returns a human-readable identifier for this source.
+func (g *GitRuleSource) Name() string {
+	name := fmt.Sprintf("git:%s@%s", redactURL(g.repoURL), g.ref)
+	if g.subdir != "" {
+		name += "#" + filepath.ToSlash(g.subdir)
+	}
+	return name
+}
+
+// Info returns the repository, the requested ref and the commit it
+// resolved to, with a content fingerprint of the loaded rule files computed
+// as for local rules. Before Load() it returns the zero RulesInfo.
+func (g *GitRuleSource) Info() entities.RulesInfo {
+	if len(g.loadedPaths) == 0 {
+		return entities.RulesInfo{}
+	}
+	return entities.RulesInfo{
+		Source:         "git",
+		Name:           redactURL(g.repoURL),
+		Version:        g.ref,
+		Revision:       g.commit,
+		ChecksumSHA256: hashRuleFiles(g.loadedPaths),
+	}
+}
+
+// redactURL removes credentials embedded in a URL (including tokens passed
+// as the user name), which must not end up in logs or reports. Other values
+// are returned as is.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
+
+func redactURLsIn(text, raw string) string {
+	if redacted := redactURL(raw); redacted != raw {
+		return strings.ReplaceAll(text, raw, redacted)
+	}
+	return text
+}
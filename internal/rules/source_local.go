@@ -230,8 +230,18 @@ func (l *LocalRuleSource) Info() entities.RulesInfo {
 	if len(l.loadedPaths) == 0 {
 		return entities.RulesInfo{}
 	}
-	paths := make([]string, len(l.loadedPaths))
-	copy(paths, l.loadedPaths)
+	return entities.RulesInfo{
+		Source:         "local",
+		ChecksumSHA256: hashRuleFiles(l.loadedPaths),
+	}
+}
+
+// hashRuleFiles returns the content fingerprint of rule files described on
+// LocalRuleSource.Info. Unreadable files are skipped with a warning; an
+// empty string is returned if hashing itself fails.
+func hashRuleFiles(rulePaths []string) string {
+	paths := make([]string, len(rulePaths))
+	copy(paths, rulePaths)
 	sort.Strings(paths)
 
 	h := sha256.New()
@@ -245,15 +255,12 @@ func (l *LocalRuleSource) Info() entities.RulesInfo {
 		// with a single file containing their concatenation.
 		if _, err := fmt.Fprintf(h, "%d:", len(data)); err != nil {
 			log.Warn().Err(err).Str("rule_path", p).Msg("RulesInfo: failed to hash rule file size prefix")
-			return entities.RulesInfo{Source: "local"}
+			return ""
 		}
 		if _, err := h.Write(data); err != nil {
 			log.Warn().Err(err).Str("rule_path", p).Msg("RulesInfo: failed to hash rule file contents")
-			return entities.RulesInfo{Source: "local"}
+			return ""
 		}
 	}
-	return entities.RulesInfo{
-		Source:         "local",
-		ChecksumSHA256: hex.EncodeToString(h.Sum(nil)),
-	}
+	return hex.EncodeToString(h.Sum(nil))
 }
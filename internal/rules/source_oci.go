@@ -0,0 +1,629 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rules
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+const (
+	ociManifestMediaType       = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType    = "application/vnd.docker.distribution.manifest.v2+json"
+	ociTitleAnnotation         = "org.opencontainers.image.title"
+	maxOCIManifestSize         = 4 << 20
+	maxOCIBlobSize             = 256 << 20
+	defaultOCIRegistryTimeout  = 5 * time.Minute
+	defaultOCIReferenceTag     = "latest"
+	ociDockerConfigFileName    = "config.json"
+	ociDockerConfigDirEnv      = "DOCKER_CONFIG"
+	ociDockerConfigDefaultPath = ".docker"
+)
+
+var (
+	ociRepositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	ociTagPattern        = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+	ociDigestPattern     = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+)
+
+// OCIReference identifies a rules artifact in an OCI registry.
+type OCIReference struct {
+	Registry   string
+	Repository string
+	// Tag is empty when the artifact is referenced by Digest only.
+	Tag    string
+	Digest string
+}
+
+// ParseOCIReference parses a --rules-oci value of the form
+// <registry>/<repository>[:<tag>][@<digest>]. The registry host is
+// required; without a tag or digest the tag defaults to "latest".
+func ParseOCIReference(value string) (OCIReference, error) {
+	invalid := func(reason string) (OCIReference, error) {
+		return OCIReference{}, fmt.Errorf("invalid OCI rules reference %q: %s (want <registry>/<repository>:<tag>, e.g. ghcr.io/org/rules:v1.4.0)", value, reason)
+	}
+
+	spec, digest, hasDigest := strings.Cut(strings.TrimPrefix(value, "oci://"), "@")
+	if hasDigest && !ociDigestPattern.MatchString(digest) {
+		return invalid("digest must be sha256:<64 hex digits>")
+	}
+	registry, repository, ok := strings.Cut(spec, "/")
+	if !ok || !(strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		return invalid("the registry host is missing")
+	}
+	tag := ""
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+		if !ociTagPattern.MatchString(tag) {
+			return invalid("malformed tag")
+		}
+	}
+	if !ociRepositoryPattern.MatchString(repository) {
+		return invalid("malformed repository name")
+	}
+	if tag == "" && digest == "" {
+		tag = defaultOCIReferenceTag
+	}
+	return OCIReference{Registry: registry, Repository: repository, Tag: tag, Digest: digest}, nil
+}
+
+// String returns the reference in the form it is parsed from.
+func (r OCIReference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// OCIRuleSource loads rules from an artifact in an OCI registry, as pushed
+// with e.g. `oras push`. Artifacts are cached per manifest digest, so an
+// artifact referenced by digest is only pulled once; tags are resolved
+// again by every new source, but only once per source, so everything that
+// loads one source (a --locked check, then the scan) sees the same digest.
+//
+// Layers that are tar archives (optionally gzip-compressed) are extracted;
+// other layers are saved under their org.opencontainers.image.title
+// annotation. Registry credentials are read from the Docker config file
+// ($DOCKER_CONFIG/config.json or ~/.docker/config.json); credential
+// helpers are not supported. Registries on loopback hosts are reached over
+// plain HTTP, all others over HTTPS; credentials are only sent to a token
+// realm over HTTPS, or over HTTP for a loopback registry.
+type OCIRuleSource struct {
+	ctx        context.Context
+	ref        OCIReference
+	cacheDir   string
+	httpClient *http.Client
+
+	// digest and loadedPaths record the first successful Load(), which later
+	// loads return.
+	digest      string
+	loadedPaths []string
+}
+
+// NewOCIRuleSource creates a rule source for the artifact ref, cached below
+// cacheDir.
+func NewOCIRuleSource(ctx context.Context, ref OCIReference, cacheDir string) *OCIRuleSource {
+	return &OCIRuleSource{
+		ctx:        ctx,
+		ref:        ref,
+		cacheDir:   cacheDir,
+		httpClient: &http.Client{Timeout: defaultOCIRegistryTimeout},
+	}
+}
+
+// Load resolves the reference to a manifest digest, pulls the artifact into
+// the cache unless it is already there, and returns its rule files. When
+// the registry is unreachable, the digest a tag resolved to most recently
+// is used from the cache, with a warning. Once a load succeeds, later loads
+// return its rule files without resolving the tag again.
+func (o *OCIRuleSource) Load() ([]string, error) {
+	if o.loadedPaths != nil {
+		return o.loadedPaths, nil
+	}
+	repoDir := filepath.Join(o.cacheDir, fetchedSourceCacheKey(o.ref.Registry+"/"+o.ref.Repository))
+
+	artifactDir, err := o.pull(repoDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OCI rules %s: %w", o.ref, err)
+	}
+	ruleFiles, err := collectRuleFiles(artifactDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI rules %s: %w", o.ref, err)
+	}
+	o.loadedPaths = ruleFiles
+	return ruleFiles, nil
+}
+
+func (o *OCIRuleSource) pull(repoDir string) (string, error) {
+	if o.ref.Digest != "" {
+		if dir := cachedRevision(repoDir, o.ref.Digest, o.ref.Tag); dir != "" {
+			o.digest = o.ref.Digest
+			return dir, nil
+		}
+	}
+
+	client := &ociRegistryClient{httpClient: o.httpClient, registry: o.ref.Registry, repository: o.ref.Repository}
+	manifest, digest, err := client.manifest(o.ctx, o.reference())
+	if err != nil {
+		if o.ref.Digest == "" {
+			if dir, metadata, ok := lastResolvedRevision(repoDir, o.ref.Tag); ok {
+				log.Warn().
+					Err(err).
+					Str("artifact", o.ref.String()).
+					Str("digest", metadata.Revision).
+					Msg("OCI registry unreachable, using the cached digest the tag last resolved to")
+				o.digest = metadata.Revision
+				return dir, nil
+			}
+		}
+		return "", err
+	}
+	if o.ref.Digest != "" && digest != o.ref.Digest {
+		return "", fmt.Errorf("registry returned manifest %s for digest %s", digest, o.ref.Digest)
+	}
+
+	if dir := cachedRevision(repoDir, digest, o.ref.Tag); dir != "" {
+		o.digest = digest
+		return dir, nil
+	}
+
+	metadata := &fetchedSourceMetadata{Location: o.ref.Registry + "/" + o.ref.Repository}
+	dir, err := installRevision(repoDir, metadata, func(stagingDir string) (string, error) {
+		for _, layer := range manifest.Layers {
+			if err := client.pullLayer(o.ctx, layer, stagingDir); err != nil {
+				return "", err
+			}
+		}
+		return digest, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	recordRevisionUse(dir, metadata, o.ref.Tag)
+	o.digest = digest
+	log.Info().
+		Str("artifact", o.ref.String()).
+		Str("digest", digest).
+		Int("layers", len(manifest.Layers)).
+		Msg("Pulled OCI rules")
+	return dir, nil
+}
+
+// reference returns the manifest reference to request: the digest when
+// pinned, otherwise the tag.
+func (o *OCIRuleSource) reference() string {
+	if o.ref.Digest != "" {
+		return o.ref.Digest
+	}
+	return o.ref.Tag
+}
+
+// Name returns a human-readable identifier for this source.
+func (o *OCIRuleSource) Name() string {
+	return "oci:" + o.ref.String()
+}
+
+// Info returns the repository, the tag (or digest, when pulled by digest)
+// and the manifest digest it resolved to, with a content fingerprint of the
+// loaded rule files computed as for local rules. Before Load() it returns
+// the zero RulesInfo.
+func (o *OCIRuleSource) Info() entities.RulesInfo {
+	if len(o.loadedPaths) == 0 {
+		return entities.RulesInfo{}
+	}
+	version := o.ref.Tag
+	if version == "" {
+		version = o.ref.Digest
+	}
+	return entities.RulesInfo{
+		Source:         "oci",
+		Name:           o.ref.Registry + "/" + o.ref.Repository,
+		Version:        version,
+		Revision:       o.digest,
+		ChecksumSHA256: hashRuleFiles(o.loadedPaths),
+	}
+}
+
+// ociManifest is the subset of an OCI image manifest (or Docker schema 2
+// manifest) needed to pull an artifact's layers.
+type ociManifest struct {
+	MediaType string            `json:"mediaType"`
+	Layers    []ociDescriptor   `json:"layers"`
+	Manifests []json.RawMessage `json:"manifests"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// ociRegistryClient speaks the subset of the OCI distribution API needed to
+// pull an artifact: manifests and blobs, with anonymous or Docker-config
+// credentials for Basic and Bearer token authentication.
+type ociRegistryClient struct {
+	httpClient *http.Client
+	registry   string
+	repository string
+	// authorization is the Authorization header value that satisfied the
+	// registry's last challenge, reused for the following requests.
+	authorization string
+}
+
+// manifest fetches the manifest for reference and returns it with its digest.
+func (c *ociRegistryClient) manifest(ctx context.Context, reference string) (*ociManifest, string, error) {
+	resp, err := c.get(ctx, "manifests/"+reference, ociManifestMediaType+", "+dockerManifestMediaType)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOCIManifestSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if len(body) > maxOCIManifestSize {
+		return nil, "", fmt.Errorf("manifest exceeds %d bytes", maxOCIManifestSize)
+	}
+	sum := sha256.Sum256(body)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	var manifest ociManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(manifest.Manifests) > 0 {
+		return nil, "", fmt.Errorf("%s is an image index, not a rules artifact", reference)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, "", fmt.Errorf("manifest %s has no layers", digest)
+	}
+	return &manifest, digest, nil
+}
+
+// pullLayer downloads a layer, verifies it against its digest and unpacks
+// it into dir.
+func (c *ociRegistryClient) pullLayer(ctx context.Context, layer ociDescriptor, dir string) error {
+	if !ociDigestPattern.MatchString(layer.Digest) {
+		return fmt.Errorf("unsupported layer digest %q", layer.Digest)
+	}
+	if layer.Size > maxOCIBlobSize {
+		return fmt.Errorf("layer %s exceeds %d bytes", layer.Digest, maxOCIBlobSize)
+	}
+	resp, err := c.get(ctx, "blobs/"+layer.Digest, "")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxOCIBlobSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read layer %s: %w", layer.Digest, err)
+	}
+	sum := sha256.Sum256(data)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != layer.Digest {
+		return fmt.Errorf("layer digest mismatch: expected %s, got %s", layer.Digest, got)
+	}
+
+	if strings.Contains(layer.MediaType, "tar") {
+		if err := extractRulesTar(data, dir); err != nil {
+			return fmt.Errorf("failed to extract layer %s: %w", layer.Digest, err)
+		}
+		return nil
+	}
+	title := layer.Annotations[ociTitleAnnotation]
+	if title == "" {
+		log.Debug().Str("digest", layer.Digest).Str("media_type", layer.MediaType).Msg("Skipping OCI layer without a title")
+		return nil
+	}
+	path, err := safeArchivePath(dir, title)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// get issues a GET against the repository's registry API path, answering
+// one authentication challenge if the registry sends one.
+func (c *ociRegistryClient) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	requestURL := fmt.Sprintf("%s://%s/v2/%s/%s", registryScheme(c.registry), c.registry, c.repository, path)
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+		return c.httpClient.Do(req)
+	}
+
+	resp, err := do()
+	if err != nil {
+		return nil, fmt.Errorf("registry request failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if c.authorization, err = c.authorize(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = do(); err != nil {
+			return nil, fmt.Errorf("registry request failed: %w", err)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("registry returned %s for %s", resp.Status, path)
+	}
+	return resp, nil
+}
+
+// authorize answers a WWW-Authenticate challenge and returns the
+// Authorization header value for the following requests.
+func (c *ociRegistryClient) authorize(ctx context.Context, challenge string) (string, error) {
+	username, password, hasCredentials := dockerConfigCredentials(c.registry)
+	scheme, params := parseAuthChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCredentials {
+			return "", fmt.Errorf("registry %s requires credentials (docker login %s)", c.registry, c.registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	case "bearer":
+		realm := params["realm"]
+		if realm == "" {
+			return "", fmt.Errorf("registry %s sent a bearer challenge without realm", c.registry)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm, http.NoBody)
+		if err != nil {
+			return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+		}
+		// The realm receives the registry credentials, so it must be as
+		// private as the registry: HTTPS, unless the registry is itself
+		// reached over plain HTTP on a loopback host.
+		if req.URL.Scheme != "https" && (req.URL.Scheme != "http" || registryScheme(c.registry) != "http") {
+			return "", fmt.Errorf("registry %s sent token realm %q, which is not an https URL", c.registry, realm)
+		}
+		query := req.URL.Query()
+		if service := params["service"]; service != "" {
+			query.Set("service", service)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = "repository:" + c.repository + ":pull"
+		}
+		query.Set("scope", scope)
+		req.URL.RawQuery = query.Encode()
+		if hasCredentials {
+			req.SetBasicAuth(username, password)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("registry token request failed: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("registry token request returned %s", resp.Status)
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxOCIManifestSize)).Decode(&token); err != nil {
+			return "", fmt.Errorf("failed to parse registry token: %w", err)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return "", errors.New("registry token response has no token")
+		}
+		return "Bearer " + token.Token, nil
+	default:
+		return "", fmt.Errorf("registry %s returned 401 Unauthorized with unsupported challenge %q", c.registry, challenge)
+	}
+}
+
+// parseAuthChallenge splits a WWW-Authenticate header such as
+// `Bearer realm="https://auth.example.com/token",service="registry"` into
+// its scheme and parameters.
+func parseAuthChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+	return scheme, params
+}
+
+// registryScheme returns "http" for registries on loopback hosts, which are
+// commonly run without TLS, and "https" for everything else.
+func registryScheme(registry string) string {
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return "https"
+}
+
+// dockerConfigCredentials returns the credentials `docker login` stored for
+// registry in the Docker config file.
+func dockerConfigCredentials(registry string) (string, string, bool) {
+	configDir := os.Getenv(ociDockerConfigDirEnv)
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", false
+		}
+		configDir = filepath.Join(home, ociDockerConfigDefaultPath)
+	}
+	// #nosec G304 -- the Docker config path comes from DOCKER_CONFIG or the user's home directory.
+	data, err := os.ReadFile(filepath.Join(configDir, ociDockerConfigFileName))
+	if err != nil {
+		return "", "", false
+	}
+	var config struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		log.Debug().Err(err).Msg("Ignoring unparseable Docker config")
+		return "", "", false
+	}
+	for _, key := range []string{registry, "https://" + registry, "http://" + registry} {
+		entry, ok := config.Auths[key]
+		if !ok {
+			continue
+		}
+		if entry.Username != "" {
+			return entry.Username, entry.Password, true
+		}
+		if decoded, err := base64.StdEncoding.DecodeString(entry.Auth); err == nil {
+			if username, password, ok := strings.Cut(string(decoded), ":"); ok {
+				return username, password, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// extractRulesTar unpacks a tar archive, gzip-compressed or not, into dir.
+// Only regular files and directories are extracted; other entry types are
+// skipped.
+func extractRulesTar(data []byte, dir string) error {
+	reader := bufio.NewReader(bytes.NewReader(data))
+	var r io.Reader = reader
+	if magic, err := reader.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path, err := safeArchivePath(dir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				return err
+			}
+			if err := writeArchiveFile(path, tr); err != nil {
+				return err
+			}
+		default:
+			log.Debug().Str("entry", header.Name).Msg("Skipping non-regular OCI layer entry")
+		}
+	}
+}
+
+func writeArchiveFile(path string, r io.Reader) error {
+	// #nosec G304 -- path is validated by safeArchivePath to stay inside the staging directory.
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, io.LimitReader(r, maxOCIBlobSize)); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// safeArchivePath joins an archive entry name to dir, rejecting names that
+// would resolve outside of it.
+func safeArchivePath(dir, name string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry %q escapes the artifact directory", name)
+	}
+	return filepath.Join(dir, clean), nil
+}
@@ -0,0 +1,379 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package rules
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// testRegistry is a stand-in for an OCI registry serving one repository.
+// It requires a bearer token, which its token endpoint issues for the
+// credentials user/secret.
+type testRegistry struct {
+	server     *httptest.Server
+	repository string
+	manifests  map[string][]byte
+	blobs      map[string][]byte
+}
+
+func newTestRegistry(t *testing.T, repository string) *testRegistry {
+	t.Helper()
+	r := &testRegistry{repository: repository, manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.URL.Query().Get("scope") != "repository:"+r.repository+":pull" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "pull-token"})
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer pull-token" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.server.URL+`/token",service="test-registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	prefix := "/v2/" + r.repository + "/"
+	rest, ok := strings.CutPrefix(req.URL.Path, prefix)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	if reference, ok := strings.CutPrefix(rest, "manifests/"); ok {
+		if manifest, ok := r.manifests[reference]; ok {
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			_, _ = w.Write(manifest)
+			return
+		}
+	}
+	if digest, ok := strings.CutPrefix(rest, "blobs/"); ok {
+		if blob, ok := r.blobs[digest]; ok {
+			_, _ = w.Write(blob)
+			return
+		}
+	}
+	http.NotFound(w, req)
+}
+
+// push stores an artifact with the given layers under tag and returns its
+// manifest digest.
+func (r *testRegistry) push(t *testing.T, tag string, layers []ociDescriptor, blobs [][]byte) string {
+	t.Helper()
+	for i, blob := range blobs {
+		layers[i].Digest = testDigest(blob)
+		layers[i].Size = int64(len(blob))
+		r.blobs[layers[i].Digest] = blob
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     ociManifestMediaType,
+		"artifactType":  "application/vnd.scanoss.crypto-finder.rules",
+		"layers":        layers,
+	})
+	if err != nil {
+		t.Fatalf("marshal manifest: %v", err)
+	}
+	digest := testDigest(manifest)
+	r.manifests[tag] = manifest
+	r.manifests[digest] = manifest
+	return digest
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+func testDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func testRulesTarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(files[name])), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(files[name])); err != nil {
+			t.Fatalf("write tar content: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// setTestDockerLogin writes a Docker config holding credentials for registry.
+func setTestDockerLogin(t *testing.T, registry string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv(ociDockerConfigDirEnv, dir)
+	config := `{"auths":{"` + registry + `":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("user:secret")) + `"}}}`
+	if err := os.WriteFile(filepath.Join(dir, ociDockerConfigFileName), []byte(config), 0o600); err != nil {
+		t.Fatalf("write docker config: %v", err)
+	}
+}
+
+func pushTestRulesArtifact(t *testing.T, registry *testRegistry, tag, ruleID string) string {
+	t.Helper()
+	return registry.push(t, tag,
+		[]ociDescriptor{
+			{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip"},
+			{MediaType: "application/vnd.scanoss.crypto-finder.rule.v1+yaml", Annotations: map[string]string{ociTitleAnnotation: "extra.yaml"}},
+		},
+		[][]byte{
+			testRulesTarGz(t, map[string]string{
+				"rules/java/crypto.yaml": "rules:\n  - id: " + ruleID + "\n",
+				"rules/.ci/lint.yml":     "on: push\n",
+			}),
+			[]byte("rules:\n  - id: extra\n"),
+		})
+}
+
+func TestOCIRuleSource_Load(t *testing.T) {
+	registry := newTestRegistry(t, "org/crypto-rules")
+	setTestDockerLogin(t, registry.host())
+	digest := pushTestRulesArtifact(t, registry, "v1.4.0", "java.crypto.v1")
+	cacheDir := t.TempDir()
+	ctx := context.Background()
+
+	ref, err := ParseOCIReference(registry.host() + "/org/crypto-rules:v1.4.0")
+	if err != nil {
+		t.Fatalf("ParseOCIReference() error = %v", err)
+	}
+	source := NewOCIRuleSource(ctx, ref, cacheDir)
+	paths, err := source.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var names []string
+	for _, path := range paths {
+		names = append(names, filepath.Base(path))
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "crypto.yaml,extra.yaml" {
+		t.Fatalf("Load() = %v, want crypto.yaml and extra.yaml", paths)
+	}
+
+	info := source.Info()
+	if info.Source != "oci" || info.Name != registry.host()+"/org/crypto-rules" || info.Version != "v1.4.0" || info.Revision != digest || len(info.ChecksumSHA256) != 64 {
+		t.Errorf("Info() = %+v, want oci source at %s", info, digest)
+	}
+
+	// Moving the tag pulls the new artifact into new sources; a source
+	// already loaded keeps the digest it resolved.
+	movedDigest := pushTestRulesArtifact(t, registry, "v1.4.0", "java.crypto.v2")
+	if _, err := source.Load(); err != nil {
+		t.Fatalf("second Load() error = %v", err)
+	}
+	if got := source.Info().Revision; got != digest {
+		t.Errorf("Info().Revision of a source loaded before the tag moved = %s, want %s", got, digest)
+	}
+	moved := NewOCIRuleSource(ctx, ref, cacheDir)
+	if _, err := moved.Load(); err != nil {
+		t.Fatalf("Load() after moving tag error = %v", err)
+	}
+	if got := moved.Info().Revision; got != movedDigest {
+		t.Errorf("Info().Revision after moving tag = %s, want %s", got, movedDigest)
+	}
+
+	// Without the registry, digests are served from the cache and tags fall
+	// back to the digest they resolved to last.
+	registry.server.Close()
+	byDigest, err := ParseOCIReference(registry.host() + "/org/crypto-rules@" + digest)
+	if err != nil {
+		t.Fatalf("ParseOCIReference() error = %v", err)
+	}
+	pinned := NewOCIRuleSource(ctx, byDigest, cacheDir)
+	if _, err := pinned.Load(); err != nil {
+		t.Fatalf("Load() of cached digest error = %v", err)
+	}
+	if info := pinned.Info(); info.Version != digest || info.Revision != digest {
+		t.Errorf("Info() of digest reference = %+v", info)
+	}
+	offline := NewOCIRuleSource(ctx, ref, cacheDir)
+	if _, err := offline.Load(); err != nil {
+		t.Fatalf("Load() of unreachable registry with cached tag error = %v", err)
+	}
+	if got := offline.Info().Revision; got != movedDigest {
+		t.Errorf("offline Info().Revision = %s, want %s", got, movedDigest)
+	}
+}
+
+func TestOCIRuleSource_Errors(t *testing.T) {
+	registry := newTestRegistry(t, "org/crypto-rules")
+	digest := pushTestRulesArtifact(t, registry, "v1.4.0", "java.crypto.v1")
+	ctx := context.Background()
+	load := func(reference string) error {
+		t.Helper()
+		ref, err := ParseOCIReference(reference)
+		if err != nil {
+			t.Fatalf("ParseOCIReference(%q) error = %v", reference, err)
+		}
+		_, err = NewOCIRuleSource(ctx, ref, t.TempDir()).Load()
+		return err
+	}
+
+	t.Setenv(ociDockerConfigDirEnv, t.TempDir())
+	if err := load(registry.host() + "/org/crypto-rules:v1.4.0"); err == nil || !strings.Contains(err.Error(), "token request returned 401") {
+		t.Errorf("Load() without credentials error = %v", err)
+	}
+
+	setTestDockerLogin(t, registry.host())
+	if err := load(registry.host() + "/org/crypto-rules:v9"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Load() of unknown tag error = %v", err)
+	}
+
+	// A blob that does not match its digest is refused.
+	var manifest ociManifest
+	if err := json.Unmarshal(registry.manifests[digest], &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	registry.blobs[manifest.Layers[1].Digest] = []byte("rules:\n  - id: tampered\n")
+	if err := load(registry.host() + "/org/crypto-rules:v1.4.0"); err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Errorf("Load() of tampered layer error = %v", err)
+	}
+
+	// A layer escaping the artifact directory is refused.
+	registry.push(t, "evil",
+		[]ociDescriptor{{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip"}},
+		[][]byte{testRulesTarGz(t, map[string]string{"../escape.yaml": "rules: []\n"})})
+	if err := load(registry.host() + "/org/crypto-rules:evil"); err == nil || !strings.Contains(err.Error(), "escapes") {
+		t.Errorf("Load() of escaping layer error = %v", err)
+	}
+}
+
+// TestOCIRegistryClient_RefusesPlainHTTPRealm checks that registry
+// credentials are not sent to a plain HTTP token realm unless the registry
+// itself is a loopback host.
+func TestOCIRegistryClient_RefusesPlainHTTPRealm(t *testing.T) {
+	var sent bool
+	realm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _, sent = req.BasicAuth()
+		_, _ = w.Write([]byte(`{"token":"t"}`))
+	}))
+	t.Cleanup(realm.Close)
+	challenge := `Bearer realm="` + realm.URL + `/token",service="registry"`
+
+	setTestDockerLogin(t, "registry.example.com")
+	remote := &ociRegistryClient{httpClient: realm.Client(), registry: "registry.example.com", repository: "org/crypto-rules"}
+	if _, err := remote.authorize(context.Background(), challenge); err == nil || !strings.Contains(err.Error(), "not an https URL") {
+		t.Errorf("authorize() with a plain HTTP realm error = %v, want it refused", err)
+	}
+	if sent {
+		t.Error("registry credentials were sent to a plain HTTP realm")
+	}
+
+	host := strings.TrimPrefix(realm.URL, "http://")
+	setTestDockerLogin(t, host)
+	loopback := &ociRegistryClient{httpClient: realm.Client(), registry: host, repository: "org/crypto-rules"}
+	if got, err := loopback.authorize(context.Background(), challenge); err != nil || got != "Bearer t" {
+		t.Errorf("authorize() for a loopback registry = %q, %v, want its token", got, err)
+	}
+}
+
+func TestParseOCIReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		value   string
+		want    OCIReference
+		wantErr bool
+	}{
+		{value: "ghcr.io/org/rules:v1.4.0", want: OCIReference{Registry: "ghcr.io", Repository: "org/rules", Tag: "v1.4.0"}},
+		{value: "oci://registry.example.com:5000/team/crypto/rules", want: OCIReference{Registry: "registry.example.com:5000", Repository: "team/crypto/rules", Tag: "latest"}},
+		{value: "localhost/rules@" + digest, want: OCIReference{Registry: "localhost", Repository: "rules", Digest: digest}},
+		{value: "ghcr.io/org/rules:v1@" + digest, want: OCIReference{Registry: "ghcr.io", Repository: "org/rules", Tag: "v1", Digest: digest}},
+		{value: "org/rules:v1", wantErr: true},
+		{value: "ghcr.io/Org/rules:v1", wantErr: true},
+		{value: "ghcr.io/org/rules:v1@sha256:abc", wantErr: true},
+		{value: "ghcr.io/org/rules:", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOCIReference(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseOCIReference(%q) = %+v, want error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseOCIReference(%q) = %+v, %v; want %+v", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestRegistryScheme(t *testing.T) {
+	for registry, want := range map[string]string{
+		"localhost":       "http",
+		"localhost:5000":  "http",
+		"127.0.0.1:38211": "http",
+		"[::1]:5000":      "http",
+		"ghcr.io":         "https",
+		"10.0.0.5:5000":   "https",
+	} {
+		if got := registryScheme(registry); got != want {
+			t.Errorf("registryScheme(%q) = %q, want %q", registry, got, want)
+		}
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/rules:pull"`)
+	if scheme != "Bearer" || params["realm"] != "https://auth.example.com/token" || params["service"] != "registry.example.com" || params["scope"] != "repository:org/rules:pull" {
+		t.Errorf("parseAuthChallenge() = %q, %v", scheme, params)
+	}
+	scheme, params = parseAuthChallenge(`Basic realm=registry`)
+	if scheme != "Basic" || params["realm"] != "registry" {
+		t.Errorf("parseAuthChallenge(Basic) = %q, %v", scheme, params)
+	}
+}
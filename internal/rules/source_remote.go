@@ -91,8 +91,8 @@ func (r *RemoteRuleSource) Name() string {
 	return fmt.Sprintf("remote:%s@%s", r.rulesetName, r.version)
 }
 
-// Info returns the ruleset version and checksum recorded in .cache-meta.json
-// alongside the cached ruleset. Call after Load(); before Load() it returns
+// Info returns the ruleset version, checksum and signature verification
+// result recorded in .cache-meta.json alongside the cached ruleset. Call after Load(); before Load() it returns
 // the zero RulesInfo.
 //
 // Failure modes (missing metadata file, parse error) are logged at warn but
@@ -117,5 +117,7 @@ func (r *RemoteRuleSource) Info() entities.RulesInfo {
 		Name:           meta.RulesetName,
 		Version:        meta.Version,
 		ChecksumSHA256: meta.ChecksumSHA256,
+		Signature:      meta.SignatureStatus,
+		SignatureKeyID: meta.SignatureKeyID,
 	}
 }
@@ -18,7 +18,11 @@ package rules
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
 )
 
 func TestMultiSource_Load_AggregatesSources(t *testing.T) {
@@ -280,3 +284,100 @@ func TestMultiSource_Load_Integration(t *testing.T) {
 		t.Errorf("Expected at least 2 rule paths, got %d", len(paths))
 	}
 }
+
+func TestMultiSource_Info_ListsSources(t *testing.T) {
+	t.Parallel()
+
+	remote := &mockRuleSource{infoFunc: func() entities.RulesInfo {
+		return entities.RulesInfo{Source: "remote", Name: "dca", Version: "v1.0.0", ChecksumSHA256: "abc", Signature: "verified"}
+	}}
+	unknown := &mockRuleSource{}
+	gitJava := &mockRuleSource{infoFunc: func() entities.RulesInfo {
+		return entities.RulesInfo{Source: "git", Name: "https://example.com/rules", Version: "v2", Revision: "c0ffee"}
+	}}
+	gitPython := &mockRuleSource{infoFunc: func() entities.RulesInfo {
+		return entities.RulesInfo{Source: "git", Name: "https://example.com/rules", Version: "v2", Revision: "c0ffee"}
+	}}
+	local := &mockRuleSource{infoFunc: func() entities.RulesInfo {
+		return entities.RulesInfo{Source: "local", ChecksumSHA256: "def"}
+	}}
+
+	info := NewMultiSource(unknown, remote, gitJava, gitPython, local).Info()
+	if info.Source != "remote" || info.Name != "dca" || info.Version != "v1.0.0" || info.Signature != "verified" {
+		t.Errorf("Info() top-level = %+v, want the remote ruleset", info)
+	}
+	want := []entities.RulesSourceInfo{
+		{ID: "remote:dca@v1.0.0", Source: "remote", Name: "dca", Version: "v1.0.0", ChecksumSHA256: "abc", Signature: "verified"},
+		{ID: "git:https://example.com/rules@v2", Source: "git", Name: "https://example.com/rules", Version: "v2", Revision: "c0ffee"},
+		{ID: "git:https://example.com/rules@v2#2", Source: "git", Name: "https://example.com/rules", Version: "v2", Revision: "c0ffee"},
+		{ID: "local", Source: "local", ChecksumSHA256: "def"},
+	}
+	if len(info.Sources) != len(want) {
+		t.Fatalf("Info().Sources = %+v, want %+v", info.Sources, want)
+	}
+	for i := range want {
+		if info.Sources[i] != want[i] {
+			t.Errorf("Info().Sources[%d] = %+v, want %+v", i, info.Sources[i], want[i])
+		}
+	}
+
+	if got := NewMultiSource(unknown).Info(); got.Source != "" || got.Sources != nil {
+		t.Errorf("Info() without known sources = %+v, want zero value", got)
+	}
+}
+
+func TestMultiSource_RuleOrigins(t *testing.T) {
+	t.Parallel()
+
+	vendorDir := t.TempDir()
+	writeRuleIDsFile(t, filepath.Join(vendorDir, "java", "cipher.yaml"), "java.crypto.cipher", "java.crypto.digest")
+	writeRuleIDsFile(t, filepath.Join(vendorDir, "go", "aes.yml"), "go.crypto.aes")
+	overrides := filepath.Join(t.TempDir(), "overrides.yaml")
+	writeRuleIDsFile(t, overrides, "java.crypto.digest", "java.crypto.custom")
+
+	vendor := &mockRuleSource{
+		loadFunc: func() ([]string, error) { return []string{vendorDir}, nil },
+		infoFunc: func() entities.RulesInfo { return entities.RulesInfo{Source: "remote", Name: "dca", Version: "latest"} },
+	}
+	local := &mockRuleSource{
+		loadFunc: func() ([]string, error) { return []string{overrides}, nil },
+		infoFunc: func() entities.RulesInfo { return entities.RulesInfo{Source: "local"} },
+	}
+	multiSource := NewMultiSource(vendor, local)
+	if got := multiSource.RuleOrigins(); len(got) != 0 {
+		t.Errorf("RuleOrigins() before Load() = %v, want empty", got)
+	}
+	if _, err := multiSource.Load(); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	want := map[string]string{
+		"java.crypto.cipher": "remote:dca@latest",
+		"go.crypto.aes":      "remote:dca@latest",
+		"java.crypto.digest": "local",
+		"java.crypto.custom": "local",
+	}
+	got := multiSource.RuleOrigins()
+	if len(got) != len(want) {
+		t.Fatalf("RuleOrigins() = %v, want %v", got, want)
+	}
+	for id, source := range want {
+		if got[id] != source {
+			t.Errorf("RuleOrigins()[%q] = %q, want %q", id, got[id], source)
+		}
+	}
+}
+
+func writeRuleIDsFile(t *testing.T, path string, ids ...string) {
+	t.Helper()
+	content := "rules:\n"
+	for _, id := range ids {
+		content += "  - id: " + id + "\n    message: test\n    severity: INFO\n"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("create rule dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write rule file: %v", err)
+	}
+}
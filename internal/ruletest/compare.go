@@ -0,0 +1,268 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package ruletest
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// FailureKind classifies a rule test failure.
+type FailureKind string
+
+const (
+	// KindMissing is an expectation no finding satisfies.
+	KindMissing FailureKind = "missing"
+	// KindUnexpected is a finding no expectation asks for.
+	KindUnexpected FailureKind = "unexpected"
+	// KindMetadata is an expected finding whose metadata differs.
+	KindMetadata FailureKind = "metadata"
+	// KindCondition is a parameterCondition predicate that does not parse.
+	KindCondition FailureKind = "condition"
+)
+
+// Failure is one rule test failure.
+type Failure struct {
+	Kind    FailureKind
+	File    string
+	Line    int
+	RuleID  string
+	Message string
+}
+
+// Result is the outcome of a rule test run.
+type Result struct {
+	Fixtures     int
+	Expectations int
+	Passed       int
+	// Materialized counts the conditioned findings added by
+	// MaterializeConditionedFindings before comparison.
+	Materialized int
+	Failures     []Failure
+	// Untested lists the rules no fixture has an expectation for.
+	Untested []string
+}
+
+// OK reports whether every expectation passed and no match was unexpected.
+func (r *Result) OK() bool {
+	return len(r.Failures) == 0
+}
+
+// metadataAliases maps expectation shorthands to the metadata keys they may
+// match, in order of preference.
+var metadataAliases = map[string][]string{
+	"algorithm": {"algorithmName", "algorithmFamily"},
+	"family":    {"algorithmFamily"},
+	"keySize":   {"keySize", "keyLength", "algorithmParameterSetIdentifier"},
+	"mode":      {"algorithmMode"},
+	"padding":   {"algorithmPadding"},
+	"primitive": {"algorithmPrimitive"},
+	"curve":     {"algorithmCurve", "curve"},
+}
+
+// MetadataValues returns the values of a finding an expectation key is
+// compared with: the metadata entry of that exact key, then the entries of
+// its shorthand aliases (algorithm, family, keySize, mode, padding,
+// primitive, curve). The key "oid" compares with the finding's OID.
+func MetadataValues(asset *entities.CryptographicAsset, key string) []string {
+	if key == "oid" {
+		if asset.OID == "" {
+			return nil
+		}
+		return []string{asset.OID}
+	}
+
+	var values []string
+	seen := make(map[string]bool)
+	for _, candidate := range append([]string{key}, metadataAliases[key]...) {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		if value, ok := asset.Metadata[candidate]; ok && value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// matchKey identifies the findings of one rule starting on one fixture line.
+type matchKey struct {
+	file   string
+	line   int
+	ruleID string
+}
+
+// Compare checks the findings of a report run over the fixtures against
+// their expectations. Report file paths are relative to the fixtures root.
+func Compare(report *entities.InterimReport, fixtures []Fixture, tested []Rule) *Result {
+	result := &Result{Fixtures: len(fixtures)}
+
+	known := make(map[string]Rule, len(tested))
+	for _, rule := range tested {
+		known[rule.ID] = rule
+	}
+
+	actual := make(map[matchKey][]*entities.CryptographicAsset)
+	if report != nil {
+		for i := range report.Findings {
+			finding := &report.Findings[i]
+			file := filepath.ToSlash(filepath.Clean(finding.FilePath))
+			for j := range finding.CryptographicAssets {
+				asset := &finding.CryptographicAssets[j]
+				for _, rule := range asset.Rules {
+					key := matchKey{file: file, line: asset.StartLine, ruleID: rule.ID}
+					actual[key] = append(actual[key], asset)
+				}
+			}
+		}
+	}
+
+	expected := make(map[matchKey]bool)
+	covered := make(map[string]bool)
+	for _, fixture := range fixtures {
+		for _, expectation := range fixture.Expectations {
+			result.Expectations++
+			covered[expectation.RuleID] = true
+			key := matchKey{file: expectation.File, line: expectation.Line, ruleID: expectation.RuleID}
+			expected[key] = true
+
+			if failure, ok := checkExpectation(expectation, actual[key], known); ok {
+				result.Passed++
+			} else {
+				result.Failures = append(result.Failures, failure)
+			}
+		}
+	}
+
+	for key, assets := range actual {
+		if expected[key] {
+			continue
+		}
+		result.Failures = append(result.Failures, Failure{
+			Kind:    KindUnexpected,
+			File:    key.file,
+			Line:    key.line,
+			RuleID:  key.ruleID,
+			Message: "unexpected match: " + snippet(assets[0].Match),
+		})
+	}
+
+	for _, rule := range tested {
+		if !covered[rule.ID] {
+			result.Untested = append(result.Untested, rule.ID)
+		}
+	}
+	sort.Strings(result.Untested)
+	result.Untested = slices.Compact(result.Untested)
+	sortFailures(result.Failures)
+	return result
+}
+
+// checkExpectation checks one expectation against the findings of its rule
+// on its line. It returns true when some finding matches every expected
+// metadata value, and otherwise the failure to report.
+func checkExpectation(expectation Expectation, assets []*entities.CryptographicAsset, known map[string]Rule) (Failure, bool) {
+	failure := Failure{File: expectation.File, Line: expectation.Line, RuleID: expectation.RuleID}
+
+	if len(assets) == 0 {
+		failure.Kind = KindMissing
+		rule, ok := known[expectation.RuleID]
+		switch {
+		case !ok:
+			failure.Message = "no match: rule is not defined in the tested rules"
+		case rule.Conditioned:
+			failure.Message = "no match: conditioned rule did not materialize (selector unresolved or parameterCondition not satisfied)"
+		default:
+			failure.Message = "no match"
+		}
+		return failure, false
+	}
+
+	var mismatches []string
+	for _, asset := range assets {
+		mismatches = metadataMismatches(expectation.Metadata, asset)
+		if len(mismatches) == 0 {
+			return Failure{}, true
+		}
+	}
+	failure.Kind = KindMetadata
+	failure.Message = strings.Join(mismatches, "; ")
+	return failure, false
+}
+
+// metadataMismatches describes every expected metadata value the finding
+// does not carry. Values compare case-insensitively.
+func metadataMismatches(want map[string]string, asset *entities.CryptographicAsset) []string {
+	keys := make([]string, 0, len(want))
+	for key := range want {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var mismatches []string
+	for _, key := range keys {
+		values := MetadataValues(asset, key)
+		matched := false
+		for _, value := range values {
+			if strings.EqualFold(value, want[key]) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if len(values) == 0 {
+			mismatches = append(mismatches, fmt.Sprintf("%s: want %s, got nothing", key, want[key]))
+		} else {
+			mismatches = append(mismatches, fmt.Sprintf("%s: want %s, got %s", key, want[key], strings.Join(values, "|")))
+		}
+	}
+	return mismatches
+}
+
+func sortFailures(failures []Failure) {
+	sort.SliceStable(failures, func(i, j int) bool {
+		a, b := failures[i], failures[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Kind < b.Kind
+	})
+}
+
+// snippet collapses a matched code snippet to one short line.
+func snippet(match string) string {
+	const maxSnippetLength = 80
+	match = strings.Join(strings.Fields(match), " ")
+	if len(match) > maxSnippetLength {
+		match = match[:maxSnippetLength] + "..."
+	}
+	return "`" + match + "`"
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package ruletest
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func asset(line int, ruleID string, metadata map[string]string) entities.CryptographicAsset {
+	return entities.CryptographicAsset{
+		StartLine: line,
+		EndLine:   line,
+		Match:     "match on line",
+		Rules:     []entities.RuleInfo{{ID: ruleID}},
+		Metadata:  metadata,
+	}
+}
+
+// fixtureRun loads the test fixtures and rules with a report as a scanner
+// would produce it over them.
+func fixtureRun(t *testing.T) (*entities.InterimReport, []Fixture, []Rule) {
+	t.Helper()
+
+	fixtures, err := LoadFixtures("testdata/fixtures")
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	rules, err := LoadRules([]string{"testdata/fixtures"})
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+	report := &entities.InterimReport{Findings: []entities.Finding{
+		{FilePath: "Crypto.java", CryptographicAssets: []entities.CryptographicAsset{
+			asset(6, "java.crypto.cipher", map[string]string{"algorithmName": "AES-256-GCM", "algorithmFamily": "AES", "algorithmMode": "gcm"}),
+			asset(10, "java.crypto.cipher", map[string]string{"algorithmName": "DES"}),
+			asset(11, "java.crypto.cipher", map[string]string{"algorithmName": "RC2"}),
+		}},
+		{FilePath: "nested/hash.py", CryptographicAssets: []entities.CryptographicAsset{
+			asset(4, "python.crypto.hash", map[string]string{"algorithmName": "SHA-256"}),
+			asset(4, "python.crypto.md5", map[string]string{"algorithmName": "MD5"}),
+		}},
+	}}
+	return report, fixtures, rules
+}
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules([]string{"testdata/fixtures"})
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+	var conditioned []string
+	for _, rule := range rules {
+		if rule.Conditioned {
+			conditioned = append(conditioned, rule.ID)
+		}
+	}
+	if len(rules) != 4 {
+		t.Errorf("rules = %d, want 4", len(rules))
+	}
+	if !reflect.DeepEqual(conditioned, []string{"java.crypto.weak-cipher"}) {
+		t.Errorf("conditioned rules = %v, want [java.crypto.weak-cipher]", conditioned)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	result := Compare(fixtureRun(t))
+
+	if result.Fixtures != 2 || result.Expectations != 5 || result.Passed != 3 {
+		t.Errorf("result = %d fixtures, %d expectations, %d passed; want 2, 5, 3", result.Fixtures, result.Expectations, result.Passed)
+	}
+	if result.OK() {
+		t.Error("OK() = true, want failures")
+	}
+
+	type failureKey struct {
+		Kind   FailureKind
+		File   string
+		Line   int
+		RuleID string
+	}
+	var got []failureKey
+	for _, failure := range result.Failures {
+		got = append(got, failureKey{failure.Kind, failure.File, failure.Line, failure.RuleID})
+	}
+	want := []failureKey{
+		{KindMissing, "Crypto.java", 10, "java.crypto.weak-cipher"},
+		{KindMetadata, "Crypto.java", 11, "java.crypto.cipher"},
+		{KindUnexpected, "nested/hash.py", 4, "python.crypto.md5"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("failures =\n%+v\nwant\n%+v", got, want)
+	}
+	if msg := result.Failures[0].Message; !strings.Contains(msg, "did not materialize") {
+		t.Errorf("conditioned missing message = %q, want a materialization hint", msg)
+	}
+	if msg := result.Failures[1].Message; msg != "algorithm: want RC4, got RC2" {
+		t.Errorf("metadata message = %q", msg)
+	}
+	if !reflect.DeepEqual(result.Untested, []string{"python.crypto.md5"}) {
+		t.Errorf("Untested = %v, want [python.crypto.md5]", result.Untested)
+	}
+}
+
+func TestCompare_UnknownRule(t *testing.T) {
+	fixtures := []Fixture{{Path: "a.go", Expectations: []Expectation{{File: "a.go", Line: 3, RuleID: "go.crypto.typo"}}}}
+	result := Compare(&entities.InterimReport{}, fixtures, []Rule{{ID: "go.crypto.aes"}})
+
+	if len(result.Failures) != 1 || !strings.Contains(result.Failures[0].Message, "not defined") {
+		t.Errorf("failures = %+v, want one missing failure naming the unknown rule", result.Failures)
+	}
+}
+
+func TestMetadataValues(t *testing.T) {
+	finding := &entities.CryptographicAsset{
+		OID: "2.16.840.1.101.3.4.1.46",
+		Metadata: map[string]string{
+			"algorithmName":                   "AES-256-GCM",
+			"algorithmFamily":                 "AES",
+			"algorithmParameterSetIdentifier": "256",
+		},
+	}
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"algorithm", []string{"AES-256-GCM", "AES"}},
+		{"algorithmName", []string{"AES-256-GCM"}},
+		{"keySize", []string{"256"}},
+		{"oid", []string{"2.16.840.1.101.3.4.1.46"}},
+		{"mode", nil},
+	}
+	for _, tt := range tests {
+		if got := MetadataValues(finding, tt.key); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MetadataValues(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestCheckParameterConditions(t *testing.T) {
+	failures := CheckParameterConditions([]string{"testdata/bad-rules"})
+	if len(failures) != 2 {
+		t.Fatalf("failures = %+v, want one per malformed predicate", failures)
+	}
+	for i, ruleID := range []string{"java.crypto.bad-a", "java.crypto.bad-b"} {
+		if failures[i].Kind != KindCondition || !strings.Contains(failures[i].Message, ruleID) {
+			t.Errorf("failure %d = %+v, want a condition failure naming %s", i, failures[i], ruleID)
+		}
+	}
+
+	if failures := CheckParameterConditions([]string{"testdata/fixtures"}); failures != nil {
+		t.Errorf("valid predicates reported %+v", failures)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteText(&out, Compare(fixtureRun(t))); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		"FAIL  missing     Crypto.java:10",
+		"FAIL  metadata    Crypto.java:11",
+		"FAIL  unexpected  nested/hash.py:4",
+		"WARN  untested",
+		"FAIL: 3/5 expectations passed in 2 fixtures, 3 failures, 1 untested rules",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("output missing %q:\n%s", want, text)
+		}
+	}
+}
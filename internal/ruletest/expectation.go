@@ -0,0 +1,185 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+// Package ruletest checks detection rules against fixture files annotated
+// with expectation comments, for `crypto-finder rules test`.
+//
+// An expectation is a comment of the form
+//
+//	// expect: <rule-id> [key=value ...]
+//
+// using any of the comment markers //, /*, #, -- or ;. A comment on its own
+// line applies to the next non-blank line that is not itself an expectation;
+// a comment trailing code applies to its own line. Each key=value pair must
+// match the metadata of the finding (see MetadataValues for the accepted
+// keys).
+package ruletest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// expectComment matches an expectation comment and captures its body.
+var expectComment = regexp.MustCompile(`(?://|/\*|#|--|;)\s*expect:\s*(.*?)\s*(?:\*/)?\s*$`)
+
+// Expectation is one match a fixture expects a rule to produce.
+type Expectation struct {
+	// File is the fixture path relative to the fixtures root, slash-separated.
+	File string
+	// Line is the 1-based line the match must start on.
+	Line int
+	// RuleID is the rule expected to match.
+	RuleID string
+	// Metadata holds the expected metadata values by key.
+	Metadata map[string]string
+}
+
+// Fixture is a fixture file and the expectations annotated in it.
+type Fixture struct {
+	// Path is the fixture path relative to the fixtures root, slash-separated.
+	Path         string
+	Expectations []Expectation
+}
+
+// ParseExpectations extracts the expectation comments of one fixture file.
+// file is the fixture's slash-separated path, recorded on each expectation.
+func ParseExpectations(file string, content []byte) ([]Expectation, error) {
+	var expectations []Expectation
+	var pending []Expectation
+	pendingLine := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+
+		loc := expectComment.FindStringSubmatchIndex(text)
+		if loc == nil {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			for i := range pending {
+				pending[i].Line = line
+			}
+			expectations = append(expectations, pending...)
+			pending = nil
+			continue
+		}
+
+		expectation, err := parseExpectation(text[loc[2]:loc[3]])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+		}
+		expectation.File = file
+		if strings.TrimSpace(text[:loc[0]]) != "" {
+			expectation.Line = line
+			expectations = append(expectations, expectation)
+			continue
+		}
+		if len(pending) == 0 {
+			pendingLine = line
+		}
+		pending = append(pending, expectation)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", file, err)
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("%s:%d: expectation is not followed by a line of code", file, pendingLine)
+	}
+	return expectations, nil
+}
+
+// parseExpectation parses the body of an expectation comment:
+// a rule ID followed by key=value pairs.
+func parseExpectation(body string) (Expectation, error) {
+	fields := strings.Fields(body)
+	if len(fields) == 0 {
+		return Expectation{}, fmt.Errorf("expectation has no rule ID")
+	}
+
+	expectation := Expectation{RuleID: fields[0]}
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return Expectation{}, fmt.Errorf("malformed expectation %q for rule %s (want key=value)", field, fields[0])
+		}
+		if expectation.Metadata == nil {
+			expectation.Metadata = make(map[string]string)
+		}
+		expectation.Metadata[key] = value
+	}
+	return expectation, nil
+}
+
+// LoadFixtures reads every fixture under root and parses its expectations.
+// Rule files (.yaml/.yml) and hidden files and directories are skipped. A
+// fixture without expectations is kept: any match in it is unexpected.
+func LoadFixtures(root string) ([]Fixture, error) {
+	var fixtures []Fixture
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		name := d.Name()
+		if path != root && strings.HasPrefix(name, ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || isRuleFile(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		expectations, err := ParseExpectations(rel, content)
+		if err != nil {
+			return err
+		}
+		fixtures = append(fixtures, Fixture{Path: rel, Expectations: expectations})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].Path < fixtures[j].Path })
+	return fixtures, nil
+}
+
+func isRuleFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package ruletest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExpectations(t *testing.T) {
+	content := strings.Join([]string{
+		"import hashlib",
+		"",
+		"# expect: python.crypto.hash algorithm=SHA-256",
+		"",
+		"# expect: python.crypto.digest",
+		"digest = hashlib.sha256(b'data')",
+		"other = hashlib.md5(b'data')  # expect: python.crypto.md5",
+		"/* expect: c.crypto.aes keySize=128 */",
+		"AES_set_encrypt_key(key, 128, &aes);",
+		"-- expect: sql.crypto.digest",
+		"SELECT digest('x', 'sha1');",
+	}, "\n")
+
+	got, err := ParseExpectations("dir/hash.py", []byte(content))
+	if err != nil {
+		t.Fatalf("ParseExpectations() error = %v", err)
+	}
+	want := []Expectation{
+		{File: "dir/hash.py", Line: 6, RuleID: "python.crypto.hash", Metadata: map[string]string{"algorithm": "SHA-256"}},
+		{File: "dir/hash.py", Line: 6, RuleID: "python.crypto.digest"},
+		{File: "dir/hash.py", Line: 7, RuleID: "python.crypto.md5"},
+		{File: "dir/hash.py", Line: 9, RuleID: "c.crypto.aes", Metadata: map[string]string{"keySize": "128"}},
+		{File: "dir/hash.py", Line: 11, RuleID: "sql.crypto.digest"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExpectations() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseExpectations_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing rule ID", "// expect:\nfoo();", "a.go:1: expectation has no rule ID"},
+		{"malformed metadata", "// expect: rule.id keySize\nfoo();", "a.go:1: malformed expectation \"keySize\""},
+		{"dangling expectation", "foo();\n// expect: rule.id\n\n", "a.go:2: expectation is not followed by a line of code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExpectations("a.go", []byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseExpectations() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := LoadFixtures("testdata/fixtures")
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+
+	var paths []string
+	expectations := 0
+	for _, fixture := range fixtures {
+		paths = append(paths, fixture.Path)
+		expectations += len(fixture.Expectations)
+	}
+	if want := []string{"Crypto.java", "nested/hash.py"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("fixture paths = %v, want %v (rule files and hidden directories skipped)", paths, want)
+	}
+	if expectations != 5 {
+		t.Errorf("expectations = %d, want 5", expectations)
+	}
+}
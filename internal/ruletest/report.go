@@ -0,0 +1,55 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package ruletest
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// WriteText renders a result for terminals: one line per failure, one per
+// untested rule, then a summary line.
+func WriteText(w io.Writer, result *Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, failure := range result.Failures {
+		location := failure.File
+		if failure.Line > 0 {
+			location += ":" + strconv.Itoa(failure.Line)
+		}
+		if _, err := fmt.Fprintf(tw, "FAIL\t%s\t%s\t%s\t%s\n", failure.Kind, location, failure.RuleID, failure.Message); err != nil {
+			return err
+		}
+	}
+	for _, ruleID := range result.Untested {
+		if _, err := fmt.Fprintf(tw, "WARN\tuntested\t\t%s\tno fixture expects this rule\n", ruleID); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	status := "PASS"
+	if !result.OK() {
+		status = "FAIL"
+	}
+	_, err := fmt.Fprintf(w, "%s: %d/%d expectations passed in %d fixtures, %d failures, %d untested rules, %d conditioned findings materialized\n",
+		status, result.Passed, result.Expectations, result.Fixtures, len(result.Failures), len(result.Untested), result.Materialized)
+	return err
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package ruletest
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/scanoss/crypto-finder/internal/rules"
+)
+
+// Rule is a rule under test.
+type Rule struct {
+	ID   string
+	File string
+	// Conditioned is set for rules with a parameterCondition predicate. Their
+	// findings only exist once MaterializeConditionedFindings specializes a
+	// generic anchor against the call graph.
+	Conditioned bool
+}
+
+// ruleFile is a narrow representation of a semgrep rule file.
+type ruleFile struct {
+	Rules []struct {
+		ID       string `yaml:"id"`
+		Metadata struct {
+			Crypto struct {
+				ParameterCondition string `yaml:"parameterCondition"`
+			} `yaml:"crypto"`
+		} `yaml:"metadata"`
+	} `yaml:"rules"`
+}
+
+// LoadRules lists the rules declared in the given rule files or directories.
+func LoadRules(rulePaths []string) ([]Rule, error) {
+	var loaded []Rule
+	for _, file := range expandRuleFiles(rulePaths) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read rule file %s: %w", file, err)
+		}
+		var parsed ruleFile
+		if err := yaml.Unmarshal(data, &parsed); err != nil {
+			return nil, fmt.Errorf("parse rule file %s: %w", file, err)
+		}
+		for _, rule := range parsed.Rules {
+			if rule.ID == "" {
+				continue
+			}
+			loaded = append(loaded, Rule{
+				ID:          rule.ID,
+				File:        file,
+				Conditioned: strings.TrimSpace(rule.Metadata.Crypto.ParameterCondition) != "",
+			})
+		}
+	}
+	return loaded, nil
+}
+
+// CheckParameterConditions runs rules.ValidateParameterConditions and returns
+// one condition failure per malformed predicate.
+func CheckParameterConditions(rulePaths []string) []Failure {
+	err := rules.ValidateParameterConditions(rulePaths)
+	if err == nil {
+		return nil
+	}
+	var failures []Failure
+	for _, cause := range flattenJoined(err) {
+		failures = append(failures, Failure{Kind: KindCondition, Message: cause.Error()})
+	}
+	return failures
+}
+
+// flattenJoined splits an errors.Join tree into its leaf errors.
+func flattenJoined(err error) []error {
+	// Only the Join tree itself is split; wrapped causes keep their context.
+	joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint // see above
+	if !ok {
+		return []error{err}
+	}
+	var leaves []error
+	for _, cause := range joined.Unwrap() {
+		leaves = append(leaves, flattenJoined(cause)...)
+	}
+	return leaves
+}
+
+func expandRuleFiles(rulePaths []string) []string {
+	var files []string
+	for _, path := range rulePaths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, walkErr error) error {
+			if walkErr == nil && !d.IsDir() && isRuleFile(p) {
+				files = append(files, p)
+			}
+			return nil
+		})
+	}
+	return files
+}
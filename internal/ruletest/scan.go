@@ -0,0 +1,134 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package ruletest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/engine"
+	"github.com/scanoss/crypto-finder/internal/enricher"
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/language"
+	"github.com/scanoss/crypto-finder/internal/rules"
+	scanutil "github.com/scanoss/crypto-finder/internal/scan"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/skip"
+)
+
+// CallGraphFunc builds the call graph of one ecosystem of the fixture tree
+// at target.
+type CallGraphFunc func(target string, report *entities.InterimReport, ecosystem string) (*engine.DepScanResult, error)
+
+// EcosystemsFunc returns the ecosystems whose call graph covers the
+// findings of report.
+type EcosystemsFunc func(target string, report *entities.InterimReport, languages []string) []string
+
+// ScanOptions configures Scan.
+type ScanOptions struct {
+	// FixturesDir is the fixture tree to scan.
+	FixturesDir string
+	// Languages overrides language detection when set.
+	Languages []string
+	// Scanners holds the scanners available to the run; Scanner names the
+	// one to run.
+	Scanners *scanner.Registry
+	Scanner  string
+	// Timeout bounds the scanner run.
+	Timeout time.Duration
+	// RulesManager provides the rules under test; RulePaths are the files it
+	// loaded and Rules their parsed form.
+	RulesManager *rules.Manager
+	RulePaths    []string
+	Rules        []Rule
+	// Ecosystems and CallGraph build the fixtures' call graph for
+	// conditioned rules. Conditioned findings are not materialized when
+	// either is nil.
+	Ecosystems EcosystemsFunc
+	CallGraph  CallGraphFunc
+}
+
+// Scan scans the fixtures with the rules under test, then runs the same
+// post-detection steps as scan for conditioned rules: entry-point synthesis
+// and conditioned-finding materialization over the fixtures' call graph. It
+// returns the report and the number of materialized findings.
+func Scan(ctx context.Context, opts ScanOptions) (*entities.InterimReport, int, error) {
+	target, err := filepath.Abs(opts.FixturesDir)
+	if err != nil {
+		return nil, 0, failure.WrapUnknown(err, failure.CodeInvalidArguments, failure.StageInput,
+			fmt.Sprintf("failed to resolve fixture directory '%s'", opts.FixturesDir))
+	}
+
+	languages := opts.Languages
+	langDetector := language.NewEnryDetector(skip.NewGitIgnoreMatcher(nil))
+	if len(languages) == 0 {
+		languages, err = langDetector.Detect(target)
+		if err != nil {
+			return nil, 0, failure.WrapUnknown(err, failure.CodeLanguageDetectionFailed, failure.StageScan, "failed to detect languages")
+		}
+	}
+
+	orchestrator := engine.NewOrchestrator(langDetector, opts.RulesManager, opts.Scanners)
+
+	// Fixtures are scanned as is: no default exclusions and no test-source
+	// skipping, so fixture trees named tests/ or testdata/ are covered.
+	report, err := orchestrator.Scan(ctx, engine.ScanOptions{
+		Target:        target,
+		ScannerName:   opts.Scanner,
+		LanguageHint:  languages,
+		ScannerConfig: scanner.Config{Timeout: opts.Timeout},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	enricher.NewOIDEnricher().EnrichReport(report)
+
+	if !HasConditionedRule(opts.Rules) || scanutil.CountFindings(report) == 0 ||
+		opts.Ecosystems == nil || opts.CallGraph == nil {
+		return report, 0, nil
+	}
+
+	materialized := 0
+	for _, ecosystem := range opts.Ecosystems(target, report, languages) {
+		result, err := opts.CallGraph(target, report, ecosystem)
+		if err != nil {
+			// Conditioned expectations of this ecosystem then fail as not
+			// materialized, which names the affected rules.
+			log.Warn().Err(err).Str("ecosystem", ecosystem).Msg("Failed to build fixture call graph")
+			continue
+		}
+		engine.SynthesizeRuleCryptoEntryPoints(report, result.CallGraph, opts.RulePaths, result.Ecosystem)
+		materialized += scanutil.MaterializeConditionedFindings(report, result.CallGraph, opts.RulePaths, result.Ecosystem)
+	}
+	return report, materialized, nil
+}
+
+// HasConditionedRule reports whether any rule under test has
+// parameterCondition predicates.
+func HasConditionedRule(tested []Rule) bool {
+	for _, rule := range tested {
+		if rule.Conditioned {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,2 @@
+// expect: ignored.rule
+int x;
@@ -0,0 +1,13 @@
+import javax.crypto.Cipher;
+
+class Crypto {
+    void encrypt() throws Exception {
+        // expect: java.crypto.cipher algorithm=AES mode=GCM
+        Cipher aes = Cipher.getInstance("AES/GCM/NoPadding");
+
+        // expect: java.crypto.cipher algorithm=DES
+        // expect: java.crypto.weak-cipher
+        Cipher des = Cipher.getInstance("DES");
+        Cipher rc4 = Cipher.getInstance("RC4"); // expect: java.crypto.cipher algorithm=RC4
+    }
+}
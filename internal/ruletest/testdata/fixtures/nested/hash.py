@@ -0,0 +1,4 @@
+import hashlib
+
+# expect: python.crypto.hash algorithm=SHA-256
+digest = hashlib.sha256(b"data")
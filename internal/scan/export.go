@@ -159,6 +159,9 @@ type callGraphDependencyContext struct {
 	Module  string `json:"module"`
 	Version string `json:"version"`
 	PURL    string `json:"purl,omitempty"`
+	// DependencyScope is the dependency's build-tool scope (6.14+), absent
+	// when the resolver records none.
+	DependencyScope string `json:"dependency_scope,omitempty"`
 }
 
 // callGraphRoleProvenance explains where a method_role/parameter contribution
@@ -372,6 +375,7 @@ type exportDependencyRoot struct {
 	Module  string
 	Version string
 	Dir     string
+	Scope   string
 }
 
 // --- Entry point ---
@@ -1295,6 +1299,7 @@ func newExportBuildContext(result *engine.DepScanResult) *exportBuildContext {
 			Module:  dep.Module,
 			Version: dep.Version,
 			Dir:     filepath.Clean(dep.Dir),
+			Scope:   dep.Scope,
 		})
 	}
 	sort.SliceStable(ctx.dependencies, func(i, j int) bool {
@@ -4119,9 +4124,10 @@ func normalizeExportPath(ctx *exportBuildContext, actualPath string) normalizedE
 			return normalizedExportLocation{
 				FilePath: filepath.ToSlash(rel),
 				DependencyInfo: &callGraphDependencyContext{
-					Module:  dep.Module,
-					Version: dep.Version,
-					PURL:    purl.Dependency(ctx.ecosystem, dep.Module, dep.Version),
+					Module:          dep.Module,
+					Version:         dep.Version,
+					PURL:            purl.Dependency(ctx.ecosystem, dep.Module, dep.Version),
+					DependencyScope: dep.Scope,
 				},
 			}
 		}
@@ -4129,9 +4135,10 @@ func normalizeExportPath(ctx *exportBuildContext, actualPath string) normalizedE
 			return normalizedExportLocation{
 				FilePath: filepath.ToSlash(cleanPath),
 				DependencyInfo: &callGraphDependencyContext{
-					Module:  dep.Module,
-					Version: dep.Version,
-					PURL:    purl.Dependency(ctx.ecosystem, dep.Module, dep.Version),
+					Module:          dep.Module,
+					Version:         dep.Version,
+					PURL:            purl.Dependency(ctx.ecosystem, dep.Module, dep.Version),
+					DependencyScope: dep.Scope,
 				},
 			}
 		}
@@ -4178,9 +4185,10 @@ func dependencyContextFromEntity(depInfo *entities.DependencyInfo, ecosystem str
 		return nil
 	}
 	return &callGraphDependencyContext{
-		Module:  depInfo.Module,
-		Version: depInfo.Version,
-		PURL:    purl.Dependency(ecosystem, depInfo.Module, depInfo.Version),
+		Module:          depInfo.Module,
+		Version:         depInfo.Version,
+		PURL:            purl.Dependency(ecosystem, depInfo.Module, depInfo.Version),
+		DependencyScope: depInfo.DependencyScope,
 	}
 }
 
@@ -65,7 +65,7 @@ func TestGeneratedExportsMatchSchemas(t *testing.T) {
 			name:             "report",
 			schema:           filepath.Join("..", "..", "schemas", "interim-report-schema.json"),
 			document:         reportPath,
-			properties:       []string{"findings", "rules", "tool", "version"},
+			properties:       []string{"dependencies", "findings", "rules", "tool", "version"},
 			outputProperties: []string{"findings", "rules", "tool", "version"},
 			populatedArrays:  []string{"findings"},
 			invalidVersion:   "1.4",
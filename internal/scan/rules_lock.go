@@ -0,0 +1,238 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/scanoss/crypto-finder/internal/engine"
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+const (
+	// RulesLockFileName is the default name of the rules lockfile, written
+	// next to the scanned project.
+	RulesLockFileName = "crypto-finder.lock"
+
+	rulesLockVersion = 1
+)
+
+// RulesLock pins the rules a scan resolved, so later scans can check they
+// run with the same rules.
+type RulesLock struct {
+	LockVersion int `json:"lock_version"`
+	// Remote is the remote ruleset; nil when remote rules are disabled.
+	Remote *LockedRuleset `json:"remote,omitempty"`
+	// Fetched holds one entry per --rules-git / --rules-oci source, sorted
+	// by source.
+	Fetched []LockedSource `json:"fetched,omitempty"`
+	// Local holds one entry per --rules / --rules-dir path, sorted by path.
+	Local []LockedRules `json:"local,omitempty"`
+}
+
+// LockedRuleset pins a remote ruleset.
+type LockedRuleset struct {
+	Name string `json:"name"`
+	// Version is the concrete version the API resolved, never "latest".
+	Version        string `json:"version"`
+	ChecksumSHA256 string `json:"checksum_sha256"`
+}
+
+// LockedSource pins a git or OCI rule source to what its ref resolved to,
+// so a moved branch or tag is detected.
+type LockedSource struct {
+	// Source is the rule source's name, e.g. "git:<repository>@main#rules"
+	// or "oci:<registry>/<repository>:v1".
+	Source string `json:"source"`
+	// Revision is the commit SHA or manifest digest the ref resolved to.
+	Revision string `json:"revision"`
+	// ChecksumSHA256 fingerprints the loaded rule files.
+	ChecksumSHA256 string `json:"checksum_sha256"`
+}
+
+// LockedRules pins the content of a local rule file or directory.
+type LockedRules struct {
+	// Path is slash-separated and relative to the lockfile's directory when
+	// the rules are below it, absolute otherwise.
+	Path string `json:"path"`
+	// Hash is engine.ComputeRulesHash of the path's rule files.
+	Hash string `json:"hash"`
+}
+
+// DefaultRulesLockPath returns the lockfile path for a scan target: inside
+// the target directory, or next to the target file.
+func DefaultRulesLockPath(target string) string {
+	if info, err := os.Stat(target); err == nil && !info.IsDir() {
+		return filepath.Join(filepath.Dir(target), RulesLockFileName)
+	}
+	return filepath.Join(target, RulesLockFileName)
+}
+
+// BuildRulesLock records the resolved rules. remote is the RulesInfo of the
+// loaded remote source (nil without remote rules), fetched the loaded git
+// and OCI sources, localPaths are the --rules and --rules-dir paths, and
+// lockDir is the lockfile's directory.
+func BuildRulesLock(remote *entities.RulesInfo, fetched []LockedSource, localPaths []string, lockDir string) (*RulesLock, error) {
+	lock := &RulesLock{LockVersion: rulesLockVersion}
+	if remote != nil {
+		if remote.Version == "" || remote.ChecksumSHA256 == "" {
+			return nil, fmt.Errorf("scan: remote ruleset %s has no recorded version or checksum", remote.Name)
+		}
+		lock.Remote = &LockedRuleset{Name: remote.Name, Version: remote.Version, ChecksumSHA256: remote.ChecksumSHA256}
+	}
+
+	for _, source := range fetched {
+		if source.Revision == "" {
+			return nil, fmt.Errorf("scan: rule source %s has no resolved revision", source.Source)
+		}
+		lock.Fetched = append(lock.Fetched, source)
+	}
+	sort.Slice(lock.Fetched, func(i, j int) bool { return lock.Fetched[i].Source < lock.Fetched[j].Source })
+
+	for _, path := range localPaths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("scan: failed to resolve rule path %s: %w", path, err)
+		}
+		hash, err := engine.ComputeRulesHash([]string{absPath})
+		if err != nil {
+			return nil, fmt.Errorf("scan: failed to hash rules in %s: %w", path, err)
+		}
+		lock.Local = append(lock.Local, LockedRules{Path: lockRelativePath(absPath, lockDir), Hash: hash})
+	}
+	sort.Slice(lock.Local, func(i, j int) bool { return lock.Local[i].Path < lock.Local[j].Path })
+	return lock, nil
+}
+
+func lockRelativePath(absPath, lockDir string) string {
+	absLockDir, err := filepath.Abs(lockDir)
+	if err != nil {
+		return filepath.ToSlash(absPath)
+	}
+	rel, err := filepath.Rel(absLockDir, absPath)
+	if err != nil || !filepath.IsLocal(rel) {
+		return filepath.ToSlash(absPath)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ReadRulesLock reads a lockfile.
+func ReadRulesLock(path string) (*RulesLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("scan: failed to read rules lockfile: %w", err)
+	}
+	var lock RulesLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("scan: failed to parse rules lockfile %s: %w", path, err)
+	}
+	if lock.LockVersion != rulesLockVersion {
+		return nil, fmt.Errorf("scan: unsupported rules lockfile version %d in %s", lock.LockVersion, path)
+	}
+	return &lock, nil
+}
+
+// Write writes the lockfile as indented JSON.
+func (l *RulesLock) Write(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("scan: failed to marshal rules lockfile: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("scan: failed to write rules lockfile: %w", err)
+	}
+	return nil
+}
+
+// Diff lists how the resolved rules differ from the lock, one message per
+// difference. It returns nil when they match.
+func (l *RulesLock) Diff(resolved *RulesLock) []string {
+	var diffs []string
+	switch {
+	case l.Remote == nil && resolved.Remote != nil:
+		diffs = append(diffs, fmt.Sprintf("remote ruleset %s@%s is not in the lockfile", resolved.Remote.Name, resolved.Remote.Version))
+	case l.Remote != nil && resolved.Remote == nil:
+		diffs = append(diffs, fmt.Sprintf("locked remote ruleset %s@%s is not loaded", l.Remote.Name, l.Remote.Version))
+	case l.Remote != nil && *l.Remote != *resolved.Remote:
+		diffs = append(diffs, fmt.Sprintf("remote ruleset is %s@%s (checksum %s), locked %s@%s (checksum %s)",
+			resolved.Remote.Name, resolved.Remote.Version, resolved.Remote.ChecksumSHA256,
+			l.Remote.Name, l.Remote.Version, l.Remote.ChecksumSHA256))
+	}
+
+	diffs = append(diffs, l.diffFetched(resolved)...)
+
+	locked := make(map[string]string, len(l.Local))
+	for _, rules := range l.Local {
+		locked[rules.Path] = rules.Hash
+	}
+	for _, rules := range resolved.Local {
+		hash, ok := locked[rules.Path]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("local rules %s are not in the lockfile", rules.Path))
+		case hash != rules.Hash:
+			diffs = append(diffs, fmt.Sprintf("local rules %s changed (hash %s, locked %s)", rules.Path, rules.Hash, hash))
+		}
+		delete(locked, rules.Path)
+	}
+	missing := make([]string, 0, len(locked))
+	for path := range locked {
+		missing = append(missing, path)
+	}
+	sort.Strings(missing)
+	for _, path := range missing {
+		diffs = append(diffs, fmt.Sprintf("locked local rules %s are not loaded", path))
+	}
+	return diffs
+}
+
+func (l *RulesLock) diffFetched(resolved *RulesLock) []string {
+	var diffs []string
+	locked := make(map[string]LockedSource, len(l.Fetched))
+	for _, source := range l.Fetched {
+		locked[source.Source] = source
+	}
+	for _, source := range resolved.Fetched {
+		lockedSource, ok := locked[source.Source]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("rule source %s is not in the lockfile", source.Source))
+		case lockedSource.Revision != source.Revision:
+			diffs = append(diffs, fmt.Sprintf("rule source %s resolved to %s, locked %s", source.Source, source.Revision, lockedSource.Revision))
+		case lockedSource.ChecksumSHA256 != source.ChecksumSHA256:
+			diffs = append(diffs, fmt.Sprintf("rule source %s changed at %s (checksum %s, locked %s)",
+				source.Source, source.Revision, source.ChecksumSHA256, lockedSource.ChecksumSHA256))
+		}
+		delete(locked, source.Source)
+	}
+	missing := make([]string, 0, len(locked))
+	for name := range locked {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		diffs = append(diffs, fmt.Sprintf("locked rule source %s is not loaded", name))
+	}
+	return diffs
+}
+
+// String summarizes the lock for logs.
+func (l *RulesLock) String() string {
+	parts := make([]string, 0, 1+len(l.Fetched)+len(l.Local))
+	if l.Remote != nil {
+		parts = append(parts, fmt.Sprintf("remote %s@%s", l.Remote.Name, l.Remote.Version))
+	}
+	for _, source := range l.Fetched {
+		parts = append(parts, fmt.Sprintf("%s (%s)", source.Source, source.Revision))
+	}
+	for _, rules := range l.Local {
+		parts = append(parts, "local "+rules.Path)
+	}
+	return strings.Join(parts, ", ")
+}
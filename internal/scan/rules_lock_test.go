@@ -0,0 +1,145 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func writeLockTestRule(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestRulesLock_WriteReadDiff(t *testing.T) {
+	project := t.TempDir()
+	outside := t.TempDir()
+	writeLockTestRule(t, filepath.Join(project, "rules", "java.yaml"), "rules: []\n")
+	writeLockTestRule(t, filepath.Join(outside, "extra.yaml"), "rules: []\n")
+
+	remote := &entities.RulesInfo{Source: "remote", Name: "dca", Version: "v1.0.4", ChecksumSHA256: "abc123"}
+	localPaths := []string{filepath.Join(project, "rules"), filepath.Join(outside, "extra.yaml")}
+	fetched := []LockedSource{
+		{Source: "oci:ghcr.io/org/rules:v1", Revision: "sha256:aaaa", ChecksumSHA256: "c1"},
+		{Source: "git:https://github.com/org/rules@main", Revision: "1111", ChecksumSHA256: "c2"},
+	}
+	lock, err := BuildRulesLock(remote, fetched, localPaths, project)
+	if err != nil {
+		t.Fatalf("BuildRulesLock() error = %v", err)
+	}
+	if lock.Remote == nil || *lock.Remote != (LockedRuleset{Name: "dca", Version: "v1.0.4", ChecksumSHA256: "abc123"}) {
+		t.Errorf("Remote = %+v", lock.Remote)
+	}
+	if len(lock.Fetched) != 2 || lock.Fetched[0] != fetched[1] || lock.Fetched[1] != fetched[0] {
+		t.Errorf("Fetched = %+v, want %+v sorted by source", lock.Fetched, fetched)
+	}
+	wantPaths := []string{filepath.ToSlash(filepath.Join(outside, "extra.yaml")), "rules"}
+	if filepath.ToSlash(outside) > "rules" {
+		wantPaths[0], wantPaths[1] = wantPaths[1], wantPaths[0]
+	}
+	var gotPaths []string
+	for _, rules := range lock.Local {
+		gotPaths = append(gotPaths, rules.Path)
+		if len(rules.Hash) != 16 {
+			t.Errorf("hash of %s = %q, want 16 hex characters", rules.Path, rules.Hash)
+		}
+	}
+	if !reflect.DeepEqual(gotPaths, wantPaths) {
+		t.Errorf("local paths = %v, want %v", gotPaths, wantPaths)
+	}
+
+	lockPath := DefaultRulesLockPath(project)
+	if lockPath != filepath.Join(project, RulesLockFileName) {
+		t.Errorf("DefaultRulesLockPath() = %s", lockPath)
+	}
+	if err := lock.Write(lockPath); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	read, err := ReadRulesLock(lockPath)
+	if err != nil {
+		t.Fatalf("ReadRulesLock() error = %v", err)
+	}
+	if !reflect.DeepEqual(read, lock) {
+		t.Errorf("ReadRulesLock() = %+v, want %+v", read, lock)
+	}
+
+	resolved, err := BuildRulesLock(remote, fetched, localPaths, project)
+	if err != nil {
+		t.Fatalf("BuildRulesLock() error = %v", err)
+	}
+	if diffs := read.Diff(resolved); diffs != nil {
+		t.Errorf("Diff() of unchanged rules = %v", diffs)
+	}
+
+	// An edited rule file, a newer remote ruleset, a moved git branch, a
+	// dropped OCI source and a dropped --rules path.
+	writeLockTestRule(t, filepath.Join(project, "rules", "java.yaml"), "rules: [{id: new}]\n")
+	newer := &entities.RulesInfo{Source: "remote", Name: "dca", Version: "v1.0.5", ChecksumSHA256: "def456"}
+	moved := []LockedSource{{Source: "git:https://github.com/org/rules@main", Revision: "2222", ChecksumSHA256: "c3"}}
+	resolved, err = BuildRulesLock(newer, moved, localPaths[:1], project)
+	if err != nil {
+		t.Fatalf("BuildRulesLock() error = %v", err)
+	}
+	diffs := read.Diff(resolved)
+	if len(diffs) != 5 {
+		t.Fatalf("Diff() = %q, want 5 differences", diffs)
+	}
+	want := []string{
+		"remote ruleset is dca@v1.0.5 (checksum def456), locked dca@v1.0.4 (checksum abc123)",
+		"rule source git:https://github.com/org/rules@main resolved to 2222, locked 1111",
+		"locked rule source oci:ghcr.io/org/rules:v1 is not loaded",
+		"local rules rules changed (hash " + resolved.Local[0].Hash + ", locked " + lock.Local[indexOfLocked(lock, "rules")].Hash + ")",
+		"locked local rules " + filepath.ToSlash(filepath.Join(outside, "extra.yaml")) + " are not loaded",
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("Diff() =\n%q\nwant\n%q", diffs, want)
+	}
+
+	if diffs := read.Diff(&RulesLock{LockVersion: 1, Fetched: read.Fetched, Local: read.Local}); !reflect.DeepEqual(diffs, []string{"locked remote ruleset dca@v1.0.4 is not loaded"}) {
+		t.Errorf("Diff() without remote rules = %q", diffs)
+	}
+}
+
+func indexOfLocked(lock *RulesLock, path string) int {
+	for i, rules := range lock.Local {
+		if rules.Path == path {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestBuildRulesLock_Errors(t *testing.T) {
+	if _, err := BuildRulesLock(&entities.RulesInfo{Source: "remote", Name: "dca"}, nil, nil, t.TempDir()); err == nil {
+		t.Error("BuildRulesLock() without remote checksum succeeded")
+	}
+	if _, err := BuildRulesLock(nil, []LockedSource{{Source: "git:https://github.com/org/rules@main"}}, nil, t.TempDir()); err == nil {
+		t.Error("BuildRulesLock() of a rule source without revision succeeded")
+	}
+	if _, err := BuildRulesLock(nil, nil, []string{t.TempDir()}, t.TempDir()); err == nil {
+		t.Error("BuildRulesLock() with an empty rules directory succeeded")
+	}
+}
+
+func TestReadRulesLock_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadRulesLock(filepath.Join(dir, RulesLockFileName)); err == nil {
+		t.Error("ReadRulesLock() of a missing file succeeded")
+	}
+	path := filepath.Join(dir, "future.lock")
+	writeLockTestRule(t, path, `{"lock_version": 2}`)
+	if _, err := ReadRulesLock(path); err == nil {
+		t.Error("ReadRulesLock() of an unsupported version succeeded")
+	}
+}
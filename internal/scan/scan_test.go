@@ -1819,6 +1819,19 @@ func TestValidateFlags(t *testing.T) {
 			}(),
 			want: "",
 		},
+		{
+			name:   "git-and-oci-rules-only",
+			target: target,
+			opts: func() ValidationOptions {
+				o := validOpts
+				o.RuleFiles = nil
+				o.NoRemoteRules = true
+				o.RuleGitRepos = []string{"https://example.com/rules.git@v1.4.0"}
+				o.RuleOCIArtifacts = []string{"ghcr.io/org/rules:v1"}
+				return o
+			}(),
+			want: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -13,6 +13,8 @@ import (
 type ValidationOptions struct {
 	RuleFiles        []string
 	RuleDirs         []string
+	RuleGitRepos     []string
+	RuleOCIArtifacts []string
 	NoRemoteRules    bool
 	Scanner          string
 	AllowedScanners  []string
@@ -33,9 +35,10 @@ func ValidateFlags(target string, opts ValidationOptions) ([]string, error) {
 	}
 
 	// Validate that at least one rule source is specified.
-	// Either local rules OR remote rules (unless --no-remote-rules is set).
-	if len(opts.RuleFiles) == 0 && len(opts.RuleDirs) == 0 && opts.NoRemoteRules {
-		return nil, fmt.Errorf("no rules specified: use --rules <file>, --rules-dir <directory>, or enable remote rules")
+	// Either local, git or OCI rules OR remote rules (unless --no-remote-rules is set).
+	if len(opts.RuleFiles) == 0 && len(opts.RuleDirs) == 0 && len(opts.RuleGitRepos) == 0 &&
+		len(opts.RuleOCIArtifacts) == 0 && opts.NoRemoteRules {
+		return nil, fmt.Errorf("no rules specified: use --rules <file>, --rules-dir <directory>, --rules-git <repo>@<ref>, --rules-oci <reference>, or enable remote rules")
 	}
 
 	for _, ruleDir := range opts.RuleDirs {
@@ -82,6 +82,16 @@ type Scanner interface {
 	GetInfo() Info
 }
 
+// RuleProfiler is implemented by scanners that can measure the time spent
+// on each rule.
+type RuleProfiler interface {
+	// ScanWithRuleTimes runs Scan with the scanner's timing output enabled
+	// and also returns the time spent matching each rule, keyed by the rule
+	// IDs the report uses. The times are nil when the scanner emitted no
+	// timing data.
+	ScanWithRuleTimes(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, map[string]time.Duration, error)
+}
+
 // Config holds the configuration parameters for initializing a scanner.
 // Each scanner adapter receives this configuration during initialization.
 type Config struct {
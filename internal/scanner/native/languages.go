@@ -0,0 +1,207 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	treec "github.com/smacker/go-tree-sitter/c"
+	treecpp "github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// language describes how the native scanner parses one rule language.
+type language struct {
+	// name is the canonical Semgrep language name.
+	name string
+	// grammar parses patterns and, unless grammars overrides it, targets.
+	grammar *sitter.Language
+	// extensions lists the target file extensions, lower case.
+	extensions []string
+	// grammars overrides grammar for some extensions (TSX for .tsx).
+	grammars map[string]*sitter.Language
+	// templates embed a pattern into a compilable snippet. They are tried
+	// in order; the first that parses without errors is used.
+	templates []template
+	// quotes are the characters that delimit string literals, inside which
+	// metavariables and ellipses are left alone.
+	quotes string
+	// statementEnd terminates an ellipsis that stands for statements.
+	statementEnd string
+}
+
+// template is the code placed around a pattern to make it parse.
+type template struct {
+	prefix, suffix string
+}
+
+// grammarFor returns the grammar that parses files with extension ext.
+func (l *language) grammarFor(ext string) *sitter.Language {
+	if g, ok := l.grammars[ext]; ok {
+		return g
+	}
+	return l.grammar
+}
+
+var cTemplates = []template{
+	{"void __sg(void) {\n", ";\n}"},
+	{"void __sg(void) {\n", "\n}"},
+	{"", ""},
+}
+
+var languages = []*language{
+	{
+		name:         "c",
+		grammar:      treec.GetLanguage(),
+		extensions:   []string{".c", ".h"},
+		templates:    cTemplates,
+		quotes:       `"'`,
+		statementEnd: ";",
+	},
+	{
+		name:         "cpp",
+		grammar:      treecpp.GetLanguage(),
+		extensions:   []string{".cc", ".cp", ".cpp", ".cxx", ".c++", ".h", ".hh", ".hpp", ".hxx", ".h++"},
+		templates:    cTemplates,
+		quotes:       `"'`,
+		statementEnd: ";",
+	},
+	{
+		name:       "go",
+		grammar:    golang.GetLanguage(),
+		extensions: []string{".go"},
+		templates: []template{
+			{"package __sg\nfunc __sg() {\n", "\n}"},
+			{"package __sg\n", ""},
+		},
+		quotes: "\"'`",
+	},
+	{
+		name:       "java",
+		grammar:    java.GetLanguage(),
+		extensions: []string{".java"},
+		templates: []template{
+			{"class __SG { void __sg() {\n", ";\n} }"},
+			{"class __SG { void __sg() {\n", "\n} }"},
+			{"class __SG {\n", "\n}"},
+			{"", ""},
+		},
+		quotes:       `"'`,
+		statementEnd: ";",
+	},
+	{
+		name:       "javascript",
+		grammar:    javascript.GetLanguage(),
+		extensions: []string{".js", ".jsx", ".mjs", ".cjs"},
+		templates: []template{
+			{"", ""},
+			{"function __sg() {\n", "\n}"},
+		},
+		quotes: "\"'`",
+	},
+	{
+		name:       "typescript",
+		grammar:    typescript.GetLanguage(),
+		extensions: []string{".ts", ".tsx", ".mts", ".cts"},
+		grammars:   map[string]*sitter.Language{".tsx": tsx.GetLanguage()},
+		templates: []template{
+			{"", ""},
+			{"function __sg() {\n", "\n}"},
+		},
+		quotes: "\"'`",
+	},
+	{
+		name:       "python",
+		grammar:    python.GetLanguage(),
+		extensions: []string{".py", ".pyi"},
+		templates:  []template{{"", ""}},
+		quotes:     `"'`,
+	},
+	{
+		name:       "rust",
+		grammar:    rust.GetLanguage(),
+		extensions: []string{".rs"},
+		templates: []template{
+			{"fn __sg() {\n", ";\n}"},
+			{"fn __sg() {\n", "\n}"},
+			{"", ""},
+		},
+		quotes:       `"`,
+		statementEnd: ";",
+	},
+}
+
+// languageAliases maps the language names rules may use to the canonical
+// names above.
+var languageAliases = map[string]string{
+	"c":          "c",
+	"cpp":        "cpp",
+	"c++":        "cpp",
+	"go":         "go",
+	"golang":     "go",
+	"java":       "java",
+	"javascript": "javascript",
+	"js":         "javascript",
+	"typescript": "typescript",
+	"ts":         "typescript",
+	"python":     "python",
+	"python3":    "python",
+	"py":         "python",
+	"rust":       "rust",
+}
+
+// lookupLanguage returns the language a rule's languages entry names, or
+// nil when the native scanner does not support it.
+func lookupLanguage(name string) *language {
+	canonical, ok := languageAliases[strings.ToLower(name)]
+	if !ok {
+		return nil
+	}
+	for _, l := range languages {
+		if l.name == canonical {
+			return l
+		}
+	}
+	return nil
+}
+
+// languagesForFile returns the languages a target file is scanned as.
+// Headers (.h) are both C and C++.
+func languagesForFile(path string) []*language {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return nil
+	}
+	var matched []*language
+	for _, l := range languages {
+		for _, e := range l.extensions {
+			if e == ext {
+				matched = append(matched, l)
+				break
+			}
+		}
+	}
+	return matched
+}
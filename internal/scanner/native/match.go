@@ -0,0 +1,227 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"bytes"
+	"strings"
+)
+
+// binding is the source range a metavariable is bound to.
+type binding struct {
+	start, end uint32
+}
+
+// bindings maps metavariable names ("$X") to what they matched. Bindings
+// are never modified in place: bind returns a copy.
+type bindings map[string]binding
+
+// match is one match of a formula in a file.
+type match struct {
+	start, end uint32
+	bindings   bindings
+}
+
+// target is a parsed file with its nodes indexed by kind.
+type target struct {
+	src    []byte
+	root   *node
+	byKind map[string][]*node
+	named  []*node
+	lists  []*node // nodes with at least one child, for sequence patterns
+}
+
+func newTarget(src []byte, root *node) *target {
+	t := &target{src: src, root: root, byKind: make(map[string][]*node)}
+	root.walk(func(n *node) {
+		t.byKind[n.kind] = append(t.byKind[n.kind], n)
+		if n.named {
+			t.named = append(t.named, n)
+		}
+		if len(n.children) > 0 {
+			t.lists = append(t.lists, n)
+		}
+	})
+	return t
+}
+
+func (t *target) text(start, end uint32) string {
+	return string(t.src[start:end])
+}
+
+// matcher compares one pattern against one target.
+type matcher struct {
+	pattern *pattern
+	target  *target
+}
+
+// findAll returns every match of p in t.
+func (p *pattern) findAll(t *target) []match {
+	m := &matcher{pattern: p, target: t}
+	var matches []match
+
+	if p.root != nil {
+		var candidates []*node
+		switch {
+		case p.root.ellipsis:
+			return nil
+		case p.root.metavar != "":
+			candidates = t.named
+		default:
+			candidates = t.byKind[p.root.kind]
+		}
+		for _, n := range candidates {
+			if b, ok := m.match(p.root, n, nil); ok {
+				matches = append(matches, match{start: n.start, end: n.end, bindings: b})
+			}
+		}
+		return matches
+	}
+
+	first := p.seq[0]
+	for _, list := range t.lists {
+		for i, n := range list.children {
+			if !first.ellipsis && first.metavar == "" && n.kind != first.kind {
+				continue
+			}
+			b, consumed, ok := m.matchPrefix(p.seq, list.children[i:], nil)
+			if !ok || consumed == 0 {
+				continue
+			}
+			matches = append(matches, match{start: n.start, end: list.children[i+consumed-1].end, bindings: b})
+		}
+	}
+	return matches
+}
+
+// match reports whether pattern node p matches target node n.
+func (m *matcher) match(p, n *node, b bindings) (bindings, bool) {
+	switch {
+	case p.ellipsis:
+		return b, true
+	case p.metavar != "":
+		if !n.named {
+			return nil, false
+		}
+		return m.bind(b, p.metavar, n.start, n.end)
+	case p.anyString:
+		return b, n.kind == p.kind
+	}
+	if p.kind != n.kind || p.named != n.named {
+		return nil, false
+	}
+	if p.leaf {
+		return b, bytes.Equal(m.pattern.src[p.start:p.end], m.target.src[n.start:n.end])
+	}
+	return m.matchList(p.children, n.children, b)
+}
+
+// matchList matches a child list, where an ellipsis stands for any number
+// of siblings.
+func (m *matcher) matchList(ps, ns []*node, b bindings) (bindings, bool) {
+	if len(ps) == 0 {
+		return b, len(ns) == 0
+	}
+	if ps[0].ellipsis {
+		if len(ps) == 1 {
+			return b, true
+		}
+		for k := 0; k <= len(ns); k++ {
+			if nb, ok := m.matchList(ps[1:], ns[k:], b); ok {
+				return nb, true
+			}
+		}
+		return nil, false
+	}
+	if len(ns) == 0 {
+		return nil, false
+	}
+	nb, ok := m.match(ps[0], ns[0], b)
+	if !ok {
+		return nil, false
+	}
+	return m.matchList(ps[1:], ns[1:], nb)
+}
+
+// matchPrefix matches a statement sequence against the start of a sibling
+// list and returns how many siblings it covers. A trailing ellipsis covers
+// the rest of the list.
+func (m *matcher) matchPrefix(ps, ns []*node, b bindings) (bindings, int, bool) {
+	if len(ps) == 0 {
+		return b, 0, true
+	}
+	if ps[0].ellipsis {
+		if len(ps) == 1 {
+			return b, len(ns), true
+		}
+		for k := 0; k <= len(ns); k++ {
+			if nb, consumed, ok := m.matchPrefix(ps[1:], ns[k:], b); ok {
+				return nb, k + consumed, true
+			}
+		}
+		return nil, 0, false
+	}
+	if len(ns) == 0 {
+		return nil, 0, false
+	}
+	nb, ok := m.match(ps[0], ns[0], b)
+	if !ok {
+		return nil, 0, false
+	}
+	nb, consumed, ok := m.matchPrefix(ps[1:], ns[1:], nb)
+	return nb, consumed + 1, ok
+}
+
+// bind binds name to a target range. A metavariable that is already bound
+// only matches code that reads the same, ignoring whitespace.
+func (m *matcher) bind(b bindings, name string, start, end uint32) (bindings, bool) {
+	if existing, ok := b[name]; ok {
+		return b, sameCode(m.target.text(existing.start, existing.end), m.target.text(start, end))
+	}
+	bound := make(bindings, len(b)+1)
+	for k, v := range b {
+		bound[k] = v
+	}
+	bound[name] = binding{start: start, end: end}
+	return bound, true
+}
+
+// merge combines the bindings of two matches in the same file, failing
+// when they bind a metavariable to different code.
+func merge(t *target, a, b bindings) (bindings, bool) {
+	if len(b) == 0 {
+		return a, true
+	}
+	merged := make(bindings, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		if existing, ok := merged[k]; ok {
+			if !sameCode(t.text(existing.start, existing.end), t.text(v.start, v.end)) {
+				return nil, false
+			}
+			continue
+		}
+		merged[k] = v
+	}
+	return merged, true
+}
+
+func sameCode(a, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
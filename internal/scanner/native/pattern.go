@@ -0,0 +1,259 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+const (
+	// metavarPrefix replaces the $ of a metavariable so that every grammar
+	// parses it as an identifier.
+	metavarPrefix = "__mv_"
+	// ellipsisIdent replaces a Semgrep ellipsis (...) for the same reason.
+	ellipsisIdent = "__sg_ellipsis__"
+)
+
+// node is a tree-sitter node copied out of the C tree, without comments
+// and list punctuation, which the matcher walks many times per file.
+type node struct {
+	kind       string
+	named      bool
+	start, end uint32
+	children   []*node
+	// leaf is set when no child is named; such nodes compare by text.
+	leaf bool
+
+	// Pattern nodes only.
+	metavar   string // "$X" when the node is a metavariable
+	ellipsis  bool   // matches any number of siblings
+	anyString bool   // a "..." string literal, matching any string
+}
+
+// punctuation tokens are dropped from child lists so that ellipses can
+// stand for whole argument or statement lists.
+var punctuation = map[string]bool{
+	"(": true, ")": true, "{": true, "}": true, "[": true, "]": true,
+	",": true, ";": true,
+}
+
+// convertTree copies a tree-sitter subtree. Extras (comments) are dropped.
+func convertTree(n *sitter.Node) *node {
+	converted := &node{
+		kind:  n.Type(),
+		named: n.IsNamed(),
+		start: n.StartByte(),
+		end:   n.EndByte(),
+		leaf:  true,
+	}
+	count := int(n.ChildCount())
+	for i := 0; i < count; i++ {
+		child := n.Child(i)
+		if child == nil || child.IsExtra() || (!child.IsNamed() && punctuation[child.Type()]) {
+			continue
+		}
+		c := convertTree(child)
+		if c.named {
+			converted.leaf = false
+		}
+		converted.children = append(converted.children, c)
+	}
+	return converted
+}
+
+// walk calls fn for n and every descendant, parents first.
+func (n *node) walk(fn func(*node)) {
+	fn(n)
+	for _, c := range n.children {
+		c.walk(fn)
+	}
+}
+
+// pattern is a compiled code pattern: a single node, or a sequence of
+// statements when the pattern spans several.
+type pattern struct {
+	src  []byte
+	root *node
+	seq  []*node
+}
+
+// parsePattern compiles a Semgrep code pattern for lang.
+func parsePattern(ctx context.Context, text string, lang *language) (*pattern, error) {
+	body := strings.TrimSpace(preprocessPattern(text, lang))
+	if body == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	parser := sitter.NewParser()
+	defer parser.Close()
+	parser.SetLanguage(lang.grammar)
+
+	for _, tmpl := range lang.templates {
+		src := []byte(tmpl.prefix + body + tmpl.suffix)
+		tree, err := parser.ParseCtx(ctx, nil, src)
+		if err != nil {
+			return nil, err
+		}
+		root := tree.RootNode()
+		if root.HasError() {
+			tree.Close()
+			continue
+		}
+		converted := convertTree(root)
+		tree.Close()
+
+		start := uint32(len(tmpl.prefix))
+		p := &pattern{src: src}
+		p.extract(converted, start, start+uint32(len(body)))
+		if p.root == nil && len(p.seq) == 0 {
+			continue
+		}
+		return p, nil
+	}
+	return nil, fmt.Errorf("pattern does not parse as %s: %s", lang.name, strings.TrimSpace(text))
+}
+
+// extract finds the pattern inside the template: the innermost node that
+// spans exactly [start, end), or else the children of the smallest node
+// covering the range that start inside it.
+func (p *pattern) extract(root *node, start, end uint32) {
+	var exact, container *node
+	root.walk(func(n *node) {
+		if n.start == start && n.end == end {
+			exact = n
+		}
+		if n.start <= start && n.end >= end {
+			container = n
+		}
+	})
+	annotate(root, p.src)
+
+	if exact != nil {
+		p.root = exact
+		return
+	}
+	if container == nil {
+		return
+	}
+	for _, c := range container.children {
+		if c.start >= start && c.start < end {
+			p.seq = append(p.seq, c)
+		}
+	}
+	if len(p.seq) == 1 {
+		p.root, p.seq = p.seq[0], nil
+	}
+}
+
+// annotate marks metavariables, ellipses and "..." string literals.
+func annotate(n *node, src []byte) {
+	for _, c := range n.children {
+		annotate(c, src)
+	}
+	text := string(src[n.start:n.end])
+	switch {
+	case n.named && len(n.children) == 0 && strings.HasPrefix(text, metavarPrefix):
+		n.metavar = "$" + strings.TrimPrefix(text, metavarPrefix)
+	case n.named && strings.TrimRight(text, "; \t\r\n") == ellipsisIdent:
+		// The identifier itself, or a statement or element wrapping it.
+		n.ellipsis = true
+	case n.named && strings.Contains(n.kind, "string") && isEllipsisString(text):
+		n.anyString = true
+	}
+}
+
+func isEllipsisString(text string) bool {
+	return len(text) == 5 && text[1:4] == "..." && text[0] == text[4]
+}
+
+// preprocessPattern rewrites metavariables ($X) and ellipses (..., and
+// $...X) outside string literals into identifiers the grammar accepts. An
+// ellipsis standing for statements gets the language's statement end.
+func preprocessPattern(text string, lang *language) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case strings.IndexByte(lang.quotes, c) >= 0:
+			end := stringEnd(text, i)
+			b.WriteString(text[i:end])
+			i = end
+		case strings.HasPrefix(text[i:], "$...") && isMetavarStart(text, i+4):
+			b.WriteString(ellipsisIdent)
+			i = metavarEnd(text, i+4)
+		case c == '$' && isMetavarStart(text, i+1):
+			end := metavarEnd(text, i+1)
+			b.WriteString(metavarPrefix + text[i+1:end])
+			i = end
+		case strings.HasPrefix(text[i:], "...") && (i == 0 || !isIdentChar(text[i-1])):
+			b.WriteString(ellipsisIdent)
+			if isStatementPosition(b.String()[:b.Len()-len(ellipsisIdent)], text[i+3:]) {
+				b.WriteString(lang.statementEnd)
+			}
+			i += 3
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// isStatementPosition reports whether an ellipsis between before and after
+// stands alone where a statement would: at the start of a line or after a
+// block or statement end, and followed by a line end or a block end.
+func isStatementPosition(before, after string) bool {
+	before = strings.TrimRight(before, " \t")
+	after = strings.TrimLeft(after, " \t")
+	if before != "" && !strings.ContainsAny(before[len(before)-1:], "\n{};") {
+		return false
+	}
+	return after == "" || after[0] == '\n' || after[0] == '\r' || after[0] == '}'
+}
+
+// stringEnd returns the index just past the string literal opening at i.
+func stringEnd(text string, i int) int {
+	quote := text[i]
+	for j := i + 1; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		}
+	}
+	return len(text)
+}
+
+func isMetavarStart(text string, i int) bool {
+	return i < len(text) && (text[i] == '_' || (text[i] >= 'A' && text[i] <= 'Z'))
+}
+
+func metavarEnd(text string, i int) int {
+	for i < len(text) && (text[i] == '_' || (text[i] >= 'A' && text[i] <= 'Z') || (text[i] >= '0' && text[i] <= '9')) {
+		i++
+	}
+	return i
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"context"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+func TestPreprocessPattern(t *testing.T) {
+	tests := []struct {
+		lang    string
+		pattern string
+		want    string
+	}{
+		{"python", "hashlib.new($NAME)", "hashlib.new(__mv_NAME)"},
+		{"python", "f(..., $X)", "f(__sg_ellipsis__, __mv_X)"},
+		{"python", "f($...ARGS)", "f(__sg_ellipsis__)"},
+		{"python", `f("$X", '...')`, `f("$X", '...')`},
+		{"python", "$x.y", "$x.y"},
+		{"java", "if (legacy) { ... }", "if (legacy) { __sg_ellipsis__; }"},
+		{"java", "foo();\n...\nbar();", "foo();\n__sg_ellipsis__;\nbar();"},
+		{"go", "x := $F(...)\n...", "x := __mv_F(__sg_ellipsis__)\n__sg_ellipsis__"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := preprocessPattern(tt.pattern, lookupLanguage(tt.lang)); got != tt.want {
+				t.Errorf("preprocessPattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		lang    string
+		pattern string
+		seq     int
+	}{
+		{"java", "Cipher.getInstance($ALG)", 0},
+		{"java", "$C.init(...);\n$C.doFinal(...);", 2},
+		{"python", "hashlib.md5(...)", 0},
+		{"go", "gcm, err := cipher.NewGCM($B)", 0},
+		{"c", "EVP_aes_128_cbc()", 0},
+		{"rust", "Sha256::new()", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			p, err := parsePattern(context.Background(), tt.pattern, lookupLanguage(tt.lang))
+			if err != nil {
+				t.Fatalf("parsePattern() error = %v", err)
+			}
+			if len(p.seq) != tt.seq {
+				t.Errorf("sequence length = %d, want %d", len(p.seq), tt.seq)
+			}
+			if tt.seq == 0 && p.root == nil {
+				t.Error("expected a single-node pattern")
+			}
+		})
+	}
+
+	if _, err := parsePattern(context.Background(), "foo(((", lookupLanguage("python")); err == nil {
+		t.Error("expected an error for an unparsable pattern")
+	}
+	if _, err := parsePattern(context.Background(), "  ", lookupLanguage("python")); err == nil {
+		t.Error("expected an error for an empty pattern")
+	}
+}
+
+// parseTarget parses src as lang for matching.
+func parseTarget(t *testing.T, lang, ext, src string) *target {
+	t.Helper()
+	parser := sitter.NewParser()
+	defer parser.Close()
+	parser.SetLanguage(lookupLanguage(lang).grammarFor(ext))
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(src))
+	if err != nil {
+		t.Fatalf("ParseCtx() error = %v", err)
+	}
+	defer tree.Close()
+	return newTarget([]byte(src), convertTree(tree.RootNode()))
+}
+
+func TestPattern_FindAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		ext     string
+		pattern string
+		src     string
+		want    []string
+	}{
+		{
+			name:    "metavariable binds argument",
+			lang:    "python",
+			ext:     ".py",
+			pattern: "hashlib.new($NAME)",
+			src:     "hashlib.new('sha1')\nhashlib.new(name)\nhashlib.sha1()\n",
+			want:    []string{"hashlib.new('sha1')", "hashlib.new(name)"},
+		},
+		{
+			name:    "repeated metavariable must agree",
+			lang:    "go",
+			ext:     ".go",
+			pattern: "hmac.Equal($X, $X)",
+			src:     "package p\nfunc f() {\n\thmac.Equal(a, a)\n\thmac.Equal(a, b)\n}\n",
+			want:    []string{"hmac.Equal(a, a)"},
+		},
+		{
+			name:    "ellipsis spans arguments",
+			lang:    "javascript",
+			ext:     ".js",
+			pattern: "crypto.createHash('md5', ...)",
+			src:     "crypto.createHash('md5')\ncrypto.createHash('md5', opts)\ncrypto.createHash('sha256')\n",
+			want:    []string{"crypto.createHash('md5')", "crypto.createHash('md5', opts)"},
+		},
+		{
+			name:    "any string literal",
+			lang:    "java",
+			ext:     ".java",
+			pattern: `Cipher.getInstance("...")`,
+			src:     "class A { void f() { Cipher.getInstance(\"DES\"); Cipher.getInstance(alg); } }",
+			want:    []string{`Cipher.getInstance("DES")`},
+		},
+		{
+			name:    "statement sequence",
+			lang:    "java",
+			ext:     ".java",
+			pattern: "$C.init(...);\n...\n$C.doFinal(...);",
+			src:     "class A { void f() { c.init(k); log(); c.doFinal(d); d.init(k); e.doFinal(x); } }",
+			want:    []string{"c.init(k); log(); c.doFinal(d);"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := parsePattern(context.Background(), tt.pattern, lookupLanguage(tt.lang))
+			if err != nil {
+				t.Fatalf("parsePattern() error = %v", err)
+			}
+			target := parseTarget(t, tt.lang, tt.ext, tt.src)
+			var got []string
+			for _, m := range dedupe(p.findAll(target)) {
+				got = append(got, target.text(m.start, m.end))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matches = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("match %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
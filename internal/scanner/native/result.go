@@ -0,0 +1,100 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// messageMetavar matches metavariable references in rule messages.
+var messageMetavar = regexp.MustCompile(`\$[A-Z_][A-Z0-9_]*|\$[0-9]+`)
+
+// lineIndex converts byte offsets to Semgrep's 1-based lines and columns.
+type lineIndex struct {
+	src    []byte
+	starts []uint32
+}
+
+func newLineIndex(src []byte) *lineIndex {
+	starts := []uint32{0}
+	for i, c := range src {
+		if c == '\n' {
+			starts = append(starts, uint32(i+1))
+		}
+	}
+	return &lineIndex{src: src, starts: starts}
+}
+
+// location returns the Semgrep location of a byte offset. Columns count
+// bytes, as in tree-sitter.
+func (l *lineIndex) location(offset uint32) entities.SemgrepLocation {
+	line := sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > offset }) - 1
+	return entities.SemgrepLocation{
+		Line:   line + 1,
+		Col:    int(offset-l.starts[line]) + 1,
+		Offset: int(offset),
+	}
+}
+
+// lines returns the full source lines spanned by [start, end).
+func (l *lineIndex) lines(start, end uint32) string {
+	from := l.starts[l.location(start).Line-1]
+	to := end
+	for int(to) < len(l.src) && l.src[to] != '\n' {
+		to++
+	}
+	return string(l.src[from:to])
+}
+
+// result renders a match the way Semgrep reports it.
+func (r *rule) result(path string, t *target, lines *lineIndex, m match) entities.SemgrepResult {
+	metavars := make(map[string]entities.MetavarInfo, len(m.bindings))
+	for name, b := range m.bindings {
+		var info entities.MetavarInfo
+		start, end := lines.location(b.start), lines.location(b.end)
+		info.Start.Line, info.Start.Col, info.Start.Offset = start.Line, start.Col, start.Offset
+		info.End.Line, info.End.Col, info.End.Offset = end.Line, end.Col, end.Offset
+		info.AbstractContent = t.text(b.start, b.end)
+		metavars[name] = info
+	}
+
+	message := messageMetavar.ReplaceAllStringFunc(r.message, func(name string) string {
+		if info, ok := metavars[name]; ok {
+			return info.AbstractContent
+		}
+		return name
+	})
+
+	return entities.SemgrepResult{
+		CheckID: r.id,
+		Path:    path,
+		Start:   lines.location(m.start),
+		End:     lines.location(m.end),
+		Extra: entities.SemgrepExtra{
+			Message:  message,
+			Metadata: r.metadata,
+			Metavars: metavars,
+			Severity: r.severity,
+			Lines:    lines.lines(m.start, m.end),
+		},
+		Message:  message,
+		Severity: r.severity,
+	}
+}
@@ -0,0 +1,522 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/skip"
+)
+
+// ruleSpec is the YAML layout of one Semgrep rule.
+type ruleSpec struct {
+	ID        string         `yaml:"id"`
+	Message   string         `yaml:"message"`
+	Severity  string         `yaml:"severity"`
+	Languages []string       `yaml:"languages"`
+	Mode      string         `yaml:"mode"`
+	Metadata  map[string]any `yaml:"metadata"`
+	Paths     struct {
+		Include []string `yaml:"include"`
+		Exclude []string `yaml:"exclude"`
+	} `yaml:"paths"`
+	Rest map[string]any `yaml:",inline"`
+}
+
+// formulaKeys are the top-level rule keys that hold the match formula.
+var formulaKeys = []string{"pattern", "patterns", "pattern-either", "pattern-regex"}
+
+// rule is a rule compiled for one language.
+type rule struct {
+	id       string
+	message  string
+	severity string
+	lang     *language
+	formula  *formula
+	metadata entities.SemgrepMetadata
+	include  *skip.GitIgnoreMatcher
+	exclude  *skip.GitIgnoreMatcher
+}
+
+// appliesTo reports whether the rule's paths filter admits relPath.
+func (r *rule) appliesTo(relPath string) bool {
+	if r.include != nil && !r.include.ShouldSkip(relPath, false) {
+		return false
+	}
+	return r.exclude == nil || !r.exclude.ShouldSkip(relPath, false)
+}
+
+// formula is a compiled match formula. Exactly one field is set.
+type formula struct {
+	pattern *pattern
+	regex   *regexp.Regexp
+	either  []*formula
+	all     *conjunction
+}
+
+// conjunction is a compiled patterns block.
+type conjunction struct {
+	positive  []*formula
+	inside    []*formula
+	not       []*formula
+	notInside []*formula
+	regexes   []metavariableRegex
+}
+
+// metavariableRegex is a metavariable-regex condition.
+type metavariableRegex struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// compiler compiles rules, sharing parsed patterns between them.
+type compiler struct {
+	ctx      context.Context
+	patterns map[string]*pattern
+}
+
+func newCompiler(ctx context.Context) *compiler {
+	return &compiler{ctx: ctx, patterns: make(map[string]*pattern)}
+}
+
+// loadRuleFile compiles the rules of one file. Rules the native scanner
+// cannot evaluate are reported as errors and left out; the file only
+// fails as a whole when it cannot be read or parsed.
+func (c *compiler) loadRuleFile(path string) ([]*rule, []error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read rule file %s: %w", path, err)
+	}
+	var file struct {
+		Rules []ruleSpec `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse rule file %s: %w", path, err)
+	}
+
+	var compiled []*rule
+	var ruleErrs []error
+	for i := range file.Rules {
+		rules, err := c.compileRule(&file.Rules[i])
+		if err != nil {
+			ruleErrs = append(ruleErrs, fmt.Errorf("rule %s: %w", file.Rules[i].ID, err))
+			continue
+		}
+		compiled = append(compiled, rules...)
+	}
+	return compiled, ruleErrs, nil
+}
+
+// compileRule compiles a rule once per supported language it lists.
+func (c *compiler) compileRule(spec *ruleSpec) ([]*rule, error) {
+	if spec.ID == "" {
+		return nil, fmt.Errorf("rule has no id")
+	}
+	if spec.Mode != "" && spec.Mode != "search" {
+		return nil, fmt.Errorf("mode %q is not supported", spec.Mode)
+	}
+	key, value, err := ruleFormula(spec.Rest)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := convertMetadata(spec.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var include, exclude *skip.GitIgnoreMatcher
+	if len(spec.Paths.Include) > 0 {
+		include = skip.NewGitIgnoreMatcher(spec.Paths.Include)
+	}
+	if len(spec.Paths.Exclude) > 0 {
+		exclude = skip.NewGitIgnoreMatcher(spec.Paths.Exclude)
+	}
+
+	var compiled []*rule
+	var unsupported []string
+	for _, name := range spec.Languages {
+		lang := lookupLanguage(name)
+		if lang == nil {
+			unsupported = append(unsupported, name)
+			continue
+		}
+		f, err := c.compileFormula(key, value, lang)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, &rule{
+			id:       spec.ID,
+			message:  spec.Message,
+			severity: spec.Severity,
+			lang:     lang,
+			formula:  f,
+			metadata: metadata,
+			include:  include,
+			exclude:  exclude,
+		})
+	}
+	if len(compiled) == 0 {
+		return nil, fmt.Errorf("no supported language in %v", unsupported)
+	}
+	return compiled, nil
+}
+
+// ruleFormula returns the single formula key of a rule.
+func ruleFormula(rest map[string]any) (string, any, error) {
+	var found []string
+	for _, key := range formulaKeys {
+		if _, ok := rest[key]; ok {
+			found = append(found, key)
+		}
+	}
+	switch len(found) {
+	case 1:
+		return found[0], rest[found[0]], nil
+	case 0:
+		for _, key := range []string{"match", "pattern-sources"} {
+			if _, ok := rest[key]; ok {
+				return "", nil, fmt.Errorf("%s is not supported", key)
+			}
+		}
+		return "", nil, fmt.Errorf("rule has no pattern")
+	default:
+		return "", nil, fmt.Errorf("rule has more than one of %s", strings.Join(found, ", "))
+	}
+}
+
+// convertMetadata converts rule metadata to the form Semgrep reports it in.
+func convertMetadata(raw map[string]any) (entities.SemgrepMetadata, error) {
+	var metadata entities.SemgrepMetadata
+	if len(raw) == 0 {
+		return metadata, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return metadata, fmt.Errorf("invalid metadata: %w", err)
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return metadata, fmt.Errorf("invalid metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+func (c *compiler) compileFormula(key string, value any, lang *language) (*formula, error) {
+	switch key {
+	case "pattern", "pattern-inside", "pattern-not", "pattern-not-inside":
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a string", key)
+		}
+		p, err := c.compilePattern(text, lang)
+		if err != nil {
+			return nil, err
+		}
+		return &formula{pattern: p}, nil
+	case "pattern-regex":
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a string", key)
+		}
+		re, err := regexp.Compile(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern-regex: %w", err)
+		}
+		return &formula{regex: re}, nil
+	case "pattern-either":
+		items, err := formulaItems(key, value)
+		if err != nil {
+			return nil, err
+		}
+		f := &formula{}
+		for _, item := range items {
+			child, err := c.compileFormula(item.key, item.value, lang)
+			if err != nil {
+				return nil, err
+			}
+			f.either = append(f.either, child)
+		}
+		return f, nil
+	case "patterns":
+		return c.compileConjunction(value, lang)
+	default:
+		return nil, fmt.Errorf("%s is not supported", key)
+	}
+}
+
+func (c *compiler) compileConjunction(value any, lang *language) (*formula, error) {
+	items, err := formulaItems("patterns", value)
+	if err != nil {
+		return nil, err
+	}
+	all := &conjunction{}
+	for _, item := range items {
+		if item.key == "metavariable-regex" {
+			condition, err := compileMetavariableRegex(item.value)
+			if err != nil {
+				return nil, err
+			}
+			all.regexes = append(all.regexes, condition)
+			continue
+		}
+		child, err := c.compileFormula(item.key, item.value, lang)
+		if err != nil {
+			return nil, err
+		}
+		switch item.key {
+		case "pattern-inside":
+			all.inside = append(all.inside, child)
+		case "pattern-not":
+			all.not = append(all.not, child)
+		case "pattern-not-inside":
+			all.notInside = append(all.notInside, child)
+		default:
+			all.positive = append(all.positive, child)
+		}
+	}
+	if len(all.positive) == 0 && len(all.inside) == 0 {
+		return nil, fmt.Errorf("patterns needs at least one positive pattern")
+	}
+	if len(all.positive) == 0 {
+		// A block of pattern-insides alone matches the innermost region.
+		all.positive, all.inside = all.inside[len(all.inside)-1:], all.inside[:len(all.inside)-1]
+	}
+	return &formula{all: all}, nil
+}
+
+func compileMetavariableRegex(value any) (metavariableRegex, error) {
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return metavariableRegex{}, fmt.Errorf("metavariable-regex must be a mapping")
+	}
+	name, _ := spec["metavariable"].(string)
+	expr, _ := spec["regex"].(string)
+	if name == "" || expr == "" {
+		return metavariableRegex{}, fmt.Errorf("metavariable-regex needs metavariable and regex")
+	}
+	// Semgrep anchors the regex at the start of the value.
+	re, err := regexp.Compile(`^(?:` + expr + `)`)
+	if err != nil {
+		return metavariableRegex{}, fmt.Errorf("invalid metavariable-regex for %s: %w", name, err)
+	}
+	return metavariableRegex{name: name, re: re}, nil
+}
+
+func (c *compiler) compilePattern(text string, lang *language) (*pattern, error) {
+	key := lang.name + "\x00" + text
+	if p, ok := c.patterns[key]; ok {
+		return p, nil
+	}
+	p, err := parsePattern(c.ctx, text, lang)
+	if err != nil {
+		return nil, err
+	}
+	c.patterns[key] = p
+	return p, nil
+}
+
+type formulaItem struct {
+	key   string
+	value any
+}
+
+// formulaItems splits a list of single-key mappings.
+func formulaItems(key string, value any) ([]formulaItem, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", key)
+	}
+	items := make([]formulaItem, 0, len(list))
+	for _, entry := range list {
+		mapping, ok := entry.(map[string]any)
+		if !ok || len(mapping) != 1 {
+			return nil, fmt.Errorf("%s entries must be mappings with a single key", key)
+		}
+		for k, v := range mapping {
+			items = append(items, formulaItem{key: k, value: v})
+		}
+	}
+	return items, nil
+}
+
+// eval returns the matches of f in t.
+func (f *formula) eval(t *target) []match {
+	switch {
+	case f.pattern != nil:
+		return f.pattern.findAll(t)
+	case f.regex != nil:
+		return regexMatches(f.regex, t, 0, uint32(len(t.src)))
+	case f.all != nil:
+		return f.all.eval(t)
+	default:
+		var matches []match
+		for _, child := range f.either {
+			matches = append(matches, child.eval(t)...)
+		}
+		return matches
+	}
+}
+
+func (c *conjunction) eval(t *target) []match {
+	matches := c.positive[0].eval(t)
+	for _, f := range c.positive[1:] {
+		matches = constrain(t, matches, f.eval(t), sameRange, true)
+	}
+	for _, f := range c.inside {
+		matches = constrain(t, matches, f.eval(t), contains, true)
+	}
+	for _, f := range c.not {
+		matches = constrain(t, matches, f.eval(t), sameRange, false)
+	}
+	for _, f := range c.notInside {
+		matches = constrain(t, matches, f.eval(t), contains, false)
+	}
+	for _, condition := range c.regexes {
+		matches = condition.filter(t, matches)
+	}
+	return matches
+}
+
+func sameRange(m, other match) bool {
+	return m.start == other.start && m.end == other.end
+}
+
+func contains(m, region match) bool {
+	return region.start <= m.start && m.end <= region.end
+}
+
+// constrain keeps the matches that relate to one of others (keep) or to
+// none of them (!keep). Kept matches take over the related match's
+// bindings; related matches must bind shared metavariables alike.
+func constrain(t *target, matches, others []match, related func(m, other match) bool, keep bool) []match {
+	var kept []match
+	for _, m := range matches {
+		found := false
+		for _, other := range others {
+			if !related(m, other) {
+				continue
+			}
+			merged, ok := merge(t, m.bindings, other.bindings)
+			if !ok {
+				continue
+			}
+			found = true
+			if keep {
+				m.bindings = merged
+			}
+			break
+		}
+		if found == keep {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// filter keeps the matches whose metavariable matches the regex. A string
+// literal matches when either its source text or its content does. Named
+// groups bind new metavariables.
+func (c metavariableRegex) filter(t *target, matches []match) []match {
+	var kept []match
+	for _, m := range matches {
+		bound, ok := m.bindings[c.name]
+		if !ok {
+			continue
+		}
+		start, end := bound.start, bound.end
+		text := t.text(start, end)
+		loc := c.re.FindStringSubmatchIndex(text)
+		if loc == nil && len(text) >= 2 && strings.ContainsRune(`"'`+"`", rune(text[0])) && text[len(text)-1] == text[0] {
+			start, end = start+1, end-1
+			loc = c.re.FindStringSubmatchIndex(t.text(start, end))
+		}
+		if loc == nil {
+			continue
+		}
+		if b, ok := groupBindings(c.re, loc, start, m.bindings, t); ok {
+			m.bindings = b
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
+
+// regexMatches returns the matches of re in t.src[start:end], binding
+// numbered groups as $1, $2, ... and named groups by name.
+func regexMatches(re *regexp.Regexp, t *target, start, end uint32) []match {
+	var matches []match
+	for _, loc := range re.FindAllSubmatchIndex(t.src[start:end], -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		b, ok := groupBindings(re, loc, start, nil, t)
+		if !ok {
+			continue
+		}
+		matches = append(matches, match{start: start + uint32(loc[0]), end: start + uint32(loc[1]), bindings: b})
+	}
+	return matches
+}
+
+func groupBindings(re *regexp.Regexp, loc []int, offset uint32, b bindings, t *target) (bindings, bool) {
+	names := re.SubexpNames()
+	groups := make(bindings)
+	for i := 1; i < len(names); i++ {
+		if loc[2*i] < 0 {
+			continue
+		}
+		name := names[i]
+		if name == "" {
+			name = fmt.Sprint(i)
+		}
+		groups["$"+name] = binding{start: offset + uint32(loc[2*i]), end: offset + uint32(loc[2*i+1])}
+	}
+	return merge(t, b, groups)
+}
+
+// dedupe drops repeated ranges and ranges strictly inside another match,
+// as Semgrep reports only the outermost match of a rule.
+func dedupe(matches []match) []match {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+	var kept []match
+	for _, m := range matches {
+		nested := false
+		for _, k := range kept {
+			if contains(m, k) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
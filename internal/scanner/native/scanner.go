@@ -0,0 +1,462 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+// Package native provides a built-in scanner that evaluates Semgrep rules on
+// tree-sitter syntax trees, so scans need no OpenGrep or Semgrep install.
+//
+// It supports the subset of the rule language the crypto rules use:
+// pattern, patterns, pattern-either, pattern-inside, pattern-not,
+// pattern-not-inside, pattern-regex and metavariable-regex, with
+// metavariables and ellipses in patterns. Rules using anything else are
+// skipped with a warning.
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/scanner/semgrep"
+	"github.com/scanoss/crypto-finder/internal/skip"
+	"github.com/scanoss/crypto-finder/internal/version"
+)
+
+// ScannerName is the identifier for the native scanner.
+const ScannerName = "native"
+
+// maxTargetBytes skips files larger than this, like Semgrep's default
+// --max-target-bytes.
+const maxTargetBytes = 1_000_000
+
+// Scanner implements the scanner.Scanner interface without an external
+// executable.
+type Scanner struct {
+	timeout      time.Duration
+	skipPatterns []string
+	disableDedup bool
+	workers      int
+}
+
+// NewScanner creates a native scanner with default settings.
+func NewScanner() *Scanner {
+	return &Scanner{
+		timeout: 10 * time.Minute,
+		workers: runtime.GOMAXPROCS(0),
+	}
+}
+
+// Initialize applies the scanner configuration. The executable path and
+// extra arguments do not apply to the native scanner and are ignored.
+func (s *Scanner) Initialize(ctx context.Context, config scanner.Config) error {
+	if ctxErr := scanner.InitializationContextError(ctx, ScannerName); ctxErr != nil {
+		return ctxErr
+	}
+	if config.Timeout > 0 {
+		s.timeout = config.Timeout
+	}
+	s.skipPatterns = config.SkipPatterns
+	s.disableDedup = config.DisableDedup
+	if config.Interfile {
+		log.Warn().Msg("The native scanner does not support interfile analysis, scanning file by file")
+	}
+	return nil
+}
+
+// Scan evaluates the rules in rulePaths against the target.
+func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, error) {
+	report, _, err := s.ScanWithRuleTimes(ctx, target, rulePaths, toolInfo)
+	return report, err
+}
+
+// ScanWithRuleTimes scans like Scan and also returns the time spent
+// matching each rule.
+func (s *Scanner) ScanWithRuleTimes(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, map[string]time.Duration, error) {
+	if len(rulePaths) == 0 {
+		return nil, nil, failure.New(
+			failure.CodeRulesLoadFailed,
+			failure.StageRules,
+			"no rule paths provided",
+			failure.WithDetail("scanner", ScannerName),
+		)
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	startTime := time.Now()
+
+	rules, ruleErrors, err := loadRules(ctx, rulePaths)
+	if err != nil {
+		return nil, nil, failure.Wrap(err, failure.CodeRulesLoadFailed, failure.StageRules,
+			"failed to load rules", failure.WithDetail("scanner", ScannerName))
+	}
+	if len(rules) == 0 {
+		return nil, nil, failure.New(
+			failure.CodeRulesLoadFailed,
+			failure.StageRules,
+			"none of the rules can be evaluated by the native scanner",
+			failure.WithDetail("scanner", ScannerName),
+		)
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil, nil, failure.Wrap(err, failure.CodeInvalidArguments, failure.StageInput,
+			"scan target not found", failure.WithDetail("scanner", ScannerName))
+	}
+	files, err := s.collectTargets(target, info, rules)
+	if err != nil {
+		return nil, nil, failure.Wrap(err, failure.CodeScannerExecutionFailed, failure.StageScan,
+			"failed to list scan targets", failure.WithDetail("scanner", ScannerName))
+	}
+
+	root := target
+	if !info.IsDir() {
+		root = filepath.Dir(target)
+	}
+	results, times, err := s.scanFiles(ctx, root, files, rules)
+	if err != nil {
+		return nil, nil, s.contextFailure(ctx, err)
+	}
+
+	output := &entities.SemgrepOutput{Results: results, Errors: ruleErrors}
+	semgrep.LogSemgrepCompatibleErrors(output.Errors)
+
+	duration := time.Since(startTime)
+	log.Info().
+		Dur("duration", duration).
+		Int("files", len(files)).
+		Int("findings", len(results)).
+		Msgf("native scanner completed in %.2fs", duration.Seconds())
+
+	report := semgrep.TransformSemgrepCompatibleOutputToInterimFormat(output, toolInfo, target, rulePaths, s.disableDedup)
+	return report, times, nil
+}
+
+// GetInfo returns metadata about the native scanner.
+func (s *Scanner) GetInfo() scanner.Info {
+	return scanner.Info{
+		Name:        ScannerName,
+		Version:     version.Version,
+		Description: "Built-in Semgrep rule evaluation on tree-sitter syntax trees",
+	}
+}
+
+// contextFailure maps a scan error caused by the context to the scanner
+// timeout and cancellation failures.
+func (s *Scanner) contextFailure(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return failure.New(
+			failure.CodeScannerTimeout,
+			failure.StageScan,
+			fmt.Sprintf("native scan timed out after %v", s.timeout),
+			failure.WithRetryable(true),
+			failure.WithDetail("scanner", ScannerName),
+		)
+	case ctx.Err() != nil:
+		return failure.Wrap(ctx.Err(), failure.CodeScannerCancelled, failure.StageScan,
+			"native scan canceled", failure.WithDetail("scanner", ScannerName))
+	default:
+		return failure.Wrap(err, failure.CodeScannerExecutionFailed, failure.StageScan,
+			"native scan failed", failure.WithDetail("scanner", ScannerName))
+	}
+}
+
+// loadRules compiles the rules of every rule file. Rules that cannot be
+// evaluated natively come back as warnings in Semgrep's error format.
+func loadRules(ctx context.Context, rulePaths []string) ([]*rule, []entities.SemgrepError, error) {
+	files, err := ruleFiles(rulePaths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := newCompiler(ctx)
+	var rules []*rule
+	var ruleErrors []entities.SemgrepError
+	for _, path := range files {
+		compiled, errs, err := c.loadRuleFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		rules = append(rules, compiled...)
+		for _, ruleErr := range errs {
+			ruleErrors = append(ruleErrors, entities.SemgrepError{
+				Type:    "Rule not supported",
+				Level:   "warn",
+				Message: ruleErr.Error(),
+				Path:    path,
+			})
+		}
+	}
+	log.Debug().Int("rules", len(rules)).Int("skipped", len(ruleErrors)).Msg("Compiled rules for the native scanner")
+	return rules, ruleErrors, nil
+}
+
+// ruleFiles expands rule directories into their YAML files.
+func ruleFiles(rulePaths []string) ([]string, error) {
+	var files []string
+	for _, root := range rulePaths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules %s: %w", root, err)
+		}
+	}
+	return files, nil
+}
+
+// collectTargets lists the files under target that some rule's language
+// covers, honouring the skip patterns.
+func (s *Scanner) collectTargets(target string, info os.FileInfo, rules []*rule) ([]string, error) {
+	wanted := make(map[*language]bool)
+	for _, r := range rules {
+		wanted[r.lang] = true
+	}
+	scannable := func(path string) bool {
+		for _, l := range languagesForFile(path) {
+			if wanted[l] {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !info.IsDir() {
+		if scannable(target) {
+			return []string{target}, nil
+		}
+		return nil, nil
+	}
+
+	matcher := skip.NewGitIgnoreMatcher(s.skipPatterns)
+	var files []string
+	err := filepath.WalkDir(target, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == target {
+			return nil
+		}
+		rel, relErr := filepath.Rel(target, path)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if matcher.ShouldSkip(rel, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || matcher.ShouldSkip(rel, false) || !scannable(path) {
+			return nil
+		}
+		if fileInfo, err := d.Info(); err == nil && fileInfo.Size() > maxTargetBytes {
+			log.Debug().Str("path", path).Int64("size", fileInfo.Size()).Msg("Skipping file larger than the native scanner limit")
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// scanFiles matches the rules against the files on s.workers goroutines.
+// Rule path filters are applied to paths relative to root.
+func (s *Scanner) scanFiles(ctx context.Context, root string, files []string, rules []*rule) ([]entities.SemgrepResult, map[string]time.Duration, error) {
+	byLanguage := make(map[*language][]*rule)
+	times := make(map[string]time.Duration)
+	for _, r := range rules {
+		byLanguage[r.lang] = append(byLanguage[r.lang], r)
+		times[r.id] = 0
+	}
+
+	jobs := make(chan string)
+	var (
+		mu       sync.Mutex
+		results  []entities.SemgrepResult
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	workers := max(1, min(s.workers, len(files)))
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := newWorker(root, byLanguage)
+			defer w.close()
+			for path := range jobs {
+				fileResults, err := w.scanFile(ctx, path)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				results = append(results, fileResults...)
+				mu.Unlock()
+			}
+			mu.Lock()
+			for id, d := range w.times {
+				times[id] += d
+			}
+			mu.Unlock()
+		}()
+	}
+
+feed:
+	for _, path := range files {
+		select {
+		case jobs <- path:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, nil, firstErr
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Path != results[j].Path {
+			return results[i].Path < results[j].Path
+		}
+		if results[i].Start.Offset != results[j].Start.Offset {
+			return results[i].Start.Offset < results[j].Start.Offset
+		}
+		return results[i].CheckID < results[j].CheckID
+	})
+	return results, times, nil
+}
+
+// worker owns one tree-sitter parser per grammar; parsers are not safe for
+// concurrent use.
+type worker struct {
+	root    string
+	rules   map[*language][]*rule
+	parsers map[*sitter.Language]*sitter.Parser
+	times   map[string]time.Duration
+}
+
+func newWorker(root string, rules map[*language][]*rule) *worker {
+	return &worker{
+		root:    root,
+		rules:   rules,
+		parsers: make(map[*sitter.Language]*sitter.Parser),
+		times:   make(map[string]time.Duration),
+	}
+}
+
+func (w *worker) close() {
+	for _, p := range w.parsers {
+		p.Close()
+	}
+}
+
+func (w *worker) parser(grammar *sitter.Language) *sitter.Parser {
+	p, ok := w.parsers[grammar]
+	if !ok {
+		p = sitter.NewParser()
+		p.SetLanguage(grammar)
+		w.parsers[grammar] = p
+	}
+	return p
+}
+
+// scanFile parses path once per language that has rules for it and
+// matches those rules. Unreadable files are skipped.
+func (w *worker) scanFile(ctx context.Context, path string) ([]entities.SemgrepResult, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("Skipping unreadable file")
+		return nil, nil
+	}
+	relPath := path
+	if rel, err := filepath.Rel(w.root, path); err == nil {
+		relPath = filepath.ToSlash(rel)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+
+	// A rule for both C and C++ sees headers twice; report each match once.
+	type matchKey struct {
+		id         string
+		start, end uint32
+	}
+	seen := make(map[matchKey]bool)
+	var results []entities.SemgrepResult
+	lines := newLineIndex(src)
+	for _, lang := range languagesForFile(path) {
+		rules := w.rules[lang]
+		if len(rules) == 0 {
+			continue
+		}
+		tree, err := w.parser(lang.grammarFor(ext)).ParseCtx(ctx, nil, src)
+		if err != nil {
+			return nil, err
+		}
+		t := newTarget(src, convertTree(tree.RootNode()))
+		tree.Close()
+
+		for _, r := range rules {
+			if !r.appliesTo(relPath) {
+				continue
+			}
+			start := time.Now()
+			matches := dedupe(r.formula.eval(t))
+			w.times[r.id] += time.Since(start)
+			for _, m := range matches {
+				key := matchKey{id: r.id, start: m.start, end: m.end}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				results = append(results, r.result(path, t, lines, m))
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,293 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+	"github.com/scanoss/crypto-finder/internal/failure"
+	"github.com/scanoss/crypto-finder/internal/ruletest"
+	"github.com/scanoss/crypto-finder/internal/scanner"
+	"github.com/scanoss/crypto-finder/internal/scanner/opengrep"
+)
+
+func newTestScanner(t *testing.T, config scanner.Config) *Scanner {
+	t.Helper()
+	s := NewScanner()
+	if err := s.Initialize(context.Background(), config); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	return s
+}
+
+func scanFixtures(t *testing.T, s scanner.Scanner, dir string) *entities.InterimReport {
+	t.Helper()
+	report, err := s.Scan(context.Background(), dir, []string{filepath.Join(dir, "rules.yaml")}, entities.ToolInfo{Name: "test", Version: "1.0"})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	return report
+}
+
+// matchKeys lists the file, start line and rule ID of every finding.
+func matchKeys(report *entities.InterimReport) []string {
+	var keys []string
+	for _, finding := range report.Findings {
+		for _, asset := range finding.CryptographicAssets {
+			for _, rule := range asset.Rules {
+				keys = append(keys, fmt.Sprintf("%s:%03d:%s", filepath.ToSlash(finding.FilePath), asset.StartLine, rule.ID))
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func expectationKeys(fixtures []ruletest.Fixture) []string {
+	var keys []string
+	for _, fixture := range fixtures {
+		for _, e := range fixture.Expectations {
+			keys = append(keys, fmt.Sprintf("%s:%03d:%s", e.File, e.Line, e.RuleID))
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestScanner_Fixtures(t *testing.T) {
+	dir := filepath.Join("testdata", "fixtures")
+	report := scanFixtures(t, newTestScanner(t, scanner.Config{}), dir)
+
+	fixtures, err := ruletest.LoadFixtures(dir)
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	rules, err := ruletest.LoadRules([]string{filepath.Join(dir, "rules.yaml")})
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+	result := ruletest.Compare(report, fixtures, rules)
+	for _, f := range result.Failures {
+		t.Errorf("%s:%d %s: %s", f.File, f.Line, f.RuleID, f.Message)
+	}
+	if len(result.Untested) > 0 {
+		t.Errorf("rules without fixtures: %v", result.Untested)
+	}
+}
+
+func TestScanner_RuleTestFixtures(t *testing.T) {
+	// The rules test fixtures expect metadata the scanner does not produce
+	// on its own, so only the rule and line of each match are compared.
+	dir := filepath.Join("..", "..", "ruletest", "testdata", "fixtures")
+	report := scanFixtures(t, newTestScanner(t, scanner.Config{}), dir)
+
+	fixtures, err := ruletest.LoadFixtures(dir)
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+	if got, want := matchKeys(report), expectationKeys(fixtures); !reflect.DeepEqual(got, want) {
+		t.Errorf("matches = %v, want %v", got, want)
+	}
+}
+
+func TestScanner_GoTestdata(t *testing.T) {
+	target := filepath.Join("..", "..", "..", "testdata", "code", "go")
+	rules := filepath.Join("..", "..", "..", "testdata", "rules", "go.yaml")
+	report, err := newTestScanner(t, scanner.Config{}).Scan(context.Background(), target, []string{rules}, entities.ToolInfo{})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	want := []string{
+		"crypto_usage.go:026:go.crypto.tls.load-key-pair",
+		"crypto_usage.go:032:go.crypto.des.key-generation",
+	}
+	if got := matchKeys(report); !reflect.DeepEqual(got, want) {
+		t.Fatalf("matches = %v, want %v", got, want)
+	}
+	asset := report.Findings[0].CryptographicAssets[0]
+	if asset.Rules[0].ID == "go.crypto.des.key-generation" {
+		asset = report.Findings[0].CryptographicAssets[1]
+	}
+	if asset.StartCol != 15 || asset.EndCol != 57 {
+		t.Errorf("columns = %d-%d, want 15-57", asset.StartCol, asset.EndCol)
+	}
+	if asset.Metadata["api"] != "tls.LoadX509KeyPair" {
+		t.Errorf("metadata api = %q, want tls.LoadX509KeyPair", asset.Metadata["api"])
+	}
+}
+
+func TestScanner_ParityWithOpengrep(t *testing.T) {
+	if _, err := exec.LookPath("opengrep"); err != nil {
+		t.Skip("opengrep not found in PATH - skipping parity test")
+	}
+	og := opengrep.NewScanner()
+	if err := og.Initialize(context.Background(), scanner.Config{}); err != nil {
+		t.Skipf("opengrep unusable in this environment: %v", err)
+	}
+
+	for _, dir := range []string{
+		filepath.Join("testdata", "fixtures"),
+		filepath.Join("..", "..", "ruletest", "testdata", "fixtures"),
+	} {
+		want := matchKeys(scanFixtures(t, og, dir))
+		got := matchKeys(scanFixtures(t, newTestScanner(t, scanner.Config{}), dir))
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: native matches = %v, opengrep matches = %v", dir, got, want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const md5Rule = `
+  - id: python.md5
+    message: MD5
+    severity: INFO
+    languages: [python]
+    pattern: hashlib.md5(...)
+`
+
+func TestScanner_SkipsUnsupportedRules(t *testing.T) {
+	dir := t.TempDir()
+	rules := filepath.Join(dir, "rules", "rules.yaml")
+	writeFile(t, rules, "rules:"+md5Rule+`
+  - id: python.compare
+    message: Compare
+    severity: INFO
+    languages: [python]
+    patterns:
+      - pattern: hashlib.new($N)
+      - metavariable-comparison:
+          comparison: $N > 1
+`)
+	target := filepath.Join(dir, "src")
+	writeFile(t, filepath.Join(target, "app.py"), "import hashlib\nhashlib.md5(b'x')\nhashlib.new(2)\n")
+
+	report, err := newTestScanner(t, scanner.Config{}).Scan(context.Background(), target, []string{filepath.Dir(rules)}, entities.ToolInfo{})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if got, want := matchKeys(report), []string{"app.py:002:python.md5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matches = %v, want %v", got, want)
+	}
+}
+
+func TestScanner_Errors(t *testing.T) {
+	dir := t.TempDir()
+	unsupported := filepath.Join(dir, "unsupported.yaml")
+	writeFile(t, unsupported, `rules:
+  - id: taint
+    message: Taint
+    severity: INFO
+    languages: [python]
+    mode: taint
+`)
+	s := newTestScanner(t, scanner.Config{})
+
+	tests := []struct {
+		name      string
+		target    string
+		rulePaths []string
+		want      failure.Code
+	}{
+		{"no rule paths", dir, nil, failure.CodeRulesLoadFailed},
+		{"missing rules", dir, []string{filepath.Join(dir, "missing.yaml")}, failure.CodeRulesLoadFailed},
+		{"no supported rule", dir, []string{unsupported}, failure.CodeRulesLoadFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Scan(context.Background(), tt.target, tt.rulePaths, entities.ToolInfo{})
+			structured, ok := failure.As(err)
+			if !ok || structured.Code != tt.want {
+				t.Errorf("Scan() error = %v, want code %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_SkipPatterns(t *testing.T) {
+	dir := t.TempDir()
+	rules := filepath.Join(dir, "rules.yaml")
+	writeFile(t, rules, "rules:"+md5Rule)
+	writeFile(t, filepath.Join(dir, "app.py"), "hashlib.md5(b'x')\n")
+	writeFile(t, filepath.Join(dir, "vendor", "lib.py"), "hashlib.md5(b'x')\n")
+	writeFile(t, filepath.Join(dir, ".hidden", "lib.py"), "hashlib.md5(b'x')\n")
+
+	s := newTestScanner(t, scanner.Config{SkipPatterns: []string{"vendor/"}})
+	report, err := s.Scan(context.Background(), dir, []string{rules}, entities.ToolInfo{})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if got, want := matchKeys(report), []string{"app.py:001:python.md5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("matches = %v, want %v", got, want)
+	}
+}
+
+func TestScanner_ScanWithRuleTimes(t *testing.T) {
+	dir := filepath.Join("testdata", "fixtures")
+	_, times, err := newTestScanner(t, scanner.Config{}).ScanWithRuleTimes(context.Background(), dir, []string{filepath.Join(dir, "rules.yaml")}, entities.ToolInfo{})
+	if err != nil {
+		t.Fatalf("ScanWithRuleTimes() error = %v", err)
+	}
+	for _, id := range []string{"java.cipher.weak", "go.aes.gcm", "rust.sha2.sha256"} {
+		if _, ok := times[id]; !ok {
+			t.Errorf("no time for rule %s", id)
+		}
+	}
+	if _, ok := any(NewScanner()).(scanner.RuleProfiler); !ok {
+		t.Error("Expected native scanner to implement scanner.RuleProfiler")
+	}
+}
+
+func TestScanner_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dir := filepath.Join("testdata", "fixtures")
+	_, err := newTestScanner(t, scanner.Config{}).Scan(ctx, dir, []string{filepath.Join(dir, "rules.yaml")}, entities.ToolInfo{})
+	structured, ok := failure.As(err)
+	if !ok || structured.Code != failure.CodeScannerCancelled {
+		t.Fatalf("Scan() error = %v, want %s", err, failure.CodeScannerCancelled)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error does not wrap context.Canceled: %v", err)
+	}
+}
+
+func TestScanner_GetInfo(t *testing.T) {
+	if info := NewScanner().GetInfo(); info.Name != ScannerName {
+		t.Errorf("GetInfo().Name = %q, want %q", info.Name, ScannerName)
+	}
+}
@@ -0,0 +1,32 @@
+import java.security.MessageDigest;
+import javax.crypto.Cipher;
+
+class Crypto {
+    void ciphers(String alg) throws Exception {
+        Cipher aes = Cipher.getInstance("AES/GCM/NoPadding");
+        // expect: java.cipher.weak algorithmName=DES
+        Cipher des = Cipher.getInstance("DES");
+        Cipher rc4 = Cipher.getInstance("RC4"); // expect: java.cipher.weak algorithmName=RC4
+        Cipher dynamic = Cipher.getInstance(alg);
+    }
+
+    void passwords(String password, boolean strong) {
+        HashBuilder builder = Password.hash(password);
+        if (strong) {
+            // expect: java.password4j.hash algorithmPrimitive=kdf
+            builder.withScrypt();
+        }
+        // expect: java.password4j.hash
+        builder.withBcrypt();
+        other.withBcrypt();
+    }
+
+    void digests(boolean legacy) throws Exception {
+        if (legacy) {
+            MessageDigest md5 = MessageDigest.getInstance("MD5");
+        }
+        // expect: java.digest.outside-legacy
+        MessageDigest sha = MessageDigest.getInstance("SHA-256");
+        MessageDigest named = MessageDigest.getInstance(name);
+    }
+}
@@ -0,0 +1,16 @@
+package fixtures
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+)
+
+func seal(key, mac []byte) (cipher.AEAD, bool) {
+	// expect: go.aes.gcm algorithmFamily=AES
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block) // expect: go.aes.gcm
+	same := hmac.Equal(mac, mac)    // expect: go.hmac.self-compare
+	_ = hmac.Equal(mac, key)
+	return aead, same
+}
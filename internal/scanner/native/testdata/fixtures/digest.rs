@@ -0,0 +1,7 @@
+use sha2::{Digest, Sha256};
+
+fn digest(data: &[u8]) -> Vec<u8> {
+    let mut hasher = Sha256::new(); // expect: rust.sha2.sha256 algorithmName=SHA-256
+    hasher.update(data);
+    hasher.finalize().to_vec()
+}
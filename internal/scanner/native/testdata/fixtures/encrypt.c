@@ -0,0 +1,7 @@
+#include <openssl/evp.h>
+
+void encrypt(EVP_CIPHER_CTX *ctx, const unsigned char *key, const unsigned char *iv) {
+    // expect: c.openssl.aes-cbc algorithmName=AES-128-CBC
+    EVP_EncryptInit_ex(ctx, EVP_aes_128_cbc(), NULL, key, iv);
+    EVP_EncryptInit_ex(ctx, EVP_aes_256_gcm(), NULL, key, iv);
+}
@@ -0,0 +1,5 @@
+const crypto = require("crypto");
+
+// expect: js.crypto.md5 algorithmName=MD5
+const md5 = crypto.createHash("md5");
+const sha = crypto.createHash("sha256");
@@ -0,0 +1,3 @@
+import * as crypto from "crypto";
+
+export const digest: string = crypto.createHash("md5").digest("hex"); // expect: js.crypto.md5
@@ -139,8 +139,19 @@ func (s *Scanner) Initialize(ctx context.Context, config scanner.Config) error {
 
 // Scan executes OpenGrep against the target with the given rule paths.
 func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, error) {
+	report, _, err := s.scan(ctx, target, rulePaths, toolInfo, false)
+	return report, err
+}
+
+// ScanWithRuleTimes executes OpenGrep with --time and also returns the time
+// spent matching each rule.
+func (s *Scanner) ScanWithRuleTimes(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, map[string]time.Duration, error) {
+	return s.scan(ctx, target, rulePaths, toolInfo, true)
+}
+
+func (s *Scanner) scan(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo, timed bool) (*entities.InterimReport, map[string]time.Duration, error) {
 	if len(rulePaths) == 0 {
-		return nil, failure.New(
+		return nil, nil, failure.New(
 			failure.CodeRulesLoadFailed,
 			failure.StageRules,
 			"no rule paths provided",
@@ -157,6 +168,9 @@ func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, t
 
 	// Build opengrep command
 	args := s.buildCommand(ctx, target, rulePaths)
+	if timed {
+		args = semgrep.WithTimeFlag(args)
+	}
 
 	// Execute opengrep
 	output, stderr, err := s.execute(ctx, args)
@@ -167,13 +181,13 @@ func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, t
 			Str("stderr", semgrep.SanitizeScannerStderr(stderr)).
 			Msg("opengrep command failed")
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Parse opengrep JSON output (uses same format as Semgrep)
 	opengrepResults, err := semgrep.ParseSemgrepCompatibleOutput(output)
 	if err != nil {
-		return nil, failure.Wrap(
+		return nil, nil, failure.Wrap(
 			err,
 			failure.CodeScannerOutputParseFailed,
 			failure.StageScan,
@@ -187,7 +201,7 @@ func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, t
 	// Transform to interim format (reuse Semgrep transformer)
 	report := semgrep.TransformSemgrepCompatibleOutputToInterimFormat(opengrepResults, toolInfo, target, rulePaths, s.disableDedup)
 
-	return report, nil
+	return report, semgrep.RuleTimes(opengrepResults, rulePaths), nil
 }
 
 // GetInfo returns metadata about the OpenGrep adapter.
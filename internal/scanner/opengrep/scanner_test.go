@@ -38,6 +38,12 @@ func TestNewScanner(t *testing.T) {
 	}
 }
 
+func TestScanner_ImplementsRuleProfiler(t *testing.T) {
+	if _, ok := any(NewScanner()).(scanner.RuleProfiler); !ok {
+		t.Error("Expected opengrep scanner to implement scanner.RuleProfiler")
+	}
+}
+
 func TestGetInfo(t *testing.T) {
 	s := NewScanner()
 	s.version = "1.12.1"
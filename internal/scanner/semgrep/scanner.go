@@ -107,8 +107,19 @@ func (s *Scanner) Initialize(ctx context.Context, config scanner.Config) error {
 
 // Scan executes Semgrep against the target with the given rule paths.
 func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, error) {
+	report, _, err := s.scan(ctx, target, rulePaths, toolInfo, false)
+	return report, err
+}
+
+// ScanWithRuleTimes executes Semgrep with --time and also returns the time
+// spent matching each rule.
+func (s *Scanner) ScanWithRuleTimes(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo) (*entities.InterimReport, map[string]time.Duration, error) {
+	return s.scan(ctx, target, rulePaths, toolInfo, true)
+}
+
+func (s *Scanner) scan(ctx context.Context, target string, rulePaths []string, toolInfo entities.ToolInfo, timed bool) (*entities.InterimReport, map[string]time.Duration, error) {
 	if len(rulePaths) == 0 {
-		return nil, failure.New(
+		return nil, nil, failure.New(
 			failure.CodeRulesLoadFailed,
 			failure.StageRules,
 			"no rule paths provided",
@@ -125,6 +136,9 @@ func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, t
 
 	// Build semgrep command
 	args := s.buildCommand(target, rulePaths)
+	if timed {
+		args = WithTimeFlag(args)
+	}
 
 	// Execute semgrep
 	output, stderr, err := s.execute(ctx, args)
@@ -135,12 +149,12 @@ func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, t
 			Str("stderr", SanitizeScannerStderr(stderr)).
 			Msg("semgrep command failed")
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	semgrepResults, err := ParseSemgrepCompatibleOutput(output)
 	if err != nil {
-		return nil, failure.Wrap(
+		return nil, nil, failure.Wrap(
 			err,
 			failure.CodeScannerOutputParseFailed,
 			failure.StageScan,
@@ -153,7 +167,7 @@ func (s *Scanner) Scan(ctx context.Context, target string, rulePaths []string, t
 
 	report := TransformSemgrepCompatibleOutputToInterimFormat(semgrepResults, toolInfo, target, rulePaths, s.disableDedup)
 
-	return report, nil
+	return report, RuleTimes(semgrepResults, rulePaths), nil
 }
 
 // GetInfo returns metadata about the Semgrep adapter.
@@ -0,0 +1,65 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package semgrep
+
+import (
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// TimeFlag makes Semgrep-compatible scanners add a timing profile to their
+// JSON output.
+const TimeFlag = "--time"
+
+// WithTimeFlag returns args with TimeFlag inserted before the scan target,
+// which is the last argument.
+func WithTimeFlag(args []string) []string {
+	if len(args) == 0 {
+		return []string{TimeFlag}
+	}
+	timed := make([]string, 0, len(args)+1)
+	timed = append(timed, args[:len(args)-1]...)
+	return append(timed, TimeFlag, args[len(args)-1])
+}
+
+// RuleTimes sums the match time of each rule across all scanned files,
+// keyed by the same cleaned rule ID the interim report uses. It returns
+// nil when the output carries no timing profile.
+func RuleTimes(output *entities.SemgrepOutput, rulePaths []string) map[string]time.Duration {
+	if output == nil || output.Time == nil {
+		return nil
+	}
+
+	ids := make([]string, len(output.Time.Rules))
+	times := make(map[string]time.Duration, len(ids))
+	for i, rule := range output.Time.Rules {
+		ids[i] = cleanRuleID(string(rule), rulePaths)
+		times[ids[i]] = 0
+	}
+	for _, target := range output.Time.Targets {
+		for i, seconds := range target.MatchTimes {
+			// Rules that did not run on a file are reported with a
+			// negative time.
+			if i >= len(ids) || seconds <= 0 {
+				continue
+			}
+			times[ids[i]] += time.Duration(seconds * float64(time.Second))
+		}
+	}
+	return times
+}
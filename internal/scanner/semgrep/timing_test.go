@@ -0,0 +1,89 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package semgrep
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scanoss/crypto-finder/internal/scanner"
+)
+
+func TestScanner_ImplementsRuleProfiler(t *testing.T) {
+	if _, ok := any(NewScanner()).(scanner.RuleProfiler); !ok {
+		t.Error("Expected semgrep scanner to implement scanner.RuleProfiler")
+	}
+}
+
+func TestWithTimeFlag(t *testing.T) {
+	got := WithTimeFlag([]string{"--json", "--config", "rules", "/src"})
+	want := []string{"--json", "--config", "rules", TimeFlag, "/src"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithTimeFlag() = %v, want %v", got, want)
+	}
+	if got := WithTimeFlag(nil); !reflect.DeepEqual(got, []string{TimeFlag}) {
+		t.Errorf("WithTimeFlag(nil) = %v", got)
+	}
+}
+
+func TestRuleTimes(t *testing.T) {
+	// Rule IDs are prefixed with the dotted rule path; Semgrep reports them
+	// as objects, OpenGrep as plain strings.
+	rulesDir := t.TempDir()
+	prefix := strings.Trim(strings.ReplaceAll(filepath.ToSlash(rulesDir), "/", "."), ".")
+	jsonData := strings.ReplaceAll(`{
+		"results": [],
+		"errors": [],
+		"time": {
+			"rules": [{"id": "PREFIX.aes-usage"}, "PREFIX.rsa-usage", "PREFIX.unused"],
+			"targets": [
+				{"path": "a.go", "match_times": [0.5, 0.25, -1], "run_time": 1},
+				{"path": "b.go", "match_times": [1.0, 0, -1], "run_time": 1}
+			]
+		}
+	}`, "PREFIX", prefix)
+	output, err := ParseSemgrepCompatibleOutput([]byte(jsonData))
+	if err != nil {
+		t.Fatalf("ParseSemgrepCompatibleOutput() error = %v", err)
+	}
+
+	got := RuleTimes(output, []string{rulesDir})
+	want := map[string]time.Duration{
+		"aes-usage": 1500 * time.Millisecond,
+		"rsa-usage": 250 * time.Millisecond,
+		"unused":    0,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RuleTimes() = %v, want %v", got, want)
+	}
+}
+
+func TestRuleTimes_NoTiming(t *testing.T) {
+	output, err := ParseSemgrepCompatibleOutput([]byte(`{"results": [], "errors": []}`))
+	if err != nil {
+		t.Fatalf("ParseSemgrepCompatibleOutput() error = %v", err)
+	}
+	if got := RuleTimes(output, nil); got != nil {
+		t.Errorf("RuleTimes() = %v, want nil", got)
+	}
+	if got := RuleTimes(nil, nil); got != nil {
+		t.Errorf("RuleTimes(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package spdx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/scanoss/crypto-finder/internal/converter"
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+// propertyPrefix prefixes the crypto properties carried in the CycloneDX
+// properties extension, e.g. "scanoss:algorithmFamily".
+const propertyPrefix = "scanoss:"
+
+// Converter transforms interim reports to SPDX 3.0 documents.
+//
+// SPDX 3.0 has no cryptographic element class, so every unique cryptographic
+// asset (grouped like CycloneDX components) becomes a software_Package with
+// primaryPurpose "other". Its crypto properties (asset type, algorithm, key
+// length, mode, ...) are carried as scanoss:* entries of the CycloneDX
+// properties extension, and its OID as an external identifier. Each detection
+// becomes a software_Snippet of its source file, linked from the asset with a
+// hasEvidence relationship.
+type Converter struct {
+	aggregator *converter.Aggregator
+	now        func() time.Time
+}
+
+// NewConverter creates a new SPDX converter.
+func NewConverter() *Converter {
+	return &Converter{
+		aggregator: converter.NewAggregator(),
+		now:        time.Now,
+	}
+}
+
+// documentBuilder accumulates the graph of one document.
+type documentBuilder struct {
+	namespace string
+	graph     []any
+	elements  []string
+	counters  map[string]int
+}
+
+// id returns a new document-local SPDX ID of the given kind.
+func (b *documentBuilder) id(kind string) string {
+	b.counters[kind]++
+	return fmt.Sprintf("%s#SPDXRef-%s-%d", b.namespace, kind, b.counters[kind])
+}
+
+func (b *documentBuilder) element(typ, kind, name string) Element {
+	return Element{Type: typ, SpdxID: b.id(kind), CreationInfo: creationInfoID, Name: name}
+}
+
+// add appends an element to the graph and to the document's element list.
+func (b *documentBuilder) add(spdxID string, element any) {
+	b.graph = append(b.graph, element)
+	b.elements = append(b.elements, spdxID)
+}
+
+func (b *documentBuilder) relate(from, relationshipType string, to []string) {
+	if len(to) == 0 {
+		return
+	}
+	relationship := Relationship{
+		Element:          b.element(TypeRelationship, "Relationship", ""),
+		RelationshipType: relationshipType,
+		From:             from,
+		To:               to,
+	}
+	b.add(relationship.SpdxID, relationship)
+}
+
+// Convert transforms an interim report into an SPDX 3.0 document.
+func (c *Converter) Convert(report *entities.InterimReport) (*Document, error) {
+	if report == nil {
+		return nil, fmt.Errorf("report cannot be nil")
+	}
+
+	log.Info().Msg("Starting conversion to SPDX 3.0 format")
+
+	assets, err := c.aggregator.AggregateAssets(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate assets: %w", err)
+	}
+	c.aggregator.SortAssets(assets)
+
+	b := &documentBuilder{
+		namespace: "https://spdx.org/spdxdocs/crypto-finder-" + uuid.NewString(),
+		counters:  make(map[string]int),
+	}
+
+	organization := b.element(TypeOrganization, "Organization", "SCANOSS")
+	tool := b.element(TypeTool, "Tool", toolName(report))
+	creationInfo := CreationInfo{
+		Type:         TypeCreationInfo,
+		ID:           creationInfoID,
+		SpecVersion:  SpecVersion,
+		Created:      c.now().UTC().Format(time.RFC3339),
+		CreatedBy:    []string{organization.SpdxID},
+		CreatedUsing: []string{tool.SpdxID},
+	}
+	b.graph = append(b.graph, creationInfo)
+	b.add(organization.SpdxID, organization)
+	b.add(tool.SpdxID, tool)
+
+	owners, root := c.addSoftware(b, report)
+
+	files := make(map[string]string)
+	var assetIDs []string
+	for i := range assets {
+		assetID := c.addAsset(b, &assets[i], files)
+		assetIDs = append(assetIDs, assetID)
+		for _, owner := range assets[i].Owners {
+			if ownerID, ok := owners[owner]; ok {
+				b.relate(ownerID, RelationshipContains, []string{assetID})
+			}
+		}
+	}
+
+	rootElements := assetIDs
+	if root != "" {
+		rootElements = []string{root}
+	}
+	if rootElements == nil {
+		rootElements = []string{}
+	}
+
+	sbom := Sbom{
+		Element:      b.element(TypeSbom, "Sbom", "crypto-finder CBOM"),
+		SbomTypes:    []string{"analyzed"},
+		Elements:     append([]string{}, b.elements...),
+		RootElements: rootElements,
+	}
+	b.add(sbom.SpdxID, sbom)
+
+	document := SpdxDocument{
+		Element:            b.element(TypeSpdxDocument, "DOCUMENT", "crypto-finder"),
+		ProfileConformance: []string{"core", "software"},
+		Elements:           b.elements,
+		RootElements:       []string{sbom.SpdxID},
+	}
+	b.graph = append(b.graph, document)
+
+	log.Info().
+		Int("unique_assets", len(assets)).
+		Int("files", len(files)).
+		Int("elements", len(b.elements)).
+		Msg("SPDX conversion complete")
+
+	return &Document{Context: Context, Graph: b.graph}, nil
+}
+
+// addSoftware adds the scanned project and its dependency libraries, with the
+// dependency graph between them. It returns the SPDX ID of each software
+// component by dependency key ("" for the project) and the project's SPDX ID,
+// which is empty when the report does not name a root module.
+func (c *Converter) addSoftware(b *documentBuilder, report *entities.InterimReport) (map[string]string, string) {
+	owners := make(map[string]string)
+	var root string
+	dependsOn := make(map[string][]string)
+	var order []string
+
+	addLibrary := func(key, module, version, packageURL string) {
+		if _, exists := owners[key]; exists {
+			return
+		}
+		library := Package{
+			Element:        b.element(TypePackage, "Package", module),
+			PrimaryPurpose: "library",
+			Version:        version,
+			PackageURL:     packageURL,
+		}
+		b.add(library.SpdxID, library)
+		owners[key] = library.SpdxID
+		order = append(order, key)
+	}
+
+	if graph := report.Dependencies; graph != nil {
+		if graph.RootModule != "" {
+			project := Package{
+				Element:        b.element(TypePackage, "Package", graph.RootModule),
+				PrimaryPurpose: "application",
+			}
+			b.add(project.SpdxID, project)
+			root = project.SpdxID
+			owners[""] = root
+		}
+		for _, dep := range graph.Components {
+			addLibrary(dep.Key(), dep.Module, dep.Version, dep.PURL)
+			dependsOn[dep.Key()] = dep.DependsOn
+			if dep.Scope == entities.DependencyScopeDirect {
+				dependsOn[""] = append(dependsOn[""], dep.Key())
+			}
+		}
+	}
+	for _, finding := range report.Findings {
+		for _, asset := range finding.CryptographicAssets {
+			if info := asset.DependencyInfo; info != nil && info.Module != "" {
+				key := entities.DependencyComponent{Module: info.Module, Version: info.Version}.Key()
+				addLibrary(key, info.Module, info.Version, info.PURL)
+			}
+		}
+	}
+
+	if root != "" {
+		order = append([]string{""}, order...)
+	}
+	for _, key := range order {
+		var to []string
+		for _, child := range dependsOn[key] {
+			if childID, ok := owners[child]; ok {
+				to = append(to, childID)
+			}
+		}
+		b.relate(owners[key], RelationshipDependsOn, to)
+	}
+	return owners, root
+}
+
+// addAsset adds one aggregated cryptographic asset with its evidence snippets
+// and returns its SPDX ID. files maps source paths to File SPDX IDs and is
+// extended as new files are seen.
+func (c *Converter) addAsset(b *documentBuilder, aggregated *converter.AggregatedAsset, files map[string]string) string {
+	reference := aggregated.ReferenceAsset
+	asset := Package{
+		Element:        b.element(TypePackage, "CryptoAsset", aggregated.Name),
+		PrimaryPurpose: "other",
+	}
+	asset.Comment = "Cryptographic asset (" + aggregated.AssetType + ") detected by crypto-finder"
+	if reference.OID != "" {
+		asset.ExternalIdentifiers = []ExternalIdentifier{{
+			Type:                   TypeExternalIdentifier,
+			ExternalIdentifierType: "other",
+			Identifier:             "urn:oid:" + reference.OID,
+			IssuingAuthority:       "ITU-T/ISO/IEC",
+			Comment:                "Object identifier of the algorithm",
+		}}
+	}
+	if properties := assetProperties(aggregated); len(properties) > 0 {
+		asset.Extensions = []PropertiesExt{{Type: TypeCdxPropertiesExt, Properties: properties}}
+	}
+	b.add(asset.SpdxID, asset)
+
+	var snippets []string
+	for _, occurrence := range aggregated.Occurrences {
+		fileID, ok := files[occurrence.FilePath]
+		if !ok {
+			file := File{
+				Element:        b.element(TypeFile, "File", occurrence.FilePath),
+				PrimaryPurpose: "source",
+			}
+			b.add(file.SpdxID, file)
+			files[occurrence.FilePath] = file.SpdxID
+			fileID = file.SpdxID
+		}
+
+		snippet := Snippet{
+			Element:         b.element(TypeSnippet, "Snippet", occurrence.FilePath+":"+strconv.Itoa(occurrence.StartLine)),
+			SnippetFromFile: fileID,
+		}
+		if occurrence.StartLine > 0 {
+			end := max(occurrence.EndLine, occurrence.StartLine)
+			snippet.LineRange = &PositiveIntegerRange{Type: TypePositiveIntegerRange, Begin: occurrence.StartLine, End: end}
+		}
+		if match := strings.Join(strings.Fields(occurrence.Match), " "); match != "" {
+			snippet.Comment = match
+		}
+		if len(occurrence.RuleIDs) > 0 {
+			snippet.Extensions = []PropertiesExt{{
+				Type:       TypeCdxPropertiesExt,
+				Properties: []PropertyEntry{property("ruleIds", strings.Join(occurrence.RuleIDs, ","))},
+			}}
+		}
+		b.add(snippet.SpdxID, snippet)
+		snippets = append(snippets, snippet.SpdxID)
+	}
+	b.relate(asset.SpdxID, RelationshipHasEvidence, snippets)
+
+	return asset.SpdxID
+}
+
+// assetProperties returns the crypto properties of an asset: its type, OID,
+// every metadata entry of the representative detection (algorithm family and
+// name, parameter set or key length, mode, padding, primitive, ...), and the
+// values collected across all grouped detections.
+func assetProperties(aggregated *converter.AggregatedAsset) []PropertyEntry {
+	reference := aggregated.ReferenceAsset
+	values := map[string]string{
+		"assetType": aggregated.AssetType,
+		"oid":       reference.OID,
+	}
+	for key, value := range reference.Metadata {
+		if _, reserved := values[key]; !reserved {
+			values[key] = value
+		}
+	}
+	if len(aggregated.CryptoFunctions) > 0 {
+		values["cryptoFunctions"] = strings.Join(aggregated.CryptoFunctions, ",")
+	}
+	if aggregated.ProtocolVersion != "" {
+		values["protocolVersion"] = aggregated.ProtocolVersion
+	}
+	if len(aggregated.CipherSuites) > 0 {
+		values["cipherSuites"] = strings.Join(aggregated.CipherSuites, ",")
+	}
+
+	keys := make([]string, 0, len(values))
+	for key, value := range values {
+		if strings.TrimSpace(value) != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	properties := make([]PropertyEntry, 0, len(keys))
+	for _, key := range keys {
+		properties = append(properties, property(key, values[key]))
+	}
+	return properties
+}
+
+func property(name, value string) PropertyEntry {
+	return PropertyEntry{Type: TypeCdxPropertyEntry, Name: propertyPrefix + name, Value: value}
+}
+
+func toolName(report *entities.InterimReport) string {
+	name := report.Tool.Name
+	if name == "" {
+		name = "crypto-finder"
+	}
+	if report.Tool.Version != "" {
+		name += "-" + report.Tool.Version
+	}
+	return name
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+package spdx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scanoss/crypto-finder/internal/entities"
+)
+
+func loadFixture(t *testing.T, filename string) *entities.InterimReport {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", filename))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", filename, err)
+	}
+	var report entities.InterimReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to parse fixture %s: %v", filename, err)
+	}
+	return &report
+}
+
+// decodeGraph round-trips a document through JSON so assertions see exactly
+// what the writer emits.
+func decodeGraph(t *testing.T, document *Document) []map[string]any {
+	t.Helper()
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("Failed to marshal document: %v", err)
+	}
+	var decoded struct {
+		Context string           `json:"@context"`
+		Graph   []map[string]any `json:"@graph"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal document: %v", err)
+	}
+	if decoded.Context != Context {
+		t.Errorf("@context = %q, want %q", decoded.Context, Context)
+	}
+	return decoded.Graph
+}
+
+func TestConverter_Convert(t *testing.T) {
+	report := loadFixture(t, "dependency_graph.json")
+	report.Findings[0].CryptographicAssets[0].OID = "2.16.840.1.101.3.4.1.46"
+
+	document, err := NewConverter().Convert(report)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	graph := decodeGraph(t, document)
+
+	byID := make(map[string]map[string]any)
+	byType := make(map[string][]map[string]any)
+	for _, node := range graph {
+		typ, _ := node["type"].(string)
+		byType[typ] = append(byType[typ], node)
+		if id, ok := node["spdxId"].(string); ok {
+			if _, dup := byID[id]; dup {
+				t.Fatalf("duplicate spdxId %s", id)
+			}
+			byID[id] = node
+		}
+	}
+
+	if len(byType[TypeCreationInfo]) != 1 || byType[TypeCreationInfo][0]["specVersion"] != SpecVersion {
+		t.Errorf("CreationInfo = %v, want one with specVersion %s", byType[TypeCreationInfo], SpecVersion)
+	}
+	if len(byType[TypeSpdxDocument]) != 1 || len(byType[TypeSbom]) != 1 {
+		t.Fatalf("want one SpdxDocument and one software_Sbom")
+	}
+
+	// Every reference resolves to an element of the document.
+	for _, node := range graph {
+		for _, key := range []string{"from", "snippetFromFile"} {
+			if ref, ok := node[key].(string); ok && byID[ref] == nil {
+				t.Errorf("%s %q does not resolve", key, ref)
+			}
+		}
+		if to, ok := node["to"].([]any); ok {
+			for _, ref := range to {
+				if byID[ref.(string)] == nil {
+					t.Errorf("to %q does not resolve", ref)
+				}
+			}
+		}
+	}
+
+	assets := make(map[string]map[string]any)
+	libraries := 0
+	for _, pkg := range byType[TypePackage] {
+		switch pkg["software_primaryPurpose"] {
+		case "other":
+			assets[pkg["name"].(string)] = pkg
+		case "library":
+			libraries++
+		}
+	}
+	if libraries != 3 {
+		t.Errorf("library packages = %d, want 3 (two from the graph, one from dependency_info)", libraries)
+	}
+
+	aes := assets["AES-256-GCM"]
+	if aes == nil {
+		t.Fatalf("crypto asset packages = %v, want AES-256-GCM", assets)
+	}
+	identifiers := aes["externalIdentifier"].([]any)
+	if got := identifiers[0].(map[string]any)["identifier"]; got != "urn:oid:2.16.840.1.101.3.4.1.46" {
+		t.Errorf("OID identifier = %v", got)
+	}
+	properties := make(map[string]string)
+	for _, ext := range aes["extension"].([]any) {
+		for _, entry := range ext.(map[string]any)["extension_cdxProperty"].([]any) {
+			e := entry.(map[string]any)
+			properties[e["extension_cdxPropName"].(string)] = e["extension_cdxPropValue"].(string)
+		}
+	}
+	for name, want := range map[string]string{
+		"scanoss:assetType":                       "algorithm",
+		"scanoss:algorithmFamily":                 "AES",
+		"scanoss:algorithmParameterSetIdentifier": "256",
+		"scanoss:algorithmMode":                   "GCM",
+		"scanoss:oid":                             "2.16.840.1.101.3.4.1.46",
+	} {
+		if properties[name] != want {
+			t.Errorf("property %s = %q, want %q", name, properties[name], want)
+		}
+	}
+
+	evidence := 0
+	contains := 0
+	for _, relationship := range byType[TypeRelationship] {
+		switch relationship["relationshipType"] {
+		case RelationshipHasEvidence:
+			if relationship["from"] == aes["spdxId"] {
+				evidence = len(relationship["to"].([]any))
+			}
+		case RelationshipContains:
+			if relationship["to"].([]any)[0] == aes["spdxId"] {
+				contains++
+			}
+		}
+	}
+	if evidence != 2 {
+		t.Errorf("AES evidence snippets = %d, want 2", evidence)
+	}
+	if contains != 2 {
+		t.Errorf("AES containers = %d, want the project and bcprov", contains)
+	}
+
+	for _, snippet := range byType[TypeSnippet] {
+		lineRange, ok := snippet["software_lineRange"].(map[string]any)
+		if !ok || lineRange["beginIntegerRange"].(float64) < 1 {
+			t.Errorf("snippet %v has no positive line range", snippet["name"])
+		}
+	}
+	if len(byType[TypeFile]) != 3 {
+		t.Errorf("files = %d, want 3", len(byType[TypeFile]))
+	}
+}
+
+func TestConverter_ConvertEmptyReport(t *testing.T) {
+	document, err := NewConverter().Convert(&entities.InterimReport{Tool: entities.ToolInfo{Name: "crypto-finder", Version: "1.0.0"}})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	graph := decodeGraph(t, document)
+	for _, node := range graph {
+		if node["type"] == TypeSbom {
+			if roots := node["rootElement"].([]any); len(roots) != 0 {
+				t.Errorf("rootElement = %v, want empty", roots)
+			}
+			return
+		}
+	}
+	t.Error("missing software_Sbom")
+}
+
+func TestConverter_ConvertNilReport(t *testing.T) {
+	if _, err := NewConverter().Convert(nil); err == nil {
+		t.Error("Convert(nil) should fail")
+	}
+}
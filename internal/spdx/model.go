@@ -0,0 +1,157 @@
+// Copyright (C) 2026 SCANOSS.COM
+// SPDX-License-Identifier: GPL-2.0-only
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; version 2.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301, USA.
+
+// Package spdx transforms crypto-finder interim format to SPDX 3.0 JSON-LD.
+package spdx
+
+// SpecVersion is the SPDX specification version emitted.
+const SpecVersion = "3.0.1"
+
+// Context is the JSON-LD context of SPDX 3.0.1 documents.
+const Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+// creationInfoID is the blank node shared by every element of a document.
+const creationInfoID = "_:creationinfo"
+
+// SPDX 3.0 class names in their JSON-LD compact form.
+const (
+	TypeCreationInfo         = "CreationInfo"
+	TypeOrganization         = "Organization"
+	TypeTool                 = "Tool"
+	TypeSpdxDocument         = "SpdxDocument"
+	TypeRelationship         = "Relationship"
+	TypeSbom                 = "software_Sbom"
+	TypePackage              = "software_Package"
+	TypeFile                 = "software_File"
+	TypeSnippet              = "software_Snippet"
+	TypePositiveIntegerRange = "PositiveIntegerRange"
+	TypeExternalIdentifier   = "ExternalIdentifier"
+	TypeCdxPropertiesExt     = "extension_CdxPropertiesExtension"
+	TypeCdxPropertyEntry     = "extension_CdxPropertyEntry"
+)
+
+// SPDX 3.0 relationship types used by crypto-finder documents.
+const (
+	RelationshipContains    = "contains"
+	RelationshipDependsOn   = "dependsOn"
+	RelationshipHasEvidence = "hasEvidence"
+	RelationshipDescribes   = "describes"
+)
+
+// Document is an SPDX 3.0 JSON-LD serialization: a context and a flat graph
+// of elements.
+type Document struct {
+	Context string `json:"@context"`
+	Graph   []any  `json:"@graph"`
+}
+
+// CreationInfo records who created the elements of a document and when.
+type CreationInfo struct {
+	Type         string   `json:"type"`
+	ID           string   `json:"@id"`
+	SpecVersion  string   `json:"specVersion"`
+	Created      string   `json:"created"`
+	CreatedBy    []string `json:"createdBy"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+}
+
+// Element holds the properties shared by every SPDX element emitted here.
+// Class-specific properties live in the embedding types.
+type Element struct {
+	Type                string               `json:"type"`
+	SpdxID              string               `json:"spdxId"`
+	CreationInfo        string               `json:"creationInfo"`
+	Name                string               `json:"name,omitempty"`
+	Comment             string               `json:"comment,omitempty"`
+	ExternalIdentifiers []ExternalIdentifier `json:"externalIdentifier,omitempty"`
+	Extensions          []PropertiesExt      `json:"extension,omitempty"`
+}
+
+// ExternalIdentifier identifies an element in another system, e.g. an OID.
+type ExternalIdentifier struct {
+	Type                   string `json:"type"`
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+	IssuingAuthority       string `json:"issuingAuthority,omitempty"`
+	Comment                string `json:"comment,omitempty"`
+}
+
+// PropertiesExt is the SPDX 3.0 CycloneDX properties extension, used to carry
+// name/value properties SPDX has no native field for.
+type PropertiesExt struct {
+	Type       string          `json:"type"`
+	Properties []PropertyEntry `json:"extension_cdxProperty"`
+}
+
+// PropertyEntry is one name/value pair of a PropertiesExt.
+type PropertyEntry struct {
+	Type  string `json:"type"`
+	Name  string `json:"extension_cdxPropName"`
+	Value string `json:"extension_cdxPropValue,omitempty"`
+}
+
+// SpdxDocument is the document element collecting every emitted element.
+type SpdxDocument struct {
+	Element
+	ProfileConformance []string `json:"profileConformance"`
+	Elements           []string `json:"element"`
+	RootElements       []string `json:"rootElement"`
+}
+
+// Sbom is the software bill of materials describing the scanned software.
+type Sbom struct {
+	Element
+	SbomTypes    []string `json:"software_sbomType"`
+	Elements     []string `json:"element"`
+	RootElements []string `json:"rootElement"`
+}
+
+// Package is a software package: the scanned project, a dependency library,
+// or a cryptographic asset.
+type Package struct {
+	Element
+	PrimaryPurpose string `json:"software_primaryPurpose,omitempty"`
+	Version        string `json:"software_packageVersion,omitempty"`
+	PackageURL     string `json:"software_packageUrl,omitempty"`
+}
+
+// File is a source file holding cryptographic evidence.
+type File struct {
+	Element
+	PrimaryPurpose string `json:"software_primaryPurpose,omitempty"`
+}
+
+// Snippet is the location of one detection inside a File.
+type Snippet struct {
+	Element
+	SnippetFromFile string                `json:"snippetFromFile"`
+	LineRange       *PositiveIntegerRange `json:"software_lineRange,omitempty"`
+}
+
+// PositiveIntegerRange is an inclusive range of 1-based line numbers.
+type PositiveIntegerRange struct {
+	Type  string `json:"type"`
+	Begin int    `json:"beginIntegerRange"`
+	End   int    `json:"endIntegerRange"`
+}
+
+// Relationship links one element to others.
+type Relationship struct {
+	Element
+	RelationshipType string   `json:"relationshipType"`
+	From             string   `json:"from"`
+	To               []string `json:"to"`
+}
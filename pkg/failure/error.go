@@ -43,6 +43,10 @@ const (
 	CodeOutputWriterUnavailable     Code = "output_writer_unavailable"
 	CodeOutputWriteFailed           Code = "output_write_failed"
 	CodeFindingsDetected            Code = "findings_detected"
+	CodeRuleTestsFailed             Code = "rule_tests_failed"
+	CodeRuleLintFailed              Code = "rule_lint_failed"
+	CodeRulesetSignatureRejected    Code = "ruleset_signature_rejected"
+	CodeRulesetBundleInvalid        Code = "ruleset_bundle_invalid"
 )
 
 // Failure stages identify which pipeline phase produced a terminal error.
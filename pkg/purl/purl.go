@@ -33,6 +33,18 @@ func Identity(raw string) (string, bool) {
 	return fmt.Sprintf("%s\x00%s\x00%s", p.Type, p.Namespace, p.Name), true
 }
 
+// VersionedIdentity returns the normalized identity of a package URL plus its
+// version, used to match the same package version across BOMs produced by
+// different tools. Qualifiers and subpaths are ignored. A leading "v" is
+// dropped from the version so Go-style and plain versions compare equal.
+func VersionedIdentity(raw string) (string, bool) {
+	p, ok := parse(raw)
+	if !ok || p.Version == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s", p.Type, p.Namespace, p.Name, strings.TrimPrefix(p.Version, "v")), true
+}
+
 // WithVersion adds a resolved version to a valid versionless rule PURL.
 func WithVersion(raw, version string) string {
 	p, ok := parse(raw)
@@ -80,6 +92,9 @@ func Dependency(ecosystem, module, version string) string {
 		namespace, name = splitModule(module)
 	case "rust":
 		typ, name = packageurl.TypeCargo, module
+	case "c", "cpp":
+		// Conan, vcpkg and FetchContent dependencies share one namespace.
+		typ, name = packageurl.TypeGeneric, module
 	default:
 		return ""
 	}
@@ -94,6 +109,40 @@ func Dependency(ecosystem, module, version string) string {
 	return p.ToString()
 }
 
+// Module is the inverse of Dependency: it returns the resolver module and
+// version a package URL names in ecosystem, and false when the package URL
+// is invalid or belongs to another ecosystem. Conan and generic package URLs
+// belong to both C and C++. Package URLs normalize Go module paths to lower
+// case, so callers that need the original spelling must recover it.
+func Module(ecosystem, raw string) (string, string, bool) {
+	p, ok := parse(raw)
+	if !ok {
+		return "", "", false
+	}
+	var module string
+	switch {
+	case ecosystem == "java" && p.Type == packageurl.TypeMaven:
+		if p.Namespace == "" {
+			return "", "", false
+		}
+		module = p.Namespace + ":" + p.Name
+	case ecosystem == "python" && p.Type == packageurl.TypePyPi:
+		module = p.Name
+	case ecosystem == "go" && p.Type == packageurl.TypeGolang:
+		module = p.Name
+		if p.Namespace != "" {
+			module = p.Namespace + "/" + p.Name
+		}
+	case ecosystem == "rust" && p.Type == packageurl.TypeCargo:
+		module = p.Name
+	case (ecosystem == "c" || ecosystem == "cpp") && (p.Type == packageurl.TypeConan || p.Type == packageurl.TypeGeneric):
+		module = p.Name
+	default:
+		return "", "", false
+	}
+	return module, p.Version, true
+}
+
 func splitModule(module string) (namespace, name string) {
 	if index := strings.LastIndexByte(module, '/'); index >= 0 {
 		return module[:index], module[index+1:]
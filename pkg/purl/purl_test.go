@@ -15,6 +15,7 @@ func TestDependency(t *testing.T) {
 		{name: "pypi-normalization", ecosystem: "python", module: "My_Package.Name", version: "2.0", want: "pkg:pypi/my-package-name@2.0"},
 		{name: "golang", ecosystem: "go", module: "GitHub.com/Example/Crypto", version: "v1.2.3", want: "pkg:golang/github.com/example/crypto@v1.2.3"},
 		{name: "cargo", ecosystem: "rust", module: "ring", version: "0.17.8", want: "pkg:cargo/ring@0.17.8"},
+		{name: "c generic", ecosystem: "c", module: "mbedtls", version: "3.5.0", want: "pkg:generic/mbedtls@3.5.0"},
 		{name: "versionless", ecosystem: "python", module: "cryptography", want: "pkg:pypi/cryptography"},
 		{name: "unknown-ecosystem", ecosystem: "node", module: "left-pad", version: "1.3.0"},
 		{name: "missing-module", ecosystem: "go", version: "v1.2.3"},
@@ -29,6 +30,32 @@ func TestDependency(t *testing.T) {
 	}
 }
 
+func TestModule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name, ecosystem, raw, module, version string
+		ok                                    bool
+	}{
+		{name: "maven", ecosystem: "java", raw: "pkg:maven/org.bouncycastle/bcprov-jdk18on@1.77?type=jar", module: "org.bouncycastle:bcprov-jdk18on", version: "1.77", ok: true},
+		{name: "maven without group", ecosystem: "java", raw: "pkg:maven/lib@1.0"},
+		{name: "pypi", ecosystem: "python", raw: "pkg:pypi/PyYAML@6.0.1", module: "pyyaml", version: "6.0.1", ok: true},
+		{name: "golang subpath", ecosystem: "go", raw: "pkg:golang/golang.org/x/crypto@v0.17.0#ssh", module: "golang.org/x/crypto", version: "v0.17.0", ok: true},
+		{name: "cargo", ecosystem: "rust", raw: "pkg:cargo/ring@0.17.8", module: "ring", version: "0.17.8", ok: true},
+		{name: "conan for cpp", ecosystem: "cpp", raw: "pkg:conan/openssl@3.2.0", module: "openssl", version: "3.2.0", ok: true},
+		{name: "other ecosystem", ecosystem: "go", raw: "pkg:maven/org.example/lib@1.0"},
+		{name: "invalid", ecosystem: "go", raw: "not-a-purl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			module, version, ok := Module(tt.ecosystem, tt.raw)
+			if module != tt.module || version != tt.version || ok != tt.ok {
+				t.Fatalf("Module(%q, %q) = %q, %q, %v, want %q, %q, %v", tt.ecosystem, tt.raw, module, version, ok, tt.module, tt.version, tt.ok)
+			}
+		})
+	}
+}
+
 func TestRule(t *testing.T) {
 	t.Parallel()
 
@@ -56,3 +83,34 @@ func TestWithVersion(t *testing.T) {
 		t.Fatalf("WithVersion(versioned) = %q, want empty", got)
 	}
 }
+
+func TestVersionedIdentity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		a, b   string
+		wantEq bool
+	}{
+		{name: "ignores qualifiers", a: "pkg:maven/org.example/lib@1.2.3", b: "pkg:maven/org.example/lib@1.2.3?type=jar", wantEq: true},
+		{name: "normalizes pypi names", a: "pkg:pypi/My_Package@1.0", b: "pkg:pypi/my-package@1.0", wantEq: true},
+		{name: "ignores leading v", a: "pkg:golang/golang.org/x/crypto@v0.31.0", b: "pkg:golang/golang.org/x/crypto@0.31.0", wantEq: true},
+		{name: "different versions", a: "pkg:maven/org.example/lib@1.2.3", b: "pkg:maven/org.example/lib@1.2.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, okA := VersionedIdentity(tt.a)
+			b, okB := VersionedIdentity(tt.b)
+			if !okA || !okB {
+				t.Fatalf("VersionedIdentity(%q, %q) not ok", tt.a, tt.b)
+			}
+			if (a == b) != tt.wantEq {
+				t.Fatalf("VersionedIdentity equal = %v, want %v", a == b, tt.wantEq)
+			}
+		})
+	}
+
+	if _, ok := VersionedIdentity("pkg:maven/org.example/lib"); ok {
+		t.Fatal("VersionedIdentity(versionless) should not be ok")
+	}
+}
@@ -27,7 +27,7 @@ import (
 )
 
 // InterimFormatVersion is the current version of the interim report schema.
-const InterimFormatVersion = "1.6"
+const InterimFormatVersion = "1.7"
 
 // InterimReport is the standardized output format for all scanners.
 // This format provides a unified representation of cryptographic findings
@@ -45,6 +45,12 @@ type InterimReport struct {
 	// source could supply a version (e.g. ad-hoc local files with no manifest).
 	Rules RulesInfo `json:"rules,omitempty"`
 
+	// Dependencies describes the resolved dependency graph of the scanned
+	// project. Only present for dependency scans (--scan-dependencies); it lets
+	// consumers attribute dependency findings to a library version and tell
+	// direct from transitive dependencies.
+	Dependencies *DependencyGraph `json:"dependencies,omitempty"`
+
 	// Findings contains all detected cryptographic assets grouped by file
 	Findings []Finding `json:"findings"`
 }
@@ -68,19 +74,61 @@ type ToolInfo struct {
 // is empty and ChecksumSHA256 is a hash of the loaded rule file contents
 // in deterministic order.
 type RulesInfo struct {
-	// Source is "remote" or "local". Empty when no rules were loaded.
+	// Source is "remote", "local", "git" or "oci". Empty when no rules were
+	// loaded.
 	Source string `json:"source,omitempty"`
 
-	// Name of the ruleset (e.g. "dca"). Remote sources only.
+	// Name of the ruleset (e.g. "dca"). For git sources the repository URL,
+	// for OCI sources the registry repository. Empty for local sources.
 	Name string `json:"name,omitempty"`
 
-	// Version of the ruleset (e.g. "v1.0.0", "latest"). Remote sources only.
+	// Version of the ruleset (e.g. "v1.0.0", "latest"). For git sources the
+	// requested ref, for OCI sources the tag. Empty for local sources.
 	Version string `json:"version,omitempty"`
 
+	// Revision pins the exact content Version resolved to: the commit SHA
+	// for git sources, the manifest digest (e.g. "sha256:...") for OCI
+	// sources. Empty for remote and local sources.
+	Revision string `json:"revision,omitempty"`
+
 	// ChecksumSHA256 is the content fingerprint of the ruleset.
 	// For remote sources: the manifest checksum reported by the API.
-	// For local sources: SHA256 of concatenated rule file contents in path-sorted order.
+	// For local, git and OCI sources: SHA256 of concatenated rule file
+	// contents in path-sorted order.
 	ChecksumSHA256 string `json:"checksum_sha256,omitempty"`
+
+	// Signature is the outcome of verifying the ruleset's detached signature
+	// when it was downloaded: "verified", "unsigned", "invalid" or
+	// "unverified" (signed, but no trusted key configured). Remote sources only.
+	Signature string `json:"signature,omitempty"`
+
+	// SignatureKeyID identifies the trusted public key that verified the
+	// signature. Set only when Signature is "verified".
+	SignatureKeyID string `json:"signature_key_id,omitempty"`
+
+	// Sources lists every rule source that contributed rules to the scan,
+	// in load order. The fields above describe the first of them, for
+	// consumers that predate multi-source provenance.
+	Sources []RulesSourceInfo `json:"sources,omitempty"`
+}
+
+// RulesSourceInfo describes one rule source of a scan. Its fields have the
+// same meaning as the matching RulesInfo fields.
+type RulesSourceInfo struct {
+	// ID identifies the source within the report and is what RuleInfo.Ruleset
+	// refers to, e.g. "remote:dca@latest", "local" or
+	// "git:https://github.com/org/rules@v1.4.0".
+	ID string `json:"id"`
+
+	// Source is "remote", "local", "git" or "oci".
+	Source string `json:"source"`
+
+	Name           string `json:"name,omitempty"`
+	Version        string `json:"version,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	ChecksumSHA256 string `json:"checksum_sha256,omitempty"`
+	Signature      string `json:"signature,omitempty"`
+	SignatureKeyID string `json:"signature_key_id,omitempty"`
 }
 
 // Finding represents all cryptographic assets discovered in a single file.
@@ -164,6 +212,83 @@ type CryptographicAsset struct {
 	PURL string `json:"purl,omitempty"`
 }
 
+// Dependency scope values for DependencyComponent.Scope.
+const (
+	// DependencyScopeDirect marks a dependency declared by the project itself
+	// (the root module or one of its workspace members).
+	DependencyScopeDirect = "direct"
+	// DependencyScopeTransitive marks a dependency pulled in only through
+	// another dependency.
+	DependencyScopeTransitive = "transitive"
+)
+
+// Skip reasons for DependencyComponent.SkipReason.
+const (
+	// DependencySkipScope marks a dependency outside the scopes selected with
+	// --dep-scopes.
+	DependencySkipScope = "scope"
+	// DependencySkipNotImported marks a dependency no import of the project,
+	// or of a dependency it imports, refers to.
+	DependencySkipNotImported = "not_imported"
+)
+
+// DependencyGraph describes the dependencies resolved for the scanned project.
+type DependencyGraph struct {
+	// Ecosystem is the resolver ecosystem (e.g., "go", "java", "python").
+	Ecosystem string `json:"ecosystem"`
+
+	// RootModule is the scanned project's module path or coordinate.
+	// Empty when the resolver could not determine it.
+	RootModule string `json:"root_module,omitempty"`
+
+	// Components lists every resolved dependency version, sorted by module
+	// and version.
+	Components []DependencyComponent `json:"components"`
+}
+
+// DependencyComponent is a single resolved dependency version and its edges.
+type DependencyComponent struct {
+	// Module is the dependency module path (e.g., "golang.org/x/crypto").
+	Module string `json:"module"`
+
+	// Version is the resolved version (e.g., "v0.17.0"). Empty when unknown.
+	Version string `json:"version"`
+
+	// PURL is the canonical package URL when the dependency ecosystem is known.
+	PURL string `json:"purl,omitempty"`
+
+	// Scope is "direct" or "transitive".
+	Scope string `json:"scope"`
+
+	// DependencyScope is the build-tool scope of the dependency ("compile",
+	// "runtime", "provided", "test", "dev" or "optional"). Empty when the
+	// resolver records none.
+	DependencyScope string `json:"dependency_scope,omitempty"`
+
+	// SkipReason says why the dependency's sources were not scanned ("scope"
+	// or "not_imported"). Empty when it was scanned, or had no sources.
+	SkipReason string `json:"skip_reason,omitempty"`
+
+	// DependsOn lists the keys of this component's own direct dependencies.
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// Key returns the identifier other components use in DependsOn: the module
+// and version joined as "module@version", or the bare module when the
+// version is unknown. It matches the DependencyInfo of findings attributed
+// to this component.
+func (d DependencyComponent) Key() string {
+	return DependencyKey(d.Module, d.Version)
+}
+
+// DependencyKey builds the "module@version" key used by DependencyComponent.
+func DependencyKey(module, version string) string {
+	if version == "" {
+		return module
+	}
+	return module + "@" + version
+}
+
 // DependencyInfo contains attribution metadata for findings originating from dependencies.
 type DependencyInfo struct {
 	// Module is the dependency module path (e.g., "golang.org/x/crypto").
@@ -172,6 +297,9 @@ type DependencyInfo struct {
 	Version string `json:"version"`
 	// PURL is the canonical package URL when the dependency ecosystem is known.
 	PURL string `json:"purl,omitempty"`
+	// DependencyScope is the build-tool scope of the dependency (see
+	// DependencyComponent.DependencyScope). Empty when the resolver records none.
+	DependencyScope string `json:"dependency_scope,omitempty"`
 }
 
 // RuleInfo contains information about the detection rule that identified the cryptographic asset.
@@ -189,6 +317,10 @@ type RuleInfo struct {
 
 	// Version is the ruleset version when known (e.g., "latest", "v1.0.1")
 	Version string `json:"version,omitempty"`
+
+	// Ruleset is the ID of the RulesSourceInfo the rule was loaded from.
+	// Empty for rules no source defines, such as synthesized entry points.
+	Ruleset string `json:"ruleset,omitempty"`
 }
 
 // GetKey generates a unique key for deduplication based on asset type and identifying metadata.
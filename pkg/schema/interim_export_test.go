@@ -13,7 +13,7 @@ import (
 
 func TestInterimReportPublicContract(t *testing.T) {
 	report := schema.InterimReport{
-		Version: "1.6",
+		Version: "1.7",
 		Tool:    schema.ToolInfo{Name: "crypto-finder", Version: "0.1.0"},
 		Findings: []schema.Finding{{
 			FilePath: "src/crypto.go",
@@ -42,11 +42,14 @@ func TestInterimReportPublicContract(t *testing.T) {
 	if err := json.Unmarshal(data, &got); err != nil {
 		t.Fatalf("Unmarshal: %v", err)
 	}
-	if got["version"] != "1.6" || got["tool"] == nil || got["rules"] == nil || got["findings"] == nil {
+	if got["version"] != "1.7" || got["tool"] == nil || got["rules"] == nil || got["findings"] == nil {
 		t.Fatalf("required report fields missing: %s", data)
 	}
 
 	asset := got["findings"].([]any)[0].(map[string]any)["cryptographic_assets"].([]any)[0].(map[string]any)
+	if _, ok := got["dependencies"]; ok {
+		t.Errorf("optional dependencies section present in %s", data)
+	}
 	for _, key := range []string{"start_col", "end_col", "parameter_conditions", "oid", "finding_id", "occurrence_key", "dependency_info"} {
 		if _, ok := asset[key]; ok {
 			t.Errorf("optional field %q present in %s", key, data)
@@ -62,8 +65,8 @@ func TestInterimReportPublicContract(t *testing.T) {
 		t.Errorf("internal field leaked in %s", data)
 	}
 
-	if schema.InterimFormatVersion != "1.6" {
-		t.Errorf("InterimFormatVersion = %q, want 1.6", schema.InterimFormatVersion)
+	if schema.InterimFormatVersion != "1.7" {
+		t.Errorf("InterimFormatVersion = %q, want 1.7", schema.InterimFormatVersion)
 	}
 }
 
@@ -87,14 +90,30 @@ func TestCryptographicAssetLegacyRuleCompatibility(t *testing.T) {
 
 func TestInterimReportPublicJSONFieldNames(t *testing.T) {
 	report := schema.InterimReport{
-		Version: "1.6",
+		Version: "1.7",
 		Tool:    schema.ToolInfo{Name: "crypto-finder", Version: "0.1.0"},
-		Rules:   schema.RulesInfo{Source: "remote", Name: "dca", Version: "v1", ChecksumSHA256: "abc"},
+		Rules: schema.RulesInfo{
+			Source: "remote", Name: "dca", Version: "v1", Revision: "r1", ChecksumSHA256: "abc", Signature: "verified", SignatureKeyID: "0123456789abcdef",
+			Sources: []schema.RulesSourceInfo{{
+				ID: "remote:dca@v1", Source: "remote", Name: "dca", Version: "v1", Revision: "r1", ChecksumSHA256: "abc", Signature: "verified", SignatureKeyID: "0123456789abcdef",
+			}},
+		},
+		Dependencies: &schema.DependencyGraph{
+			Ecosystem:  "go",
+			RootModule: "example.com/app",
+			Components: []schema.DependencyComponent{{
+				Module:    "golang.org/x/crypto",
+				Version:   "v0.1.0",
+				PURL:      "pkg:golang/golang.org/x/crypto@v0.1.0",
+				Scope:     schema.DependencyScopeDirect,
+				DependsOn: []string{"golang.org/x/sys@v0.1.0"},
+			}},
+		},
 		Findings: []schema.Finding{{
 			FilePath: "src/crypto.go", Language: "go",
 			CryptographicAssets: []schema.CryptographicAsset{{
 				StartLine: 1, EndLine: 2, StartCol: 3, EndCol: 4, Match: "cipher.NewGCM(block)",
-				Rules:               []schema.RuleInfo{{ID: "go.crypto.aes", Message: "AES", Severity: "INFO", Version: "v1"}},
+				Rules:               []schema.RuleInfo{{ID: "go.crypto.aes", Message: "AES", Severity: "INFO", Version: "v1", Ruleset: "remote:dca@v1"}},
 				Status:              "reviewed",
 				Metadata:            map[string]string{"assetType": "algorithm"},
 				ParameterConditions: []paramcondition.Condition{{Raw: "param[0]==true"}},
@@ -114,17 +133,36 @@ func TestInterimReportPublicJSONFieldNames(t *testing.T) {
 	if err := json.Unmarshal(data, &got); err != nil {
 		t.Fatalf("Unmarshal: %v", err)
 	}
-	assertJSONKeys(t, got, "report", "findings", "rules", "tool", "version")
+	assertJSONKeys(t, got, "report", "dependencies", "findings", "rules", "tool", "version")
+	dependencies := got["dependencies"].(map[string]any)
+	assertJSONKeys(t, dependencies, "dependencies", "components", "ecosystem", "root_module")
+	assertJSONKeys(t, dependencies["components"].([]any)[0].(map[string]any), "component", "depends_on", "module", "purl", "scope", "version")
 	assertJSONKeys(t, got["tool"].(map[string]any), "tool", "name", "version")
-	assertJSONKeys(t, got["rules"].(map[string]any), "rules", "checksum_sha256", "name", "source", "version")
+	assertJSONKeys(t, got["rules"].(map[string]any), "rules", "checksum_sha256", "name", "revision", "signature", "signature_key_id", "source", "sources", "version")
+	assertJSONKeys(t, got["rules"].(map[string]any)["sources"].([]any)[0].(map[string]any), "rules source", "checksum_sha256", "id", "name", "revision", "signature", "signature_key_id", "source", "version")
 	finding := got["findings"].([]any)[0].(map[string]any)
 	assertJSONKeys(t, finding, "finding", "cryptographic_assets", "file_path", "language")
 	asset := finding["cryptographic_assets"].([]any)[0].(map[string]any)
 	assertJSONKeys(t, asset, "asset", "dependency_info", "end_col", "end_line", "finding_id", "match", "occurrence_key", "metadata", "oid", "parameter_conditions", "rules", "source", "start_col", "start_line", "status")
-	assertJSONKeys(t, asset["rules"].([]any)[0].(map[string]any), "rule", "id", "message", "severity", "version")
+	assertJSONKeys(t, asset["rules"].([]any)[0].(map[string]any), "rule", "id", "message", "ruleset", "severity", "version")
 	assertJSONKeys(t, asset["dependency_info"].(map[string]any), "dependency_info", "module", "purl", "version")
 }
 
+func TestDependencyComponentKey(t *testing.T) {
+	tests := []struct {
+		component schema.DependencyComponent
+		want      string
+	}{
+		{schema.DependencyComponent{Module: "golang.org/x/crypto", Version: "v0.1.0"}, "golang.org/x/crypto@v0.1.0"},
+		{schema.DependencyComponent{Module: "org.example:lib"}, "org.example:lib"},
+	}
+	for _, tt := range tests {
+		if got := tt.component.Key(); got != tt.want {
+			t.Errorf("Key() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
 func assertJSONKeys(t *testing.T, object map[string]any, name string, want ...string) {
 	t.Helper()
 	if len(object) != len(want) {